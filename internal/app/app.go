@@ -16,6 +16,10 @@ type App struct {
 }
 
 func New(cfg *config.Config) (*App, error) {
+	if err := validateLintConfig(&cfg.Lint); err != nil {
+		return nil, fmt.Errorf("invalid lint config: %w", err)
+	}
+
 	client, err := k8s.NewClient(cfg.Kubeconfig, cfg.Context)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
@@ -31,6 +35,20 @@ func New(cfg *config.Config) (*App, error) {
 	}, nil
 }
 
+func validateLintConfig(cfg *config.LintConfig) error {
+	if err := k8s.ValidateLintRules(cfg.Disabled...); err != nil {
+		return err
+	}
+
+	for namespace, rules := range cfg.Suppress {
+		if err := k8s.ValidateLintRules(rules...); err != nil {
+			return fmt.Errorf("suppress.%s: %w", namespace, err)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) Run() error {
 	model := ui.NewModel(a.k8sClient, a.config)
 
@@ -42,5 +60,9 @@ func (a *App) Run() error {
 
 	_, err := program.Run()
 
+	// Losing the usage history only costs right-sizing its older samples,
+	// so a failed save isn't worth failing the exit over
+	_ = model.SaveUsageHistory()
+
 	return err
 }
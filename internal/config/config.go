@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/spf13/viper"
 )
@@ -16,6 +17,17 @@ type Config struct {
 	Keybindings KeybindingsConfig `mapstructure:"keybindings"`
 	Defaults    DefaultsConfig    `mapstructure:"defaults"`
 	Panels      PanelsConfig      `mapstructure:"panels"`
+	Secrets     SecretsConfig     `mapstructure:"secrets"`
+	Lint        LintConfig        `mapstructure:"lint"`
+	Metrics     MetricsConfig     `mapstructure:"metrics"`
+	Debug       DebugConfig       `mapstructure:"debug"`
+
+	// File is the config file settings changed in the UI are saved to: the
+	// one loaded, or where one would be looked for first.
+	File string `mapstructure:"-"`
+
+	// mu guards the settings changed in the UI while they are saved.
+	mu sync.Mutex
 }
 
 type ThemeConfig struct {
@@ -57,6 +69,90 @@ type DefaultsConfig struct {
 type PanelsConfig struct {
 	Visible []string `mapstructure:"visible"`
 	Layout  string   `mapstructure:"layout"`
+	// Sort maps a panel name to its sort order, like "restarts desc"; it is
+	// saved whenever a panel's sort is changed.
+	Sort map[string]string `mapstructure:"sort"`
+	// Columns maps a panel name to the columns its rows show, in order,
+	// in place of the panel's own layout.
+	Columns map[string][]ColumnConfig `mapstructure:"columns"`
+}
+
+// ColumnConfig is one column of a panel. Name alone picks a built-in
+// column; JSONPath, Label or Annotation define a custom one titled Name.
+type ColumnConfig struct {
+	Name       string `mapstructure:"name"`
+	Header     string `mapstructure:"header"`
+	Width      int    `mapstructure:"width"`
+	JSONPath   string `mapstructure:"jsonPath"`
+	Label      string `mapstructure:"label"`
+	Annotation string `mapstructure:"annotation"`
+}
+
+type SecretsConfig struct {
+	// RevealTimeout is how many seconds a revealed secret value stays
+	// visible before it is masked again.
+	RevealTimeout int `mapstructure:"revealTimeout"`
+	// CertExpiryWarningDays flags TLS certificates that expire within this
+	// many days.
+	CertExpiryWarningDays int `mapstructure:"certExpiryWarningDays"`
+}
+
+type LintConfig struct {
+	// Disabled lists best-practice rules that are turned off everywhere.
+	Disabled []string `mapstructure:"disabled"`
+	// Suppress maps a namespace to the rules ignored in it; "*" ignores
+	// every rule.
+	Suppress map[string][]string `mapstructure:"suppress"`
+}
+
+type MetricsConfig struct {
+	// HistorySamples is how many usage samples are kept per container;
+	// metrics are sampled every 10 seconds, so 720 covers two hours.
+	HistorySamples int `mapstructure:"historySamples"`
+	// PersistHistory saves usage history between sessions so right-sizing
+	// can draw on more than the current one.
+	PersistHistory bool `mapstructure:"persistHistory"`
+	// ChartSamples is how many samples each pod and node keeps for the
+	// sparklines and detail charts; 90 covers 15 minutes.
+	ChartSamples int `mapstructure:"chartSamples"`
+}
+
+type DebugConfig struct {
+	// Image runs the debug containers and pod copies; it needs a shell.
+	Image string          `mapstructure:"image"`
+	Node  NodeShellConfig `mapstructure:"node"`
+}
+
+// NodeShellConfig sets up the privileged pods node shells run in.
+type NodeShellConfig struct {
+	// Image needs nsenter and a shell.
+	Image string `mapstructure:"image"`
+	// Namespace is where the pods are created and swept from on startup.
+	Namespace   string             `mapstructure:"namespace"`
+	Tolerations []TolerationConfig `mapstructure:"tolerations"`
+}
+
+// TolerationConfig is a pod toleration; an empty key with the Exists
+// operator tolerates every taint.
+type TolerationConfig struct {
+	Key      string `mapstructure:"key"`
+	Operator string `mapstructure:"operator"`
+	Value    string `mapstructure:"value"`
+	Effect   string `mapstructure:"effect"`
+}
+
+// DefaultDebugConfig is the debug setup used when the config file leaves
+// it out.
+func DefaultDebugConfig() DebugConfig {
+	return DebugConfig{
+		Image: "busybox",
+		Node: NodeShellConfig{
+			Image:     "busybox",
+			Namespace: "default",
+			// Node shells are wanted most on nodes that are tainted
+			Tolerations: []TolerationConfig{{Operator: "Exists"}},
+		},
+	}
 }
 
 func Load() (*Config, error) {
@@ -98,6 +194,16 @@ func Load() (*Config, error) {
 			Visible: []string{"namespaces", "pods", "deployments", "services"},
 			Layout:  "vertical",
 		},
+		Secrets: SecretsConfig{
+			RevealTimeout:         30,
+			CertExpiryWarningDays: 30,
+		},
+		Metrics: MetricsConfig{
+			HistorySamples: 720,
+			PersistHistory: true,
+			ChartSamples:   90,
+		},
+		Debug: DefaultDebugConfig(),
 	}
 
 	viper.SetConfigName("config")
@@ -130,5 +236,12 @@ func Load() (*Config, error) {
 		cfg.Namespace = cfg.Defaults.Namespace
 	}
 
+	cfg.File = viper.ConfigFileUsed()
+	if cfg.File == "" {
+		if configDir, err := os.UserConfigDir(); err == nil {
+			cfg.File = filepath.Join(configDir, "lazy-k8s", "config.yaml")
+		}
+	}
+
 	return cfg, nil
 }
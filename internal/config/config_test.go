@@ -150,6 +150,78 @@ func TestLoad_DefaultPanels(t *testing.T) {
 	}
 }
 
+func TestLoad_DefaultSecrets(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.Secrets.RevealTimeout != 30 {
+		t.Errorf("Secrets.RevealTimeout = %d, want %d", cfg.Secrets.RevealTimeout, 30)
+	}
+
+	if cfg.Secrets.CertExpiryWarningDays != 30 {
+		t.Errorf(
+			"Secrets.CertExpiryWarningDays = %d, want %d",
+			cfg.Secrets.CertExpiryWarningDays, 30,
+		)
+	}
+}
+
+func TestLoad_DefaultMetrics(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.Metrics.HistorySamples != 720 {
+		t.Errorf("Metrics.HistorySamples = %d, want %d", cfg.Metrics.HistorySamples, 720)
+	}
+
+	if !cfg.Metrics.PersistHistory {
+		t.Error("Metrics.PersistHistory should default to true")
+	}
+
+	if cfg.Metrics.ChartSamples != 90 {
+		t.Errorf("Metrics.ChartSamples = %d, want %d", cfg.Metrics.ChartSamples, 90)
+	}
+}
+
+func TestLoad_DefaultDebugImage(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.Debug.Image != "busybox" {
+		t.Errorf("Debug.Image = %q, want %q", cfg.Debug.Image, "busybox")
+	}
+}
+
+func TestLoad_DefaultNodeShell(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	node := cfg.Debug.Node
+	if node.Image != "busybox" || node.Namespace != "default" {
+		t.Errorf("Debug.Node = %+v, want busybox in default", node)
+	}
+
+	if len(node.Tolerations) != 1 || node.Tolerations[0].Operator != "Exists" {
+		t.Errorf("Debug.Node.Tolerations = %+v, want one tolerating every taint", node.Tolerations)
+	}
+}
+
 func TestLoad_NamespaceFallback(t *testing.T) {
 	viper.Reset()
 
@@ -246,6 +318,90 @@ panels:
 	}
 }
 
+func TestLoad_LintSection(t *testing.T) {
+	viper.Reset()
+
+	tmpDir := t.TempDir()
+	configContent := `
+lint:
+  disabled:
+    - latest-tag
+  suppress:
+    kube-system: ["*"]
+    batch: [missing-limits, missing-pdb]
+`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte(configContent), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	t.Chdir(tmpDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if len(cfg.Lint.Disabled) != 1 || cfg.Lint.Disabled[0] != "latest-tag" {
+		t.Errorf("Lint.Disabled = %v, want [latest-tag]", cfg.Lint.Disabled)
+	}
+
+	if got := cfg.Lint.Suppress["batch"]; len(got) != 2 || got[1] != "missing-pdb" {
+		t.Errorf("Lint.Suppress[batch] = %v, want [missing-limits missing-pdb]", got)
+	}
+
+	if got := cfg.Lint.Suppress["kube-system"]; len(got) != 1 || got[0] != "*" {
+		t.Errorf("Lint.Suppress[kube-system] = %v, want [*]", got)
+	}
+}
+
+func TestLoad_PanelColumns(t *testing.T) {
+	viper.Reset()
+
+	tmpDir := t.TempDir()
+	configContent := `
+panels:
+  columns:
+    pods:
+      - name: name
+      - name: ip
+        width: 16
+      - name: version
+        label: app.kubernetes.io/version
+      - name: qos
+        header: class
+        jsonPath: .status.qosClass
+`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte(configContent), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	t.Chdir(tmpDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	columns := cfg.Panels.Columns["pods"]
+	if len(columns) != 4 {
+		t.Fatalf("Panels.Columns[pods] = %v, want 4 columns", columns)
+	}
+
+	if columns[1].Name != "ip" || columns[1].Width != 16 {
+		t.Errorf("columns[1] = %+v, want ip with width 16", columns[1])
+	}
+
+	if columns[2].Label != "app.kubernetes.io/version" {
+		t.Errorf("columns[2].Label = %q, want app.kubernetes.io/version", columns[2].Label)
+	}
+
+	if columns[3].JSONPath != ".status.qosClass" || columns[3].Header != "class" {
+		t.Errorf("columns[3] = %+v, want the qosClass JSONPath headed class", columns[3])
+	}
+}
+
 func TestConfigStruct(t *testing.T) {
 	// Test that the Config struct can be instantiated with all fields
 	cfg := Config{
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNoConfigFile = errors.New("no config file to save to")
+
+// SetPanelSort records a panel's sort order for this session; SavePanelSort
+// writes it to the config file.
+func (c *Config) SetPanelSort(panel, sort string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Panels.Sort == nil {
+		c.Panels.Sort = make(map[string]string)
+	}
+
+	c.Panels.Sort[panel] = sort
+}
+
+// SavePanelSort saves a panel's current sort order to the config file,
+// creating the file if needed. Only that key's line is written, so the
+// rest of the file, comments and layout included, is kept as written. It
+// may run in the background: whichever save runs last writes the latest
+// order.
+func (c *Config) SavePanelSort(panel string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.File == "" {
+		return ErrNoConfigFile
+	}
+
+	return setFileValue(c.File, []string{"panels", "sort", panel}, c.Panels.Sort[panel])
+}
+
+// setFileValue sets the string at path in the YAML file, adding any
+// missing mappings along the way. The file keeps its mode; a new one is
+// only readable by the user.
+func setFileValue(file string, path []string, value string) error {
+	mode := fs.FileMode(0o600)
+
+	data, err := os.ReadFile(file) //nolint:gosec // the user's own config file
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+
+		mode = info.Mode().Perm()
+	}
+
+	updated, err := setYAMLValue(data, path, value)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", file, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, updated, mode)
+}
+
+// setYAMLValue edits the text of a block-style YAML document: an existing
+// value is replaced on its line, and missing keys are inserted after the
+// last line of the mapping they belong in.
+func setYAMLValue(data []byte, path []string, value string) ([]byte, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	if doc.Kind == 0 {
+		return []byte(strings.Join(appendAtEnd(lines, yamlEntries(0, path, value)), "\n")), nil
+	}
+
+	node := doc.Content[0]
+	if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+		return nil, errors.New("the top level is not a block mapping")
+	}
+
+	var parentKey *yaml.Node
+
+	for i, key := range path {
+		keyNode, child := mappingEntry(node, key)
+		if child == nil {
+			lines = insertEntries(lines, node, parentKey, yamlEntries(0, path[i:], value))
+
+			return []byte(strings.Join(lines, "\n")), nil
+		}
+
+		if i == len(path)-1 {
+			line, err := replaceValue(lines[keyNode.Line-1], keyNode, child, value)
+			if err != nil {
+				return nil, err
+			}
+
+			lines[keyNode.Line-1] = line
+
+			return []byte(strings.Join(lines, "\n")), nil
+		}
+
+		switch {
+		case isEmptyValue(child):
+			// "key:" with nothing below becomes a mapping
+			indent := keyNode.Column - 1 + 2
+			lines = appendAfter(lines, keyNode.Line-1, yamlEntries(indent, path[i+1:], value))
+
+			return []byte(strings.Join(lines, "\n")), nil
+		case child.Kind != yaml.MappingNode || child.Style&yaml.FlowStyle != 0:
+			return nil, fmt.Errorf("%s is not a block mapping", key)
+		}
+
+		parentKey, node = keyNode, child
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+func isEmptyValue(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null" && node.Value == ""
+}
+
+// replaceValue rewrites a "key: value" line with a new value, keeping the
+// key as written and any comment after the value.
+func replaceValue(line string, keyNode, valueNode *yaml.Node, value string) (string, error) {
+	if valueNode.Kind != yaml.ScalarNode || valueNode.Line != keyNode.Line {
+		return "", fmt.Errorf("%s is not a single-line value", keyNode.Value)
+	}
+
+	colon := strings.Index(line[keyNode.Column-1:], ":")
+	if colon < 0 {
+		return "", fmt.Errorf("%s is not a single-line value", keyNode.Value)
+	}
+
+	line = line[:keyNode.Column-1+colon+1] + " " + yamlScalar(value)
+	if valueNode.LineComment != "" {
+		line += " " + valueNode.LineComment
+	}
+
+	return line, nil
+}
+
+// insertEntries adds entries at the end of a block mapping, indented like
+// its keys; parentKey is the mapping's key, nil for the top level.
+func insertEntries(lines []string, mapping, parentKey *yaml.Node, entries []string) []string {
+	indent := 0
+	if len(mapping.Content) > 0 {
+		indent = mapping.Content[0].Column - 1
+	}
+
+	for i := range entries {
+		entries[i] = strings.Repeat(" ", indent) + entries[i]
+	}
+
+	if parentKey == nil {
+		return appendAtEnd(lines, entries)
+	}
+
+	// The mapping runs on while lines are indented deeper than its key;
+	// blank lines and comments only count if more of it follows
+	last := parentKey.Line - 1
+
+	for i := parentKey.Line; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if len(lines[i])-len(strings.TrimLeft(lines[i], " ")) <= parentKey.Column-1 {
+			break
+		}
+
+		last = i
+	}
+
+	return appendAfter(lines, last, entries)
+}
+
+// yamlEntries renders nested "key:" lines for path ending in value.
+func yamlEntries(indent int, path []string, value string) []string {
+	entries := make([]string, 0, len(path))
+
+	for i, key := range path {
+		entry := strings.Repeat(" ", indent+2*i) + yamlScalar(key) + ":"
+		if i == len(path)-1 {
+			entry += " " + yamlScalar(value)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// yamlScalar renders a string as YAML would, quoting it when needed.
+func yamlScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+func appendAfter(lines []string, idx int, entries []string) []string {
+	return append(lines[:idx+1], append(entries, lines[idx+1:]...)...)
+}
+
+// appendAtEnd adds entries after the last line that isn't blank, keeping
+// the file's final newline.
+func appendAtEnd(lines, entries []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return append(append(lines, entries...), "")
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func savePanelSort(t *testing.T, cfg *Config, panel, sort string) {
+	t.Helper()
+
+	cfg.SetPanelSort(panel, sort)
+
+	if err := cfg.SavePanelSort(panel); err != nil {
+		t.Fatalf("SavePanelSort returned unexpected error: %v", err)
+	}
+}
+
+func TestSetPanelSort(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+
+	original := "# my settings\npanels:\n  layout: vertical # stacked\ntheme:\n  primaryColor: \"#ffffff\"\n"
+	if err := os.WriteFile(file, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{File: file}
+
+	savePanelSort(t, cfg, "pods", "cpu desc")
+	savePanelSort(t, cfg, "pods", "age asc")
+
+	data, _ := os.ReadFile(file)
+	saved := string(data)
+
+	wants := []string{"# my settings", "layout: vertical # stacked", "primaryColor: \"#ffffff\"", "pods: age asc"}
+
+	for _, want := range wants {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config should contain %q:\n%s", want, saved)
+		}
+	}
+
+	if strings.Contains(saved, "cpu desc") || cfg.Panels.Sort["pods"] != "age asc" {
+		t.Errorf("the later sort should replace the earlier one:\n%s", saved)
+	}
+}
+
+func TestSetPanelSortKeepsLayout(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+
+	original := `# lazy-k8s settings
+theme:
+    primaryColor: '#ffffff'   # brand colour
+
+panels:
+    visible: [pods, deployments]
+    sort:
+        pods: name asc # by name
+
+keybindings:
+    quit: ["q"]
+`
+	if err := os.WriteFile(file, []byte(original), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{File: file}
+
+	savePanelSort(t, cfg, "pods", "cpu desc")
+	savePanelSort(t, cfg, "nodes", "memory desc")
+
+	want := strings.Replace(original, "pods: name asc # by name",
+		"pods: cpu desc # by name\n        nodes: memory desc", 1)
+
+	data, _ := os.ReadFile(file)
+	if string(data) != want {
+		t.Errorf("only the sort lines should change, got:\n%s\nwant:\n%s", data, want)
+	}
+
+	if info, err := os.Stat(file); err != nil || info.Mode().Perm() != 0o640 {
+		t.Errorf("the file mode should be kept, got %v (%v)", info.Mode().Perm(), err)
+	}
+}
+
+func TestSetPanelSortAddsSection(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+
+	original := "panels:\n  layout: vertical\n\n# colours\ntheme:\n  primaryColor: red\n"
+	if err := os.WriteFile(file, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	savePanelSort(t, &Config{File: file}, "pods", "-cpu")
+
+	want := "panels:\n  layout: vertical\n  sort:\n    pods: -cpu\n\n# colours\ntheme:\n  primaryColor: red\n"
+
+	data, _ := os.ReadFile(file)
+	if string(data) != want {
+		t.Errorf("the sort should be added under panels, got:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestSetPanelSortCreatesFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lazy-k8s", "config.yaml")
+	cfg := &Config{File: file}
+
+	savePanelSort(t, cfg, "nodes", "memory desc")
+
+	data, err := os.ReadFile(file)
+	if err != nil || string(data) != "panels:\n  sort:\n    nodes: memory desc\n" {
+		t.Errorf("expected a new config with the sort, got %q, %v", data, err)
+	}
+
+	if err := (&Config{}).SavePanelSort("pods"); !errors.Is(err, ErrNoConfigFile) {
+		t.Errorf("error = %v, want ErrNoConfigFile", err)
+	}
+}
@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// PodAllocation is what one pod reserves on its node.
+type PodAllocation struct {
+	Namespace string
+	Name      string
+	Requests  corev1.ResourceList
+	Limits    corev1.ResourceList
+}
+
+// NodeAllocation is the "Allocated resources" section of kubectl describe
+// node: the requests and limits of the node's non-terminated pods.
+type NodeAllocation struct {
+	Node        string
+	Allocatable corev1.ResourceList
+	Requests    corev1.ResourceList
+	Limits      corev1.ResourceList
+	// Pods is sorted by CPU request, then memory request, largest first.
+	Pods []PodAllocation
+}
+
+// MaxPods is the node's allocatable pod count, 0 when not reported.
+func (a *NodeAllocation) MaxPods() int64 {
+	maxPods := a.Allocatable[corev1.ResourcePods]
+
+	return maxPods.Value()
+}
+
+// MilliCPU returns requested or limited CPU in millicores.
+func MilliCPU(list corev1.ResourceList) int64 {
+	qty := list[corev1.ResourceCPU]
+
+	return qty.MilliValue()
+}
+
+// MemoryBytes returns requested or limited memory in bytes.
+func MemoryBytes(list corev1.ResourceList) int64 {
+	qty := list[corev1.ResourceMemory]
+
+	return qty.Value()
+}
+
+// GetNodeAllocation lists the pods bound to a node and sums what they
+// request.
+func (c *Client) GetNodeAllocation(ctx context.Context, name string) (*NodeAllocation, error) {
+	node, err := c.GetNode(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", name, err)
+	}
+
+	return ComputeNodeAllocations([]corev1.Node{*node}, list.Items)[name], nil
+}
+
+// ComputeNodeAllocations returns the allocation of every node, keyed by node
+// name. Pods that aren't bound to one of nodes or have terminated are
+// ignored.
+func ComputeNodeAllocations(nodes []corev1.Node, pods []corev1.Pod) map[string]*NodeAllocation {
+	allocations := make(map[string]*NodeAllocation, len(nodes))
+
+	for i := range nodes {
+		allocations[nodes[i].Name] = &NodeAllocation{
+			Node:        nodes[i].Name,
+			Allocatable: nodes[i].Status.Allocatable,
+			Requests:    corev1.ResourceList{},
+			Limits:      corev1.ResourceList{},
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+
+		alloc, ok := allocations[pod.Spec.NodeName]
+		if !ok || isTerminated(pod) {
+			continue
+		}
+
+		podAlloc := PodAllocation{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Requests:  PodRequests(pod),
+			Limits:    PodLimits(pod),
+		}
+
+		addResources(alloc.Requests, podAlloc.Requests)
+		addResources(alloc.Limits, podAlloc.Limits)
+		alloc.Pods = append(alloc.Pods, podAlloc)
+	}
+
+	for _, alloc := range allocations {
+		slices.SortFunc(alloc.Pods, func(a, b PodAllocation) int {
+			return cmp.Or(
+				cmp.Compare(MilliCPU(b.Requests), MilliCPU(a.Requests)),
+				cmp.Compare(MemoryBytes(b.Requests), MemoryBytes(a.Requests)),
+				cmp.Compare(a.Namespace, b.Namespace),
+				cmp.Compare(a.Name, b.Name),
+			)
+		})
+	}
+
+	return allocations
+}
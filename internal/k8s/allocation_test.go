@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func allocationPod(name, node, cpu, memory string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func allocationNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}
+}
+
+func TestComputeNodeAllocations(t *testing.T) {
+	pods := []corev1.Pod{
+		*allocationPod("small", "worker-1", "100m", "128Mi", corev1.PodRunning),
+		*allocationPod("big", "worker-1", "1500m", "1Gi", corev1.PodRunning),
+		*allocationPod("done", "worker-1", "2", "2Gi", corev1.PodSucceeded),
+		*allocationPod("elsewhere", "worker-2", "1", "1Gi", corev1.PodRunning),
+		*allocationPod("pending", "", "1", "1Gi", corev1.PodPending),
+	}
+
+	allocations := ComputeNodeAllocations([]corev1.Node{*allocationNode("worker-1")}, pods)
+
+	alloc := allocations["worker-1"]
+	if alloc == nil {
+		t.Fatal("expected an allocation for worker-1")
+	}
+
+	if len(alloc.Pods) != 2 || alloc.Pods[0].Name != "big" || alloc.Pods[1].Name != "small" {
+		t.Fatalf("pods should be the non-terminated ones sorted by request, got %+v", alloc.Pods)
+	}
+
+	if got := MilliCPU(alloc.Requests); got != 1600 {
+		t.Errorf("CPU requests = %dm, want 1600m", got)
+	}
+
+	if got, want := MemoryBytes(alloc.Limits), int64(1152*1024*1024); got != want {
+		t.Errorf("memory limits = %d, want %d", got, want)
+	}
+
+	if MilliCPU(alloc.Limits) != 0 {
+		t.Error("pods without CPU limits should add nothing to CPU limits")
+	}
+
+	if alloc.MaxPods() != 110 {
+		t.Errorf("MaxPods() = %d, want 110", alloc.MaxPods())
+	}
+
+	if _, ok := allocations["worker-2"]; ok {
+		t.Error("nodes that weren't passed in should not get an allocation")
+	}
+}
+
+func TestGetNodeAllocation(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(
+		allocationNode("worker-1"),
+		allocationPod("web", "worker-1", "250m", "256Mi", corev1.PodRunning),
+		allocationPod("db", "worker-2", "1", "1Gi", corev1.PodRunning),
+	))
+
+	alloc, err := client.GetNodeAllocation(context.Background(), "worker-1")
+	if err != nil {
+		t.Fatalf("GetNodeAllocation() error = %v", err)
+	}
+
+	if len(alloc.Pods) != 1 || alloc.Pods[0].Name != "web" || MilliCPU(alloc.Requests) != 250 {
+		t.Errorf("unexpected allocation %+v", alloc)
+	}
+
+	if _, err := client.GetNodeAllocation(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for a missing node")
+	}
+}
@@ -0,0 +1,177 @@
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	ErrNotTLSSecret     = errors.New("secret is not of type kubernetes.io/tls")
+	ErrNoCertificatePEM = errors.New("tls.crt contains no PEM certificate")
+)
+
+// CertExpiry classifies a certificate's validity relative to a warning
+// threshold.
+type CertExpiry int
+
+const (
+	CertValid CertExpiry = iota
+	CertExpiringSoon
+	CertExpired
+	CertNotYetValid
+)
+
+// CertificateInfo is the parsed leaf certificate of a TLS secret.
+type CertificateInfo struct {
+	Subject     string
+	Issuer      string
+	DNSNames    []string
+	IPAddresses []string
+	NotBefore   time.Time
+	NotAfter    time.Time
+	// ChainLength counts every certificate in tls.crt, leaf included.
+	ChainLength int
+	// KeyMatches is true when tls.key is the private key of the leaf.
+	KeyMatches bool
+}
+
+// ExpiringCertificate is one row of the cluster-wide expiry report. Err is
+// set when the secret's certificate couldn't be parsed at all.
+type ExpiringCertificate struct {
+	Namespace string
+	Name      string
+	Info      *CertificateInfo
+	Err       error
+}
+
+// ParseTLSSecret decodes the leaf certificate in tls.crt and checks whether
+// tls.key belongs to it.
+func ParseTLSSecret(secret *corev1.Secret) (*CertificateInfo, error) {
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil, ErrNotTLSSecret
+	}
+
+	certPEM := secret.Data[corev1.TLSCertKey]
+
+	var certs []*x509.Certificate
+
+	for rest := certPEM; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrNoCertificatePEM
+	}
+
+	leaf := certs[0]
+
+	info := &CertificateInfo{
+		Subject:     leaf.Subject.String(),
+		Issuer:      leaf.Issuer.String(),
+		DNSNames:    leaf.DNSNames,
+		NotBefore:   leaf.NotBefore,
+		NotAfter:    leaf.NotAfter,
+		ChainLength: len(certs),
+	}
+
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+
+	// X509KeyPair verifies the private key matches the leaf's public key.
+	_, err := tls.X509KeyPair(certPEM, secret.Data[corev1.TLSPrivateKeyKey])
+	info.KeyMatches = err == nil
+
+	return info, nil
+}
+
+// DaysUntilExpiry returns whole days until NotAfter; negative once expired.
+func (ci *CertificateInfo) DaysUntilExpiry(now time.Time) int {
+	return int(ci.NotAfter.Sub(now).Hours() / 24)
+}
+
+// Expiry classifies the certificate at now, treating anything that expires
+// within threshold as expiring soon.
+func (ci *CertificateInfo) Expiry(now time.Time, threshold time.Duration) CertExpiry {
+	switch {
+	case now.After(ci.NotAfter):
+		return CertExpired
+	case now.Before(ci.NotBefore):
+		return CertNotYetValid
+	case ci.NotAfter.Sub(now) <= threshold:
+		return CertExpiringSoon
+	default:
+		return CertValid
+	}
+}
+
+// ListExpiringCertificates parses every TLS secret in the cluster and returns
+// those that are expired or expire within threshold, soonest first. Secrets
+// whose certificate can't be parsed are included with Err set.
+func (c *Client) ListExpiringCertificates(
+	ctx context.Context,
+	threshold time.Duration,
+) ([]ExpiringCertificate, error) {
+	secrets, err := c.ListSecretsAllNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var report []ExpiringCertificate
+
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+
+		info, err := ParseTLSSecret(secret)
+		if err == nil && info.Expiry(now, threshold) == CertValid {
+			continue
+		}
+
+		report = append(report, ExpiringCertificate{
+			Namespace: secret.Namespace,
+			Name:      secret.Name,
+			Info:      info,
+			Err:       err,
+		})
+	}
+
+	// Unparseable certificates first, then by expiry date
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Info == nil || report[j].Info == nil {
+			return report[i].Info == nil && report[j].Info != nil
+		}
+
+		return report[i].Info.NotAfter.Before(report[j].Info.NotAfter)
+	})
+
+	return report, nil
+}
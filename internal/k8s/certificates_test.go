@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// testCertificate returns a self-signed PEM certificate and its PEM key,
+// valid from notBefore to notAfter.
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func tlsSecret(name string, cert, key []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+}
+
+func TestParseTLSSecret(t *testing.T) {
+	now := time.Now()
+	cert, key := testCertificate(t, now.Add(-time.Hour), now.Add(90*24*time.Hour))
+
+	info, err := ParseTLSSecret(tlsSecret("web-tls", cert, key))
+	if err != nil {
+		t.Fatalf("ParseTLSSecret returned unexpected error: %v", err)
+	}
+
+	if info.Subject != "CN=example.com" {
+		t.Errorf("Subject = %q, want %q", info.Subject, "CN=example.com")
+	}
+
+	if len(info.DNSNames) != 2 || len(info.IPAddresses) != 1 || info.IPAddresses[0] != "10.0.0.1" {
+		t.Errorf("unexpected SANs: %v %v", info.DNSNames, info.IPAddresses)
+	}
+
+	if !info.KeyMatches {
+		t.Error("KeyMatches should be true for the generating key")
+	}
+
+	if days := info.DaysUntilExpiry(now); days != 89 && days != 90 {
+		t.Errorf("DaysUntilExpiry = %d, want ~90", days)
+	}
+
+	if got := info.Expiry(now, 30*24*time.Hour); got != CertValid {
+		t.Errorf("Expiry = %d, want CertValid", got)
+	}
+}
+
+func TestParseTLSSecret_KeyMismatch(t *testing.T) {
+	now := time.Now()
+	cert, _ := testCertificate(t, now, now.Add(time.Hour))
+	_, otherKey := testCertificate(t, now, now.Add(time.Hour))
+
+	info, err := ParseTLSSecret(tlsSecret("web-tls", cert, otherKey))
+	if err != nil {
+		t.Fatalf("ParseTLSSecret returned unexpected error: %v", err)
+	}
+
+	if info.KeyMatches {
+		t.Error("KeyMatches should be false for an unrelated key")
+	}
+}
+
+func TestParseTLSSecret_Errors(t *testing.T) {
+	opaque := &corev1.Secret{Type: corev1.SecretTypeOpaque}
+	if _, err := ParseTLSSecret(opaque); !errors.Is(err, ErrNotTLSSecret) {
+		t.Errorf("expected ErrNotTLSSecret, got %v", err)
+	}
+
+	garbage := tlsSecret("bad", []byte("not a cert"), nil)
+	if _, err := ParseTLSSecret(garbage); !errors.Is(err, ErrNoCertificatePEM) {
+		t.Errorf("expected ErrNoCertificatePEM, got %v", err)
+	}
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	now := time.Now()
+	threshold := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      CertExpiry
+	}{
+		{"valid", now.Add(-time.Hour), now.Add(60 * 24 * time.Hour), CertValid},
+		{"expiring soon", now.Add(-time.Hour), now.Add(10 * 24 * time.Hour), CertExpiringSoon},
+		{"expired", now.Add(-48 * time.Hour), now.Add(-time.Hour), CertExpired},
+		{"not yet valid", now.Add(time.Hour), now.Add(60 * 24 * time.Hour), CertNotYetValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &CertificateInfo{NotBefore: tt.notBefore, NotAfter: tt.notAfter}
+			if got := info.Expiry(now, threshold); got != tt.want {
+				t.Errorf("Expiry = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListExpiringCertificates(t *testing.T) {
+	now := time.Now()
+	okCert, okKey := testCertificate(t, now.Add(-time.Hour), now.Add(300*24*time.Hour))
+	soonCert, soonKey := testCertificate(t, now.Add(-time.Hour), now.Add(5*24*time.Hour))
+	oldCert, oldKey := testCertificate(t, now.Add(-48*time.Hour), now.Add(-time.Hour))
+
+	clientset := fake.NewSimpleClientset(
+		tlsSecret("fine", okCert, okKey),
+		tlsSecret("soon", soonCert, soonKey),
+		tlsSecret("old", oldCert, oldKey),
+		tlsSecret("broken", []byte("junk"), nil),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+			Type:       corev1.SecretTypeOpaque,
+		},
+	)
+
+	client := createTestClient(clientset)
+
+	report, err := client.ListExpiringCertificates(context.Background(), 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ListExpiringCertificates returned unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, cert := range report {
+		names = append(names, cert.Name)
+	}
+
+	want := []string{"broken", "old", "soon"}
+	if len(names) != len(want) {
+		t.Fatalf("report = %v, want %v", names, want)
+	}
+
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("report = %v, want %v", names, want)
+
+			break
+		}
+	}
+
+	if report[0].Err == nil {
+		t.Error("unparseable certificate should carry its error")
+	}
+}
@@ -94,6 +94,15 @@ func (c *Client) Clientset() kubernetes.Interface {
 	return c.clientset
 }
 
+// Pinned returns a copy of the client that stays on the current context
+// and namespace, for work that must finish against the cluster it started
+// in even if the user switches context meanwhile.
+func (c *Client) Pinned() *Client {
+	pinned := *c
+
+	return &pinned
+}
+
 func (c *Client) GetContexts() []string {
 	contexts := make([]string, 0, len(c.rawConfig.Contexts))
 	for name := range c.rawConfig.Contexts {
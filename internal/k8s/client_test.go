@@ -59,6 +59,20 @@ func TestClientClientset(t *testing.T) {
 	}
 }
 
+func TestClientPinned(t *testing.T) {
+	original := fake.NewSimpleClientset()
+	client := &Client{clientset: original, contextName: "prod", namespace: "default"}
+
+	pinned := client.Pinned()
+	client.clientset = fake.NewSimpleClientset()
+	client.contextName = "staging"
+	client.SetNamespace("other")
+
+	if pinned.Clientset() != original || pinned.CurrentContext() != "prod" || pinned.CurrentNamespace() != "default" {
+		t.Errorf("Pinned() client followed the switch: %+v", pinned)
+	}
+}
+
 func TestClientGetContexts(t *testing.T) {
 	client := &Client{
 		rawConfig: api.Config{
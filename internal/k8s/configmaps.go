@@ -2,16 +2,42 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
+// ConfigMapSizeLimit is the maximum size of a ConfigMap's data enforced by
+// the API server (etcd's 1MiB object limit).
+const ConfigMapSizeLimit = 1 << 20
+
+var (
+	ErrConfigMapKeyNotFound = errors.New("configmap key not found")
+	ErrConfigMapKeyExists   = errors.New("configmap key already exists")
+	ErrConfigMapTooLarge    = errors.New("configmap data exceeds the 1MiB limit")
+	ErrInvalidConfigMapKey  = errors.New("invalid configmap key")
+)
+
+// ValidateConfigMapKey checks a key the way the API server does, so a bad
+// name is reported before anything is edited.
+func ValidateConfigMapKey(key string) error {
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return fmt.Errorf("%w %q: %s", ErrInvalidConfigMapKey, key, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
 func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]corev1.ConfigMap, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +46,7 @@ func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]corev1
 }
 
 func (c *Client) ListConfigMapsAllNamespaces(ctx context.Context) ([]corev1.ConfigMap, error) {
-	list, err := c.clientset.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().ConfigMaps("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -62,3 +88,120 @@ func (c *Client) CreateConfigMap(
 ) (*corev1.ConfigMap, error) {
 	return c.clientset.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
 }
+
+// SetConfigMapKey sets a single text key, creating it when it doesn't exist.
+// A binary key of the same name is replaced, since a key can't be in both.
+func (c *Client) SetConfigMapKey(ctx context.Context, namespace, name, key, value string) error {
+	if err := ValidateConfigMapKey(key); err != nil {
+		return err
+	}
+
+	cm, err := c.GetConfigMap(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+
+	cm.Data[key] = value
+	delete(cm.BinaryData, key)
+
+	_, err = c.UpdateConfigMap(ctx, cm)
+
+	return err
+}
+
+// RenameConfigMapKey moves the value stored under oldKey to newKey. Both
+// text and binary keys are supported.
+func (c *Client) RenameConfigMapKey(ctx context.Context, namespace, name, oldKey, newKey string) error {
+	if err := ValidateConfigMapKey(newKey); err != nil {
+		return err
+	}
+
+	cm, err := c.GetConfigMap(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if hasConfigMapKey(cm, newKey) {
+		return fmt.Errorf("%w: %s", ErrConfigMapKeyExists, newKey)
+	}
+
+	switch {
+	case hasKey(cm.Data, oldKey):
+		cm.Data[newKey] = cm.Data[oldKey]
+		delete(cm.Data, oldKey)
+	case hasKey(cm.BinaryData, oldKey):
+		cm.BinaryData[newKey] = cm.BinaryData[oldKey]
+		delete(cm.BinaryData, oldKey)
+	default:
+		return fmt.Errorf("%w: %s", ErrConfigMapKeyNotFound, oldKey)
+	}
+
+	_, err = c.UpdateConfigMap(ctx, cm)
+
+	return err
+}
+
+// RemoveConfigMapKey deletes a single text or binary key.
+func (c *Client) RemoveConfigMapKey(ctx context.Context, namespace, name, key string) error {
+	cm, err := c.GetConfigMap(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if !hasConfigMapKey(cm, key) {
+		return fmt.Errorf("%w: %s", ErrConfigMapKeyNotFound, key)
+	}
+
+	delete(cm.Data, key)
+	delete(cm.BinaryData, key)
+
+	_, err = c.UpdateConfigMap(ctx, cm)
+
+	return err
+}
+
+// NewConfigMap builds a ConfigMap from raw data, the equivalent of
+// `kubectl create configmap`. Values that aren't valid UTF-8 go into
+// BinaryData like kubectl does.
+func NewConfigMap(namespace, name string, data map[string][]byte) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	for key, value := range data {
+		if utf8.Valid(value) {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+
+			cm.Data[key] = string(value)
+
+			continue
+		}
+
+		if cm.BinaryData == nil {
+			cm.BinaryData = make(map[string][]byte)
+		}
+
+		cm.BinaryData[key] = value
+	}
+
+	return cm
+}
+
+func hasConfigMapKey(cm *corev1.ConfigMap, key string) bool {
+	return hasKey(cm.Data, key) || hasKey(cm.BinaryData, key)
+}
+
+func hasKey[V any](m map[string]V, key string) bool {
+	_, ok := m[key]
+
+	return ok
+}
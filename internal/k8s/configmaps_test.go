@@ -0,0 +1,169 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testConfigMapClient() *Client {
+	return createTestClient(fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app-config",
+				Namespace: "default",
+			},
+			Data:       map[string]string{"env": "prod", "level": "info"},
+			BinaryData: map[string][]byte{"logo.png": {0x89, 0x50}},
+		},
+	))
+}
+
+func TestSetConfigMapKey(t *testing.T) {
+	client := testConfigMapClient()
+	ctx := context.Background()
+
+	if err := client.SetConfigMapKey(ctx, "default", "app-config", "env", "staging"); err != nil {
+		t.Fatalf("SetConfigMapKey returned unexpected error: %v", err)
+	}
+
+	if err := client.SetConfigMapKey(ctx, "default", "app-config", "region", "eu"); err != nil {
+		t.Fatalf("SetConfigMapKey returned unexpected error: %v", err)
+	}
+
+	cm, err := client.GetConfigMap(ctx, "default", "app-config")
+	if err != nil {
+		t.Fatalf("GetConfigMap returned unexpected error: %v", err)
+	}
+
+	if cm.Data["env"] != "staging" {
+		t.Errorf("env = %q, want %q", cm.Data["env"], "staging")
+	}
+
+	if cm.Data["region"] != "eu" {
+		t.Errorf("region = %q, want %q", cm.Data["region"], "eu")
+	}
+
+	if cm.Data["level"] != "info" {
+		t.Errorf("level = %q, want it untouched", cm.Data["level"])
+	}
+}
+
+func TestSetConfigMapKey_ReplacesBinaryKey(t *testing.T) {
+	client := testConfigMapClient()
+	ctx := context.Background()
+
+	if err := client.SetConfigMapKey(ctx, "default", "app-config", "logo.png", "<svg/>"); err != nil {
+		t.Fatalf("SetConfigMapKey returned unexpected error: %v", err)
+	}
+
+	cm, err := client.GetConfigMap(ctx, "default", "app-config")
+	if err != nil {
+		t.Fatalf("GetConfigMap returned unexpected error: %v", err)
+	}
+
+	if _, ok := cm.BinaryData["logo.png"]; ok || cm.Data["logo.png"] != "<svg/>" {
+		t.Errorf("logo.png should only be a text key now, got data %v binary %v", cm.Data, cm.BinaryData)
+	}
+
+	err = client.SetConfigMapKey(ctx, "default", "app-config", "bad key", "x")
+	if !errors.Is(err, ErrInvalidConfigMapKey) {
+		t.Errorf("expected ErrInvalidConfigMapKey, got %v", err)
+	}
+}
+
+func TestRenameConfigMapKey(t *testing.T) {
+	client := testConfigMapClient()
+	ctx := context.Background()
+
+	if err := client.RenameConfigMapKey(ctx, "default", "app-config", "env", "environment"); err != nil {
+		t.Fatalf("RenameConfigMapKey returned unexpected error: %v", err)
+	}
+
+	if err := client.RenameConfigMapKey(ctx, "default", "app-config", "logo.png", "icon.png"); err != nil {
+		t.Fatalf("RenameConfigMapKey returned unexpected error for binary key: %v", err)
+	}
+
+	cm, err := client.GetConfigMap(ctx, "default", "app-config")
+	if err != nil {
+		t.Fatalf("GetConfigMap returned unexpected error: %v", err)
+	}
+
+	if _, ok := cm.Data["env"]; ok {
+		t.Error("old key env should be gone")
+	}
+
+	if cm.Data["environment"] != "prod" {
+		t.Errorf("environment = %q, want %q", cm.Data["environment"], "prod")
+	}
+
+	if len(cm.BinaryData["icon.png"]) != 2 {
+		t.Errorf("binary key was not renamed: %v", cm.BinaryData)
+	}
+}
+
+func TestRenameConfigMapKey_Errors(t *testing.T) {
+	client := testConfigMapClient()
+	ctx := context.Background()
+
+	err := client.RenameConfigMapKey(ctx, "default", "app-config", "env", "level")
+	if !errors.Is(err, ErrConfigMapKeyExists) {
+		t.Errorf("expected ErrConfigMapKeyExists, got %v", err)
+	}
+
+	err = client.RenameConfigMapKey(ctx, "default", "app-config", "missing", "other")
+	if !errors.Is(err, ErrConfigMapKeyNotFound) {
+		t.Errorf("expected ErrConfigMapKeyNotFound, got %v", err)
+	}
+
+	err = client.RenameConfigMapKey(ctx, "default", "app-config", "env", "../env")
+	if !errors.Is(err, ErrInvalidConfigMapKey) {
+		t.Errorf("expected ErrInvalidConfigMapKey, got %v", err)
+	}
+}
+
+func TestRemoveConfigMapKey(t *testing.T) {
+	client := testConfigMapClient()
+	ctx := context.Background()
+
+	if err := client.RemoveConfigMapKey(ctx, "default", "app-config", "level"); err != nil {
+		t.Fatalf("RemoveConfigMapKey returned unexpected error: %v", err)
+	}
+
+	cm, err := client.GetConfigMap(ctx, "default", "app-config")
+	if err != nil {
+		t.Fatalf("GetConfigMap returned unexpected error: %v", err)
+	}
+
+	if _, ok := cm.Data["level"]; ok {
+		t.Error("level should have been removed")
+	}
+
+	err = client.RemoveConfigMapKey(ctx, "default", "app-config", "level")
+	if !errors.Is(err, ErrConfigMapKeyNotFound) {
+		t.Errorf("expected ErrConfigMapKeyNotFound, got %v", err)
+	}
+}
+
+func TestNewConfigMap(t *testing.T) {
+	cm := NewConfigMap("default", "mixed", map[string][]byte{
+		"app.yaml": []byte("port: 8080\n"),
+		"blob":     {0xff, 0xfe},
+	})
+
+	if cm.Namespace != "default" || cm.Name != "mixed" {
+		t.Errorf("unexpected metadata: %s/%s", cm.Namespace, cm.Name)
+	}
+
+	if cm.Data["app.yaml"] != "port: 8080\n" {
+		t.Errorf("app.yaml = %q, want text data", cm.Data["app.yaml"])
+	}
+
+	if _, ok := cm.BinaryData["blob"]; !ok {
+		t.Error("non-UTF-8 value should go into BinaryData")
+	}
+}
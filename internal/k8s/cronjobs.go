@@ -17,7 +17,7 @@ func (c *Client) ListCronJobs(
 ) ([]batchv1.CronJob, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func (c *Client) ListCronJobs(
 }
 
 func (c *Client) ListCronJobsAllNamespaces(ctx context.Context) ([]batchv1.CronJob, error) {
-	list, err := c.clientset.BatchV1().CronJobs("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.BatchV1().CronJobs("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -16,7 +16,7 @@ func (c *Client) ListDaemonSets(
 ) ([]appsv1.DaemonSet, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func (c *Client) ListDaemonSets(
 }
 
 func (c *Client) ListDaemonSetsAllNamespaces(ctx context.Context) ([]appsv1.DaemonSet, error) {
-	list, err := c.clientset.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().DaemonSets("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrEmptyDataSource   = errors.New("no data sources given")
+	ErrInvalidDataSource = errors.New("invalid data source, use key=value, key=@file or @file")
+	ErrDuplicateDataKey  = errors.New("duplicate data key")
+	ErrKeyedDirectory    = errors.New("a directory can't be stored under a single key")
+)
+
+// ReadDataSources parses a whitespace-separated list of data sources in the
+// style of kubectl's --from-literal and --from-file flags:
+//
+//	key=value    literal value
+//	key=@path    contents of a local file stored under key
+//	@path        contents of a local file stored under its base name
+//	@dir         every regular file directly inside dir, keyed by file name
+//
+// Values containing whitespace must be supplied through a file.
+func ReadDataSources(spec string) (map[string][]byte, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, ErrEmptyDataSource
+	}
+
+	data := make(map[string][]byte, len(fields))
+
+	for _, field := range fields {
+		if err := readDataSource(field, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// DataSize returns the total number of bytes held by a data map, which is
+// what counts towards the 1MiB object size limit.
+func DataSize(data map[string][]byte) int {
+	total := 0
+	for key, value := range data {
+		total += len(key) + len(value)
+	}
+
+	return total
+}
+
+func readDataSource(field string, data map[string][]byte) error {
+	if path, ok := strings.CutPrefix(field, "@"); ok {
+		if path == "" {
+			return fmt.Errorf("%w: %q", ErrInvalidDataSource, field)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return readDataDir(path, data)
+		}
+
+		return readDataFile(filepath.Base(path), path, data)
+	}
+
+	key, value, ok := strings.Cut(field, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidDataSource, field)
+	}
+
+	if path, isFile := strings.CutPrefix(value, "@"); isFile {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return fmt.Errorf("%w: %s", ErrKeyedDirectory, field)
+		}
+
+		return readDataFile(key, path, data)
+	}
+
+	return addDataKey(data, key, []byte(value))
+}
+
+// readDataDir adds every regular file directly inside dir. Subdirectories,
+// symlinks and other special files are skipped, matching kubectl.
+func readDataDir(dir string, data map[string][]byte) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		if err := readDataFile(entry.Name(), filepath.Join(dir, entry.Name()), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readDataFile(key, path string, data map[string][]byte) error {
+	value, err := os.ReadFile(path) //nolint:gosec // user-supplied local path
+	if err != nil {
+		return err
+	}
+
+	return addDataKey(data, key, value)
+}
+
+func addDataKey(data map[string][]byte, key string, value []byte) error {
+	if _, exists := data[key]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateDataKey, key)
+	}
+
+	data[key] = value
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadDataSources_Literals(t *testing.T) {
+	data, err := ReadDataSources("user=admin  pass=a=b")
+	if err != nil {
+		t.Fatalf("ReadDataSources returned unexpected error: %v", err)
+	}
+
+	if string(data["user"]) != "admin" {
+		t.Errorf("user = %q, want %q", data["user"], "admin")
+	}
+
+	// Only the first '=' separates key from value
+	if string(data["pass"]) != "a=b" {
+		t.Errorf("pass = %q, want %q", data["pass"], "a=b")
+	}
+}
+
+func TestReadDataSources_Files(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	if err := os.WriteFile(path, []byte(`{"a":1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ReadDataSources("@" + path + " renamed=@" + path)
+	if err != nil {
+		t.Fatalf("ReadDataSources returned unexpected error: %v", err)
+	}
+
+	if string(data["config.json"]) != `{"a":1}` {
+		t.Errorf("config.json = %q, want file contents", data["config.json"])
+	}
+
+	if string(data["renamed"]) != `{"a":1}` {
+		t.Errorf("renamed = %q, want file contents", data["renamed"])
+	}
+}
+
+func TestReadDataSources_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want error
+	}{
+		{"empty", "   ", ErrEmptyDataSource},
+		{"no separator", "justakey", ErrInvalidDataSource},
+		{"empty key", "=value", ErrInvalidDataSource},
+		{"bare at", "@", ErrInvalidDataSource},
+		{"duplicate", "a=1 a=2", ErrDuplicateDataKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDataSources(tt.spec)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ReadDataSources(%q) error = %v, want %v", tt.spec, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDataSources_MissingFile(t *testing.T) {
+	_, err := ReadDataSources("@/nonexistent/file")
+	if err == nil {
+		t.Error("ReadDataSources should fail for a missing file")
+	}
+}
+
+func TestReadDataSources_Directory(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{"a.conf": "a", "b.conf": "bb"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Subdirectories are skipped like kubectl does
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ReadDataSources("@" + dir + " extra=1")
+	if err != nil {
+		t.Fatalf("ReadDataSources returned unexpected error: %v", err)
+	}
+
+	if len(data) != 3 {
+		t.Errorf("expected 3 keys, got %d: %v", len(data), data)
+	}
+
+	if string(data["b.conf"]) != "bb" {
+		t.Errorf("b.conf = %q, want %q", data["b.conf"], "bb")
+	}
+
+	if DataSize(data) != len("a.conf")+1+len("b.conf")+2+len("extra")+1 {
+		t.Errorf("DataSize = %d, want sum of keys and values", DataSize(data))
+	}
+
+	_, err = ReadDataSources("conf=@" + dir)
+	if !errors.Is(err, ErrKeyedDirectory) {
+		t.Errorf("expected ErrKeyedDirectory, got %v", err)
+	}
+}
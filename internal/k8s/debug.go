@@ -0,0 +1,228 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DebugCopyLabel marks the debug copies of pods.
+const DebugCopyLabel = "lazy-k8s/debug-copy"
+
+// DebugCopyOfAnnotation names the pod a debug copy was made from; pod
+// names can be too long for a label value.
+const DebugCopyOfAnnotation = "lazy-k8s/debug-copy-of"
+
+// debugCopySuffix is what a debug copy's name adds to the pod's.
+const debugCopySuffix = "-debug-"
+
+// containerStartPoll is how often WaitForContainer checks on a container.
+const containerStartPoll = time.Second
+
+// ContainerStartTimeout is how long WaitForContainer waits, pulling the
+// image included.
+const ContainerStartTimeout = 2 * time.Minute
+
+// AddDebugContainer adds an interactive ephemeral container running image
+// to a pod, as kubectl debug does. Target names the container whose
+// process namespace it joins, so its processes and files under
+// /proc/1/root can be inspected. It returns the new container's name.
+func (c *Client) AddDebugContainer(ctx context.Context, namespace, podName, target, image string) (string, error) {
+	pods := c.clientset.CoreV1().Pods(c.ns(namespace))
+
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	name := "debugger-" + utilrand.String(5)
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+
+	if _, err := pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// CreateDebugCopy creates a copy of a pod whose container runs a shell in
+// image in place of its program, as kubectl debug --copy-to does. The
+// copy has none of the pod's labels, so no controller or service adopts
+// it; DebugCopyLabel marks it instead.
+func (c *Client) CreateDebugCopy(
+	ctx context.Context,
+	namespace, podName, container, image string,
+) (*corev1.Pod, error) {
+	pods := c.clientset.CoreV1().Pods(c.ns(namespace))
+
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	debugCopy, err := DebugPodCopy(pod, container, image)
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Create(ctx, debugCopy, metav1.CreateOptions{})
+}
+
+// DebugPodCopy builds the debug copy of a pod: container runs a shell in
+// image on a TTY, the processes of all containers are shared and probes
+// are dropped so the copy isn't restarted while being debugged.
+func DebugPodCopy(pod *corev1.Pod, container, image string) (*corev1.Pod, error) {
+	annotations := maps.Clone(pod.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+
+	annotations[DebugCopyOfAnnotation] = pod.Name
+
+	debugCopy := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        debugCopyName(pod.Name),
+			Namespace:   pod.Namespace,
+			Labels:      map[string]string{DebugCopyLabel: "true"},
+			Annotations: annotations,
+		},
+		Spec: *pod.Spec.DeepCopy(),
+	}
+
+	// Like kubectl debug --copy-to, drop what belongs to the running pod:
+	// the API server refuses ephemeral containers on create, and the
+	// scheduler picks a node
+	spec := &debugCopy.Spec
+	spec.EphemeralContainers = nil
+	spec.NodeName = ""
+	spec.RestartPolicy = corev1.RestartPolicyNever
+	spec.ShareProcessNamespace = new(bool)
+	*spec.ShareProcessNamespace = true
+
+	found := false
+
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		c.LivenessProbe, c.ReadinessProbe, c.StartupProbe = nil, nil, nil
+
+		if c.Name != container {
+			continue
+		}
+
+		found = true
+		c.Image = image
+		c.Command = []string{"sh"}
+		c.Args = nil
+		c.Stdin = true
+		c.TTY = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("pod %s has no container %s", pod.Name, container)
+	}
+
+	return debugCopy, nil
+}
+
+// debugCopyName names a pod's debug copy, shortening the pod's name so the
+// copy's stays valid.
+func debugCopyName(podName string) string {
+	suffix := debugCopySuffix + utilrand.String(5)
+
+	base := podName
+	if maxBase := validation.DNS1123SubdomainMaxLength - len(suffix); len(base) > maxBase {
+		base = strings.TrimRight(base[:maxBase], "-.")
+	}
+
+	return base + suffix
+}
+
+// WaitForContainer waits until a pod's container, regular or ephemeral,
+// is running, failing if it ends first or can't start in
+// ContainerStartTimeout.
+func (c *Client) WaitForContainer(ctx context.Context, namespace, podName, container string) error {
+	ctx, cancel := context.WithTimeout(ctx, ContainerStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(containerStartPoll)
+	defer ticker.Stop()
+
+	for {
+		pod, err := c.GetPod(ctx, namespace, podName)
+		if err != nil {
+			return err
+		}
+
+		if running, err := containerRunning(pod, container); running || err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("container %s didn't start in %s", container, ContainerStartTimeout)
+			}
+
+			return ctx.Err()
+		}
+	}
+}
+
+// containerRunning reports whether a container is running, with an error
+// once it can no longer start.
+func containerRunning(pod *corev1.Pod, container string) (bool, error) {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false, fmt.Errorf("pod %s has ended: %s", pod.Name, pod.Status.Phase)
+	}
+
+	statuses := append(
+		append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...),
+		pod.Status.EphemeralContainerStatuses...,
+	)
+
+	for _, status := range statuses {
+		if status.Name != container {
+			continue
+		}
+
+		switch {
+		case status.State.Running != nil:
+			return true, nil
+		case status.State.Terminated != nil:
+			return false, fmt.Errorf("container %s exited: %s", container, status.State.Terminated.Reason)
+		case status.State.Waiting != nil && imagePullFailed(status.State.Waiting.Reason):
+			return false, fmt.Errorf("container %s can't start: %s", container, status.State.Waiting.Reason)
+		}
+	}
+
+	return false, nil
+}
+
+func imagePullFailed(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerError":
+		return true
+	}
+
+	return false
+}
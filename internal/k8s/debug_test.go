@@ -0,0 +1,206 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func debugTestPod() *corev1.Pod {
+	probe := &corev1.Probe{}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "web"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc"}},
+			ResourceVersion: "42",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{
+				{Name: "app", Image: "gcr.io/distroless/static", Command: []string{"/app"}, LivenessProbe: probe},
+				{Name: "proxy", Image: "envoy", ReadinessProbe: probe},
+			},
+		},
+	}
+}
+
+func TestAddDebugContainer(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(debugTestPod()))
+
+	name, err := client.AddDebugContainer(context.Background(), "default", "web", "app", "busybox")
+	if err != nil {
+		t.Fatalf("AddDebugContainer returned unexpected error: %v", err)
+	}
+
+	pod, _ := client.GetPod(context.Background(), "default", "web")
+	if len(pod.Spec.EphemeralContainers) != 1 {
+		t.Fatalf("expected 1 ephemeral container, got %d", len(pod.Spec.EphemeralContainers))
+	}
+
+	debugger := pod.Spec.EphemeralContainers[0]
+	if debugger.Name != name || !strings.HasPrefix(name, "debugger-") {
+		t.Errorf("ephemeral container name = %q, returned %q", debugger.Name, name)
+	}
+
+	if debugger.Image != "busybox" || debugger.TargetContainerName != "app" {
+		t.Errorf("ephemeral container = %s targeting %s, want busybox targeting app",
+			debugger.Image, debugger.TargetContainerName)
+	}
+
+	if !debugger.Stdin || !debugger.TTY {
+		t.Error("the debug container should be interactive")
+	}
+}
+
+func TestDebugPodCopy(t *testing.T) {
+	pod := debugTestPod()
+	// Debugged before with an ephemeral container
+	pod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-abcde", Image: "busybox"},
+	}}
+
+	debugCopy, err := DebugPodCopy(pod, "app", "busybox")
+	if err != nil {
+		t.Fatalf("DebugPodCopy returned unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(debugCopy.Name, "web-debug-") {
+		t.Errorf("copy name = %q, want a web-debug- prefix", debugCopy.Name)
+	}
+
+	if debugCopy.Labels[DebugCopyLabel] != "true" || debugCopy.Labels["app"] != "" {
+		t.Errorf("copy labels = %v, want only the debug copy label", debugCopy.Labels)
+	}
+
+	if debugCopy.Annotations[DebugCopyOfAnnotation] != "web" {
+		t.Errorf("copy annotations = %v, want the source pod named", debugCopy.Annotations)
+	}
+
+	if len(debugCopy.OwnerReferences) != 0 || debugCopy.ResourceVersion != "" || debugCopy.Spec.NodeName != "" {
+		t.Error("the copy shouldn't keep the pod's owner, version or node")
+	}
+
+	if len(debugCopy.Spec.EphemeralContainers) != 0 {
+		t.Error("the copy shouldn't keep ephemeral containers, which can't be created")
+	}
+
+	app := debugCopy.Spec.Containers[0]
+	if app.Image != "busybox" || app.Command[0] != "sh" || !app.TTY || app.LivenessProbe != nil {
+		t.Errorf("debugged container = %+v, want busybox running sh without probes", app)
+	}
+
+	if proxy := debugCopy.Spec.Containers[1]; proxy.Image != "envoy" || proxy.ReadinessProbe != nil {
+		t.Errorf("other containers should keep their image and lose probes, got %+v", proxy)
+	}
+
+	if !*debugCopy.Spec.ShareProcessNamespace || debugCopy.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Error("the copy should share processes and never restart")
+	}
+
+	// The original is untouched
+	if pod.Spec.Containers[0].Image != "gcr.io/distroless/static" {
+		t.Error("DebugPodCopy changed the pod it copied")
+	}
+
+	if _, err := DebugPodCopy(pod, "missing", "busybox"); err == nil {
+		t.Error("expected an error for a container the pod doesn't have")
+	}
+}
+
+func TestDebugPodCopy_LongName(t *testing.T) {
+	pod := debugTestPod()
+	pod.Name = strings.Repeat("a", 240) + "-" + strings.Repeat("b", 12)
+
+	debugCopy, err := DebugPodCopy(pod, "app", "busybox")
+	if err != nil {
+		t.Fatalf("DebugPodCopy returned unexpected error: %v", err)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(debugCopy.Name); len(errs) > 0 {
+		t.Errorf("copy name %q is invalid: %v", debugCopy.Name, errs)
+	}
+
+	if !strings.HasPrefix(debugCopy.Name, "aaa") || strings.Contains(debugCopy.Name, "--debug") {
+		t.Errorf("copy name %q should keep the start of the pod's name", debugCopy.Name)
+	}
+
+	if debugCopy.Annotations[DebugCopyOfAnnotation] != pod.Name {
+		t.Error("the annotation should name the source pod in full")
+	}
+}
+
+func TestCreateDebugCopy(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(debugTestPod()))
+
+	debugCopy, err := client.CreateDebugCopy(context.Background(), "default", "web", "app", "busybox")
+	if err != nil {
+		t.Fatalf("CreateDebugCopy returned unexpected error: %v", err)
+	}
+
+	if _, err := client.GetPod(context.Background(), "default", debugCopy.Name); err != nil {
+		t.Errorf("the copy should have been created: %v", err)
+	}
+}
+
+func TestWaitForContainer(t *testing.T) {
+	running := debugTestPod()
+	running.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "debugger-abcde",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+
+	client := createTestClient(fake.NewSimpleClientset(running))
+
+	if err := client.WaitForContainer(context.Background(), "default", "web", "debugger-abcde"); err != nil {
+		t.Errorf("WaitForContainer returned unexpected error: %v", err)
+	}
+}
+
+func TestContainerRunning(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   corev1.ContainerState
+		phase   corev1.PodPhase
+		running bool
+		wantErr bool
+	}{
+		{"running", corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, corev1.PodRunning, true, false},
+		{
+			"creating",
+			corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+			corev1.PodPending, false, false,
+		},
+		{
+			"bad image",
+			corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			corev1.PodPending, false, true,
+		},
+		{
+			"exited",
+			corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}},
+			corev1.PodRunning, false, true,
+		},
+		{"pod failed", corev1.ContainerState{}, corev1.PodFailed, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := debugTestPod()
+			pod.Status.Phase = tt.phase
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", State: tt.state}}
+
+			running, err := containerRunning(pod, "app")
+			if running != tt.running || (err != nil) != tt.wantErr {
+				t.Errorf("containerRunning = %v, %v; want %v, error %v", running, err, tt.running, tt.wantErr)
+			}
+		})
+	}
+}
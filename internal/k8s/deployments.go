@@ -21,7 +21,7 @@ func (c *Client) ListDeployments(
 ) ([]appsv1.Deployment, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (c *Client) ListDeployments(
 }
 
 func (c *Client) ListDeploymentsAllNamespaces(ctx context.Context) ([]appsv1.Deployment, error) {
-	list, err := c.clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().Deployments("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
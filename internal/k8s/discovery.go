@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// APIResource is a kind of resource the API server can list, as found by
+// discovery, including custom resources.
+type APIResource struct {
+	// Name is the plural resource name, e.g. "certificates".
+	Name         string
+	SingularName string
+	Kind         string
+	Group        string
+	Version      string
+	ShortNames   []string
+	Namespaced   bool
+}
+
+// QualifiedName is the name kubectl resolves unambiguously, e.g.
+// "certificates.cert-manager.io"; core resources have no group.
+func (r APIResource) QualifiedName() string {
+	if r.Group == "" {
+		return r.Name
+	}
+
+	return r.Name + "." + r.Group
+}
+
+// Matches reports whether name refers to the resource by its plural or
+// singular name, kind, a short name or its qualified name.
+func (r APIResource) Matches(name string) bool {
+	name = strings.ToLower(name)
+
+	return name == r.Name || name == r.SingularName || name == strings.ToLower(r.Kind) ||
+		name == r.QualifiedName() || slices.Contains(r.ShortNames, name)
+}
+
+// Builtin reports whether the resource comes with Kubernetes rather than
+// from a CustomResourceDefinition or an aggregated API.
+func (r APIResource) Builtin() bool {
+	return groupRank(r.Group) < 2
+}
+
+// ListAPIResources discovers the listable resources of every API group,
+// one entry per resource at the first version the server lists. Groups that
+// fail discovery, like an unavailable aggregated API, are skipped.
+func (c *Client) ListAPIResources() ([]APIResource, error) {
+	_, lists, err := c.clientset.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+
+	var resources []APIResource
+
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, res := range list.APIResources {
+			// Subresources like pods/log can't be listed on their own
+			if strings.Contains(res.Name, "/") || !slices.Contains(res.Verbs, "list") {
+				continue
+			}
+
+			resource := APIResource{
+				Name:         res.Name,
+				SingularName: res.SingularName,
+				Kind:         res.Kind,
+				Group:        gv.Group,
+				Version:      gv.Version,
+				ShortNames:   res.ShortNames,
+				Namespaced:   res.Namespaced,
+			}
+			if resource.SingularName == "" {
+				resource.SingularName = strings.ToLower(res.Kind)
+			}
+
+			if seen[resource.QualifiedName()] {
+				continue
+			}
+
+			seen[resource.QualifiedName()] = true
+
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// FindAPIResource returns the resource name refers to. When a name is
+// ambiguous the core group wins, then the built-in groups, as with kubectl.
+func FindAPIResource(resources []APIResource, name string) (APIResource, bool) {
+	var found APIResource
+
+	ok := false
+
+	for _, res := range resources {
+		if res.Matches(name) && (!ok || groupRank(res.Group) < groupRank(found.Group)) {
+			found, ok = res, true
+		}
+	}
+
+	return found, ok
+}
+
+func groupRank(group string) int {
+	switch {
+	case group == "":
+		return 0
+	case !strings.Contains(group, ".") || strings.HasSuffix(group, ".k8s.io"):
+		return 1
+	default:
+		return 2
+	}
+}
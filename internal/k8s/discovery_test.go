@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDiscoveryTestClient() *Client {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", SingularName: "pod", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}, Verbs: []string{"get", "list"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+				{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", SingularName: "deployment", Kind: "Deployment", Namespaced: true, ShortNames: []string{"deploy"}, Verbs: []string{"list"}},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", SingularName: "certificate", Kind: "Certificate", Namespaced: true, ShortNames: []string{"cert", "certs"}, Verbs: []string{"list"}},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: []string{"list"}},
+			},
+		},
+	}
+
+	return NewTestClient(clientset)
+}
+
+func TestListAPIResources(t *testing.T) {
+	resources, err := newDiscoveryTestClient().ListAPIResources()
+	if err != nil {
+		t.Fatalf("ListAPIResources returned unexpected error: %v", err)
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, res := range resources {
+		names = append(names, res.QualifiedName())
+	}
+
+	want := []string{"pods", "deployments.apps", "certificates.cert-manager.io"}
+	if len(names) != len(want) {
+		t.Fatalf("resources = %v, want %v", names, want)
+	}
+
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("resources[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+
+	if resources[2].Version != "v1" {
+		t.Errorf("certificates version = %q, want the first listed v1", resources[2].Version)
+	}
+}
+
+func TestFindAPIResource(t *testing.T) {
+	resources := []APIResource{
+		{Name: "events", SingularName: "event", Kind: "Event", Group: "events.k8s.io", ShortNames: []string{"ev"}},
+		{Name: "certificates", SingularName: "certificate", Kind: "Certificate", Group: "cert-manager.io", ShortNames: []string{"cert"}},
+		{Name: "events", SingularName: "event", Kind: "Event", ShortNames: []string{"ev"}},
+	}
+
+	tests := []struct {
+		name  string
+		want  string
+		found bool
+	}{
+		{"certificates", "certificates.cert-manager.io", true},
+		{"Certificate", "certificates.cert-manager.io", true},
+		{"cert", "certificates.cert-manager.io", true},
+		{"certificates.cert-manager.io", "certificates.cert-manager.io", true},
+		{"ev", "events", true},
+		{"issuers", "", false},
+	}
+
+	for _, tt := range tests {
+		res, ok := FindAPIResource(resources, tt.name)
+		if ok != tt.found || (ok && res.QualifiedName() != tt.want) {
+			t.Errorf("FindAPIResource(%q) = %q, %v; want %q, %v", tt.name, res.QualifiedName(), ok, tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServiceProblem is the most severe reason a service isn't serving traffic.
+type ServiceProblem int
+
+const (
+	ServiceOK ServiceProblem = iota
+	// ServiceNoMatchingPods: the selector matches no pod at all.
+	ServiceNoMatchingPods
+	// ServiceNoReadyEndpoints: no endpoint of the service is ready.
+	ServiceNoReadyEndpoints
+	// ServiceMissingTargetPort: a named targetPort isn't declared by some
+	// of the matched pods' containers.
+	ServiceMissingTargetPort
+)
+
+// ServiceEndpoint is one address of a service's EndpointSlices.
+type ServiceEndpoint struct {
+	Address     string
+	PodName     string
+	NodeName    string
+	Ready       bool
+	Terminating bool
+}
+
+// MissingTargetPort is a named targetPort together with the matched pods
+// that don't declare a container port of that name.
+type MissingTargetPort struct {
+	Port string
+	Pods []string
+}
+
+// ServiceHealth summarizes whether a service has anything behind it.
+type ServiceHealth struct {
+	Endpoints []ServiceEndpoint
+	Ready     int
+	NotReady  int
+	// MatchingPods is -1 for services without a selector, whose endpoints
+	// are managed by hand.
+	MatchingPods       int
+	MissingTargetPorts []MissingTargetPort
+}
+
+func (c *Client) ListEndpointSlices(
+	ctx context.Context,
+	namespace string,
+) ([]discoveryv1.EndpointSlice, error) {
+	namespace = c.ns(namespace)
+
+	list, err := c.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListEndpointSlicesAllNamespaces(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+	list, err := c.clientset.DiscoveryV1().EndpointSlices("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// ServiceEndpoints flattens the slices belonging to svc into one endpoint per
+// pod, or per address for endpoints without a pod, sorted with ready
+// endpoints first. Slices of other services are ignored, so the full
+// namespace list may be passed.
+func ServiceEndpoints(svc *corev1.Service, slices []discoveryv1.EndpointSlice) []ServiceEndpoint {
+	seen := make(map[string]bool)
+
+	var endpoints []ServiceEndpoint
+
+	for i := range slices {
+		slice := &slices[i]
+		if slice.Namespace != svc.Namespace || slice.Labels[discoveryv1.LabelServiceName] != svc.Name {
+			continue
+		}
+
+		for _, ep := range slice.Endpoints {
+			for _, addr := range ep.Addresses {
+				// Dual-stack services list a pod in one slice per address
+				// family; it is reported once, with the first address seen
+				id := addr
+				if ep.TargetRef != nil {
+					id = ep.TargetRef.Kind + "/" + ep.TargetRef.Namespace + "/" + ep.TargetRef.Name
+				}
+
+				if seen[id] {
+					continue
+				}
+
+				seen[id] = true
+
+				endpoint := ServiceEndpoint{
+					Address: addr,
+					// A nil ready condition means unknown and counts as ready
+					Ready:       ep.Conditions.Ready == nil || *ep.Conditions.Ready,
+					Terminating: ep.Conditions.Terminating != nil && *ep.Conditions.Terminating,
+				}
+
+				if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
+					endpoint.PodName = ep.TargetRef.Name
+				}
+
+				if ep.NodeName != nil {
+					endpoint.NodeName = *ep.NodeName
+				}
+
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].Ready != endpoints[j].Ready {
+			return endpoints[i].Ready
+		}
+
+		return endpoints[i].Address < endpoints[j].Address
+	})
+
+	return endpoints
+}
+
+// AnalyzeService combines the service's endpoints with the pods its selector
+// matches. pods may contain pods of other namespaces; they are skipped.
+func AnalyzeService(
+	svc *corev1.Service,
+	slices []discoveryv1.EndpointSlice,
+	pods []corev1.Pod,
+) *ServiceHealth {
+	health := &ServiceHealth{
+		Endpoints:    ServiceEndpoints(svc, slices),
+		MatchingPods: -1,
+	}
+
+	for _, ep := range health.Endpoints {
+		if ep.Ready {
+			health.Ready++
+		} else {
+			health.NotReady++
+		}
+	}
+
+	if len(svc.Spec.Selector) == 0 {
+		return health
+	}
+
+	var matched []*corev1.Pod
+
+	for i := range pods {
+		if pods[i].Namespace == svc.Namespace && selectsLabels(svc.Spec.Selector, pods[i].Labels) {
+			matched = append(matched, &pods[i])
+		}
+	}
+
+	health.MatchingPods = len(matched)
+	health.MissingTargetPorts = missingTargetPorts(svc, matched)
+
+	return health
+}
+
+func missingTargetPorts(svc *corev1.Service, pods []*corev1.Pod) []MissingTargetPort {
+	var missing []MissingTargetPort
+
+	for _, port := range svc.Spec.Ports {
+		if port.TargetPort.Type != intstr.String {
+			continue
+		}
+
+		name := port.TargetPort.StrVal
+
+		var lacking []string
+
+		for _, pod := range pods {
+			if !podDeclaresPort(pod, name) {
+				lacking = append(lacking, pod.Name)
+			}
+		}
+
+		if len(lacking) > 0 {
+			missing = append(missing, MissingTargetPort{Port: name, Pods: lacking})
+		}
+	}
+
+	return missing
+}
+
+func podDeclaresPort(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Problem returns the most severe issue of the service. ExternalName
+// services have no endpoints by design and are never flagged.
+func (h *ServiceHealth) Problem(svc *corev1.Service) ServiceProblem {
+	switch {
+	case svc.Spec.Type == corev1.ServiceTypeExternalName:
+		return ServiceOK
+	case h.MatchingPods == 0:
+		return ServiceNoMatchingPods
+	case h.Ready == 0:
+		return ServiceNoReadyEndpoints
+	case len(h.MissingTargetPorts) > 0:
+		return ServiceMissingTargetPort
+	default:
+		return ServiceOK
+	}
+}
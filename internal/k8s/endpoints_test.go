@@ -0,0 +1,207 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEndpointSlice(name, service string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
+
+func testEndpoint(addr, pod, node string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{addr},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		NodeName:   &node,
+		TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod},
+	}
+}
+
+func testServiceWithSelector(name string, targetPort intstr.IntOrString) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: targetPort}},
+		},
+	}
+}
+
+func testWebPod(name string, portNames ...string) corev1.Pod {
+	var ports []corev1.ContainerPort
+	for _, n := range portNames {
+		ports = append(ports, corev1.ContainerPort{Name: n, ContainerPort: 8080})
+	}
+
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Ports: ports}}},
+	}
+}
+
+func TestListEndpointSlices(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		testEndpointSlice("web-1", "web"),
+		testEndpointSlice("web-2", "web"),
+		testEndpointSlice("api-1", "api"),
+	)
+	client := createTestClient(clientset)
+
+	all, err := client.ListEndpointSlices(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListEndpointSlices() error = %v", err)
+	}
+
+	if len(all) != 3 {
+		t.Errorf("expected 3 slices, got %d", len(all))
+	}
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	svc := testServiceWithSelector("web", intstr.FromInt32(8080))
+	slices := []discoveryv1.EndpointSlice{
+		*testEndpointSlice("web-1", "web",
+			testEndpoint("10.0.0.2", "web-b", "node-2", false),
+			testEndpoint("10.0.0.1", "web-a", "node-1", true),
+		),
+		// Same address again in another slice is reported once
+		*testEndpointSlice("web-2", "web", testEndpoint("10.0.0.1", "web-a", "node-1", true)),
+		*testEndpointSlice("api-1", "api", testEndpoint("10.0.0.9", "api-a", "node-1", true)),
+	}
+
+	endpoints := ServiceEndpoints(svc, slices)
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %+v", endpoints)
+	}
+
+	if !endpoints[0].Ready || endpoints[0].PodName != "web-a" || endpoints[0].NodeName != "node-1" {
+		t.Errorf("expected ready web-a on node-1 first, got %+v", endpoints[0])
+	}
+
+	if endpoints[1].Ready || endpoints[1].PodName != "web-b" {
+		t.Errorf("expected not-ready web-b second, got %+v", endpoints[1])
+	}
+}
+
+func TestServiceEndpointsDualStack(t *testing.T) {
+	svc := testServiceWithSelector("web", intstr.FromInt32(8080))
+
+	ipv6 := testEndpointSlice("web-v6", "web", testEndpoint("fd00::1", "web-a", "node-1", true))
+	ipv6.AddressType = discoveryv1.AddressTypeIPv6
+
+	slices := []discoveryv1.EndpointSlice{
+		*testEndpointSlice("web-v4", "web", testEndpoint("10.0.0.1", "web-a", "node-1", true)),
+		*ipv6,
+	}
+
+	endpoints := ServiceEndpoints(svc, slices)
+	if len(endpoints) != 1 || endpoints[0].Address != "10.0.0.1" {
+		t.Fatalf("a pod in both address families should count once, got %+v", endpoints)
+	}
+
+	if health := AnalyzeService(svc, slices, []corev1.Pod{testWebPod("web-a")}); health.Ready != 1 {
+		t.Errorf("Ready = %d, want 1", health.Ready)
+	}
+}
+
+func TestAnalyzeService(t *testing.T) {
+	ready := []discoveryv1.EndpointSlice{
+		*testEndpointSlice("web-1", "web", testEndpoint("10.0.0.1", "web-a", "node-1", true)),
+	}
+	notReady := []discoveryv1.EndpointSlice{
+		*testEndpointSlice("web-1", "web", testEndpoint("10.0.0.1", "web-a", "node-1", false)),
+	}
+
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		slices  []discoveryv1.EndpointSlice
+		pods    []corev1.Pod
+		want    ServiceProblem
+		missing int
+	}{
+		{
+			name:   "healthy",
+			svc:    testServiceWithSelector("web", intstr.FromString("http")),
+			slices: ready,
+			pods:   []corev1.Pod{testWebPod("web-a", "http")},
+			want:   ServiceOK,
+		},
+		{
+			name: "no matching pods",
+			svc:  testServiceWithSelector("web", intstr.FromInt32(8080)),
+			want: ServiceNoMatchingPods,
+		},
+		{
+			name:   "no ready endpoints",
+			svc:    testServiceWithSelector("web", intstr.FromInt32(8080)),
+			slices: notReady,
+			pods:   []corev1.Pod{testWebPod("web-a")},
+			want:   ServiceNoReadyEndpoints,
+		},
+		{
+			name:    "missing named target port",
+			svc:     testServiceWithSelector("web", intstr.FromString("http")),
+			slices:  ready,
+			pods:    []corev1.Pod{testWebPod("web-a", "http"), testWebPod("web-b", "metrics")},
+			want:    ServiceMissingTargetPort,
+			missing: 1,
+		},
+		{
+			name: "external name never flagged",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "ext", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "example.com"},
+			},
+			want: ServiceOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := AnalyzeService(tt.svc, tt.slices, tt.pods)
+
+			if got := health.Problem(tt.svc); got != tt.want {
+				t.Errorf("Problem() = %d, want %d", got, tt.want)
+			}
+
+			if len(health.MissingTargetPorts) != tt.missing {
+				t.Errorf("MissingTargetPorts = %+v, want %d entries", health.MissingTargetPorts, tt.missing)
+			}
+		})
+	}
+}
+
+func TestAnalyzeServiceMissingPortPods(t *testing.T) {
+	svc := testServiceWithSelector("web", intstr.FromString("http"))
+	pods := []corev1.Pod{testWebPod("web-a", "http"), testWebPod("web-b")}
+
+	health := AnalyzeService(svc, nil, pods)
+
+	if health.MatchingPods != 2 {
+		t.Errorf("MatchingPods = %d, want 2", health.MatchingPods)
+	}
+
+	if len(health.MissingTargetPorts) != 1 ||
+		health.MissingTargetPorts[0].Port != "http" ||
+		len(health.MissingTargetPorts[0].Pods) != 1 ||
+		health.MissingTargetPorts[0].Pods[0] != "web-b" {
+		t.Errorf("unexpected missing ports %+v", health.MissingTargetPorts)
+	}
+}
@@ -11,7 +11,7 @@ import (
 func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,7 @@ func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Eve
 }
 
 func (c *Client) ListEventsAllNamespaces(ctx context.Context) ([]corev1.Event, error) {
-	list, err := c.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Events("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ErrNoClusterConnection is returned by streaming calls on a client built
+// without a rest config, like the fake ones in tests.
+var ErrNoClusterConnection = errors.New("client has no cluster connection")
+
+// TerminalSize is a terminal's size in character cells.
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+// TerminalOptions is an interactive session in a container on a TTY.
+type TerminalOptions struct {
+	Namespace string
+	Pod       string
+	Container string
+	Command   []string
+	Stdin     io.Reader
+	Stdout    io.Writer
+	// Resize delivers the terminal size, first the initial one and then
+	// every change.
+	Resize <-chan TerminalSize
+}
+
+// ExecTTY runs a command in a container on a TTY, as kubectl exec -it
+// does, until it exits or ctx is done.
+func (c *Client) ExecTTY(ctx context.Context, opts TerminalOptions) error {
+	return c.streamTTY(ctx, "exec", opts, &corev1.PodExecOptions{
+		Container: opts.Container,
+		Command:   opts.Command,
+		Stdin:     true,
+		Stdout:    true,
+		TTY:       true,
+	})
+}
+
+func (c *Client) streamTTY(
+	ctx context.Context,
+	subresource string,
+	opts TerminalOptions,
+	params runtime.Object,
+) error {
+	if c.restConfig == nil {
+		return ErrNoClusterConnection
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(c.ns(opts.Namespace)).
+		Name(opts.Pod).
+		SubResource(subresource).
+		VersionedParams(params, scheme.ParameterCodec)
+
+	// WebSockets first, falling back to SPDY for older API servers, as
+	// kubectl does
+	websocketExec, err := remotecommand.NewWebSocketExecutor(c.restConfig, http.MethodGet, req.URL().String())
+	if err != nil {
+		return err
+	}
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(c.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+
+	executor, err := remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return err
+	}
+
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               true,
+		TerminalSizeQueue: terminalSizeQueue{ctx: ctx, sizes: opts.Resize},
+	})
+}
+
+// terminalSizeQueue hands remotecommand the sizes from a channel until the
+// session's context ends.
+type terminalSizeQueue struct {
+	ctx   context.Context
+	sizes <-chan TerminalSize
+}
+
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &remotecommand.TerminalSize{Width: size.Width, Height: size.Height}
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+// AttachTTY attaches to a container's running process on a TTY, as
+// kubectl attach -it does, until it exits or ctx is done.
+func (c *Client) AttachTTY(ctx context.Context, opts TerminalOptions) error {
+	return c.streamTTY(ctx, "attach", opts, &corev1.PodAttachOptions{
+		Container: opts.Container,
+		Stdin:     true,
+		Stdout:    true,
+		TTY:       true,
+	})
+}
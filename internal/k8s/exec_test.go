@@ -0,0 +1,37 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExecTTYWithoutClusterConnection(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset())
+
+	err := client.ExecTTY(context.Background(), TerminalOptions{Pod: "web", Command: []string{"sh"}})
+	if !errors.Is(err, ErrNoClusterConnection) {
+		t.Errorf("ExecTTY error = %v, want ErrNoClusterConnection", err)
+	}
+}
+
+func TestTerminalSizeQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sizes := make(chan TerminalSize, 1)
+	queue := terminalSizeQueue{ctx: ctx, sizes: sizes}
+
+	sizes <- TerminalSize{Width: 120, Height: 40}
+
+	size := queue.Next()
+	if size == nil || size.Width != 120 || size.Height != 40 {
+		t.Fatalf("Next() = %+v, want 120x40", size)
+	}
+
+	cancel()
+
+	if size := queue.Next(); size != nil {
+		t.Errorf("Next() after the session ended = %+v, want nil", size)
+	}
+}
@@ -18,7 +18,7 @@ func (c *Client) ListHPAs(
 
 	list, err := c.clientset.AutoscalingV2().
 		HorizontalPodAutoscalers(namespace).
-		List(ctx, metav1.ListOptions{})
+		List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +31,7 @@ func (c *Client) ListHPAsAllNamespaces(
 ) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
 	list, err := c.clientset.AutoscalingV2().
 		HorizontalPodAutoscalers("").
-		List(ctx, metav1.ListOptions{})
+		List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
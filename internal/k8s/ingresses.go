@@ -0,0 +1,361 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// legacyIngressClassAnnotation predates spec.ingressClassName and is
+	// still honored by most controllers.
+	legacyIngressClassAnnotation  = "kubernetes.io/ingress.class"
+	defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// IngressDependencies holds the objects an ingress is validated against.
+// Secrets and Classes are nil when they couldn't be listed, in which case
+// the TLS and IngressClass checks are skipped.
+type IngressDependencies struct {
+	Services       []corev1.Service
+	EndpointSlices []discoveryv1.EndpointSlice
+	// Secrets is keyed by namespace/name.
+	Secrets map[string]*corev1.Secret
+	// Classes is keyed by IngressClass name.
+	Classes map[string]*networkingv1.IngressClass
+}
+
+// IngressRoute is one host/path rule resolved to its backend.
+type IngressRoute struct {
+	Host     string
+	Path     string
+	PathType string
+	Service  string
+	Port     string
+	Ready    int
+	NotReady int
+	// Problem is empty when the route can serve traffic.
+	Problem string
+}
+
+// IngressTLSStatus is one TLS entry with its parsed certificate.
+type IngressTLSStatus struct {
+	SecretName string
+	Hosts      []string
+	Info       *CertificateInfo
+	// Problem is empty for a present, parseable certificate; expiry is left
+	// to the caller since it depends on a threshold.
+	Problem string
+}
+
+// IngressReport is the result of validating one ingress.
+type IngressReport struct {
+	// Class is the resolved IngressClass name, empty when none applies.
+	Class        string
+	Controller   string
+	ClassProblem string
+	Routes       []IngressRoute
+	TLS          []IngressTLSStatus
+}
+
+func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
+	namespace = c.ns(namespace)
+
+	list, err := c.clientset.NetworkingV1().Ingresses(namespace).List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListIngressesAllNamespaces(ctx context.Context) ([]networkingv1.Ingress, error) {
+	list, err := c.clientset.NetworkingV1().Ingresses("").List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) DeleteIngress(ctx context.Context, namespace, name string) error {
+	namespace = c.ns(namespace)
+
+	return c.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c *Client) ListIngressClasses(ctx context.Context) ([]networkingv1.IngressClass, error) {
+	list, err := c.clientset.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// IndexSecrets keys secrets by namespace/name for IngressDependencies.
+func IndexSecrets(secrets []corev1.Secret) map[string]*corev1.Secret {
+	index := make(map[string]*corev1.Secret, len(secrets))
+	for i := range secrets {
+		index[secrets[i].Namespace+"/"+secrets[i].Name] = &secrets[i]
+	}
+
+	return index
+}
+
+// IndexIngressClasses keys classes by name for IngressDependencies.
+func IndexIngressClasses(classes []networkingv1.IngressClass) map[string]*networkingv1.IngressClass {
+	index := make(map[string]*networkingv1.IngressClass, len(classes))
+	for i := range classes {
+		index[classes[i].Name] = &classes[i]
+	}
+
+	return index
+}
+
+// ValidateIngress resolves every rule of ing to its backend service and
+// checks the service, its port and its ready endpoints, the TLS secrets and
+// the IngressClass.
+func ValidateIngress(ing *networkingv1.Ingress, deps *IngressDependencies) *IngressReport {
+	report := &IngressReport{}
+
+	resolveIngressClass(ing, deps, report)
+
+	if ing.Spec.DefaultBackend != nil {
+		report.Routes = append(report.Routes, resolveRoute(
+			ing.Namespace, "*", "(default)", "", ing.Spec.DefaultBackend, deps,
+		))
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			pathStr := path.Path
+			if pathStr == "" {
+				pathStr = "/"
+			}
+
+			pathType := ""
+			if path.PathType != nil {
+				pathType = string(*path.PathType)
+			}
+
+			report.Routes = append(report.Routes, resolveRoute(
+				ing.Namespace, host, pathStr, pathType, &path.Backend, deps,
+			))
+		}
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		report.TLS = append(report.TLS, resolveTLS(ing.Namespace, tls, deps))
+	}
+
+	return report
+}
+
+func resolveIngressClass(ing *networkingv1.Ingress, deps *IngressDependencies, report *IngressReport) {
+	switch {
+	case ing.Spec.IngressClassName != nil:
+		report.Class = *ing.Spec.IngressClassName
+	case ing.Annotations[legacyIngressClassAnnotation] != "":
+		// Annotation classes name a controller setting, not necessarily an
+		// IngressClass object, so they aren't looked up.
+		report.Class = ing.Annotations[legacyIngressClassAnnotation]
+		report.Controller = "(annotation)"
+
+		return
+	}
+
+	if deps.Classes == nil {
+		return
+	}
+
+	if report.Class == "" {
+		var defaults []string
+
+		for name, class := range deps.Classes {
+			if class.Annotations[defaultIngressClassAnnotation] == "true" {
+				defaults = append(defaults, name)
+			}
+		}
+
+		if len(defaults) == 0 {
+			report.ClassProblem = "no ingressClassName and no default IngressClass"
+
+			return
+		}
+
+		slices.Sort(defaults)
+
+		report.Class = defaults[0]
+		report.Controller = deps.Classes[defaults[0]].Spec.Controller
+
+		if len(defaults) > 1 {
+			// Which one the cluster assigns depends on its version, so the
+			// first by name is shown alongside the conflict
+			report.ClassProblem = "several default IngressClasses: " + strings.Join(defaults, ", ")
+		}
+
+		return
+	}
+
+	class, ok := deps.Classes[report.Class]
+	if !ok {
+		report.ClassProblem = fmt.Sprintf("IngressClass %s not found", report.Class)
+
+		return
+	}
+
+	report.Controller = class.Spec.Controller
+}
+
+func resolveRoute(
+	namespace, host, path, pathType string,
+	backend *networkingv1.IngressBackend,
+	deps *IngressDependencies,
+) IngressRoute {
+	route := IngressRoute{Host: host, Path: path, PathType: pathType}
+
+	if backend.Service == nil {
+		if backend.Resource != nil {
+			route.Service = backend.Resource.Kind + "/" + backend.Resource.Name
+		}
+
+		return route
+	}
+
+	route.Service = backend.Service.Name
+	route.Port = backend.Service.Port.Name
+
+	if backend.Service.Port.Number != 0 {
+		route.Port = fmt.Sprint(backend.Service.Port.Number)
+	}
+
+	var svc *corev1.Service
+
+	for i := range deps.Services {
+		if deps.Services[i].Namespace == namespace && deps.Services[i].Name == route.Service {
+			svc = &deps.Services[i]
+
+			break
+		}
+	}
+
+	if svc == nil {
+		route.Problem = "service not found"
+
+		return route
+	}
+
+	if !serviceHasPort(svc, backend.Service.Port) {
+		route.Problem = fmt.Sprintf("service has no port %s", route.Port)
+
+		return route
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return route
+	}
+
+	for _, ep := range ServiceEndpoints(svc, deps.EndpointSlices) {
+		if ep.Ready {
+			route.Ready++
+		} else {
+			route.NotReady++
+		}
+	}
+
+	if route.Ready == 0 {
+		route.Problem = "no ready endpoints"
+	}
+
+	return route
+}
+
+func serviceHasPort(svc *corev1.Service, port networkingv1.ServiceBackendPort) bool {
+	for _, p := range svc.Spec.Ports {
+		if (port.Number != 0 && p.Port == port.Number) || (port.Name != "" && p.Name == port.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resolveTLS(namespace string, tls networkingv1.IngressTLS, deps *IngressDependencies) IngressTLSStatus {
+	status := IngressTLSStatus{SecretName: tls.SecretName, Hosts: tls.Hosts}
+
+	// Without a secret name the controller's default certificate is used
+	if tls.SecretName == "" || deps.Secrets == nil {
+		return status
+	}
+
+	secret, ok := deps.Secrets[namespace+"/"+tls.SecretName]
+	if !ok {
+		status.Problem = "secret not found"
+
+		return status
+	}
+
+	info, err := ParseTLSSecret(secret)
+	if err != nil {
+		status.Problem = err.Error()
+
+		return status
+	}
+
+	status.Info = info
+
+	return status
+}
+
+// BrokenRoutes counts routes that can't serve traffic.
+func (r *IngressReport) BrokenRoutes() int {
+	broken := 0
+
+	for _, route := range r.Routes {
+		if route.Problem != "" {
+			broken++
+		}
+	}
+
+	return broken
+}
+
+// WorstCertificate returns the TLS entry needing attention first: one with a
+// problem, otherwise the certificate expiring soonest. ok is false when the
+// ingress has no checked TLS entry.
+func (r *IngressReport) WorstCertificate() (IngressTLSStatus, bool) {
+	var (
+		worst IngressTLSStatus
+		found bool
+	)
+
+	for _, tls := range r.TLS {
+		switch {
+		case tls.Problem != "":
+			return tls, true
+		case tls.Info == nil:
+			continue
+		case !found || tls.Info.NotAfter.Before(worst.Info.NotAfter):
+			worst = tls
+			found = true
+		}
+	}
+
+	return worst, found
+}
@@ -0,0 +1,204 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func ingressPath(path, service string, port networkingv1.ServiceBackendPort) networkingv1.HTTPIngressPath {
+	return networkingv1.HTTPIngressPath{
+		Path: path,
+		Backend: networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{Name: service, Port: port},
+		},
+	}
+}
+
+func testIngress(class *string, paths ...networkingv1.HTTPIngressPath) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: class,
+			TLS:              []networkingv1.IngressTLS{{SecretName: "web-tls", Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{{
+				Host: "web.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+				},
+			}},
+		},
+	}
+}
+
+func testIngressDependencies(t *testing.T) *IngressDependencies {
+	t.Helper()
+
+	svc := *testServiceWithSelector("web", intstr.FromInt32(8080))
+	svc.Spec.Ports[0].Name = "http"
+	idle := *testServiceWithSelector("idle", intstr.FromInt32(8080))
+
+	now := time.Now()
+	cert, key := testCertificate(t, now.Add(-time.Hour), now.Add(10*24*time.Hour))
+
+	return &IngressDependencies{
+		Services: []corev1.Service{svc, idle},
+		EndpointSlices: []discoveryv1.EndpointSlice{
+			*testEndpointSlice("web-1", "web", testEndpoint("10.0.0.1", "web-a", "node-1", true)),
+			*testEndpointSlice("idle-1", "idle", testEndpoint("10.0.0.2", "idle-a", "node-1", false)),
+		},
+		Secrets: IndexSecrets([]corev1.Secret{*tlsSecret("web-tls", cert, key)}),
+		Classes: IndexIngressClasses([]networkingv1.IngressClass{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nginx",
+				Annotations: map[string]string{defaultIngressClassAnnotation: "true"},
+			},
+			Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		}}),
+	}
+}
+
+func TestValidateIngressRoutes(t *testing.T) {
+	deps := testIngressDependencies(t)
+	ing := testIngress(nil,
+		ingressPath("/", "web", networkingv1.ServiceBackendPort{Number: 80}),
+		ingressPath("/named", "web", networkingv1.ServiceBackendPort{Name: "http"}),
+		ingressPath("/badport", "web", networkingv1.ServiceBackendPort{Number: 9090}),
+		ingressPath("/gone", "gone", networkingv1.ServiceBackendPort{Number: 80}),
+		ingressPath("/idle", "idle", networkingv1.ServiceBackendPort{Number: 80}),
+	)
+
+	report := ValidateIngress(ing, deps)
+
+	want := []struct {
+		path    string
+		problem string
+	}{
+		{"/", ""},
+		{"/named", ""},
+		{"/badport", "service has no port 9090"},
+		{"/gone", "service not found"},
+		{"/idle", "no ready endpoints"},
+	}
+
+	if len(report.Routes) != len(want) {
+		t.Fatalf("expected %d routes, got %+v", len(want), report.Routes)
+	}
+
+	for i, w := range want {
+		route := report.Routes[i]
+		if route.Path != w.path || route.Problem != w.problem {
+			t.Errorf("route %d = %s %q, want %s %q", i, route.Path, route.Problem, w.path, w.problem)
+		}
+	}
+
+	if report.Routes[0].Ready != 1 || report.Routes[0].Host != "web.example.com" {
+		t.Errorf("unexpected healthy route %+v", report.Routes[0])
+	}
+
+	if report.BrokenRoutes() != 3 {
+		t.Errorf("BrokenRoutes() = %d, want 3", report.BrokenRoutes())
+	}
+}
+
+func TestValidateIngressClass(t *testing.T) {
+	deps := testIngressDependencies(t)
+	missing := "traefik"
+
+	report := ValidateIngress(testIngress(nil), deps)
+	if report.Class != "nginx" || report.Controller != "k8s.io/ingress-nginx" || report.ClassProblem != "" {
+		t.Errorf("expected default class nginx, got %+v", report)
+	}
+
+	report = ValidateIngress(testIngress(&missing), deps)
+	if report.ClassProblem != "IngressClass traefik not found" {
+		t.Errorf("ClassProblem = %q", report.ClassProblem)
+	}
+
+	deps.Classes["traefik"] = &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "traefik",
+			Annotations: map[string]string{defaultIngressClassAnnotation: "true"},
+		},
+		Spec: networkingv1.IngressClassSpec{Controller: "traefik.io/ingress-controller"},
+	}
+
+	report = ValidateIngress(testIngress(nil), deps)
+	if report.Class != "nginx" || report.ClassProblem != "several default IngressClasses: nginx, traefik" {
+		t.Errorf("expected the conflict reported with nginx picked, got %+v", report)
+	}
+
+	deps.Classes = map[string]*networkingv1.IngressClass{}
+
+	report = ValidateIngress(testIngress(nil), deps)
+	if report.ClassProblem == "" {
+		t.Error("expected a problem without any default class")
+	}
+
+	// Unknown classes (list not permitted) skip the check
+	deps.Classes = nil
+
+	report = ValidateIngress(testIngress(&missing), deps)
+	if report.ClassProblem != "" {
+		t.Errorf("expected no class check without class list, got %q", report.ClassProblem)
+	}
+}
+
+func TestValidateIngressTLS(t *testing.T) {
+	deps := testIngressDependencies(t)
+
+	report := ValidateIngress(testIngress(nil), deps)
+
+	tls, ok := report.WorstCertificate()
+	if !ok || tls.Info == nil || tls.Problem != "" {
+		t.Fatalf("expected parsed certificate, got %+v", tls)
+	}
+
+	if days := tls.Info.DaysUntilExpiry(time.Now()); days < 9 || days > 10 {
+		t.Errorf("DaysUntilExpiry = %d, want ~10", days)
+	}
+
+	deps.Secrets = IndexSecrets(nil)
+
+	report = ValidateIngress(testIngress(nil), deps)
+
+	tls, ok = report.WorstCertificate()
+	if !ok || tls.Problem != "secret not found" {
+		t.Errorf("expected missing secret, got %+v", tls)
+	}
+}
+
+func TestListIngressesAndClasses(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		testIngress(nil),
+		&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+	)
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	ingresses, err := client.ListIngresses(ctx, "")
+	if err != nil || len(ingresses) != 1 {
+		t.Fatalf("ListIngresses() = %d, %v", len(ingresses), err)
+	}
+
+	classes, err := client.ListIngressClasses(ctx)
+	if err != nil || len(classes) != 1 {
+		t.Fatalf("ListIngressClasses() = %d, %v", len(classes), err)
+	}
+
+	if err := client.DeleteIngress(ctx, "default", "web"); err != nil {
+		t.Fatalf("DeleteIngress() error = %v", err)
+	}
+
+	ingresses, _ = client.ListIngressesAllNamespaces(ctx)
+	if len(ingresses) != 0 {
+		t.Errorf("expected ingress to be deleted, got %d", len(ingresses))
+	}
+}
@@ -1,10 +1,32 @@
 package k8s
 
 import (
+	"context"
+
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func (c *Client) ListJobs(ctx context.Context, namespace string) ([]batchv1.Job, error) {
+	namespace = c.ns(namespace)
+
+	list, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListJobsAllNamespaces(ctx context.Context) ([]batchv1.Job, error) {
+	list, err := c.clientset.BatchV1().Jobs("").List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
 // GetJobPodSelector returns the Job's pod selector as a string usable with
 // List(ListOptions{LabelSelector: ...}). The API server auto-populates
 // Spec.Selector with a controller-uid label, so matching is reliable even for
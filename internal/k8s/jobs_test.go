@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListJobs(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "other-namespace"}},
+	)
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	jobs, err := client.ListJobs(ctx, "")
+	if err != nil {
+		t.Fatalf("ListJobs returned unexpected error: %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Name != "migrate" {
+		t.Errorf("ListJobs with empty namespace returned %+v, want only migrate", jobs)
+	}
+
+	jobs, err = client.ListJobsAllNamespaces(ctx)
+	if err != nil {
+		t.Fatalf("ListJobsAllNamespaces returned unexpected error: %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Errorf("ListJobsAllNamespaces returned %d jobs, want 2", len(jobs))
+	}
+}
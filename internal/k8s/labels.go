@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var ErrInvalidLabel = errors.New("invalid label")
+
+// ParseLabels parses whitespace- or comma-separated key=value pairs, as typed
+// into a prompt, validating keys and values against the Kubernetes label
+// syntax. An empty spec yields an empty map.
+func ParseLabels(spec string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: expected key=value", ErrInvalidLabel, field)
+		}
+
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("%w key %q: %s", ErrInvalidLabel, key, strings.Join(errs, "; "))
+		}
+
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("%w value %q: %s", ErrInvalidLabel, value, strings.Join(errs, "; "))
+		}
+
+		labels[key] = value
+	}
+
+	return labels, nil
+}
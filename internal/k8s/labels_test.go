@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels("team=payments, env=prod pod-security.kubernetes.io/enforce=baseline")
+	if err != nil {
+		t.Fatalf("ParseLabels returned unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"team":                               "payments",
+		"env":                                "prod",
+		"pod-security.kubernetes.io/enforce": "baseline",
+	}
+
+	if len(labels) != len(want) {
+		t.Fatalf("ParseLabels = %v, want %v", labels, want)
+	}
+
+	for k, v := range want {
+		if labels[k] != v {
+			t.Errorf("labels[%q] = %q, want %q", k, labels[k], v)
+		}
+	}
+}
+
+func TestParseLabels_Empty(t *testing.T) {
+	labels, err := ParseLabels("  ")
+	if err != nil || len(labels) != 0 {
+		t.Errorf("ParseLabels(blank) = %v, %v; want empty map", labels, err)
+	}
+}
+
+func TestParseLabels_Invalid(t *testing.T) {
+	for _, spec := range []string{"novalue", "bad key=x", "-start=x", "key=has space", "key=" + string(make([]byte, 64))} {
+		if _, err := ParseLabels(spec); !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("ParseLabels(%q) error = %v, want ErrInvalidLabel", spec, err)
+		}
+	}
+}
@@ -0,0 +1,369 @@
+package k8s
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+var ErrUnknownLintRule = errors.New("unknown lint rule")
+
+// LintRule identifies a best-practice check. The values are what users put
+// in the lint section of the config file.
+type LintRule string
+
+const (
+	LintMissingRequests  LintRule = "missing-requests"
+	LintMissingLimits    LintRule = "missing-limits"
+	LintMissingReadiness LintRule = "missing-readiness-probe"
+	LintMissingLiveness  LintRule = "missing-liveness-probe"
+	LintLatestTag        LintRule = "latest-tag"
+	LintPrivileged       LintRule = "privileged"
+	LintRunAsRoot        LintRule = "run-as-root"
+	LintMissingPDB       LintRule = "missing-pdb"
+	LintHostPath         LintRule = "host-path"
+)
+
+// LintRules lists every rule in report order.
+var LintRules = []LintRule{
+	LintMissingRequests, LintMissingLimits, LintMissingReadiness, LintMissingLiveness,
+	LintLatestTag, LintPrivileged, LintRunAsRoot, LintMissingPDB, LintHostPath,
+}
+
+// lintSuppressAll suppresses every rule in a namespace.
+const lintSuppressAll = "*"
+
+// ValidateLintRules returns ErrUnknownLintRule for the first name that isn't
+// a rule or "*".
+func ValidateLintRules(names ...string) error {
+	for _, name := range names {
+		if name != lintSuppressAll && !slices.Contains(LintRules, LintRule(name)) {
+			return fmt.Errorf("%w %q", ErrUnknownLintRule, name)
+		}
+	}
+
+	return nil
+}
+
+// LintPolicy decides which rules run where. A nil policy enables every rule.
+type LintPolicy struct {
+	disabled []string
+	suppress map[string][]string
+}
+
+// NewLintPolicy builds a policy from rules disabled everywhere and rules
+// suppressed per namespace; "*" stands for every rule.
+func NewLintPolicy(disabled []string, suppress map[string][]string) *LintPolicy {
+	return &LintPolicy{disabled: disabled, suppress: suppress}
+}
+
+// Enabled reports whether rule applies to workloads in namespace.
+func (p *LintPolicy) Enabled(rule LintRule, namespace string) bool {
+	if p == nil {
+		return true
+	}
+
+	off := func(names []string) bool {
+		return slices.Contains(names, string(rule)) || slices.Contains(names, lintSuppressAll)
+	}
+
+	return !off(p.disabled) && !off(p.suppress[namespace])
+}
+
+// Suppressed returns the rules turned off in namespace, for reports.
+func (p *LintPolicy) Suppressed(namespace string) []LintRule {
+	var rules []LintRule
+
+	for _, rule := range LintRules {
+		if !p.Enabled(rule, namespace) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// LintFinding is one violated rule. Container is empty for pod-level findings.
+type LintFinding struct {
+	Rule      LintRule
+	Container string
+	Message   string
+}
+
+func (f LintFinding) String() string {
+	if f.Container == "" {
+		return fmt.Sprintf("[%s] %s", f.Rule, f.Message)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", f.Rule, f.Container, f.Message)
+}
+
+// WorkloadLint holds the findings of one workload.
+type WorkloadLint struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Findings  []LintFinding
+}
+
+// LintReport summarises a namespace. Workloads only lists workloads with
+// findings; Scanned counts all of them.
+type LintReport struct {
+	Namespace  string
+	Scanned    int
+	Workloads  []WorkloadLint
+	Suppressed []LintRule
+	// PDBsChecked is false when PodDisruptionBudgets couldn't be listed.
+	PDBsChecked bool
+}
+
+// RuleCounts returns how many findings each rule produced.
+func (r *LintReport) RuleCounts() map[LintRule]int {
+	counts := map[LintRule]int{}
+
+	for _, w := range r.Workloads {
+		for _, f := range w.Findings {
+			counts[f.Rule]++
+		}
+	}
+
+	return counts
+}
+
+// LintNamespace lints every Deployment, StatefulSet, DaemonSet and CronJob
+// in namespace.
+func (c *Client) LintNamespace(ctx context.Context, namespace string, policy *LintPolicy) (*LintReport, error) {
+	namespace = c.ns(namespace)
+
+	deployments, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	statefulSets, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	daemonSets, err := c.ListDaemonSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobs, err := c.ListCronJobs(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without PDBs the missing-pdb rule is skipped rather than reported
+	pdbs, err := c.ListPodDisruptionBudgets(ctx, namespace)
+	if err == nil && pdbs == nil {
+		pdbs = []policyv1.PodDisruptionBudget{}
+	}
+
+	report := &LintReport{
+		Namespace:   namespace,
+		Scanned:     len(deployments) + len(statefulSets) + len(daemonSets) + len(cronJobs),
+		Suppressed:  policy.Suppressed(namespace),
+		PDBsChecked: pdbs != nil,
+	}
+
+	add := func(kind, name string, findings []LintFinding) {
+		if len(findings) > 0 {
+			report.Workloads = append(report.Workloads, WorkloadLint{
+				Kind: kind, Name: name, Namespace: namespace, Findings: findings,
+			})
+		}
+	}
+
+	for i := range deployments {
+		add("Deployment", deployments[i].Name, LintDeployment(&deployments[i], pdbs, policy))
+	}
+
+	for i := range statefulSets {
+		add("StatefulSet", statefulSets[i].Name, LintStatefulSet(&statefulSets[i], policy))
+	}
+
+	for i := range daemonSets {
+		add("DaemonSet", daemonSets[i].Name, LintDaemonSet(&daemonSets[i], policy))
+	}
+
+	for i := range cronJobs {
+		add("CronJob", cronJobs[i].Name, LintCronJob(&cronJobs[i], policy))
+	}
+
+	slices.SortFunc(report.Workloads, func(a, b WorkloadLint) int {
+		return cmp.Or(cmp.Compare(len(b.Findings), len(a.Findings)), cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Name, b.Name))
+	})
+
+	return report, nil
+}
+
+// LintDeployment lints the pod template and, when pdbs is non-nil, flags
+// multi-replica deployments no PodDisruptionBudget covers.
+func LintDeployment(
+	deployment *appsv1.Deployment,
+	pdbs []policyv1.PodDisruptionBudget,
+	policy *LintPolicy,
+) []LintFinding {
+	findings := LintPodSpec(&deployment.Spec.Template.Spec, true)
+
+	if pdbs != nil && GetDeploymentDesiredReplicas(deployment) > 1 &&
+		!PDBCovers(pdbs, deployment.Namespace, deployment.Spec.Template.Labels) {
+		findings = append(findings, LintFinding{
+			Rule:    LintMissingPDB,
+			Message: fmt.Sprintf("%d replicas but no PodDisruptionBudget", GetDeploymentDesiredReplicas(deployment)),
+		})
+	}
+
+	return filterFindings(findings, deployment.Namespace, policy)
+}
+
+func LintStatefulSet(sts *appsv1.StatefulSet, policy *LintPolicy) []LintFinding {
+	return filterFindings(LintPodSpec(&sts.Spec.Template.Spec, true), sts.Namespace, policy)
+}
+
+func LintDaemonSet(ds *appsv1.DaemonSet, policy *LintPolicy) []LintFinding {
+	return filterFindings(LintPodSpec(&ds.Spec.Template.Spec, true), ds.Namespace, policy)
+}
+
+// LintCronJob skips the probe rules, which don't apply to run-to-completion
+// pods.
+func LintCronJob(cj *batchv1.CronJob, policy *LintPolicy) []LintFinding {
+	return filterFindings(LintPodSpec(&cj.Spec.JobTemplate.Spec.Template.Spec, false), cj.Namespace, policy)
+}
+
+func filterFindings(findings []LintFinding, namespace string, policy *LintPolicy) []LintFinding {
+	return slices.DeleteFunc(findings, func(f LintFinding) bool {
+		return !policy.Enabled(f.Rule, namespace)
+	})
+}
+
+// LintPodSpec runs the container and volume rules over a pod spec. Probe
+// rules only apply to longRunning workloads and regular containers.
+func LintPodSpec(spec *corev1.PodSpec, longRunning bool) []LintFinding {
+	var findings []LintFinding
+
+	var (
+		podNonRoot bool
+		podUser    *int64
+	)
+
+	if psc := spec.SecurityContext; psc != nil {
+		podNonRoot = psc.RunAsNonRoot != nil && *psc.RunAsNonRoot
+		podUser = psc.RunAsUser
+	}
+
+	check := func(c *corev1.Container, probes bool) {
+		add := func(rule LintRule, msg string) {
+			findings = append(findings, LintFinding{Rule: rule, Container: c.Name, Message: msg})
+		}
+
+		if missing := missingResources(c.Resources.Requests,
+			corev1.ResourceCPU, corev1.ResourceMemory); len(missing) > 0 {
+			add(LintMissingRequests, "no "+strings.Join(missing, "/")+" request")
+		}
+
+		if missing := missingResources(c.Resources.Limits, corev1.ResourceMemory); len(missing) > 0 {
+			add(LintMissingLimits, "no memory limit")
+		}
+
+		if probes && c.ReadinessProbe == nil {
+			add(LintMissingReadiness, "no readiness probe")
+		}
+
+		if probes && c.LivenessProbe == nil {
+			add(LintMissingLiveness, "no liveness probe")
+		}
+
+		if floatingImageTag(c.Image) {
+			add(LintLatestTag, fmt.Sprintf("image %q is not pinned to a version", c.Image))
+		}
+
+		sc := c.SecurityContext
+		if sc != nil && sc.Privileged != nil && *sc.Privileged {
+			add(LintPrivileged, "runs privileged")
+		}
+
+		if mayRunAsRoot(sc, podNonRoot, podUser) {
+			add(LintRunAsRoot, "may run as root (set runAsNonRoot or a non-zero runAsUser)")
+		}
+	}
+
+	for i := range spec.InitContainers {
+		check(&spec.InitContainers[i], false)
+	}
+
+	for i := range spec.Containers {
+		check(&spec.Containers[i], longRunning)
+	}
+
+	for _, vol := range spec.Volumes {
+		if vol.HostPath != nil {
+			findings = append(findings, LintFinding{
+				Rule:    LintHostPath,
+				Message: fmt.Sprintf("volume %s mounts host path %s", vol.Name, vol.HostPath.Path),
+			})
+		}
+	}
+
+	return findings
+}
+
+func missingResources(list corev1.ResourceList, names ...corev1.ResourceName) []string {
+	var missing []string
+
+	for _, name := range names {
+		if qty, ok := list[name]; !ok || qty.IsZero() {
+			missing = append(missing, string(name))
+		}
+	}
+
+	return missing
+}
+
+// floatingImageTag reports images without a tag or tagged latest. Digests
+// pin an image regardless of tag.
+func floatingImageTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+
+	// The tag follows the last colon after the last slash, so registry
+	// ports aren't mistaken for tags
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	_, tag, ok := strings.Cut(name, ":")
+
+	return !ok || tag == "latest"
+}
+
+func mayRunAsRoot(sc *corev1.SecurityContext, podNonRoot bool, podUser *int64) bool {
+	user := podUser
+	nonRoot := podNonRoot
+
+	if sc != nil {
+		if sc.RunAsUser != nil {
+			user = sc.RunAsUser
+		}
+
+		if sc.RunAsNonRoot != nil {
+			nonRoot = *sc.RunAsNonRoot
+		}
+	}
+
+	if user != nil {
+		return *user == 0
+	}
+
+	return !nonRoot
+}
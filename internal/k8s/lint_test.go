@@ -0,0 +1,216 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+// compliantPodSpec passes every rule.
+func compliantPodSpec() corev1.PodSpec {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+	}}
+
+	return corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)},
+		Containers: []corev1.Container{{
+			Name:  "app",
+			Image: "registry.example.com:5000/team/app:1.4.2",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			ReadinessProbe: probe,
+			LivenessProbe:  probe,
+		}},
+	}
+}
+
+func lintRules(findings []LintFinding) []LintRule {
+	rules := make([]LintRule, 0, len(findings))
+	for _, f := range findings {
+		rules = append(rules, f.Rule)
+	}
+
+	return rules
+}
+
+func TestLintPodSpec(t *testing.T) {
+	spec := compliantPodSpec()
+	if findings := LintPodSpec(&spec, true); len(findings) != 0 {
+		t.Fatalf("compliant spec produced findings: %v", findings)
+	}
+
+	spec.SecurityContext = nil
+	spec.Containers[0].Image = "registry.example.com:5000/team/app"
+	spec.Containers[0].Resources = corev1.ResourceRequirements{}
+	spec.Containers[0].ReadinessProbe = nil
+	spec.Containers[0].LivenessProbe = nil
+	spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+	spec.Volumes = []corev1.Volume{{
+		Name:         "docker",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}},
+	}}
+
+	got := lintRules(LintPodSpec(&spec, true))
+	want := []LintRule{
+		LintMissingRequests, LintMissingLimits, LintMissingReadiness, LintMissingLiveness,
+		LintLatestTag, LintPrivileged, LintRunAsRoot, LintHostPath,
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("rules = %v, want %v", got, want)
+	}
+
+	// Batch workloads aren't expected to have probes
+	if rules := lintRules(LintPodSpec(&spec, false)); slices.Contains(rules, LintMissingReadiness) {
+		t.Errorf("probe rules should not apply to batch pods: %v", rules)
+	}
+}
+
+func TestFloatingImageTag(t *testing.T) {
+	tests := map[string]bool{
+		"nginx":                         true,
+		"nginx:latest":                  true,
+		"nginx:1.27":                    false,
+		"localhost:5000/nginx":          true,
+		"localhost:5000/nginx:1.27":     false,
+		"nginx@sha256:abc":              false,
+		"ghcr.io/org/app:latest@sha256": false,
+	}
+
+	for image, want := range tests {
+		if got := floatingImageTag(image); got != want {
+			t.Errorf("floatingImageTag(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
+
+func TestMayRunAsRoot(t *testing.T) {
+	if !mayRunAsRoot(nil, false, nil) {
+		t.Error("no security context should be flagged")
+	}
+
+	if mayRunAsRoot(nil, false, ptr.To[int64](1000)) {
+		t.Error("pod-level non-zero runAsUser should pass")
+	}
+
+	if !mayRunAsRoot(&corev1.SecurityContext{RunAsUser: ptr.To[int64](0)}, true, nil) {
+		t.Error("explicit runAsUser 0 should be flagged even with runAsNonRoot")
+	}
+}
+
+func TestLintPolicy(t *testing.T) {
+	policy := NewLintPolicy(
+		[]string{string(LintLatestTag)},
+		map[string][]string{"kube-system": {"*"}, "batch": {string(LintMissingLimits)}},
+	)
+
+	if policy.Enabled(LintLatestTag, "default") {
+		t.Error("globally disabled rule should be off")
+	}
+
+	if !policy.Enabled(LintMissingLimits, "default") || policy.Enabled(LintMissingLimits, "batch") {
+		t.Error("namespace suppression should only apply to its namespace")
+	}
+
+	if len(policy.Suppressed("kube-system")) != len(LintRules) {
+		t.Error("* should suppress every rule")
+	}
+
+	var unset *LintPolicy
+	if !unset.Enabled(LintHostPath, "default") {
+		t.Error("nil policy should enable every rule")
+	}
+
+	if err := ValidateLintRules("host-path", "*", "no-such-rule"); !errors.Is(err, ErrUnknownLintRule) {
+		t.Errorf("ValidateLintRules() error = %v, want ErrUnknownLintRule", err)
+	}
+}
+
+func TestLintDeploymentPDB(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec:       compliantPodSpec(),
+			},
+		},
+	}
+	pdb := policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	if rules := lintRules(LintDeployment(deployment, []policyv1.PodDisruptionBudget{}, nil)); !slices.Equal(
+		rules, []LintRule{LintMissingPDB}) {
+		t.Errorf("expected missing-pdb, got %v", rules)
+	}
+
+	if findings := LintDeployment(deployment, []policyv1.PodDisruptionBudget{pdb}, nil); len(findings) != 0 {
+		t.Errorf("covered deployment produced %v", findings)
+	}
+
+	// Unknown PDBs skip the rule
+	if findings := LintDeployment(deployment, nil, nil); len(findings) != 0 {
+		t.Errorf("expected no findings without PDB data, got %v", findings)
+	}
+}
+
+func TestLintNamespace(t *testing.T) {
+	spec := compliantPodSpec()
+	bad := compliantPodSpec()
+	bad.Containers[0].Image = "busybox"
+
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "good", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: bad}},
+			}},
+		},
+	)
+	client := createTestClient(clientset)
+
+	report, err := client.LintNamespace(context.Background(), "default", nil)
+	if err != nil {
+		t.Fatalf("LintNamespace() error = %v", err)
+	}
+
+	if report.Scanned != 2 || len(report.Workloads) != 1 || report.Workloads[0].Name != "nightly" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	if report.RuleCounts()[LintLatestTag] != 1 || !report.PDBsChecked {
+		t.Errorf("RuleCounts() = %v, PDBsChecked = %v", report.RuleCounts(), report.PDBsChecked)
+	}
+
+	policy := NewLintPolicy(nil, map[string][]string{"default": {string(LintLatestTag)}})
+
+	report, _ = client.LintNamespace(context.Background(), "default", policy)
+	if len(report.Workloads) != 0 || len(report.Suppressed) != 1 {
+		t.Errorf("suppressed rule should not be reported: %+v", report)
+	}
+}
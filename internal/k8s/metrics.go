@@ -8,9 +8,23 @@ import (
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// PodMetrics is the usage of a pod, totalled over its containers and kept
+// per container in the order metrics-server reports them.
 type PodMetrics struct {
-	Name      string
+	Name       string
+	Namespace  string
+	CPU        int64 // in millicores
+	Memory     int64 // in bytes
+	Containers []ContainerMetrics
+}
+
+// ContainerMetrics is the usage of one container. Labels are the pod's,
+// which metrics-server copies onto its PodMetrics.
+type ContainerMetrics struct {
 	Namespace string
+	Pod       string
+	Container string
+	Labels    map[string]string
 	CPU       int64 // in millicores
 	Memory    int64 // in bytes
 }
@@ -25,6 +39,12 @@ type MetricsClient struct {
 	client metricsv.Interface
 }
 
+// NewTestMetricsClient wraps a fake metrics clientset for tests outside the
+// k8s package.
+func NewTestMetricsClient(client metricsv.Interface) *MetricsClient {
+	return &MetricsClient{client: client}
+}
+
 func (c *Client) NewMetricsClient() (*MetricsClient, error) {
 	metricsClient, err := metricsv.NewForConfig(c.restConfig)
 	if err != nil {
@@ -34,59 +54,86 @@ func (c *Client) NewMetricsClient() (*MetricsClient, error) {
 	return &MetricsClient{client: metricsClient}, nil
 }
 
-// Silently returns an empty map if metrics-server is unavailable,
-// so callers do not need to handle metrics-server absence.
+// GetPodMetrics returns usage per pod, keyed by namespace/name.
 func (m *MetricsClient) GetPodMetrics(
 	ctx context.Context,
 	namespace string,
 ) (map[string]PodMetrics, error) {
-	result := make(map[string]PodMetrics)
+	containers, err := m.GetContainerMetrics(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return SumPodMetrics(containers), nil
+}
 
+// GetContainerMetrics returns per-container usage. It fails when
+// metrics-server is unavailable, so callers can tell that apart from a
+// namespace with no pods.
+func (m *MetricsClient) GetContainerMetrics(
+	ctx context.Context,
+	namespace string,
+) ([]ContainerMetrics, error) {
 	podMetricsList, err := m.client.MetricsV1beta1().
 		PodMetricses(namespace).
 		List(ctx, metav1.ListOptions{})
 	if err != nil {
-		// Return empty map if metrics-server is not available
-		return result, nil //nolint:nilerr
+		return nil, err
 	}
 
-	for _, pm := range podMetricsList.Items {
-		var totalCPU, totalMemory int64
+	var result []ContainerMetrics
 
+	for _, pm := range podMetricsList.Items {
 		for _, container := range pm.Containers {
-			totalCPU += container.Usage.Cpu().MilliValue()
-			totalMemory += container.Usage.Memory().Value()
-		}
-
-		key := pm.Namespace + "/" + pm.Name
-		result[key] = PodMetrics{
-			Name:      pm.Name,
-			Namespace: pm.Namespace,
-			CPU:       totalCPU,
-			Memory:    totalMemory,
+			result = append(result, ContainerMetrics{
+				Namespace: pm.Namespace,
+				Pod:       pm.Name,
+				Container: container.Name,
+				Labels:    pm.Labels,
+				CPU:       container.Usage.Cpu().MilliValue(),
+				Memory:    container.Usage.Memory().Value(),
+			})
 		}
 	}
 
 	return result, nil
 }
 
+// SumPodMetrics totals container usage per pod, keyed by namespace/name.
+func SumPodMetrics(containers []ContainerMetrics) map[string]PodMetrics {
+	result := make(map[string]PodMetrics)
+
+	for _, c := range containers {
+		key := c.Namespace + "/" + c.Pod
+
+		pod := result[key]
+		pod.Name = c.Pod
+		pod.Namespace = c.Namespace
+		pod.CPU += c.CPU
+		pod.Memory += c.Memory
+		pod.Containers = append(pod.Containers, c)
+		result[key] = pod
+	}
+
+	return result
+}
+
 func (m *MetricsClient) GetAllPodMetrics(ctx context.Context) (map[string]PodMetrics, error) {
 	return m.GetPodMetrics(ctx, "")
 }
 
-// Silently returns an empty map if metrics-server is unavailable,
-// so callers do not need to handle metrics-server absence.
+// GetNodeMetrics returns usage per node, failing like GetContainerMetrics
+// when metrics-server is unavailable.
 func (m *MetricsClient) GetNodeMetrics(ctx context.Context) (map[string]NodeMetrics, error) {
-	result := make(map[string]NodeMetrics)
-
 	nodeMetricsList, err := m.client.MetricsV1beta1().
 		NodeMetricses().
 		List(ctx, metav1.ListOptions{})
 	if err != nil {
-		// Return empty map if metrics-server is not available
-		return result, nil //nolint:nilerr
+		return nil, err
 	}
 
+	result := make(map[string]NodeMetrics, len(nodeMetricsList.Items))
+
 	for _, nm := range nodeMetricsList.Items {
 		result[nm.Name] = NodeMetrics{
 			Name:   nm.Name,
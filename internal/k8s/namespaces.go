@@ -2,14 +2,31 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
+// Pod Security Admission labels; each takes a PodSecurityLevel value.
+const (
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	PodSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	PodSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+)
+
+var ErrInvalidPodSecurityLevel = errors.New("pod security level must be privileged, baseline or restricted")
+
+// PodSecurityLevels lists the Pod Security Standards from least to most
+// restrictive.
+var PodSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
 func (c *Client) ListNamespaces(ctx context.Context) ([]corev1.Namespace, error) {
-	list, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Namespaces().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +43,20 @@ func (c *Client) WatchNamespaces(ctx context.Context) (watch.Interface, error) {
 }
 
 func (c *Client) CreateNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	return c.CreateNamespaceWithLabels(ctx, name, nil)
+}
+
+// CreateNamespaceWithLabels creates a namespace carrying the given labels,
+// e.g. Pod Security Admission levels from PodSecurityLabels.
+func (c *Client) CreateNamespaceWithLabels(
+	ctx context.Context,
+	name string,
+	labels map[string]string,
+) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:   name,
+			Labels: labels,
 		},
 	}
 
@@ -38,3 +66,278 @@ func (c *Client) CreateNamespace(ctx context.Context, name string) (*corev1.Name
 func (c *Client) DeleteNamespace(ctx context.Context, name string) error {
 	return c.clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
 }
+
+// SetNamespaceMetadata replaces the labels and annotations of a namespace.
+func (c *Client) SetNamespaceMetadata(
+	ctx context.Context,
+	name string,
+	labels, annotations map[string]string,
+) error {
+	ns, err := c.GetNamespace(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	ns.Labels = labels
+	ns.Annotations = annotations
+
+	_, err = c.clientset.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+
+	return err
+}
+
+// PodSecurityLabels returns the enforce, audit and warn labels for level so
+// violations are both blocked and reported.
+func PodSecurityLabels(level string) (map[string]string, error) {
+	if !slices.Contains(PodSecurityLevels, level) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPodSecurityLevel, level)
+	}
+
+	return map[string]string{
+		PodSecurityEnforceLabel: level,
+		PodSecurityAuditLabel:   level,
+		PodSecurityWarnLabel:    level,
+	}, nil
+}
+
+func (c *Client) ListResourceQuotasAllNamespaces(ctx context.Context) ([]corev1.ResourceQuota, error) {
+	list, err := c.clientset.CoreV1().ResourceQuotas("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListLimitRangesAllNamespaces(ctx context.Context) ([]corev1.LimitRange, error) {
+	list, err := c.clientset.CoreV1().LimitRanges("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// RemainingResource is an object still present in a terminating namespace.
+type RemainingResource struct {
+	Kind       string
+	Name       string
+	Finalizers []string
+}
+
+// NamespaceBlockers explains why a namespace is stuck in Terminating.
+type NamespaceBlockers struct {
+	// Finalizers are the namespace's own spec and metadata finalizers.
+	Finalizers []string
+	// Conditions are the messages of the namespace controller's deletion
+	// conditions, which also cover custom resources.
+	Conditions []string
+	// Resources are built-in objects that still exist in the namespace.
+	Resources []RemainingResource
+}
+
+// namespaceDeletionConditions are reported by the namespace controller while
+// it can't finish deleting the namespace's content.
+var namespaceDeletionConditions = map[corev1.NamespaceConditionType]bool{
+	corev1.NamespaceDeletionDiscoveryFailure: true,
+	corev1.NamespaceDeletionContentFailure:   true,
+	corev1.NamespaceDeletionGVParsingFailure: true,
+	corev1.NamespaceContentRemaining:         true,
+	corev1.NamespaceFinalizersRemaining:      true,
+}
+
+// NamespaceDeletionConditions returns "Type: message" for each true deletion
+// condition on ns.
+func NamespaceDeletionConditions(ns *corev1.Namespace) []string {
+	var out []string
+
+	for _, cond := range ns.Status.Conditions {
+		if namespaceDeletionConditions[cond.Type] && cond.Status == corev1.ConditionTrue {
+			out = append(out, fmt.Sprintf("%s: %s", cond.Type, cond.Message))
+		}
+	}
+
+	return out
+}
+
+// NamespaceFinalizers returns the spec and metadata finalizers of ns.
+func NamespaceFinalizers(ns *corev1.Namespace) []string {
+	finalizers := make([]string, 0, len(ns.Spec.Finalizers)+len(ns.Finalizers))
+	for _, f := range ns.Spec.Finalizers {
+		finalizers = append(finalizers, string(f))
+	}
+
+	return append(finalizers, ns.Finalizers...)
+}
+
+// GetNamespaceBlockers collects the namespace's finalizers and deletion
+// conditions, and lists the common built-in resources still left in it.
+// Custom resources only show up through the conditions since the client has
+// no dynamic access.
+func (c *Client) GetNamespaceBlockers(ctx context.Context, name string) (*NamespaceBlockers, error) {
+	ns, err := c.GetNamespace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := &NamespaceBlockers{
+		Finalizers: NamespaceFinalizers(ns),
+		Conditions: NamespaceDeletionConditions(ns),
+	}
+
+	for _, lister := range c.namespacedListers() {
+		objects, err := lister.list(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", lister.kind, err)
+		}
+
+		for _, obj := range objects {
+			blockers.Resources = append(blockers.Resources, RemainingResource{
+				Kind:       lister.kind,
+				Name:       obj.GetName(),
+				Finalizers: obj.GetFinalizers(),
+			})
+		}
+	}
+
+	sort.SliceStable(blockers.Resources, func(i, j int) bool {
+		return len(blockers.Resources[i].Finalizers) > len(blockers.Resources[j].Finalizers)
+	})
+
+	return blockers, nil
+}
+
+type namespacedLister struct {
+	kind string
+	list func(ctx context.Context, namespace string) ([]metav1.Object, error)
+}
+
+// namespacedListers covers the built-in kinds most likely to hold up a
+// namespace deletion.
+//
+//nolint:funlen // one short closure per kind
+func (c *Client) namespacedListers() []namespacedLister {
+	core := c.clientset.CoreV1()
+	apps := c.clientset.AppsV1()
+	batch := c.clientset.BatchV1()
+	networking := c.clientset.NetworkingV1()
+
+	return []namespacedLister{
+		{"Pod", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.Pods(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"Service", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.Services(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"PersistentVolumeClaim", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"ConfigMap", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.ConfigMaps(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"Secret", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.Secrets(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"ServiceAccount", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := core.ServiceAccounts(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"Deployment", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := apps.Deployments(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"StatefulSet", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := apps.StatefulSets(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"DaemonSet", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := apps.DaemonSets(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"ReplicaSet", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := apps.ReplicaSets(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"Job", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := batch.Jobs(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"CronJob", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := batch.CronJobs(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+		{"Ingress", func(ctx context.Context, ns string) ([]metav1.Object, error) {
+			list, err := networking.Ingresses(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return objectsOf(list.Items), nil
+		}},
+	}
+}
+
+func objectsOf[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []metav1.Object {
+	objects := make([]metav1.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, PT(&items[i]))
+	}
+
+	return objects
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -177,3 +178,139 @@ func TestWatchNamespaces(t *testing.T) {
 		t.Error("WatchNamespaces returned watcher with nil ResultChan")
 	}
 }
+
+func TestCreateNamespaceWithLabels(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset())
+	ctx := context.Background()
+
+	labels, err := PodSecurityLabels("restricted")
+	if err != nil {
+		t.Fatalf("PodSecurityLabels returned unexpected error: %v", err)
+	}
+
+	labels["team"] = "payments"
+
+	if _, err := client.CreateNamespaceWithLabels(ctx, "payments", labels); err != nil {
+		t.Fatalf("CreateNamespaceWithLabels returned unexpected error: %v", err)
+	}
+
+	ns, err := client.GetNamespace(ctx, "payments")
+	if err != nil {
+		t.Fatalf("GetNamespace returned unexpected error: %v", err)
+	}
+
+	for _, key := range []string{PodSecurityEnforceLabel, PodSecurityAuditLabel, PodSecurityWarnLabel} {
+		if ns.Labels[key] != "restricted" {
+			t.Errorf("label %s = %q, want %q", key, ns.Labels[key], "restricted")
+		}
+	}
+
+	if ns.Labels["team"] != "payments" {
+		t.Errorf("team label = %q, want %q", ns.Labels["team"], "payments")
+	}
+}
+
+func TestPodSecurityLabels_InvalidLevel(t *testing.T) {
+	if _, err := PodSecurityLabels("strict"); !errors.Is(err, ErrInvalidPodSecurityLevel) {
+		t.Errorf("expected ErrInvalidPodSecurityLevel, got %v", err)
+	}
+}
+
+func TestSetNamespaceMetadata(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "apps",
+				Labels:      map[string]string{"old": "label"},
+				Annotations: map[string]string{"old": "annotation"},
+			},
+		},
+	)
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	err := client.SetNamespaceMetadata(
+		ctx, "apps",
+		map[string]string{"team": "web"},
+		map[string]string{"owner": "alice@example.com"},
+	)
+	if err != nil {
+		t.Fatalf("SetNamespaceMetadata returned unexpected error: %v", err)
+	}
+
+	ns, err := client.GetNamespace(ctx, "apps")
+	if err != nil {
+		t.Fatalf("GetNamespace returned unexpected error: %v", err)
+	}
+
+	if _, ok := ns.Labels["old"]; ok || ns.Labels["team"] != "web" {
+		t.Errorf("labels were not replaced: %v", ns.Labels)
+	}
+
+	if _, ok := ns.Annotations["old"]; ok || ns.Annotations["owner"] != "alice@example.com" {
+		t.Errorf("annotations were not replaced: %v", ns.Annotations)
+	}
+}
+
+func TestGetNamespaceBlockers(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck"},
+			Spec: corev1.NamespaceSpec{
+				Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes},
+			},
+			Status: corev1.NamespaceStatus{
+				Phase: corev1.NamespaceTerminating,
+				Conditions: []corev1.NamespaceCondition{
+					{
+						Type:    corev1.NamespaceFinalizersRemaining,
+						Status:  corev1.ConditionTrue,
+						Message: "Some content in the namespace has finalizers remaining",
+					},
+					{
+						Type:   corev1.NamespaceDeletionDiscoveryFailure,
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "stuck"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "data",
+				Namespace:  "stuck",
+				Finalizers: []string{"kubernetes.io/pvc-protection"},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "default"},
+		},
+	)
+
+	client := createTestClient(clientset)
+
+	blockers, err := client.GetNamespaceBlockers(context.Background(), "stuck")
+	if err != nil {
+		t.Fatalf("GetNamespaceBlockers returned unexpected error: %v", err)
+	}
+
+	if len(blockers.Finalizers) != 1 || blockers.Finalizers[0] != "kubernetes" {
+		t.Errorf("Finalizers = %v, want [kubernetes]", blockers.Finalizers)
+	}
+
+	if len(blockers.Conditions) != 1 {
+		t.Errorf("Conditions = %v, want only the true deletion condition", blockers.Conditions)
+	}
+
+	if len(blockers.Resources) != 2 {
+		t.Fatalf("Resources = %+v, want the PVC and the ConfigMap", blockers.Resources)
+	}
+
+	// Resources holding finalizers come first
+	if first := blockers.Resources[0]; first.Kind != "PersistentVolumeClaim" || len(first.Finalizers) != 1 {
+		t.Errorf("first resource = %+v, want the PVC with its finalizer", first)
+	}
+}
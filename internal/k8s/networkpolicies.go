@@ -18,7 +18,7 @@ func (c *Client) ListNetworkPolicies(
 
 	list, err := c.clientset.NetworkingV1().
 		NetworkPolicies(namespace).
-		List(ctx, metav1.ListOptions{})
+		List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +31,7 @@ func (c *Client) ListNetworkPoliciesAllNamespaces(
 ) ([]networkingv1.NetworkPolicy, error) {
 	list, err := c.clientset.NetworkingV1().
 		NetworkPolicies("").
-		List(ctx, metav1.ListOptions{})
+		List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ type NodeInfo struct {
 }
 
 func (c *Client) ListNodes(ctx context.Context) ([]corev1.Node, error) {
-	list, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Nodes().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
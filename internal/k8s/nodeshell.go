@@ -0,0 +1,185 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// NodeShellLabel marks the pods node shells run in, so ones left behind
+// can be found.
+const NodeShellLabel = "lazy-k8s/node-shell"
+
+// NodeShellContainer is the container of a node shell pod.
+const NodeShellContainer = "shell"
+
+const (
+	// NodeShellOwnerAnnotation names the host and process that created a
+	// node shell pod.
+	NodeShellOwnerAnnotation = "lazy-k8s/owner"
+	// NodeShellHeartbeatAnnotation is when the session using a node shell
+	// pod last said it was alive.
+	NodeShellHeartbeatAnnotation = "lazy-k8s/heartbeat"
+)
+
+const (
+	// NodeShellHeartbeatInterval is how often a session refreshes its
+	// pod's heartbeat.
+	NodeShellHeartbeatInterval = 30 * time.Second
+	// NodeShellHeartbeatTimeout is how old a heartbeat gets before the pod
+	// counts as abandoned, allowing for a few missed beats.
+	NodeShellHeartbeatTimeout = 3 * time.Minute
+	// NodeShellMaxLifetime is a hard limit on a node shell pod's life, for
+	// when nothing is left to delete it.
+	NodeShellMaxLifetime = 12 * time.Hour
+)
+
+// NodeShellOptions sets up the pods node shells run in.
+type NodeShellOptions struct {
+	Namespace   string
+	Image       string
+	Tolerations []corev1.Toleration
+}
+
+// NodeShellPod is a privileged pod on a node that enters the host's
+// namespaces with nsenter and runs a shell there. Its stdin closes when
+// the first session attached to it ends, so the shell exits even if
+// lazy-k8s dies without deleting the pod. Pods never attached to are
+// found by their stale heartbeat, and none outlives NodeShellMaxLifetime.
+func NodeShellPod(node string, opts NodeShellOptions, now time.Time) *corev1.Pod {
+	privileged := true
+	gracePeriod := int64(0)
+	deadline := int64(NodeShellMaxLifetime.Seconds())
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node-shell-" + utilrand.String(5),
+			Namespace: opts.Namespace,
+			Labels:    map[string]string{NodeShellLabel: "true"},
+			Annotations: map[string]string{
+				NodeShellOwnerAnnotation:     nodeShellOwner(),
+				NodeShellHeartbeatAnnotation: now.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                      node,
+			ActiveDeadlineSeconds:         &deadline,
+			HostPID:                       true,
+			HostNetwork:                   true,
+			HostIPC:                       true,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: &gracePeriod,
+			Tolerations:                   opts.Tolerations,
+			Containers: []corev1.Container{{
+				Name:  NodeShellContainer,
+				Image: opts.Image,
+				Command: []string{
+					"nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--",
+					"/bin/sh", "-c", "if command -v bash > /dev/null; then exec bash -l; else exec sh -l; fi",
+				},
+				Stdin:           true,
+				StdinOnce:       true,
+				TTY:             true,
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			}},
+		},
+	}
+}
+
+// CreateNodeShell creates the pod for a shell on a node.
+func (c *Client) CreateNodeShell(ctx context.Context, node string, opts NodeShellOptions) (*corev1.Pod, error) {
+	pod := NodeShellPod(node, opts, time.Now())
+
+	return c.clientset.CoreV1().Pods(c.ns(opts.Namespace)).Create(ctx, pod, metav1.CreateOptions{})
+}
+
+// nodeShellOwner identifies this lazy-k8s for people looking at the pod.
+func nodeShellOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return host + "/" + strconv.Itoa(os.Getpid())
+}
+
+// KeepNodeShellAlive refreshes a node shell pod's heartbeat until ctx is
+// done, so the startup sweep leaves it alone while it is in use.
+func (c *Client) KeepNodeShellAlive(ctx context.Context, namespace, name string) {
+	ticker := time.NewTicker(NodeShellHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			// A missed beat is made up by the next one
+			_ = c.NodeShellHeartbeat(ctx, namespace, name, now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// NodeShellHeartbeat records that the session using a node shell pod is
+// alive.
+func (c *Client) NodeShellHeartbeat(ctx context.Context, namespace, name string, now time.Time) error {
+	patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:%q}}}`,
+		NodeShellHeartbeatAnnotation, now.UTC().Format(time.RFC3339))
+
+	_, err := c.clientset.CoreV1().Pods(c.ns(namespace)).Patch(
+		ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{},
+	)
+
+	return err
+}
+
+// SweepNodeShells deletes the node shell pods in a namespace that a
+// crashed session left behind, returning how many it deleted.
+func (c *Client) SweepNodeShells(ctx context.Context, namespace string, now time.Time) (int, error) {
+	pods := c.clientset.CoreV1().Pods(c.ns(namespace))
+
+	list, err := pods.List(ctx, metav1.ListOptions{LabelSelector: NodeShellLabel})
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+
+	for _, pod := range list.Items {
+		if !nodeShellLeftBehind(&pod, now) {
+			continue
+		}
+
+		if err := pods.Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// nodeShellLeftBehind reports whether no session can still be using a node
+// shell pod: its shell ended, or no session has refreshed its heartbeat
+// lately, like one that died while waiting for the pod to start. Shells
+// another lazy-k8s is using are left alone.
+func nodeShellLeftBehind(pod *corev1.Pod, now time.Time) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+
+	lastSeen := pod.CreationTimestamp.Time
+	if heartbeat, err := time.Parse(time.RFC3339, pod.Annotations[NodeShellHeartbeatAnnotation]); err == nil {
+		lastSeen = heartbeat
+	}
+
+	return now.Sub(lastSeen) > NodeShellHeartbeatTimeout
+}
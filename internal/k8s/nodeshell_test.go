@@ -0,0 +1,143 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNodeShellPod(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	pod := NodeShellPod("worker-1", NodeShellOptions{
+		Namespace:   "ops",
+		Image:       "alpine",
+		Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+	}, now)
+
+	if pod.Namespace != "ops" || pod.Labels[NodeShellLabel] != "true" {
+		t.Errorf("pod %s/%s labels %v, want a labelled pod in ops", pod.Namespace, pod.Name, pod.Labels)
+	}
+
+	spec := pod.Spec
+	if spec.NodeName != "worker-1" || !spec.HostPID || !spec.HostNetwork {
+		t.Error("the pod should run on worker-1 in the host's PID and network namespaces")
+	}
+
+	if pod.Annotations[NodeShellOwnerAnnotation] == "" ||
+		pod.Annotations[NodeShellHeartbeatAnnotation] != "2026-01-02T03:04:05Z" {
+		t.Errorf("annotations = %v, want an owner and a first heartbeat", pod.Annotations)
+	}
+
+	if spec.ActiveDeadlineSeconds == nil || *spec.ActiveDeadlineSeconds != int64(NodeShellMaxLifetime.Seconds()) {
+		t.Error("the pod should have a deadline as a backstop")
+	}
+
+	if len(spec.Tolerations) != 1 || spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("tolerations %v, restart policy %s", spec.Tolerations, spec.RestartPolicy)
+	}
+
+	shell := spec.Containers[0]
+	if shell.Image != "alpine" || shell.Command[0] != "nsenter" {
+		t.Errorf("container runs %v in %s, want nsenter in alpine", shell.Command, shell.Image)
+	}
+
+	if !*shell.SecurityContext.Privileged || !shell.StdinOnce || !shell.TTY {
+		t.Error("the shell should be privileged, on a TTY and end with its session")
+	}
+}
+
+func TestCreateNodeShell(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset())
+
+	opts := NodeShellOptions{Namespace: "ops", Image: "busybox"}
+
+	pod, err := client.CreateNodeShell(context.Background(), "worker-1", opts)
+	if err != nil {
+		t.Fatalf("CreateNodeShell returned unexpected error: %v", err)
+	}
+
+	if _, err := client.GetPod(context.Background(), "ops", pod.Name); err != nil {
+		t.Errorf("the node shell pod should have been created: %v", err)
+	}
+}
+
+func TestNodeShellHeartbeat(t *testing.T) {
+	pod := NodeShellPod("worker-1", NodeShellOptions{Namespace: "default"}, time.Now().Add(-time.Hour))
+	client := createTestClient(fake.NewSimpleClientset(pod))
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := client.NodeShellHeartbeat(context.Background(), "default", pod.Name, now); err != nil {
+		t.Fatalf("NodeShellHeartbeat returned unexpected error: %v", err)
+	}
+
+	got, _ := client.GetPod(context.Background(), "default", pod.Name)
+	if got.Annotations[NodeShellHeartbeatAnnotation] != "2026-01-02T03:04:05Z" {
+		t.Errorf("heartbeat = %q", got.Annotations[NodeShellHeartbeatAnnotation])
+	}
+
+	if got.Annotations[NodeShellOwnerAnnotation] == "" {
+		t.Error("the heartbeat shouldn't drop the owner")
+	}
+}
+
+func TestSweepNodeShells(t *testing.T) {
+	now := time.Now()
+
+	shell := func(name string, phase corev1.PodPhase, heartbeat time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{NodeShellLabel: "true"},
+				Annotations:       map[string]string{NodeShellHeartbeatAnnotation: now.Add(-heartbeat).Format(time.RFC3339)},
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	// Created by a run without heartbeats
+	unannotated := shell("unannotated", corev1.PodRunning, 0)
+	unannotated.Annotations = nil
+
+	client := createTestClient(fake.NewSimpleClientset(
+		shell("ended", corev1.PodSucceeded, time.Second),
+		shell("failed", corev1.PodFailed, time.Second),
+		shell("stuck", corev1.PodPending, time.Hour),
+		shell("never-attached", corev1.PodRunning, 10*time.Minute),
+		unannotated,
+		shell("starting", corev1.PodPending, time.Second),
+		shell("in-use", corev1.PodRunning, time.Minute),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"}},
+	))
+
+	deleted, err := client.SweepNodeShells(context.Background(), "default", now)
+	if err != nil {
+		t.Fatalf("SweepNodeShells returned unexpected error: %v", err)
+	}
+
+	if deleted != 5 {
+		t.Errorf("deleted %d pods, want 5", deleted)
+	}
+
+	left, _ := client.ListPods(context.Background(), "default")
+
+	names := make(map[string]bool)
+	for _, pod := range left {
+		names[pod.Name] = true
+	}
+
+	for _, name := range []string{"starting", "in-use", "unrelated"} {
+		if !names[name] {
+			t.Errorf("%s should have been kept", name)
+		}
+	}
+
+	if names["never-attached"] {
+		t.Error("a running shell nobody keeps alive should be swept")
+	}
+}
@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (c *Client) ListPodDisruptionBudgets(
+	ctx context.Context,
+	namespace string,
+) ([]policyv1.PodDisruptionBudget, error) {
+	namespace = c.ns(namespace)
+
+	list, err := c.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListPodDisruptionBudgetsAllNamespaces(
+	ctx context.Context,
+) ([]policyv1.PodDisruptionBudget, error) {
+	list, err := c.clientset.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// PDBCovers reports whether a PodDisruptionBudget in namespace selects pods
+// carrying podLabels.
+func PDBCovers(pdbs []policyv1.PodDisruptionBudget, namespace string, podLabels map[string]string) bool {
+	for i := range pdbs {
+		if pdbs[i].Namespace == namespace && pdbs[i].Spec.Selector != nil &&
+			selectorMatches(pdbs[i].Spec.Selector, podLabels) {
+			return true
+		}
+	}
+
+	return false
+}
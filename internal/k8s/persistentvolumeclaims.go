@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (c *Client) ListPVCs(ctx context.Context, namespace string) ([]corev1.PersistentVolumeClaim, error) {
+	namespace = c.ns(namespace)
+
+	list, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) ListPVCsAllNamespaces(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+	list, err := c.clientset.CoreV1().PersistentVolumeClaims("").List(ctx, listOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListPVCs(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "other-namespace"}},
+	)
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	pvcs, err := client.ListPVCs(ctx, "")
+	if err != nil {
+		t.Fatalf("ListPVCs returned unexpected error: %v", err)
+	}
+
+	if len(pvcs) != 1 || pvcs[0].Name != "data" {
+		t.Errorf("ListPVCs with empty namespace returned %+v, want only data", pvcs)
+	}
+
+	pvcs, err = client.ListPVCsAllNamespaces(ctx)
+	if err != nil {
+		t.Fatalf("ListPVCsAllNamespaces returned unexpected error: %v", err)
+	}
+
+	if len(pvcs) != 2 {
+		t.Errorf("ListPVCsAllNamespaces returned %d PVCs, want 2", len(pvcs))
+	}
+}
@@ -13,7 +13,7 @@ import (
 func (c *Client) ListPods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +22,7 @@ func (c *Client) ListPods(ctx context.Context, namespace string) ([]corev1.Pod,
 }
 
 func (c *Client) ListPodsAllNamespaces(ctx context.Context) ([]corev1.Pod, error) {
-	list, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Pods("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,365 @@
+package k8s
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// HighRestartThreshold is the restart count at which a pod is reported even
+// if it is currently running.
+const HighRestartThreshold = 5
+
+// ProblemSeverity orders problems; lower values are more urgent.
+type ProblemSeverity int
+
+const (
+	SeverityCritical ProblemSeverity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s ProblemSeverity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "Critical"
+	case SeverityWarning:
+		return "Warning"
+	default:
+		return "Info"
+	}
+}
+
+// Problem is one thing to fix, attributed to the resource that shows it.
+// Kind is the resource kind as used by BuildRelationTree.
+type Problem struct {
+	Severity  ProblemSeverity
+	Kind      string
+	Name      string
+	Namespace string
+	Reason    string
+	Message   string
+}
+
+// ProblemSnapshot holds the resources a scan looks at. Nil slices are
+// skipped, so a missing list permission only hides that kind's problems.
+type ProblemSnapshot struct {
+	Pods        []corev1.Pod
+	Deployments []appsv1.Deployment
+	Jobs        []batchv1.Job
+	CronJobs    []batchv1.CronJob
+	Nodes       []corev1.Node
+	PVCs        []corev1.PersistentVolumeClaim
+	HPAs        []autoscalingv2.HorizontalPodAutoscaler
+}
+
+// ScanProblems lists the current namespace (or all namespaces) and returns
+// its problems sorted by severity. Only listing pods is required to succeed.
+func (c *Client) ScanProblems(ctx context.Context, allNamespaces bool) ([]Problem, error) {
+	pods, err := scanList(ctx, allNamespaces, c.ListPods, c.ListPodsAllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &ProblemSnapshot{Pods: pods}
+
+	snapshot.Deployments, _ = scanList(ctx, allNamespaces, c.ListDeployments, c.ListDeploymentsAllNamespaces)
+	snapshot.Jobs, _ = scanList(ctx, allNamespaces, c.ListJobs, c.ListJobsAllNamespaces)
+	snapshot.CronJobs, _ = scanList(ctx, allNamespaces, c.ListCronJobs, c.ListCronJobsAllNamespaces)
+	snapshot.PVCs, _ = scanList(ctx, allNamespaces, c.ListPVCs, c.ListPVCsAllNamespaces)
+	snapshot.HPAs, _ = scanList(ctx, allNamespaces, c.ListHPAs, c.ListHPAsAllNamespaces)
+	snapshot.Nodes, _ = c.ListNodes(ctx)
+
+	return DetectProblems(snapshot), nil
+}
+
+// scanList lists a kind in the current namespace or in all of them.
+func scanList[T any](
+	ctx context.Context,
+	allNamespaces bool,
+	inNamespace func(context.Context, string) ([]T, error),
+	inAll func(context.Context) ([]T, error),
+) ([]T, error) {
+	if allNamespaces {
+		return inAll(ctx)
+	}
+
+	return inNamespace(ctx, "")
+}
+
+// DetectProblems reports the problems found in snapshot, most severe first,
+// then by kind, namespace and name.
+func DetectProblems(snapshot *ProblemSnapshot) []Problem {
+	var problems []Problem
+
+	for i := range snapshot.Pods {
+		if p, ok := podProblem(&snapshot.Pods[i]); ok {
+			problems = append(problems, p)
+		}
+	}
+
+	for i := range snapshot.Deployments {
+		if p, ok := deploymentProblem(&snapshot.Deployments[i]); ok {
+			problems = append(problems, p)
+		}
+	}
+
+	for i := range snapshot.Jobs {
+		if p, ok := jobProblem(&snapshot.Jobs[i]); ok {
+			problems = append(problems, p)
+		}
+	}
+
+	for _, cj := range snapshot.CronJobs {
+		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+			problems = append(problems, Problem{
+				Severity: SeverityInfo, Kind: "CronJob", Name: cj.Name, Namespace: cj.Namespace,
+				Reason: "Suspended", Message: "no new jobs are scheduled until the cronjob is resumed",
+			})
+		}
+	}
+
+	for i := range snapshot.Nodes {
+		problems = append(problems, nodeProblems(&snapshot.Nodes[i])...)
+	}
+
+	for i := range snapshot.PVCs {
+		if p, ok := pvcProblem(&snapshot.PVCs[i]); ok {
+			problems = append(problems, p)
+		}
+	}
+
+	for i := range snapshot.HPAs {
+		if p, ok := hpaProblem(&snapshot.HPAs[i]); ok {
+			problems = append(problems, p)
+		}
+	}
+
+	slices.SortFunc(problems, func(a, b Problem) int {
+		return cmp.Or(
+			cmp.Compare(a.Severity, b.Severity),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return problems
+}
+
+// criticalPodStatuses are GetPodStatus results that keep a pod from running.
+var criticalPodStatuses = []string{
+	"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName",
+	"CreateContainerConfigError", "CreateContainerError", "RunContainerError", "OOMKilled",
+}
+
+func podProblem(pod *corev1.Pod) (Problem, bool) {
+	problem := Problem{Kind: "Pod", Name: pod.Name, Namespace: pod.Namespace}
+	status := GetPodStatus(pod)
+	restarts := GetPodRestarts(pod)
+	oomContainer := lastOOMKilledContainer(pod)
+
+	switch {
+	case slices.Contains(criticalPodStatuses, status):
+		problem.Severity = SeverityCritical
+		problem.Reason = status
+		problem.Message = waitingMessage(pod)
+
+		if oomContainer != "" && status != "OOMKilled" {
+			problem.Message = strings.TrimSpace(fmt.Sprintf("container %s was OOMKilled. %s",
+				oomContainer, problem.Message))
+		}
+	case oomContainer != "":
+		problem.Severity = SeverityWarning
+		problem.Reason = "OOMKilled"
+		problem.Message = fmt.Sprintf("container %s was OOMKilled and restarted (%d restarts)", oomContainer, restarts)
+	case pod.Status.Phase == corev1.PodFailed:
+		problem.Severity = SeverityWarning
+		problem.Reason = "Failed"
+		problem.Message = pod.Status.Message
+	case unschedulable(pod):
+		problem.Severity = SeverityWarning
+		problem.Reason = "Unschedulable"
+		problem.Message = podConditionMessage(pod, corev1.PodScheduled)
+	case restarts >= HighRestartThreshold:
+		problem.Severity = SeverityWarning
+		problem.Reason = "HighRestarts"
+		problem.Message = fmt.Sprintf("%d container restarts", restarts)
+	default:
+		return Problem{}, false
+	}
+
+	return problem, true
+}
+
+func lastOOMKilledContainer(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+			return cs.Name
+		}
+	}
+
+	return ""
+}
+
+func waitingMessage(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Message != "" {
+			return fmt.Sprintf("container %s: %s", cs.Name, cs.State.Waiting.Message)
+		}
+	}
+
+	return ""
+}
+
+func unschedulable(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse &&
+			cond.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+
+	return false
+}
+
+func podConditionMessage(pod *corev1.Pod, condType corev1.PodConditionType) string {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == condType {
+			return cond.Message
+		}
+	}
+
+	return ""
+}
+
+func deploymentProblem(deployment *appsv1.Deployment) (Problem, bool) {
+	problem := Problem{Kind: "Deployment", Name: deployment.Name, Namespace: deployment.Namespace}
+
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			problem.Severity = SeverityCritical
+			problem.Reason = cond.Reason
+			problem.Message = cond.Message
+
+			return problem, true
+		}
+	}
+
+	desired := GetDeploymentDesiredReplicas(deployment)
+	if deployment.Status.ReadyReplicas >= desired {
+		return Problem{}, false
+	}
+
+	problem.Severity = SeverityWarning
+	if deployment.Status.ReadyReplicas == 0 {
+		problem.Severity = SeverityCritical
+	}
+
+	problem.Reason = "NotReady"
+	problem.Message = GetDeploymentReadyCount(deployment) + " replicas ready"
+
+	return problem, true
+}
+
+func jobProblem(job *batchv1.Job) (Problem, bool) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return Problem{
+				Severity: SeverityCritical, Kind: "Job", Name: job.Name, Namespace: job.Namespace,
+				Reason: "Failed", Message: strings.TrimSpace(cond.Reason + ": " + cond.Message),
+			}, true
+		}
+	}
+
+	return Problem{}, false
+}
+
+func nodeProblems(node *corev1.Node) []Problem {
+	var problems []Problem
+
+	if status := GetNodeStatus(node); status != "Ready" {
+		problems = append(problems, Problem{
+			Severity: SeverityCritical, Kind: "Node", Name: node.Name,
+			Reason: status, Message: nodeConditionMessage(node, corev1.NodeReady),
+		})
+	}
+
+	for _, cond := range node.Status.Conditions {
+		switch cond.Type {
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure,
+			corev1.NodeNetworkUnavailable:
+			if cond.Status == corev1.ConditionTrue {
+				problems = append(problems, Problem{
+					Severity: SeverityWarning, Kind: "Node", Name: node.Name,
+					Reason: string(cond.Type), Message: cond.Message,
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+func nodeConditionMessage(node *corev1.Node, condType corev1.NodeConditionType) string {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == condType {
+			return cond.Message
+		}
+	}
+
+	return "no Ready condition reported"
+}
+
+func pvcProblem(pvc *corev1.PersistentVolumeClaim) (Problem, bool) {
+	problem := Problem{Kind: "PersistentVolumeClaim", Name: pvc.Name, Namespace: pvc.Namespace}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return Problem{}, false
+	case corev1.ClaimLost:
+		problem.Severity = SeverityCritical
+		problem.Reason = "Lost"
+		problem.Message = "the bound PersistentVolume no longer exists"
+	default:
+		problem.Severity = SeverityWarning
+		problem.Reason = "Unbound"
+		problem.Message = "claim is " + string(pvc.Status.Phase)
+
+		if pvc.Spec.StorageClassName != nil {
+			problem.Message += ", storage class " + *pvc.Spec.StorageClassName
+		}
+	}
+
+	return problem, true
+}
+
+func hpaProblem(hpa *autoscalingv2.HorizontalPodAutoscaler) (Problem, bool) {
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		// ScalingActive=False is how the HPA reports it can't fetch metrics,
+		// and also how it reports being turned off by scaling the target to 0
+		if cond.Type == autoscalingv2.ScalingActive && cond.Reason == "ScalingDisabled" {
+			continue
+		}
+
+		if cond.Type == autoscalingv2.ScalingActive || cond.Type == autoscalingv2.AbleToScale {
+			return Problem{
+				Severity: SeverityWarning, Kind: "HorizontalPodAutoscaler", Name: hpa.Name, Namespace: hpa.Namespace,
+				Reason: cond.Reason, Message: cond.Message,
+			}, true
+		}
+	}
+
+	return Problem{}, false
+}
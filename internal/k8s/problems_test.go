@@ -0,0 +1,209 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func problemPod(name string, status corev1.ContainerStatus) corev1.Pod {
+	status.Name = "app"
+
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{status},
+		},
+	}
+}
+
+func findProblem(problems []Problem, kind, name string) (Problem, bool) {
+	for _, p := range problems {
+		if p.Kind == kind && p.Name == name {
+			return p, true
+		}
+	}
+
+	return Problem{}, false
+}
+
+func TestDetectPodProblems(t *testing.T) {
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: "back-off"}}
+	}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	oom := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}
+
+	healthy := problemPod("healthy", corev1.ContainerStatus{State: running, Ready: true, RestartCount: 1})
+	healthy.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+
+	unschedulable := problemPod("unschedulable", corev1.ContainerStatus{})
+	unschedulable.Status.Phase = corev1.PodPending
+	unschedulable.Status.Conditions = []corev1.PodCondition{{
+		Type: corev1.PodScheduled, Status: corev1.ConditionFalse,
+		Reason: corev1.PodReasonUnschedulable, Message: "0/3 nodes are available",
+	}}
+
+	problems := DetectProblems(&ProblemSnapshot{Pods: []corev1.Pod{
+		healthy,
+		problemPod("crashing", corev1.ContainerStatus{State: waiting("CrashLoopBackOff"), LastTerminationState: oom}),
+		problemPod("pull", corev1.ContainerStatus{State: waiting("ImagePullBackOff")}),
+		problemPod("oom", corev1.ContainerStatus{State: running, LastTerminationState: oom, RestartCount: 1}),
+		problemPod("flappy", corev1.ContainerStatus{State: running, RestartCount: HighRestartThreshold}),
+		unschedulable,
+	}})
+
+	tests := []struct {
+		name     string
+		severity ProblemSeverity
+		reason   string
+		message  string
+	}{
+		{"crashing", SeverityCritical, "CrashLoopBackOff", "container app was OOMKilled. container app: back-off"},
+		{"pull", SeverityCritical, "ImagePullBackOff", "container app: back-off"},
+		{"oom", SeverityWarning, "OOMKilled", "container app was OOMKilled and restarted (1 restarts)"},
+		{"flappy", SeverityWarning, "HighRestarts", "5 container restarts"},
+		{"unschedulable", SeverityWarning, "Unschedulable", "0/3 nodes are available"},
+	}
+
+	for _, tt := range tests {
+		p, ok := findProblem(problems, "Pod", tt.name)
+		if !ok {
+			t.Errorf("no problem reported for %s", tt.name)
+
+			continue
+		}
+
+		if p.Severity != tt.severity || p.Reason != tt.reason || p.Message != tt.message {
+			t.Errorf("%s = %s %s %q, want %s %s %q",
+				tt.name, p.Severity, p.Reason, p.Message, tt.severity, tt.reason, tt.message)
+		}
+	}
+
+	if _, ok := findProblem(problems, "Pod", "healthy"); ok {
+		t.Error("healthy pod should not be reported")
+	}
+
+	if problems[0].Severity != SeverityCritical || problems[len(problems)-1].Severity != SeverityWarning {
+		t.Errorf("problems should be sorted by severity: %+v", problems)
+	}
+}
+
+func TestDetectResourceProblems(t *testing.T) {
+	problems := DetectProblems(&ProblemSnapshot{
+		Deployments: []appsv1.Deployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "partial", Namespace: "default"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ok", Namespace: "default"},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+			},
+		},
+		Jobs: []batchv1.Job{{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+				Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+			}}},
+		}},
+		CronJobs: []batchv1.CronJob{{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec:       batchv1.CronJobSpec{Suspend: ptr.To(true)},
+		}},
+		Nodes: []corev1.Node{{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Message: "kubelet stopped posting"},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+			}},
+		}},
+		PVCs: []corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		}},
+		HPAs: []autoscalingv2.HorizontalPodAutoscaler{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{{
+				Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetResourceMetric",
+			}}},
+		}, {
+			// Scaled to zero on purpose
+			ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: "default"},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{{
+				Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "ScalingDisabled",
+			}}},
+		}},
+	})
+
+	want := []struct {
+		kind, name, reason string
+		severity           ProblemSeverity
+	}{
+		{"Deployment", "partial", "NotReady", SeverityWarning},
+		{"Job", "migrate", "Failed", SeverityCritical},
+		{"CronJob", "nightly", "Suspended", SeverityInfo},
+		{"Node", "worker-1", "NotReady", SeverityCritical},
+		{"PersistentVolumeClaim", "data", "Unbound", SeverityWarning},
+		{"HorizontalPodAutoscaler", "web", "FailedGetResourceMetric", SeverityWarning},
+	}
+
+	for _, w := range want {
+		p, ok := findProblem(problems, w.kind, w.name)
+		if !ok || p.Reason != w.reason || p.Severity != w.severity {
+			t.Errorf("%s/%s = %+v, want %s %s", w.kind, w.name, p, w.severity, w.reason)
+		}
+	}
+
+	if _, ok := findProblem(problems, "Deployment", "ok"); ok {
+		t.Error("deployment at desired replicas should not be reported")
+	}
+
+	if _, ok := findProblem(problems, "HorizontalPodAutoscaler", "paused"); ok {
+		t.Error("HPA with scaling disabled should not be reported")
+	}
+
+	// NotReady plus DiskPressure
+	if len(problems) != len(want)+1 {
+		t.Errorf("expected %d problems, got %+v", len(want)+1, problems)
+	}
+}
+
+func TestScanProblems(t *testing.T) {
+	crashing := problemPod("crashing", corev1.ContainerStatus{State: corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+	}})
+	other := crashing
+	other.Namespace = "other"
+	pending := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "other"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+
+	client := createTestClient(fake.NewSimpleClientset(&crashing, &other, &pending))
+	ctx := context.Background()
+
+	problems, err := client.ScanProblems(ctx, false)
+	if err != nil {
+		t.Fatalf("ScanProblems() error = %v", err)
+	}
+
+	if len(problems) != 1 || problems[0].Namespace != "default" {
+		t.Errorf("expected one problem in the current namespace, got %+v", problems)
+	}
+
+	problems, _ = client.ScanProblems(ctx, true)
+	if len(problems) != 3 {
+		t.Errorf("expected problems from all namespaces, got %+v", problems)
+	}
+}
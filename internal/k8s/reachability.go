@@ -0,0 +1,448 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	ErrInvalidPeer    = errors.New("expected namespace/pod, pod, IP or CIDR")
+	ErrPeerNotFound   = errors.New("pod not found")
+	ErrInvalidPort    = errors.New("expected port, port/protocol or a named port")
+	ErrExternalTarget = errors.New("destination must be a pod")
+)
+
+// TrafficPeer is one side of a simulated connection: a pod, or an address
+// range outside the cluster when Pod is nil.
+type TrafficPeer struct {
+	Pod    *corev1.Pod
+	Prefix netip.Prefix
+}
+
+// TrafficDirection is the verdict of one side's policies: egress of the
+// source or ingress of the destination.
+type TrafficDirection struct {
+	// Isolated is true when at least one policy selects the pod for this
+	// direction; unisolated pods allow all traffic.
+	Isolated bool
+	Allowed  bool
+	// Policies lists namespace/name of every policy selecting the pod;
+	// AllowedBy the subset with a rule matching the connection.
+	Policies  []string
+	AllowedBy []string
+}
+
+// ReachabilityResult is the outcome of a simulated connection.
+type ReachabilityResult struct {
+	Source      string
+	Destination string
+	Port        string
+	// Egress is nil for external sources, whose egress isn't governed by
+	// NetworkPolicy.
+	Egress  *TrafficDirection
+	Ingress TrafficDirection
+}
+
+// Allowed reports whether both the source's egress and the destination's
+// ingress admit the connection.
+func (r *ReachabilityResult) Allowed() bool {
+	return (r.Egress == nil || r.Egress.Allowed) && r.Ingress.Allowed
+}
+
+// ParsePortSpec parses "8080", "8080/UDP" or a named port such as "http".
+// The protocol defaults to TCP.
+func ParsePortSpec(spec string) (intstr.IntOrString, corev1.Protocol, error) {
+	spec = strings.TrimSpace(spec)
+	protocol := corev1.ProtocolTCP
+
+	if port, proto, ok := strings.Cut(spec, "/"); ok {
+		spec = port
+		protocol = corev1.Protocol(strings.ToUpper(proto))
+
+		switch protocol {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		default:
+			return intstr.IntOrString{}, "", fmt.Errorf("%w: unknown protocol %s", ErrInvalidPort, proto)
+		}
+	}
+
+	if spec == "" {
+		return intstr.IntOrString{}, "", ErrInvalidPort
+	}
+
+	if n, err := strconv.ParseInt(spec, 10, 32); err == nil {
+		if n < 1 || n > 65535 {
+			return intstr.IntOrString{}, "", fmt.Errorf("%w: %s out of range", ErrInvalidPort, spec)
+		}
+
+		return intstr.FromInt32(int32(n)), protocol, nil
+	}
+
+	return intstr.FromString(spec), protocol, nil
+}
+
+// CheckReachability simulates a connection from source (namespace/pod, pod
+// in the current namespace, IP or CIDR) to the destination pod on port
+// (see ParsePortSpec) against every NetworkPolicy in the cluster.
+func (c *Client) CheckReachability(
+	ctx context.Context,
+	source, destination, port string,
+) (*ReachabilityResult, error) {
+	portValue, protocol, err := ParsePortSpec(port)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.ListPodsAllNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	namespaces, err := c.ListNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	policies, err := c.ListNetworkPoliciesAllNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+
+	src, err := c.resolvePeer(source, pods)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+
+	dst, err := c.resolvePeer(destination, pods)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+
+	if dst.Pod == nil {
+		return nil, ErrExternalTarget
+	}
+
+	result := EvaluateReachability(src, dst, portValue, protocol, policies, NamespaceLabels(namespaces))
+	result.Source = strings.TrimSpace(source)
+	result.Destination = dst.Pod.Namespace + "/" + dst.Pod.Name
+
+	return result, nil
+}
+
+func (c *Client) resolvePeer(spec string, pods []corev1.Pod) (TrafficPeer, error) {
+	spec = strings.TrimSpace(spec)
+
+	if addr, err := netip.ParseAddr(spec); err == nil {
+		return TrafficPeer{Prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(spec); err == nil {
+		return TrafficPeer{Prefix: prefix.Masked()}, nil
+	}
+
+	namespace, name, ok := strings.Cut(spec, "/")
+	if !ok {
+		namespace, name = c.CurrentNamespace(), spec
+	}
+
+	if namespace == "" || name == "" {
+		return TrafficPeer{}, fmt.Errorf("%w: %q", ErrInvalidPeer, spec)
+	}
+
+	for i := range pods {
+		if pods[i].Namespace == namespace && pods[i].Name == name {
+			return TrafficPeer{Pod: &pods[i]}, nil
+		}
+	}
+
+	return TrafficPeer{}, fmt.Errorf("%w: %s/%s", ErrPeerNotFound, namespace, name)
+}
+
+// NamespaceLabels maps namespace names to their labels for
+// namespaceSelector matching, adding the kubernetes.io/metadata.name label
+// the API server sets on every namespace.
+func NamespaceLabels(namespaces []corev1.Namespace) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(namespaces))
+
+	for _, ns := range namespaces {
+		set := make(map[string]string, len(ns.Labels)+1)
+		for k, v := range ns.Labels {
+			set[k] = v
+		}
+
+		set[corev1.LabelMetadataName] = ns.Name
+		result[ns.Name] = set
+	}
+
+	return result
+}
+
+// EvaluateReachability applies the egress policies selecting src and the
+// ingress policies selecting dst (which must be a pod) to a connection on
+// port. Named ports are resolved against the destination's containers.
+func EvaluateReachability(
+	src, dst TrafficPeer,
+	port intstr.IntOrString,
+	protocol corev1.Protocol,
+	policies []networkingv1.NetworkPolicy,
+	nsLabels map[string]map[string]string,
+) *ReachabilityResult {
+	result := &ReachabilityResult{Port: port.String() + "/" + string(protocol)}
+	conn := connection{port: port, protocol: protocol, dst: dst.Pod, nsLabels: nsLabels}
+
+	if src.Pod != nil {
+		egress := evaluateDirection(src.Pod, policies, networkingv1.PolicyTypeEgress,
+			func(np *networkingv1.NetworkPolicy) bool {
+				for _, rule := range np.Spec.Egress {
+					if conn.portsMatch(rule.Ports) && conn.peersMatch(np.Namespace, rule.To, dst) {
+						return true
+					}
+				}
+
+				return false
+			})
+		result.Egress = &egress
+	}
+
+	result.Ingress = evaluateDirection(dst.Pod, policies, networkingv1.PolicyTypeIngress,
+		func(np *networkingv1.NetworkPolicy) bool {
+			for _, rule := range np.Spec.Ingress {
+				if conn.portsMatch(rule.Ports) && conn.peersMatch(np.Namespace, rule.From, src) {
+					return true
+				}
+			}
+
+			return false
+		})
+
+	return result
+}
+
+func evaluateDirection(
+	pod *corev1.Pod,
+	policies []networkingv1.NetworkPolicy,
+	policyType networkingv1.PolicyType,
+	allows func(*networkingv1.NetworkPolicy) bool,
+) TrafficDirection {
+	var dir TrafficDirection
+
+	for i := range policies {
+		np := &policies[i]
+		if np.Namespace != pod.Namespace || !slices.Contains(EffectivePolicyTypes(np), policyType) {
+			continue
+		}
+
+		if !selectorMatches(&np.Spec.PodSelector, pod.Labels) {
+			continue
+		}
+
+		id := np.Namespace + "/" + np.Name
+		dir.Isolated = true
+		dir.Policies = append(dir.Policies, id)
+
+		if allows(np) {
+			dir.AllowedBy = append(dir.AllowedBy, id)
+		}
+	}
+
+	dir.Allowed = !dir.Isolated || len(dir.AllowedBy) > 0
+
+	return dir
+}
+
+// EffectivePolicyTypes applies the API defaulting: without explicit
+// policyTypes a policy always covers Ingress, and Egress when it has egress
+// rules.
+func EffectivePolicyTypes(np *networkingv1.NetworkPolicy) []networkingv1.PolicyType {
+	if len(np.Spec.PolicyTypes) > 0 {
+		return np.Spec.PolicyTypes
+	}
+
+	types := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	if len(np.Spec.Egress) > 0 {
+		types = append(types, networkingv1.PolicyTypeEgress)
+	}
+
+	return types
+}
+
+// PodsSelectedByPolicy returns the pods in the policy's namespace that its
+// podSelector applies to.
+func PodsSelectedByPolicy(np *networkingv1.NetworkPolicy, pods []corev1.Pod) []corev1.Pod {
+	var selected []corev1.Pod
+
+	for _, pod := range pods {
+		if pod.Namespace == np.Namespace && selectorMatches(&np.Spec.PodSelector, pod.Labels) {
+			selected = append(selected, pod)
+		}
+	}
+
+	return selected
+}
+
+type connection struct {
+	port     intstr.IntOrString
+	protocol corev1.Protocol
+	dst      *corev1.Pod
+	nsLabels map[string]map[string]string
+}
+
+// portsMatch reports whether any of the rule's ports admits the connection;
+// an empty list admits every port.
+func (c connection) portsMatch(ports []networkingv1.NetworkPolicyPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	target, targetKnown := resolvePortNumber(c.dst, c.port, c.protocol)
+
+	for _, p := range ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+
+		if protocol != c.protocol {
+			continue
+		}
+
+		if p.Port == nil {
+			return true
+		}
+
+		if p.Port.Type == intstr.String {
+			if c.port.Type == intstr.String && c.port.StrVal == p.Port.StrVal {
+				return true
+			}
+
+			if number, ok := resolvePortNumber(c.dst, *p.Port, protocol); ok && targetKnown && number == target {
+				return true
+			}
+
+			continue
+		}
+
+		if !targetKnown {
+			continue
+		}
+
+		end := p.Port.IntVal
+		if p.EndPort != nil {
+			end = *p.EndPort
+		}
+
+		if target >= p.Port.IntVal && target <= end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePortNumber turns a named port into the destination container's
+// port number.
+func resolvePortNumber(pod *corev1.Pod, port intstr.IntOrString, protocol corev1.Protocol) (int32, bool) {
+	if port.Type == intstr.Int {
+		return port.IntVal, true
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, cp := range container.Ports {
+			cpProtocol := cp.Protocol
+			if cpProtocol == "" {
+				cpProtocol = corev1.ProtocolTCP
+			}
+
+			if cp.Name == port.StrVal && cpProtocol == protocol {
+				return cp.ContainerPort, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// peersMatch reports whether any peer admits the other side; an empty list
+// admits everything.
+func (c connection) peersMatch(policyNamespace string, peers []networkingv1.NetworkPolicyPeer, other TrafficPeer) bool {
+	if len(peers) == 0 {
+		return true
+	}
+
+	for _, peer := range peers {
+		if c.peerMatches(policyNamespace, peer, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c connection) peerMatches(policyNamespace string, peer networkingv1.NetworkPolicyPeer, other TrafficPeer) bool {
+	if peer.IPBlock != nil {
+		return ipBlockContains(peer.IPBlock, other)
+	}
+
+	// Selector peers only ever match pods
+	if other.Pod == nil {
+		return false
+	}
+
+	if peer.NamespaceSelector != nil {
+		if !selectorMatches(peer.NamespaceSelector, c.nsLabels[other.Pod.Namespace]) {
+			return false
+		}
+	} else if other.Pod.Namespace != policyNamespace {
+		return false
+	}
+
+	return peer.PodSelector == nil || selectorMatches(peer.PodSelector, other.Pod.Labels)
+}
+
+// ipBlockContains requires the whole peer range inside the block and
+// outside every exception. Pods are matched by their pod IP.
+func ipBlockContains(block *networkingv1.IPBlock, peer TrafficPeer) bool {
+	prefix := peer.Prefix
+
+	if peer.Pod != nil {
+		addr, err := netip.ParseAddr(peer.Pod.Status.PodIP)
+		if err != nil {
+			return false
+		}
+
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	cidr, err := netip.ParsePrefix(block.CIDR)
+	if err != nil || !prefix.IsValid() || cidr.Bits() > prefix.Bits() || !cidr.Contains(prefix.Addr()) {
+		return false
+	}
+
+	for _, except := range block.Except {
+		if exceptPrefix, err := netip.ParsePrefix(except); err == nil && exceptPrefix.Overlaps(prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+
+	return sel.Matches(labels.Set(set))
+}
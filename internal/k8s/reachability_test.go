@@ -0,0 +1,247 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func netpolPod(namespace, name, ip string, podLabels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: podLabels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "app",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}}},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+// denyAllIngress plus allowFrontend: only app=frontend pods of namespaces
+// labeled team=web reach app=api on TCP 8080.
+func netpolFixtures() []networkingv1.NetworkPolicy {
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt32(8080)
+
+	return []networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "prod"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-frontend", Namespace: "prod"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+							PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+						},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "203.0.113.0/24", Except: []string{"203.0.113.128/25"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend-egress", Namespace: "web"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func netpolNamespaces() map[string]map[string]string {
+	return NamespaceLabels([]corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"team": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	})
+}
+
+func TestEvaluateReachability(t *testing.T) {
+	api := netpolPod("prod", "api", "10.0.0.1", map[string]string{"app": "api"})
+	db := netpolPod("prod", "db", "10.0.0.2", map[string]string{"app": "db"})
+	frontend := netpolPod("web", "frontend", "10.0.1.1", map[string]string{"app": "frontend"})
+	stranger := netpolPod("other", "frontend", "10.0.2.1", map[string]string{"app": "frontend"})
+
+	external := func(cidr string) TrafficPeer {
+		return TrafficPeer{Prefix: netip.MustParsePrefix(cidr)}
+	}
+
+	tests := []struct {
+		name      string
+		src       TrafficPeer
+		dst       *corev1.Pod
+		port      intstr.IntOrString
+		protocol  corev1.Protocol
+		allowed   bool
+		allowedBy []string
+	}{
+		{"frontend to api", TrafficPeer{Pod: frontend}, api, intstr.FromInt32(8080), corev1.ProtocolTCP,
+			true, []string{"prod/allow-frontend"}},
+		{"named port resolves on destination", TrafficPeer{Pod: frontend}, api, intstr.FromString("http"),
+			corev1.ProtocolTCP, true, []string{"prod/allow-frontend"}},
+		{"wrong port", TrafficPeer{Pod: frontend}, api, intstr.FromInt32(9090), corev1.ProtocolTCP, false, nil},
+		{"wrong protocol", TrafficPeer{Pod: frontend}, api, intstr.FromInt32(8080), corev1.ProtocolUDP, false, nil},
+		{"namespace not selected", TrafficPeer{Pod: stranger}, api, intstr.FromInt32(8080), corev1.ProtocolTCP,
+			false, nil},
+		{"deny-all isolates db", TrafficPeer{Pod: frontend}, db, intstr.FromInt32(8080), corev1.ProtocolTCP,
+			false, nil},
+		{"external inside ipBlock", external("203.0.113.7/32"), api, intstr.FromInt32(8080), corev1.ProtocolTCP,
+			true, []string{"prod/allow-frontend"}},
+		{"external in except", external("203.0.113.200/32"), api, intstr.FromInt32(8080), corev1.ProtocolTCP,
+			false, nil},
+		{"external CIDR partly excepted", external("203.0.113.0/24"), api, intstr.FromInt32(8080),
+			corev1.ProtocolTCP, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluateReachability(tt.src, TrafficPeer{Pod: tt.dst}, tt.port, tt.protocol,
+				netpolFixtures(), netpolNamespaces())
+
+			if result.Allowed() != tt.allowed {
+				t.Errorf("Allowed() = %v, want %v (%+v)", result.Allowed(), tt.allowed, result)
+			}
+
+			if len(result.Ingress.AllowedBy) != len(tt.allowedBy) ||
+				(len(tt.allowedBy) > 0 && result.Ingress.AllowedBy[0] != tt.allowedBy[0]) {
+				t.Errorf("Ingress.AllowedBy = %v, want %v", result.Ingress.AllowedBy, tt.allowedBy)
+			}
+		})
+	}
+}
+
+func TestEvaluateReachabilityEgress(t *testing.T) {
+	api := netpolPod("prod", "api", "10.0.0.1", map[string]string{"app": "api"})
+	frontend := netpolPod("web", "frontend", "10.0.1.1", map[string]string{"app": "frontend"})
+
+	result := EvaluateReachability(TrafficPeer{Pod: frontend}, TrafficPeer{Pod: api},
+		intstr.FromInt32(8080), corev1.ProtocolTCP, netpolFixtures(), netpolNamespaces())
+
+	if result.Egress == nil || !result.Egress.Isolated || !result.Egress.Allowed {
+		t.Fatalf("expected isolated but allowed egress, got %+v", result.Egress)
+	}
+
+	if len(result.Egress.AllowedBy) != 1 || result.Egress.AllowedBy[0] != "web/frontend-egress" {
+		t.Errorf("Egress.AllowedBy = %v", result.Egress.AllowedBy)
+	}
+
+	if len(result.Ingress.Policies) != 2 {
+		t.Errorf("expected deny-all and allow-frontend to select api, got %v", result.Ingress.Policies)
+	}
+
+	// External sources have no egress verdict
+	result = EvaluateReachability(TrafficPeer{Prefix: netip.MustParsePrefix("203.0.113.7/32")},
+		TrafficPeer{Pod: api}, intstr.FromInt32(8080), corev1.ProtocolTCP, netpolFixtures(), netpolNamespaces())
+	if result.Egress != nil {
+		t.Errorf("expected no egress verdict for external source, got %+v", result.Egress)
+	}
+}
+
+func TestEffectivePolicyTypes(t *testing.T) {
+	np := &networkingv1.NetworkPolicy{}
+	if got := EffectivePolicyTypes(np); len(got) != 1 || got[0] != networkingv1.PolicyTypeIngress {
+		t.Errorf("default types = %v, want [Ingress]", got)
+	}
+
+	np.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{{}}
+	if got := EffectivePolicyTypes(np); len(got) != 2 {
+		t.Errorf("types with egress rules = %v, want [Ingress Egress]", got)
+	}
+}
+
+func TestPodsSelectedByPolicy(t *testing.T) {
+	pods := []corev1.Pod{
+		*netpolPod("prod", "api", "", map[string]string{"app": "api"}),
+		*netpolPod("prod", "db", "", map[string]string{"app": "db"}),
+		*netpolPod("web", "api", "", map[string]string{"app": "api"}),
+	}
+	policies := netpolFixtures()
+
+	if got := PodsSelectedByPolicy(&policies[0], pods); len(got) != 2 {
+		t.Errorf("deny-all should select both prod pods, got %d", len(got))
+	}
+
+	got := PodsSelectedByPolicy(&policies[1], pods)
+	if len(got) != 1 || got[0].Name != "api" || got[0].Namespace != "prod" {
+		t.Errorf("allow-frontend should select prod/api only, got %+v", got)
+	}
+}
+
+func TestParsePortSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		port     string
+		protocol corev1.Protocol
+		wantErr  bool
+	}{
+		{"8080", "8080", corev1.ProtocolTCP, false},
+		{"53/udp", "53", corev1.ProtocolUDP, false},
+		{"http", "http", corev1.ProtocolTCP, false},
+		{"70000", "", "", true},
+		{"80/icmp", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		port, protocol, err := ParsePortSpec(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePortSpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+
+			continue
+		}
+
+		if !tt.wantErr && (port.String() != tt.port || protocol != tt.protocol) {
+			t.Errorf("ParsePortSpec(%q) = %s/%s, want %s/%s", tt.spec, port.String(), protocol, tt.port, tt.protocol)
+		}
+	}
+}
+
+func TestCheckReachability(t *testing.T) {
+	policies := netpolFixtures()
+	clientset := fake.NewSimpleClientset(
+		netpolPod("prod", "api", "10.0.0.1", map[string]string{"app": "api"}),
+		netpolPod("web", "frontend", "10.0.1.1", map[string]string{"app": "frontend"}),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"team": "web"}}},
+		&policies[0], &policies[1], &policies[2],
+	)
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	result, err := client.CheckReachability(ctx, "web/frontend", "prod/api", "8080")
+	if err != nil {
+		t.Fatalf("CheckReachability() error = %v", err)
+	}
+
+	if !result.Allowed() || result.Destination != "prod/api" || result.Port != "8080/TCP" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := client.CheckReachability(ctx, "web/missing", "prod/api", "8080"); !errors.Is(err, ErrPeerNotFound) {
+		t.Errorf("missing source error = %v, want ErrPeerNotFound", err)
+	}
+
+	if _, err := client.CheckReachability(ctx, "web/frontend", "10.0.0.0/8", "8080"); !errors.Is(err, ErrExternalTarget) {
+		t.Errorf("external destination error = %v, want ErrExternalTarget", err)
+	}
+}
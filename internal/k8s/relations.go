@@ -0,0 +1,1115 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ErrUnsupportedRelationKind = errors.New("relationship tree not supported for this kind")
+	ErrRelationRootNotFound    = errors.New("object not found")
+)
+
+// Relation describes how a node in a relationship tree relates to its parent.
+const (
+	RelationOwns    = "owns"
+	RelationOwnedBy = "owned by"
+	RelationSelects = "selects"
+	RelationRoutes  = "routes to"
+	RelationScales  = "scaled by"
+	RelationTargets = "scales"
+	RelationMounts  = "uses"
+	RelationUsedBy  = "used by"
+)
+
+// RelationNode is one object in a relationship ("x-ray") tree.
+type RelationNode struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Relation is how this node relates to its parent; empty for the root.
+	Relation string
+	Status   string
+	// Healthy is false for missing, failing or not-ready objects.
+	Healthy bool
+	// Unchecked is set when the object couldn't be looked up, e.g. without
+	// permission to list its kind; Healthy says nothing then.
+	Unchecked bool
+	Children  []*RelationNode
+}
+
+// relationSnapshot holds every object of a namespace that can appear in a
+// tree, so the tree is built from one round of list calls.
+type relationSnapshot struct {
+	namespace       string
+	pods            []corev1.Pod
+	replicaSets     []appsv1.ReplicaSet
+	jobs            []batchv1.Job
+	services        []corev1.Service
+	ingresses       []networkingv1.Ingress
+	hpas            []autoscalingv2.HorizontalPodAutoscaler
+	configMaps      map[string]bool
+	secrets         map[string]bool
+	serviceAccounts map[string]bool
+	pvcs            map[string]*corev1.PersistentVolumeClaim
+	// listErrors holds why a kind other than pods couldn't be listed.
+	listErrors map[string]error
+}
+
+// BuildRelationTree builds the tree of objects related to the named object
+// through ownerReferences, label selectors, ingress backends, HPA targets and
+// pod volume/env references. Besides workloads, pods, services and
+// ingresses it covers HPAs, network policies and the ConfigMaps, Secrets,
+// ServiceAccounts and PVCs pods use.
+func (c *Client) BuildRelationTree(
+	ctx context.Context,
+	kind, namespace, name string,
+) (*RelationNode, error) {
+	namespace = c.ns(namespace)
+
+	snap, err := c.loadRelationSnapshot(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "Deployment":
+		dep, err := c.GetDeployment(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return snap.deploymentTree(dep), nil
+	case "StatefulSet":
+		sts, err := c.GetStatefulSet(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return snap.statefulSetTree(sts), nil
+	case "DaemonSet":
+		ds, err := c.GetDaemonSet(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return snap.daemonSetTree(ds), nil
+	case "CronJob":
+		cj, err := c.GetCronJob(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return snap.cronJobTree(cj), nil
+	case "HorizontalPodAutoscaler":
+		if err := snap.listErrors[kind]; err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+
+		hpa := snap.findHPA(name)
+		if hpa == nil {
+			return nil, fmt.Errorf("%w: %s %s/%s", ErrRelationRootNotFound, kind, namespace, name)
+		}
+
+		root := hpaNode(hpa, "")
+		root.Children = append(root.Children, c.scaleTargetNode(ctx, snap, hpa.Spec.ScaleTargetRef))
+
+		return root, nil
+	case "NetworkPolicy":
+		policy, err := c.GetNetworkPolicy(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return snap.networkPolicyTree(policy)
+	}
+
+	return snap.treeFromSnapshot(kind, name)
+}
+
+// treeFromSnapshot handles kinds whose object is already in the snapshot.
+func (s *relationSnapshot) treeFromSnapshot(kind, name string) (*RelationNode, error) {
+	if err := s.listErrors[kind]; err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+
+	switch kind {
+	case "ReplicaSet":
+		for i := range s.replicaSets {
+			if s.replicaSets[i].Name == name {
+				return s.replicaSetTree(&s.replicaSets[i]), nil
+			}
+		}
+	case "Job":
+		for i := range s.jobs {
+			if s.jobs[i].Name == name {
+				return s.jobTree(&s.jobs[i], ""), nil
+			}
+		}
+	case "Pod":
+		for i := range s.pods {
+			if s.pods[i].Name == name {
+				return s.podTree(&s.pods[i]), nil
+			}
+		}
+	case "Service":
+		for i := range s.services {
+			if s.services[i].Name == name {
+				return s.serviceTree(&s.services[i], ""), nil
+			}
+		}
+	case "Ingress":
+		for i := range s.ingresses {
+			if s.ingresses[i].Name == name {
+				return s.ingressTree(&s.ingresses[i]), nil
+			}
+		}
+	case "ConfigMap", "Secret", "ServiceAccount", "PersistentVolumeClaim":
+		if s.refExists(kind, name) {
+			return s.refTree(kind, name), nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRelationKind, kind)
+	}
+
+	return nil, fmt.Errorf("%w: %s %s/%s", ErrRelationRootNotFound, kind, s.namespace, name)
+}
+
+func (c *Client) loadRelationSnapshot(ctx context.Context, namespace string) (*relationSnapshot, error) {
+	core := c.clientset.CoreV1()
+	opts := metav1.ListOptions{}
+	snap := &relationSnapshot{namespace: namespace}
+
+	pods, err := core.Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	snap.pods = pods.Items
+
+	// Only the pods are needed for every tree. Other kinds that can't be
+	// listed, as is common with namespace-scoped RBAC, leave their nodes
+	// unchecked rather than aborting the tree.
+	snap.listErrors = make(map[string]error)
+
+	if list, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, opts); err == nil {
+		snap.replicaSets = list.Items
+	} else {
+		snap.listErrors["ReplicaSet"] = err
+	}
+
+	if list, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, opts); err == nil {
+		snap.jobs = list.Items
+	} else {
+		snap.listErrors["Job"] = err
+	}
+
+	if list, err := core.Services(namespace).List(ctx, opts); err == nil {
+		snap.services = list.Items
+	} else {
+		snap.listErrors["Service"] = err
+	}
+
+	if list, err := c.clientset.NetworkingV1().Ingresses(namespace).List(ctx, opts); err == nil {
+		snap.ingresses = list.Items
+	} else {
+		snap.listErrors["Ingress"] = err
+	}
+
+	if list, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, opts); err == nil {
+		snap.hpas = list.Items
+	} else {
+		snap.listErrors["HorizontalPodAutoscaler"] = err
+	}
+
+	snap.configMaps = make(map[string]bool)
+	if list, err := core.ConfigMaps(namespace).List(ctx, opts); err == nil {
+		for _, cm := range list.Items {
+			snap.configMaps[cm.Name] = true
+		}
+	} else {
+		snap.listErrors["ConfigMap"] = err
+	}
+
+	snap.secrets = make(map[string]bool)
+	if list, err := core.Secrets(namespace).List(ctx, opts); err == nil {
+		for _, secret := range list.Items {
+			snap.secrets[secret.Name] = true
+		}
+	} else {
+		snap.listErrors["Secret"] = err
+	}
+
+	snap.serviceAccounts = make(map[string]bool)
+	if list, err := core.ServiceAccounts(namespace).List(ctx, opts); err == nil {
+		for _, sa := range list.Items {
+			snap.serviceAccounts[sa.Name] = true
+		}
+	} else {
+		snap.listErrors["ServiceAccount"] = err
+	}
+
+	snap.pvcs = make(map[string]*corev1.PersistentVolumeClaim)
+	if list, err := core.PersistentVolumeClaims(namespace).List(ctx, opts); err == nil {
+		for i := range list.Items {
+			snap.pvcs[list.Items[i].Name] = &list.Items[i]
+		}
+	} else {
+		snap.listErrors["PersistentVolumeClaim"] = err
+	}
+
+	return snap, nil
+}
+
+func (s *relationSnapshot) deploymentTree(dep *appsv1.Deployment) *RelationNode {
+	desired := GetDeploymentDesiredReplicas(dep)
+	root := &RelationNode{
+		Kind:      "Deployment",
+		Name:      dep.Name,
+		Namespace: dep.Namespace,
+		Status:    GetDeploymentReadyCount(dep),
+		Healthy:   dep.Status.ReadyReplicas >= desired,
+	}
+
+	var pods []*corev1.Pod
+
+	for i := range s.replicaSets {
+		rs := &s.replicaSets[i]
+		if !ownedBy(rs, "Deployment", dep.Name, dep.UID) {
+			continue
+		}
+
+		rsNode := s.replicaSetNode(rs, RelationOwns)
+		for _, pod := range s.podsOwnedBy("ReplicaSet", rs.Name, rs.UID) {
+			rsNode.Children = append(rsNode.Children, podNode(pod, RelationOwns))
+			pods = append(pods, pod)
+		}
+
+		root.Children = append(root.Children, rsNode)
+	}
+
+	if s.listErrors["ReplicaSet"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("ReplicaSet", RelationOwns))
+	}
+
+	s.addWorkloadRelations(root, "Deployment", dep.Name, dep.Spec.Template, pods)
+
+	return root
+}
+
+func (s *relationSnapshot) statefulSetTree(sts *appsv1.StatefulSet) *RelationNode {
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+
+	root := &RelationNode{
+		Kind:      "StatefulSet",
+		Name:      sts.Name,
+		Namespace: sts.Namespace,
+		Status:    GetStatefulSetReadyCount(sts),
+		Healthy:   sts.Status.ReadyReplicas >= desired,
+	}
+
+	pods := s.podsOwnedBy("StatefulSet", sts.Name, sts.UID)
+	for _, pod := range pods {
+		root.Children = append(root.Children, podNode(pod, RelationOwns))
+	}
+
+	s.addWorkloadRelations(root, "StatefulSet", sts.Name, sts.Spec.Template, pods)
+
+	return root
+}
+
+func (s *relationSnapshot) daemonSetTree(ds *appsv1.DaemonSet) *RelationNode {
+	root := &RelationNode{
+		Kind:      "DaemonSet",
+		Name:      ds.Name,
+		Namespace: ds.Namespace,
+		Status:    GetDaemonSetReadyCount(ds),
+		Healthy:   ds.Status.NumberReady >= ds.Status.DesiredNumberScheduled,
+	}
+
+	pods := s.podsOwnedBy("DaemonSet", ds.Name, ds.UID)
+	for _, pod := range pods {
+		root.Children = append(root.Children, podNode(pod, RelationOwns))
+	}
+
+	s.addWorkloadRelations(root, "DaemonSet", ds.Name, ds.Spec.Template, pods)
+
+	return root
+}
+
+func (s *relationSnapshot) replicaSetTree(rs *appsv1.ReplicaSet) *RelationNode {
+	root := s.replicaSetNode(rs, "")
+
+	pods := s.podsOwnedBy("ReplicaSet", rs.Name, rs.UID)
+	for _, pod := range pods {
+		root.Children = append(root.Children, podNode(pod, RelationOwns))
+	}
+
+	s.addWorkloadRelations(root, "ReplicaSet", rs.Name, rs.Spec.Template, pods)
+
+	return root
+}
+
+func (s *relationSnapshot) cronJobTree(cj *batchv1.CronJob) *RelationNode {
+	root := &RelationNode{
+		Kind:      "CronJob",
+		Name:      cj.Name,
+		Namespace: cj.Namespace,
+		Status:    GetCronJobStatus(cj),
+		Healthy:   true,
+	}
+
+	for i := range s.jobs {
+		job := &s.jobs[i]
+		if ownedBy(job, "CronJob", cj.Name, cj.UID) {
+			root.Children = append(root.Children, s.jobTree(job, RelationOwns))
+		}
+	}
+
+	if s.listErrors["Job"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("Job", RelationOwns))
+	}
+
+	root.Children = append(root.Children, s.podSpecRefs(&cj.Spec.JobTemplate.Spec.Template.Spec)...)
+
+	return root
+}
+
+func (s *relationSnapshot) jobTree(job *batchv1.Job, relation string) *RelationNode {
+	node := &RelationNode{
+		Kind:      "Job",
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Relation:  relation,
+		Status:    jobStatus(job),
+		Healthy:   job.Status.Failed == 0,
+	}
+
+	for _, pod := range s.podsOwnedBy("Job", job.Name, job.UID) {
+		node.Children = append(node.Children, podNode(pod, RelationOwns))
+	}
+
+	// Under a CronJob the template refs are shown once on the CronJob
+	if relation == "" {
+		node.Children = append(node.Children, s.podSpecRefs(&job.Spec.Template.Spec)...)
+	}
+
+	return node
+}
+
+func (s *relationSnapshot) podTree(pod *corev1.Pod) *RelationNode {
+	root := podNode(pod, "")
+
+	for _, ref := range pod.OwnerReferences {
+		root.Children = append(root.Children, s.ownerNode(ref))
+	}
+
+	for i := range s.services {
+		svc := &s.services[i]
+		if selectsLabels(svc.Spec.Selector, pod.Labels) {
+			root.Children = append(root.Children, s.serviceTree(svc, RelationSelects))
+		}
+	}
+
+	if s.listErrors["Service"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("Service", RelationSelects))
+	}
+
+	root.Children = append(root.Children, s.podSpecRefs(&pod.Spec)...)
+
+	return root
+}
+
+// serviceTree shows the pods a service selects and the ingresses routing to
+// it. Below a workload (relation != "") the pods are omitted since they are
+// already listed under the workload.
+func (s *relationSnapshot) serviceTree(svc *corev1.Service, relation string) *RelationNode {
+	node := &RelationNode{
+		Kind:      "Service",
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+		Relation:  relation,
+		Status:    string(svc.Spec.Type),
+		Healthy:   true,
+	}
+
+	if relation == "" || relation == RelationRoutes {
+		for i := range s.pods {
+			if selectsLabels(svc.Spec.Selector, s.pods[i].Labels) {
+				node.Children = append(node.Children, podNode(&s.pods[i], RelationSelects))
+			}
+		}
+
+		if len(svc.Spec.Selector) > 0 && len(node.Children) == 0 {
+			node.Status += ", no matching pods"
+			node.Healthy = false
+		}
+	}
+
+	if relation == RelationRoutes {
+		return node
+	}
+
+	for i := range s.ingresses {
+		ing := &s.ingresses[i]
+		if ingressRoutesTo(ing, svc.Name) {
+			node.Children = append(node.Children, ingressNode(ing, RelationRoutes))
+		}
+	}
+
+	if s.listErrors["Ingress"] != nil {
+		node.Children = append(node.Children, s.unlistedNode("Ingress", RelationRoutes))
+	}
+
+	return node
+}
+
+func (s *relationSnapshot) ingressTree(ing *networkingv1.Ingress) *RelationNode {
+	root := ingressNode(ing, "")
+
+	for _, name := range ingressBackendServices(ing) {
+		svc := s.findService(name)
+
+		switch {
+		case s.listErrors["Service"] != nil:
+			root.Children = append(root.Children,
+				uncheckedNode("Service", name, s.namespace, RelationRoutes, s.listErrors["Service"]))
+
+			continue
+		case svc == nil:
+			root.Children = append(root.Children, missingNode("Service", name, s.namespace, RelationRoutes))
+
+			continue
+		}
+
+		root.Children = append(root.Children, s.serviceTree(svc, RelationRoutes))
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" {
+			root.Children = append(root.Children, s.refNode("Secret", tls.SecretName))
+		}
+	}
+
+	return root
+}
+
+// refTree shows the pods using a ConfigMap, Secret, ServiceAccount or PVC,
+// and for a Secret the ingresses serving it as a TLS certificate.
+func (s *relationSnapshot) refTree(kind, name string) *RelationNode {
+	root := s.refNode(kind, name)
+	root.Relation = ""
+
+	for i := range s.pods {
+		used := false
+
+		collectPodSpecRefs(&s.pods[i].Spec, func(refKind, refName string) {
+			used = used || (refKind == kind && refName == name)
+		})
+
+		if used {
+			root.Children = append(root.Children, podNode(&s.pods[i], RelationUsedBy))
+		}
+	}
+
+	if kind != "Secret" {
+		return root
+	}
+
+	for i := range s.ingresses {
+		for _, tls := range s.ingresses[i].Spec.TLS {
+			if tls.SecretName == name {
+				root.Children = append(root.Children, ingressNode(&s.ingresses[i], RelationUsedBy))
+
+				break
+			}
+		}
+	}
+
+	if s.listErrors["Ingress"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("Ingress", RelationUsedBy))
+	}
+
+	return root
+}
+
+// networkPolicyTree shows the pods a network policy applies to.
+func (s *relationSnapshot) networkPolicyTree(policy *networkingv1.NetworkPolicy) (*RelationNode, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	policyTypes := make([]string, 0, len(policy.Spec.PolicyTypes))
+	for _, policyType := range policy.Spec.PolicyTypes {
+		policyTypes = append(policyTypes, string(policyType))
+	}
+
+	root := &RelationNode{
+		Kind:      "NetworkPolicy",
+		Name:      policy.Name,
+		Namespace: policy.Namespace,
+		Status:    strings.Join(policyTypes, ", "),
+		Healthy:   true,
+	}
+
+	for i := range s.pods {
+		if selector.Matches(labels.Set(s.pods[i].Labels)) {
+			root.Children = append(root.Children, podNode(&s.pods[i], RelationSelects))
+		}
+	}
+
+	if len(root.Children) == 0 {
+		root.Status = strings.TrimPrefix(root.Status+", no matching pods", ", ")
+		root.Healthy = false
+	}
+
+	return root, nil
+}
+
+// scaleTargetNode looks up the workload an HPA scales, which the snapshot
+// only has for replica sets.
+func (c *Client) scaleTargetNode(
+	ctx context.Context,
+	s *relationSnapshot,
+	ref autoscalingv2.CrossVersionObjectReference,
+) *RelationNode {
+	var (
+		node *RelationNode
+		err  error
+	)
+
+	switch ref.Kind {
+	case "Deployment":
+		var dep *appsv1.Deployment
+		if dep, err = c.GetDeployment(ctx, s.namespace, ref.Name); err == nil {
+			node = &RelationNode{
+				Status:  GetDeploymentReadyCount(dep),
+				Healthy: dep.Status.ReadyReplicas >= GetDeploymentDesiredReplicas(dep),
+			}
+		}
+	case "StatefulSet":
+		var sts *appsv1.StatefulSet
+		if sts, err = c.GetStatefulSet(ctx, s.namespace, ref.Name); err == nil {
+			desired := int32(1)
+			if sts.Spec.Replicas != nil {
+				desired = *sts.Spec.Replicas
+			}
+
+			node = &RelationNode{Status: GetStatefulSetReadyCount(sts), Healthy: sts.Status.ReadyReplicas >= desired}
+		}
+	case "ReplicaSet":
+		if err := s.listErrors["ReplicaSet"]; err != nil {
+			return uncheckedNode(ref.Kind, ref.Name, s.namespace, RelationTargets, err)
+		}
+
+		for i := range s.replicaSets {
+			if s.replicaSets[i].Name == ref.Name {
+				return s.replicaSetNode(&s.replicaSets[i], RelationTargets)
+			}
+		}
+
+		return missingNode(ref.Kind, ref.Name, s.namespace, RelationTargets)
+	default:
+		node = &RelationNode{Healthy: true}
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return missingNode(ref.Kind, ref.Name, s.namespace, RelationTargets)
+	case err != nil:
+		return uncheckedNode(ref.Kind, ref.Name, s.namespace, RelationTargets, err)
+	}
+
+	node.Kind, node.Name, node.Namespace, node.Relation = ref.Kind, ref.Name, s.namespace, RelationTargets
+
+	return node
+}
+
+// addWorkloadRelations appends the services selecting the workload's pods,
+// the HPAs targeting it and the objects its pods reference.
+func (s *relationSnapshot) addWorkloadRelations(
+	root *RelationNode,
+	kind, name string,
+	template corev1.PodTemplateSpec,
+	pods []*corev1.Pod,
+) {
+	for i := range s.services {
+		svc := &s.services[i]
+		if selectsAny(svc.Spec.Selector, template.Labels, pods) {
+			root.Children = append(root.Children, s.serviceTree(svc, RelationSelects))
+		}
+	}
+
+	if s.listErrors["Service"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("Service", RelationSelects))
+	}
+
+	for i := range s.hpas {
+		hpa := &s.hpas[i]
+		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name {
+			root.Children = append(root.Children, hpaNode(hpa, RelationScales))
+		}
+	}
+
+	if s.listErrors["HorizontalPodAutoscaler"] != nil {
+		root.Children = append(root.Children, s.unlistedNode("HorizontalPodAutoscaler", RelationScales))
+	}
+
+	// StatefulSet pods carry their volumeClaimTemplate PVCs only in the pod
+	// spec, so collect references from the live pods as well.
+	specs := []*corev1.PodSpec{&template.Spec}
+	for _, pod := range pods {
+		specs = append(specs, &pod.Spec)
+	}
+
+	root.Children = append(root.Children, s.podSpecRefs(specs...)...)
+}
+
+type objectRef struct {
+	kind string
+	name string
+}
+
+// podSpecRefs returns one node per ConfigMap, Secret, PVC and ServiceAccount
+// referenced by any of the pod specs through volumes, env, envFrom or image
+// pull secrets.
+func (s *relationSnapshot) podSpecRefs(specs ...*corev1.PodSpec) []*RelationNode {
+	seen := make(map[objectRef]bool)
+
+	var refs []objectRef
+
+	add := func(kind, name string) {
+		ref := objectRef{kind: kind, name: name}
+		if name == "" || seen[ref] {
+			return
+		}
+
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	for _, spec := range specs {
+		collectPodSpecRefs(spec, add)
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].kind != refs[j].kind {
+			return refs[i].kind < refs[j].kind
+		}
+
+		return refs[i].name < refs[j].name
+	})
+
+	nodes := make([]*RelationNode, 0, len(refs))
+	for _, ref := range refs {
+		nodes = append(nodes, s.refNode(ref.kind, ref.name))
+	}
+
+	return nodes
+}
+
+func collectPodSpecRefs(spec *corev1.PodSpec, add func(kind, name string)) {
+	sa := spec.ServiceAccountName
+	if sa == "" {
+		sa = "default"
+	}
+
+	add("ServiceAccount", sa)
+
+	for _, secret := range spec.ImagePullSecrets {
+		add("Secret", secret.Name)
+	}
+
+	for _, vol := range spec.Volumes {
+		switch {
+		case vol.ConfigMap != nil:
+			add("ConfigMap", vol.ConfigMap.Name)
+		case vol.Secret != nil:
+			add("Secret", vol.Secret.SecretName)
+		case vol.PersistentVolumeClaim != nil:
+			add("PersistentVolumeClaim", vol.PersistentVolumeClaim.ClaimName)
+		case vol.Projected != nil:
+			for _, src := range vol.Projected.Sources {
+				if src.ConfigMap != nil {
+					add("ConfigMap", src.ConfigMap.Name)
+				}
+
+				if src.Secret != nil {
+					add("Secret", src.Secret.Name)
+				}
+			}
+		}
+	}
+
+	for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+		for _, from := range container.EnvFrom {
+			if from.ConfigMapRef != nil {
+				add("ConfigMap", from.ConfigMapRef.Name)
+			}
+
+			if from.SecretRef != nil {
+				add("Secret", from.SecretRef.Name)
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add("ConfigMap", env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+
+			if env.ValueFrom.SecretKeyRef != nil {
+				add("Secret", env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+}
+
+// refExists reports whether a referenced object is in the snapshot.
+func (s *relationSnapshot) refExists(kind, name string) bool {
+	switch kind {
+	case "ConfigMap":
+		return s.configMaps[name]
+	case "Secret":
+		return s.secrets[name]
+	case "ServiceAccount":
+		return s.serviceAccounts[name]
+	case "PersistentVolumeClaim":
+		return s.pvcs[name] != nil
+	}
+
+	return false
+}
+
+// refNode reports whether a referenced object exists; PVCs also show phase.
+// Objects whose kind couldn't be listed are unchecked rather than missing.
+func (s *relationSnapshot) refNode(kind, name string) *RelationNode {
+	if err := s.listErrors[kind]; err != nil {
+		return uncheckedNode(kind, name, s.namespace, RelationMounts, err)
+	}
+
+	if !s.refExists(kind, name) {
+		return missingNode(kind, name, s.namespace, RelationMounts)
+	}
+
+	node := &RelationNode{
+		Kind:      kind,
+		Name:      name,
+		Namespace: s.namespace,
+		Relation:  RelationMounts,
+		Status:    "present",
+		Healthy:   true,
+	}
+
+	if pvc, ok := s.pvcs[name]; ok && kind == "PersistentVolumeClaim" {
+		node.Status = string(pvc.Status.Phase)
+		node.Healthy = pvc.Status.Phase == corev1.ClaimBound
+	}
+
+	return node
+}
+
+func (s *relationSnapshot) ownerNode(ref metav1.OwnerReference) *RelationNode {
+	if err := s.listErrors[ref.Kind]; err != nil {
+		return uncheckedNode(ref.Kind, ref.Name, s.namespace, RelationOwnedBy, err)
+	}
+
+	switch ref.Kind {
+	case "ReplicaSet":
+		for i := range s.replicaSets {
+			if s.replicaSets[i].Name == ref.Name {
+				node := s.replicaSetNode(&s.replicaSets[i], RelationOwnedBy)
+
+				// Show the deployment above the replica set as well
+				for _, parent := range s.replicaSets[i].OwnerReferences {
+					node.Children = append(node.Children, &RelationNode{
+						Kind: parent.Kind, Name: parent.Name, Namespace: s.namespace,
+						Relation: RelationOwnedBy, Healthy: true,
+					})
+				}
+
+				return node
+			}
+		}
+	case "Job":
+		for i := range s.jobs {
+			if s.jobs[i].Name == ref.Name {
+				node := s.jobTree(&s.jobs[i], RelationOwnedBy)
+				node.Children = nil
+
+				return node
+			}
+		}
+	}
+
+	return &RelationNode{
+		Kind:      ref.Kind,
+		Name:      ref.Name,
+		Namespace: s.namespace,
+		Relation:  RelationOwnedBy,
+		Healthy:   true,
+	}
+}
+
+func (s *relationSnapshot) replicaSetNode(rs *appsv1.ReplicaSet, relation string) *RelationNode {
+	desired := int32(1)
+	if rs.Spec.Replicas != nil {
+		desired = *rs.Spec.Replicas
+	}
+
+	status := fmt.Sprintf("%d/%d", rs.Status.ReadyReplicas, desired)
+	if desired == 0 {
+		status += " (old)"
+	}
+
+	return &RelationNode{
+		Kind:      "ReplicaSet",
+		Name:      rs.Name,
+		Namespace: rs.Namespace,
+		Relation:  relation,
+		Status:    status,
+		Healthy:   rs.Status.ReadyReplicas >= desired,
+	}
+}
+
+func (s *relationSnapshot) podsOwnedBy(kind, name string, uid types.UID) []*corev1.Pod {
+	var pods []*corev1.Pod
+
+	for i := range s.pods {
+		if ownedBy(&s.pods[i], kind, name, uid) {
+			pods = append(pods, &s.pods[i])
+		}
+	}
+
+	return pods
+}
+
+func (s *relationSnapshot) findHPA(name string) *autoscalingv2.HorizontalPodAutoscaler {
+	for i := range s.hpas {
+		if s.hpas[i].Name == name {
+			return &s.hpas[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *relationSnapshot) findService(name string) *corev1.Service {
+	for i := range s.services {
+		if s.services[i].Name == name {
+			return &s.services[i]
+		}
+	}
+
+	return nil
+}
+
+func podNode(pod *corev1.Pod, relation string) *RelationNode {
+	status := GetPodStatus(pod)
+
+	return &RelationNode{
+		Kind:      "Pod",
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Relation:  relation,
+		Status:    status,
+		Healthy:   status == "Running" || status == "Succeeded" || status == "Completed",
+	}
+}
+
+func ingressNode(ing *networkingv1.Ingress, relation string) *RelationNode {
+	var hosts []string
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+
+	status := strings.Join(hosts, ", ")
+	if status == "" {
+		status = "*"
+	}
+
+	return &RelationNode{
+		Kind:      "Ingress",
+		Name:      ing.Name,
+		Namespace: ing.Namespace,
+		Relation:  relation,
+		Status:    status,
+		Healthy:   true,
+	}
+}
+
+func hpaNode(hpa *autoscalingv2.HorizontalPodAutoscaler, relation string) *RelationNode {
+	return &RelationNode{
+		Kind:      "HorizontalPodAutoscaler",
+		Name:      hpa.Name,
+		Namespace: hpa.Namespace,
+		Relation:  relation,
+		Status:    GetHPAReplicaCount(hpa),
+		Healthy:   hpaHealthy(hpa),
+	}
+}
+
+// uncheckedNode stands for an object that couldn't be looked up, telling a
+// permission problem apart from other failures.
+func uncheckedNode(kind, name, namespace, relation string, err error) *RelationNode {
+	status := "Unknown"
+	if apierrors.IsForbidden(err) {
+		status = "Forbidden"
+	}
+
+	return &RelationNode{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Relation:  relation,
+		Status:    status,
+		Unchecked: true,
+	}
+}
+
+// unlistedNode stands in for the objects of a kind that couldn't be listed,
+// so the tree doesn't pass for complete without them.
+func (s *relationSnapshot) unlistedNode(kind, relation string) *RelationNode {
+	return uncheckedNode(kind, "*", s.namespace, relation, s.listErrors[kind])
+}
+
+func missingNode(kind, name, namespace, relation string) *RelationNode {
+	return &RelationNode{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Relation:  relation,
+		Status:    "Missing",
+		Healthy:   false,
+	}
+}
+
+func jobStatus(job *batchv1.Job) string {
+	switch {
+	case job.Status.Failed > 0:
+		return fmt.Sprintf("Failed (%d)", job.Status.Failed)
+	case job.Status.Active > 0:
+		return "Running"
+	case job.Status.Succeeded > 0:
+		return "Complete"
+	default:
+		return "Pending"
+	}
+}
+
+func hpaHealthy(hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Type == autoscalingv2.ScalingActive && cond.Status == corev1.ConditionFalse {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ownedBy matches an owner reference by kind and name, and by UID when both
+// sides have one.
+func ownedBy(obj metav1.Object, kind, name string, uid types.UID) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != kind || ref.Name != name {
+			continue
+		}
+
+		if uid != "" && ref.UID != "" && ref.UID != uid {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// selectsLabels reports whether a service-style selector matches the labels.
+// An empty selector selects nothing (headless/external services).
+func selectsLabels(selector, podLabels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+
+	return labels.SelectorFromSet(selector).Matches(labels.Set(podLabels))
+}
+
+// selectsAny matches against live pods, falling back to the pod template
+// labels when the workload has no pods yet.
+func selectsAny(selector, templateLabels map[string]string, pods []*corev1.Pod) bool {
+	if len(pods) == 0 {
+		return selectsLabels(selector, templateLabels)
+	}
+
+	for _, pod := range pods {
+		if selectsLabels(selector, pod.Labels) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ingressBackendServices(ing *networkingv1.Ingress) []string {
+	seen := make(map[string]bool)
+
+	var names []string
+
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || seen[backend.Service.Name] {
+			return
+		}
+
+		seen[backend.Service.Name] = true
+		names = append(names, backend.Service.Name)
+	}
+
+	add(ing.Spec.DefaultBackend)
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			add(&path.Backend)
+		}
+	}
+
+	return names
+}
+
+func ingressRoutesTo(ing *networkingv1.Ingress, service string) bool {
+	for _, name := range ingressBackendServices(ing) {
+		if name == service {
+			return true
+		}
+	}
+
+	return false
+}
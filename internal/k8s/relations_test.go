@@ -0,0 +1,434 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// relationFixtures is a deployment "web" with one replica set and pod, a
+// service and ingress in front of it, an HPA and a pod that mounts a
+// configmap, a missing secret and a PVC.
+func relationFixtures() []runtime.Object {
+	replicas := int32(1)
+	appLabels := map[string]string{"app": "web"}
+
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "dep-uid"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: appLabels},
+				},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-abc",
+				Namespace: "default",
+				UID:       "rs-uid",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Deployment", Name: "web", UID: "dep-uid"},
+				},
+			},
+			Spec:   appsv1.ReplicaSetSpec{Replicas: &replicas},
+			Status: appsv1.ReplicaSetStatus{ReadyReplicas: 1},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-abc-1",
+				Namespace: "default",
+				Labels:    appLabels,
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "web-abc", UID: "rs-uid"},
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "web-sa",
+				Volumes: []corev1.Volume{
+					{Name: "config", VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"},
+						},
+					}},
+					{Name: "data", VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"},
+					}},
+				},
+				Containers: []corev1.Container{{
+					Name: "app",
+					EnvFrom: []corev1.EnvFromSource{{
+						SecretRef: &corev1.SecretEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "web-secret"},
+						},
+					}},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-svc", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: appLabels,
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-svc", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": "other"},
+			},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-ing", Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}},
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Path: "/", Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{Name: "web-svc"},
+								}},
+								{Path: "/gone", Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{Name: "gone-svc"},
+								}},
+							},
+						},
+					},
+				}},
+			},
+		},
+		&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-hpa", Namespace: "default"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MaxReplicas:    3,
+			},
+		},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "default"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web-sa", Namespace: "default"}},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-data", Namespace: "default"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		},
+	}
+}
+
+func findRelation(node *RelationNode, kind, name string) *RelationNode {
+	if node.Kind == kind && node.Name == name {
+		return node
+	}
+
+	for _, child := range node.Children {
+		if found := findRelation(child, kind, name); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func TestBuildRelationTreeDeployment(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+
+	tree, err := client.BuildRelationTree(context.Background(), "Deployment", "default", "web")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	if !tree.Healthy || tree.Status != "1/1" {
+		t.Errorf("root = %+v, want healthy 1/1", tree)
+	}
+
+	rs := findRelation(tree, "ReplicaSet", "web-abc")
+	if rs == nil || rs.Relation != RelationOwns {
+		t.Fatalf("expected owned replica set, got %+v", rs)
+	}
+
+	if pod := findRelation(rs, "Pod", "web-abc-1"); pod == nil || !pod.Healthy {
+		t.Errorf("expected healthy pod under replica set, got %+v", pod)
+	}
+
+	svc := findRelation(tree, "Service", "web-svc")
+	if svc == nil || svc.Relation != RelationSelects {
+		t.Fatalf("expected selecting service, got %+v", svc)
+	}
+
+	if ing := findRelation(svc, "Ingress", "web-ing"); ing == nil || ing.Status != "web.example.com" {
+		t.Errorf("expected ingress routing to service, got %+v", ing)
+	}
+
+	if findRelation(tree, "Service", "other-svc") != nil {
+		t.Error("service with non-matching selector should not be in the tree")
+	}
+
+	if hpa := findRelation(tree, "HorizontalPodAutoscaler", "web-hpa"); hpa == nil {
+		t.Error("expected HPA targeting the deployment")
+	}
+
+	tests := []struct {
+		kind    string
+		name    string
+		status  string
+		healthy bool
+	}{
+		{"ConfigMap", "web-config", "present", true},
+		{"Secret", "web-secret", "Missing", false},
+		{"PersistentVolumeClaim", "web-data", "Pending", false},
+		{"ServiceAccount", "web-sa", "present", true},
+	}
+
+	for _, tt := range tests {
+		node := findRelation(tree, tt.kind, tt.name)
+		if node == nil {
+			t.Errorf("expected %s/%s in tree", tt.kind, tt.name)
+
+			continue
+		}
+
+		if node.Status != tt.status || node.Healthy != tt.healthy {
+			t.Errorf("%s/%s = %q healthy=%v, want %q healthy=%v",
+				tt.kind, tt.name, node.Status, node.Healthy, tt.status, tt.healthy)
+		}
+	}
+}
+
+func TestBuildRelationTreePod(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+
+	tree, err := client.BuildRelationTree(context.Background(), "Pod", "default", "web-abc-1")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	rs := findRelation(tree, "ReplicaSet", "web-abc")
+	if rs == nil || rs.Relation != RelationOwnedBy {
+		t.Fatalf("expected owning replica set, got %+v", rs)
+	}
+
+	if dep := findRelation(rs, "Deployment", "web"); dep == nil {
+		t.Error("expected deployment above the replica set")
+	}
+
+	if svc := findRelation(tree, "Service", "web-svc"); svc == nil {
+		t.Error("expected selecting service")
+	}
+}
+
+func TestBuildRelationTreeIngress(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+
+	tree, err := client.BuildRelationTree(context.Background(), "Ingress", "default", "web-ing")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	svc := findRelation(tree, "Service", "web-svc")
+	if svc == nil {
+		t.Fatal("expected backend service")
+	}
+
+	if pod := findRelation(svc, "Pod", "web-abc-1"); pod == nil {
+		t.Error("expected pods below the backend service")
+	}
+
+	if gone := findRelation(tree, "Service", "gone-svc"); gone == nil || gone.Healthy {
+		t.Errorf("expected missing backend service, got %+v", gone)
+	}
+
+	if tls := findRelation(tree, "Secret", "web-tls"); tls == nil || tls.Status != "Missing" {
+		t.Errorf("expected missing TLS secret, got %+v", tls)
+	}
+}
+
+func TestBuildRelationTreeReferencedObjects(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+	ctx := context.Background()
+
+	for _, ref := range []struct{ kind, name string }{
+		{"ConfigMap", "web-config"},
+		{"ServiceAccount", "web-sa"},
+		{"PersistentVolumeClaim", "web-data"},
+	} {
+		tree, err := client.BuildRelationTree(ctx, ref.kind, "default", ref.name)
+		if err != nil {
+			t.Fatalf("BuildRelationTree(%s) error = %v", ref.kind, err)
+		}
+
+		if pod := findRelation(tree, "Pod", "web-abc-1"); pod == nil || pod.Relation != RelationUsedBy {
+			t.Errorf("%s/%s should be used by the pod, got %+v", ref.kind, ref.name, pod)
+		}
+	}
+
+	_, err := client.BuildRelationTree(ctx, "Secret", "default", "web-secret")
+	if !errors.Is(err, ErrRelationRootNotFound) {
+		t.Errorf("missing secret error = %v, want ErrRelationRootNotFound", err)
+	}
+}
+
+func TestBuildRelationTreeHPA(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+
+	tree, err := client.BuildRelationTree(context.Background(), "HorizontalPodAutoscaler", "default", "web-hpa")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	dep := findRelation(tree, "Deployment", "web")
+	if dep == nil || dep.Relation != RelationTargets || dep.Status != "1/1" {
+		t.Errorf("expected the scaled deployment, got %+v", dep)
+	}
+}
+
+func TestBuildRelationTreeNetworkPolicy(t *testing.T) {
+	objects := append(relationFixtures(),
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-only", Namespace: "default"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "nothing", Namespace: "default"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "none"}},
+			},
+		},
+	)
+	client := createTestClient(fake.NewSimpleClientset(objects...))
+	ctx := context.Background()
+
+	tree, err := client.BuildRelationTree(ctx, "NetworkPolicy", "default", "web-only")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	if tree.Status != "Ingress" || findRelation(tree, "Pod", "web-abc-1") == nil {
+		t.Errorf("expected the policy to select the web pod, got %+v", tree)
+	}
+
+	tree, err = client.BuildRelationTree(ctx, "NetworkPolicy", "default", "nothing")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	if tree.Healthy || len(tree.Children) != 0 {
+		t.Errorf("policy selecting nothing = %+v, want unhealthy leaf", tree)
+	}
+}
+
+func TestBuildRelationTreeForbiddenReferences(t *testing.T) {
+	clientset := fake.NewSimpleClientset(relationFixtures()...)
+	clientset.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("denied"))
+	})
+
+	client := createTestClient(clientset)
+
+	tree, err := client.BuildRelationTree(context.Background(), "Pod", "default", "web-abc-1")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	secret := findRelation(tree, "Secret", "web-secret")
+	if secret == nil || !secret.Unchecked || secret.Status != "Forbidden" {
+		t.Errorf("a secret that can't be listed should be unchecked, got %+v", secret)
+	}
+
+	if cm := findRelation(tree, "ConfigMap", "web-config"); cm == nil || cm.Unchecked {
+		t.Errorf("listable kinds should still be checked, got %+v", cm)
+	}
+}
+
+func TestBuildRelationTreeForbiddenLists(t *testing.T) {
+	clientset := fake.NewSimpleClientset(relationFixtures()...)
+
+	for _, resource := range []string{"replicasets", "jobs", "ingresses", "horizontalpodautoscalers"} {
+		clientset.PrependReactor("list", resource, func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", errors.New("denied"))
+		})
+	}
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	tree, err := client.BuildRelationTree(ctx, "Deployment", "default", "web")
+	if err != nil {
+		t.Fatalf("only failing to list pods should fail the tree, got %v", err)
+	}
+
+	for _, kind := range []string{"ReplicaSet", "HorizontalPodAutoscaler", "Ingress"} {
+		if node := findRelation(tree, kind, "*"); node == nil || !node.Unchecked || node.Status != "Forbidden" {
+			t.Errorf("%s objects that can't be listed should be unchecked, got %+v", kind, node)
+		}
+	}
+
+	if svc := findRelation(tree, "Service", "web-svc"); svc == nil || svc.Unchecked {
+		t.Errorf("listable kinds should still be checked, got %+v", svc)
+	}
+
+	tree, err = client.BuildRelationTree(ctx, "Pod", "default", "web-abc-1")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	if owner := findRelation(tree, "ReplicaSet", "web-abc"); owner == nil || !owner.Unchecked {
+		t.Errorf("an owner that can't be listed should be unchecked, got %+v", owner)
+	}
+
+	if _, err := client.BuildRelationTree(ctx, "Ingress", "default", "web-ing"); err == nil {
+		t.Error("a root whose kind can't be listed should fail")
+	}
+}
+
+func TestBuildRelationTreeServiceWithoutPods(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+
+	tree, err := client.BuildRelationTree(context.Background(), "Service", "default", "other-svc")
+	if err != nil {
+		t.Fatalf("BuildRelationTree() error = %v", err)
+	}
+
+	if tree.Healthy || len(tree.Children) != 0 {
+		t.Errorf("service selecting nothing = %+v, want unhealthy leaf", tree)
+	}
+}
+
+func TestBuildRelationTreeErrors(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(relationFixtures()...))
+	ctx := context.Background()
+
+	if _, err := client.BuildRelationTree(ctx, "Node", "default", "n1"); !errors.Is(err, ErrUnsupportedRelationKind) {
+		t.Errorf("unsupported kind error = %v, want ErrUnsupportedRelationKind", err)
+	}
+
+	if _, err := client.BuildRelationTree(ctx, "Pod", "default", "nope"); !errors.Is(err, ErrRelationRootNotFound) {
+		t.Errorf("missing pod error = %v, want ErrRelationRootNotFound", err)
+	}
+
+	if _, err := client.BuildRelationTree(ctx, "Deployment", "default", "nope"); err == nil {
+		t.Error("expected error for missing deployment")
+	}
+}
@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ErrUnsupportedKind = errors.New("unsupported resource kind")
+	ErrNodeDelete      = errors.New("nodes are drained, not deleted")
+)
+
+// typedResource is the subset of a typed client-go interface bulk actions
+// need, so every kind shares one delete and patch path.
+type typedResource[T any] interface {
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Patch(
+		ctx context.Context, name string, pt types.PatchType, data []byte,
+		opts metav1.PatchOptions, subresources ...string,
+	) (T, error)
+}
+
+type resourceOps struct {
+	delete func(ctx context.Context, name string) error
+	patch  func(ctx context.Context, name string, pt types.PatchType, data []byte) error
+}
+
+func opsFor[T any](r typedResource[T]) resourceOps {
+	return resourceOps{
+		delete: func(ctx context.Context, name string) error {
+			// Background matches kubectl, so Jobs don't orphan their pods.
+			propagation := metav1.DeletePropagationBackground
+
+			return r.Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		},
+		patch: func(ctx context.Context, name string, pt types.PatchType, data []byte) error {
+			_, err := r.Patch(ctx, name, pt, data, metav1.PatchOptions{})
+
+			return err
+		},
+	}
+}
+
+// resource resolves a kind as listed by the panels to its typed client.
+func (c *Client) resource(kind, namespace string) (resourceOps, error) {
+	namespace = c.ns(namespace)
+
+	switch kind {
+	case "Pod":
+		return opsFor(c.clientset.CoreV1().Pods(namespace)), nil
+	case "Service":
+		return opsFor(c.clientset.CoreV1().Services(namespace)), nil
+	case "ConfigMap":
+		return opsFor(c.clientset.CoreV1().ConfigMaps(namespace)), nil
+	case "Secret":
+		return opsFor(c.clientset.CoreV1().Secrets(namespace)), nil
+	case "ServiceAccount":
+		return opsFor(c.clientset.CoreV1().ServiceAccounts(namespace)), nil
+	case "PersistentVolumeClaim":
+		return opsFor(c.clientset.CoreV1().PersistentVolumeClaims(namespace)), nil
+	case "PersistentVolume":
+		return opsFor(c.clientset.CoreV1().PersistentVolumes()), nil
+	case "Namespace":
+		return opsFor(c.clientset.CoreV1().Namespaces()), nil
+	case "Node":
+		return opsFor(c.clientset.CoreV1().Nodes()), nil
+	case "Deployment":
+		return opsFor(c.clientset.AppsV1().Deployments(namespace)), nil
+	case "StatefulSet":
+		return opsFor(c.clientset.AppsV1().StatefulSets(namespace)), nil
+	case "DaemonSet":
+		return opsFor(c.clientset.AppsV1().DaemonSets(namespace)), nil
+	case "Job":
+		return opsFor(c.clientset.BatchV1().Jobs(namespace)), nil
+	case "CronJob":
+		return opsFor(c.clientset.BatchV1().CronJobs(namespace)), nil
+	case "Ingress":
+		return opsFor(c.clientset.NetworkingV1().Ingresses(namespace)), nil
+	case "NetworkPolicy":
+		return opsFor(c.clientset.NetworkingV1().NetworkPolicies(namespace)), nil
+	case "HorizontalPodAutoscaler":
+		return opsFor(c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace)), nil
+	}
+
+	return resourceOps{}, fmt.Errorf("%w: %s", ErrUnsupportedKind, kind)
+}
+
+// DeleteResource deletes a resource of any kind the panels list.
+func (c *Client) DeleteResource(ctx context.Context, kind, namespace, name string) error {
+	if kind == "Node" {
+		return ErrNodeDelete
+	}
+
+	ops, err := c.resource(kind, namespace)
+	if err != nil {
+		return err
+	}
+
+	return ops.delete(ctx, name)
+}
+
+// LabelResource merges labels into a resource's metadata, overwriting
+// existing values of the same keys.
+func (c *Client) LabelResource(ctx context.Context, kind, namespace, name string, labels map[string]string) error {
+	ops, err := c.resource(kind, namespace)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"labels": labels},
+	})
+	if err != nil {
+		return err
+	}
+
+	return ops.patch(ctx, name, types.MergePatchType, patch)
+}
@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeleteResource(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	))
+
+	if err := client.DeleteResource(t.Context(), "Pod", "default", "web-1"); err != nil {
+		t.Fatalf("DeleteResource returned unexpected error: %v", err)
+	}
+
+	if _, err := client.GetPod(t.Context(), "default", "web-1"); err == nil {
+		t.Error("pod should be deleted")
+	}
+
+	if err := client.DeleteResource(t.Context(), "Node", "", "node-1"); !errors.Is(err, ErrNodeDelete) {
+		t.Errorf("deleting a node error = %v, want ErrNodeDelete", err)
+	}
+
+	if err := client.DeleteResource(t.Context(), "Event", "default", "x"); !errors.Is(err, ErrUnsupportedKind) {
+		t.Errorf("deleting an event error = %v, want ErrUnsupportedKind", err)
+	}
+}
+
+func TestLabelResource(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web", "team": "old"},
+		},
+	}))
+
+	err := client.LabelResource(t.Context(), "Deployment", "default", "web", map[string]string{"team": "payments"})
+	if err != nil {
+		t.Fatalf("LabelResource returned unexpected error: %v", err)
+	}
+
+	deploy, _ := client.GetDeployment(t.Context(), "default", "web")
+	if deploy.Labels["team"] != "payments" || deploy.Labels["app"] != "web" {
+		t.Errorf("labels = %v, want team overwritten and app kept", deploy.Labels)
+	}
+}
@@ -0,0 +1,303 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ErrUnsupportedRightsizingKind = errors.New("right-sizing is supported for Deployments, StatefulSets and DaemonSets")
+	ErrNoRecommendation           = errors.New("no request changes to apply")
+)
+
+// MinRightsizingSamples is how many samples a container needs before
+// requests are suggested for it.
+const MinRightsizingSamples = 10
+
+const (
+	// rightsizingHeadroom is added on top of p95 usage.
+	rightsizingHeadroom = 1.15
+	// overProvisionedRatio flags requests this many times the suggestion.
+	overProvisionedRatio = 2.0
+	// nearLimitRatio flags usage this close to a limit.
+	nearLimitRatio = 0.9
+
+	minCPURequest    = 10               // millicores
+	minMemoryRequest = 32 * 1024 * 1024 // bytes
+	// Differences below these aren't worth a rollout.
+	minCPUSaving    = 50
+	minMemorySaving = 64 * 1024 * 1024
+)
+
+// RightsizingFlag marks a container whose sizing needs attention.
+type RightsizingFlag string
+
+const (
+	FlagOverProvisioned  RightsizingFlag = "over-provisioned"
+	FlagUnderProvisioned RightsizingFlag = "under-provisioned"
+	FlagOOMRisk          RightsizingFlag = "oom-risk"
+	FlagCPUThrottling    RightsizingFlag = "cpu-throttling"
+)
+
+// ContainerRecommendation compares a container's observed usage with its
+// requests and limits. Suggested values equal the current ones when no
+// change is needed; both are 0 without enough samples.
+type ContainerRecommendation struct {
+	Container  string
+	Samples    int
+	CPUP95     int64 // millicores
+	MemoryP95  int64 // bytes
+	MemoryPeak int64 // bytes
+
+	CPURequest    int64
+	CPULimit      int64
+	MemoryRequest int64
+	MemoryLimit   int64
+
+	SuggestedCPURequest    int64
+	SuggestedMemoryRequest int64
+
+	Flags []RightsizingFlag
+}
+
+// Changed reports whether the suggestion differs from the current requests.
+func (r *ContainerRecommendation) Changed() bool {
+	return r.Samples >= MinRightsizingSamples &&
+		(r.SuggestedCPURequest != r.CPURequest || r.SuggestedMemoryRequest != r.MemoryRequest)
+}
+
+// WorkloadRecommendation holds the recommendations for every container of a
+// workload's pod template.
+type WorkloadRecommendation struct {
+	Kind       string
+	Name       string
+	Namespace  string
+	Containers []ContainerRecommendation
+}
+
+// Changed reports whether any container has a suggestion to apply.
+func (w *WorkloadRecommendation) Changed() bool {
+	return slices.ContainsFunc(w.Containers, func(r ContainerRecommendation) bool { return r.Changed() })
+}
+
+// RecommendContainer derives request suggestions from samples: p95 usage
+// plus headroom, applied only when the current request is missing, below
+// p95 or more than twice the suggestion.
+func RecommendContainer(container *corev1.Container, samples []UsageSample) ContainerRecommendation {
+	rec := ContainerRecommendation{
+		Container:     container.Name,
+		Samples:       len(samples),
+		CPURequest:    MilliCPU(container.Resources.Requests),
+		CPULimit:      MilliCPU(container.Resources.Limits),
+		MemoryRequest: MemoryBytes(container.Resources.Requests),
+		MemoryLimit:   MemoryBytes(container.Resources.Limits),
+	}
+
+	if len(samples) < MinRightsizingSamples {
+		return rec
+	}
+
+	cpu := make([]int64, 0, len(samples))
+	memory := make([]int64, 0, len(samples))
+
+	for _, s := range samples {
+		cpu = append(cpu, s.CPU)
+		memory = append(memory, s.Memory)
+	}
+
+	rec.CPUP95 = percentile(cpu, 0.95)
+	rec.MemoryP95 = percentile(memory, 0.95)
+	rec.MemoryPeak = slices.Max(memory)
+
+	rec.SuggestedCPURequest, rec.Flags = suggestRequest(
+		rec.CPURequest, rec.CPUP95, roundUp(withHeadroom(rec.CPUP95), 5, minCPURequest), minCPUSaving, rec.Flags)
+	rec.SuggestedMemoryRequest, rec.Flags = suggestRequest(
+		rec.MemoryRequest, rec.MemoryP95,
+		roundUp(withHeadroom(rec.MemoryP95), 1024*1024, minMemoryRequest), minMemorySaving, rec.Flags)
+
+	// A request above the limit would be rejected
+	if rec.CPULimit > 0 {
+		rec.SuggestedCPURequest = min(rec.SuggestedCPURequest, rec.CPULimit)
+
+		if rec.CPUP95 >= int64(float64(rec.CPULimit)*nearLimitRatio) {
+			rec.Flags = append(rec.Flags, FlagCPUThrottling)
+		}
+	}
+
+	if rec.MemoryLimit > 0 {
+		rec.SuggestedMemoryRequest = min(rec.SuggestedMemoryRequest, rec.MemoryLimit)
+
+		if rec.MemoryPeak >= int64(float64(rec.MemoryLimit)*nearLimitRatio) {
+			rec.Flags = append(rec.Flags, FlagOOMRisk)
+		}
+	}
+
+	return rec
+}
+
+func suggestRequest(
+	current, p95, suggested, minSaving int64,
+	flags []RightsizingFlag,
+) (int64, []RightsizingFlag) {
+	switch {
+	case current == 0:
+		return suggested, flags
+	case current < p95:
+		return suggested, appendFlag(flags, FlagUnderProvisioned)
+	case float64(current) > float64(suggested)*overProvisionedRatio && current-suggested >= minSaving:
+		return suggested, appendFlag(flags, FlagOverProvisioned)
+	default:
+		return current, flags
+	}
+}
+
+func appendFlag(flags []RightsizingFlag, flag RightsizingFlag) []RightsizingFlag {
+	if slices.Contains(flags, flag) {
+		return flags
+	}
+
+	return append(flags, flag)
+}
+
+// percentile uses the nearest-rank method.
+func percentile(values []int64, p float64) int64 {
+	sorted := slices.Sorted(slices.Values(values))
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+
+	return sorted[max(rank, 0)]
+}
+
+func withHeadroom(v int64) int64 {
+	return int64(math.Ceil(float64(v) * rightsizingHeadroom))
+}
+
+func roundUp(v, step, floor int64) int64 {
+	return max((v+step-1)/step*step, floor)
+}
+
+// RecommendWorkload compares each container of a Deployment, StatefulSet or
+// DaemonSet with the usage history of its pods.
+func (c *Client) RecommendWorkload(
+	ctx context.Context,
+	kind, namespace, name string,
+	history *UsageHistory,
+) (*WorkloadRecommendation, error) {
+	namespace = c.ns(namespace)
+
+	template, selector, err := c.workloadTemplate(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &WorkloadRecommendation{Kind: kind, Name: name, Namespace: namespace}
+
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+		samples := history.WorkloadSamples(namespace, selector, container.Name)
+		rec.Containers = append(rec.Containers, RecommendContainer(container, samples))
+	}
+
+	return rec, nil
+}
+
+func (c *Client) workloadTemplate(
+	ctx context.Context,
+	kind, namespace, name string,
+) (*corev1.PodTemplateSpec, *metav1.LabelSelector, error) {
+	switch kind {
+	case "Deployment":
+		d, err := c.GetDeployment(ctx, namespace, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &d.Spec.Template, d.Spec.Selector, nil
+	case "StatefulSet":
+		sts, err := c.GetStatefulSet(ctx, namespace, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &sts.Spec.Template, sts.Spec.Selector, nil
+	case "DaemonSet":
+		ds, err := c.GetDaemonSet(ctx, namespace, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &ds.Spec.Template, ds.Spec.Selector, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedRightsizingKind, kind)
+	}
+}
+
+// RightsizingPatch is the strategic merge patch that sets the suggested
+// requests of every changed container, leaving limits alone.
+func RightsizingPatch(rec *WorkloadRecommendation) ([]byte, error) {
+	var containers []map[string]any
+
+	for _, r := range rec.Containers {
+		if !r.Changed() {
+			continue
+		}
+
+		containers = append(containers, map[string]any{
+			"name": r.Container,
+			"resources": map[string]any{
+				"requests": map[string]string{
+					string(corev1.ResourceCPU):    resource.NewMilliQuantity(r.SuggestedCPURequest, resource.DecimalSI).String(),
+					string(corev1.ResourceMemory): resource.NewQuantity(r.SuggestedMemoryRequest, resource.BinarySI).String(),
+				},
+			},
+		})
+	}
+
+	if len(containers) == 0 {
+		return nil, ErrNoRecommendation
+	}
+
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{"containers": containers},
+			},
+		},
+	})
+}
+
+// ApplyRightsizing patches the workload's container requests to the
+// suggested values.
+func (c *Client) ApplyRightsizing(ctx context.Context, rec *WorkloadRecommendation) error {
+	patch, err := RightsizingPatch(rec)
+	if err != nil {
+		return err
+	}
+
+	namespace := c.ns(rec.Namespace)
+	opts := metav1.PatchOptions{}
+
+	switch rec.Kind {
+	case "Deployment":
+		_, err = c.clientset.AppsV1().Deployments(namespace).
+			Patch(ctx, rec.Name, types.StrategicMergePatchType, patch, opts)
+	case "StatefulSet":
+		_, err = c.clientset.AppsV1().StatefulSets(namespace).
+			Patch(ctx, rec.Name, types.StrategicMergePatchType, patch, opts)
+	case "DaemonSet":
+		_, err = c.clientset.AppsV1().DaemonSets(namespace).
+			Patch(ctx, rec.Name, types.StrategicMergePatchType, patch, opts)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedRightsizingKind, rec.Kind)
+	}
+
+	return err
+}
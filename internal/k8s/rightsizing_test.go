@@ -0,0 +1,196 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const mi = 1024 * 1024
+
+// steadySamples returns n samples at the given usage with one spike of
+// peakMemory, which p95 ignores.
+func steadySamples(n int, cpu, memory, peakMemory int64) []UsageSample {
+	samples := make([]UsageSample, n)
+	for i := range samples {
+		samples[i] = UsageSample{Time: time.Now(), CPU: cpu, Memory: memory}
+	}
+
+	samples[0].Memory = peakMemory
+
+	return samples
+}
+
+func sizedContainer(cpuReq, memReq, memLimit string) *corev1.Container {
+	c := &corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}}
+
+	if cpuReq != "" {
+		c.Resources.Requests[corev1.ResourceCPU] = resource.MustParse(cpuReq)
+	}
+
+	if memReq != "" {
+		c.Resources.Requests[corev1.ResourceMemory] = resource.MustParse(memReq)
+	}
+
+	if memLimit != "" {
+		c.Resources.Limits[corev1.ResourceMemory] = resource.MustParse(memLimit)
+	}
+
+	return c
+}
+
+func TestRecommendContainer(t *testing.T) {
+	tests := []struct {
+		name             string
+		container        *corev1.Container
+		samples          []UsageSample
+		wantCPU, wantMem int64
+		wantFlags        []RightsizingFlag
+		wantChanged      bool
+	}{
+		{
+			name:        "over-provisioned",
+			container:   sizedContainer("1", "1Gi", ""),
+			samples:     steadySamples(20, 100, 200*mi, 200*mi),
+			wantCPU:     115,
+			wantMem:     230 * mi,
+			wantFlags:   []RightsizingFlag{FlagOverProvisioned},
+			wantChanged: true,
+		},
+		{
+			name:      "well sized is left alone",
+			container: sizedContainer("150m", "300Mi", ""),
+			samples:   steadySamples(20, 100, 200*mi, 200*mi),
+			wantCPU:   150,
+			wantMem:   300 * mi,
+		},
+		{
+			name:        "under-provisioned near its memory limit",
+			container:   sizedContainer("50m", "128Mi", "256Mi"),
+			samples:     steadySamples(20, 100, 200*mi, 250*mi),
+			wantCPU:     115,
+			wantMem:     230 * mi,
+			wantFlags:   []RightsizingFlag{FlagUnderProvisioned, FlagOOMRisk},
+			wantChanged: true,
+		},
+		{
+			name:        "missing requests get the floor",
+			container:   sizedContainer("", "", ""),
+			samples:     steadySamples(20, 1, 1*mi, 1*mi),
+			wantCPU:     minCPURequest,
+			wantMem:     minMemoryRequest,
+			wantChanged: true,
+		},
+		{
+			name:      "too few samples",
+			container: sizedContainer("1", "1Gi", ""),
+			samples:   steadySamples(MinRightsizingSamples-1, 100, 200*mi, 200*mi),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := RecommendContainer(tt.container, tt.samples)
+
+			if rec.SuggestedCPURequest != tt.wantCPU || rec.SuggestedMemoryRequest != tt.wantMem {
+				t.Errorf("suggested = %dm/%dMi, want %dm/%dMi",
+					rec.SuggestedCPURequest, rec.SuggestedMemoryRequest/mi, tt.wantCPU, tt.wantMem/mi)
+			}
+
+			if !slices.Equal(rec.Flags, tt.wantFlags) {
+				t.Errorf("flags = %v, want %v", rec.Flags, tt.wantFlags)
+			}
+
+			if rec.Changed() != tt.wantChanged {
+				t.Errorf("Changed() = %v, want %v", rec.Changed(), tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []int64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5, 100, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+
+	if got := percentile(values, 0.95); got != 19 {
+		t.Errorf("p95 = %d, want 19", got)
+	}
+
+	if got := percentile([]int64{42}, 0.95); got != 42 {
+		t.Errorf("p95 of one value = %d, want 42", got)
+	}
+}
+
+func TestRecommendAndApplyWorkload(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					*sizedContainer("2", "2Gi", "4Gi"),
+					{Name: "sidecar"},
+				}},
+			},
+		},
+	}
+	client := createTestClient(fake.NewSimpleClientset(deployment))
+	ctx := context.Background()
+
+	history := NewUsageHistory(100)
+	for range 20 {
+		history.Record(time.Now(), []ContainerMetrics{
+			{Namespace: "default", Pod: "web-1", Container: "app", Labels: labels, CPU: 100, Memory: 200 * mi},
+		})
+	}
+
+	rec, err := client.RecommendWorkload(ctx, "Deployment", "default", "web", history)
+	if err != nil {
+		t.Fatalf("RecommendWorkload() error = %v", err)
+	}
+
+	if len(rec.Containers) != 2 || !rec.Containers[0].Changed() || rec.Containers[1].Changed() {
+		t.Fatalf("unexpected recommendation %+v", rec)
+	}
+
+	if err := client.ApplyRightsizing(ctx, rec); err != nil {
+		t.Fatalf("ApplyRightsizing() error = %v", err)
+	}
+
+	updated, _ := client.GetDeployment(ctx, "default", "web")
+	resources := updated.Spec.Template.Spec.Containers[0].Resources
+
+	if cpu := resources.Requests[corev1.ResourceCPU]; cpu.String() != "115m" {
+		t.Errorf("cpu request = %s, want 115m", cpu.String())
+	}
+
+	if memory := resources.Requests[corev1.ResourceMemory]; memory.String() != "230Mi" {
+		t.Errorf("memory request = %s, want 230Mi", memory.String())
+	}
+
+	if limit := resources.Limits[corev1.ResourceMemory]; limit.String() != "4Gi" {
+		t.Errorf("limits should be untouched, got %s", limit.String())
+	}
+
+	if _, err := client.RecommendWorkload(ctx, "Job", "default", "web", history); !errors.Is(
+		err, ErrUnsupportedRightsizingKind) {
+		t.Errorf("expected ErrUnsupportedRightsizingKind, got %v", err)
+	}
+
+	rec.Containers = rec.Containers[1:]
+	if err := client.ApplyRightsizing(ctx, rec); !errors.Is(err, ErrNoRecommendation) {
+		t.Errorf("expected ErrNoRecommendation, got %v", err)
+	}
+}
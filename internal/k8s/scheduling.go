@@ -0,0 +1,573 @@
+package k8s
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var ErrPodAlreadyScheduled = errors.New("pod is already scheduled")
+
+// NodeFit is the verdict for placing a pod on one node. Reasons is empty
+// when the pod fits.
+type NodeFit struct {
+	Node    string
+	Reasons []string
+}
+
+// Fits reports whether no predicate rejected the node.
+func (f NodeFit) Fits() bool {
+	return len(f.Reasons) == 0
+}
+
+// SchedulingDiagnosis explains why a Pending pod has not been placed.
+type SchedulingDiagnosis struct {
+	Pod    string
+	Nodes  []NodeFit
+	Events []string
+}
+
+// SchedulingState is the cluster snapshot a pod is evaluated against.
+type SchedulingState struct {
+	Nodes []corev1.Node
+	// Pods are all pods in the cluster; only those bound to a node count
+	// towards resources, affinity and topology spread.
+	Pods            []corev1.Pod
+	NamespaceLabels map[string]map[string]string
+}
+
+// DiagnoseScheduling evaluates a pod that has not been bound to a node
+// against every node and collects its FailedScheduling events.
+func (c *Client) DiagnoseScheduling(ctx context.Context, namespace, name string) (*SchedulingDiagnosis, error) {
+	namespace = c.ns(namespace)
+
+	pod, err := c.GetPod(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.Spec.NodeName != "" {
+		return nil, fmt.Errorf("%w on %s", ErrPodAlreadyScheduled, pod.Spec.NodeName)
+	}
+
+	nodes, err := c.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.ListPodsAllNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := c.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis := &SchedulingDiagnosis{
+		Pod: namespace + "/" + name,
+		Nodes: EvaluateScheduling(pod, &SchedulingState{
+			Nodes:           nodes,
+			Pods:            pods,
+			NamespaceLabels: NamespaceLabels(namespaces),
+		}),
+	}
+
+	// Events are context only; missing RBAC shouldn't hide the node table
+	events, err := c.ListEventsForResource(ctx, namespace, "Pod", name)
+	if err == nil {
+		diagnosis.Events = failedSchedulingMessages(events, name)
+	}
+
+	return diagnosis, nil
+}
+
+// EvaluateScheduling runs the scheduler's filter predicates for pod against
+// every node: cordoning, nodeSelector, required node affinity, taints,
+// allocatable resources, required pod (anti-)affinity, the required
+// anti-affinity of pods already running, and topology spread. Nodes that
+// fit come first, the rest are sorted by name.
+func EvaluateScheduling(pod *corev1.Pod, state *SchedulingState) []NodeFit {
+	nodesByName := make(map[string]*corev1.Node, len(state.Nodes))
+	for i := range state.Nodes {
+		nodesByName[state.Nodes[i].Name] = &state.Nodes[i]
+	}
+
+	var bound []boundPod
+
+	for i := range state.Pods {
+		p := &state.Pods[i]
+		if node, ok := nodesByName[p.Spec.NodeName]; ok && !isTerminated(p) {
+			bound = append(bound, boundPod{pod: p, node: node})
+		}
+	}
+
+	fits := make([]NodeFit, 0, len(state.Nodes))
+
+	for i := range state.Nodes {
+		node := &state.Nodes[i]
+
+		var reasons []string
+
+		reasons = append(reasons, nodeSelectionReasons(pod, node)...)
+		reasons = append(reasons, taintReasons(pod, node)...)
+		reasons = append(reasons, resourceReasons(pod, node, bound)...)
+		reasons = append(reasons, podAffinityReasons(pod, node, bound, state.NamespaceLabels)...)
+		reasons = append(reasons, existingAntiAffinityReasons(pod, node, bound, state.NamespaceLabels)...)
+		reasons = append(reasons, topologySpreadReasons(pod, node, state.Nodes, bound)...)
+
+		fits = append(fits, NodeFit{Node: node.Name, Reasons: reasons})
+	}
+
+	slices.SortFunc(fits, func(a, b NodeFit) int {
+		if a.Fits() != b.Fits() {
+			if a.Fits() {
+				return -1
+			}
+
+			return 1
+		}
+
+		return cmp.Compare(a.Node, b.Node)
+	})
+
+	return fits
+}
+
+// PodRequests returns the effective requests of a pod the way the scheduler
+// computes them: the sum over containers, raised to the largest init
+// container, plus pod overhead.
+func PodRequests(pod *corev1.Pod) corev1.ResourceList {
+	return effectiveResources(pod, func(c *corev1.Container) corev1.ResourceList {
+		return c.Resources.Requests
+	})
+}
+
+// PodLimits is PodRequests for limits.
+func PodLimits(pod *corev1.Pod) corev1.ResourceList {
+	return effectiveResources(pod, func(c *corev1.Container) corev1.ResourceList {
+		return c.Resources.Limits
+	})
+}
+
+func effectiveResources(pod *corev1.Pod, get func(*corev1.Container) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+
+	for i := range pod.Spec.Containers {
+		addResources(total, get(&pod.Spec.Containers[i]))
+	}
+
+	for i := range pod.Spec.InitContainers {
+		for name, qty := range get(&pod.Spec.InitContainers[i]) {
+			if current, ok := total[name]; !ok || qty.Cmp(current) > 0 {
+				total[name] = qty.DeepCopy()
+			}
+		}
+	}
+
+	addResources(total, pod.Spec.Overhead)
+
+	return total
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, qty := range add {
+		current := total[name]
+		current.Add(qty)
+		total[name] = current
+	}
+}
+
+// isTerminated reports whether a pod no longer holds node resources.
+func isTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+type boundPod struct {
+	pod  *corev1.Pod
+	node *corev1.Node
+}
+
+func nodeSelectionReasons(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+
+	if node.Spec.Unschedulable && !toleratesUnschedulable(pod) {
+		reasons = append(reasons, "node is cordoned")
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(pod.Spec.NodeSelector)) {
+		if node.Labels[k] != pod.Spec.NodeSelector[k] {
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s not matched", k, pod.Spec.NodeSelector[k]))
+		}
+	}
+
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return reasons
+	}
+
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if !slices.ContainsFunc(terms, func(term corev1.NodeSelectorTerm) bool {
+		return nodeSelectorTermMatches(&term, node)
+	}) {
+		reasons = append(reasons, "required node affinity not matched")
+	}
+
+	return reasons
+}
+
+func toleratesUnschedulable(pod *corev1.Pod) bool {
+	taint := corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}
+
+	return slices.ContainsFunc(pod.Spec.Tolerations, func(t corev1.Toleration) bool {
+		return t.ToleratesTaint(&taint)
+	})
+}
+
+// nodeSelectorTermMatches ANDs the term's expressions and fields. An empty
+// term matches nothing.
+func nodeSelectorTermMatches(term *corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+
+	for _, req := range term.MatchExpressions {
+		if !nodeRequirementMatches(req, node.Labels) {
+			return false
+		}
+	}
+
+	fields := map[string]string{"metadata.name": node.Name}
+	for _, req := range term.MatchFields {
+		if !nodeRequirementMatches(req, fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func nodeRequirementMatches(req corev1.NodeSelectorRequirement, set map[string]string) bool {
+	value, ok := set[req.Key]
+
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		return ok && slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !ok || !slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return ok
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !ok || len(req.Values) != 1 {
+			return false
+		}
+
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		bound, err := strconv.ParseInt(req.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return actual > bound
+		}
+
+		return actual < bound
+	}
+
+	return false
+}
+
+func taintReasons(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+
+		// Cordoning is reported by nodeSelectionReasons
+		if taint.Key == corev1.TaintNodeUnschedulable && node.Spec.Unschedulable {
+			continue
+		}
+
+		if !slices.ContainsFunc(pod.Spec.Tolerations, func(t corev1.Toleration) bool {
+			return t.ToleratesTaint(taint)
+		}) {
+			reasons = append(reasons, "untolerated taint "+taint.ToString())
+		}
+	}
+
+	return reasons
+}
+
+func resourceReasons(pod *corev1.Pod, node *corev1.Node, bound []boundPod) []string {
+	used := corev1.ResourceList{}
+	podCount := 0
+
+	for _, b := range bound {
+		if b.node.Name == node.Name {
+			addResources(used, PodRequests(b.pod))
+			podCount++
+		}
+	}
+
+	var reasons []string
+
+	if maxPods, ok := node.Status.Allocatable[corev1.ResourcePods]; ok && int64(podCount) >= maxPods.Value() {
+		reasons = append(reasons, fmt.Sprintf("too many pods: %d of %d", podCount, maxPods.Value()))
+	}
+
+	requests := PodRequests(pod)
+
+	for _, name := range slices.Sorted(maps.Keys(requests)) {
+		requested := requests[name]
+		if requested.IsZero() {
+			continue
+		}
+
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("no allocatable %s", name))
+
+			continue
+		}
+
+		free := allocatable.DeepCopy()
+		free.Sub(used[name])
+
+		if requested.Cmp(free) > 0 {
+			reasons = append(reasons, fmt.Sprintf("insufficient %s: requests %s, %s free of %s",
+				name, requested.String(), freeString(free), allocatable.String()))
+		}
+	}
+
+	return reasons
+}
+
+// freeString renders what's left on a node; overcommitted nodes show 0.
+func freeString(free resource.Quantity) string {
+	if free.Sign() < 0 {
+		return "0"
+	}
+
+	return free.String()
+}
+
+func podAffinityReasons(
+	pod *corev1.Pod,
+	node *corev1.Node,
+	bound []boundPod,
+	nsLabels map[string]map[string]string,
+) []string {
+	if pod.Spec.Affinity == nil {
+		return nil
+	}
+
+	var reasons []string
+
+	if aff := pod.Spec.Affinity.PodAffinity; aff != nil {
+		for i := range aff.RequiredDuringSchedulingIgnoredDuringExecution {
+			term := &aff.RequiredDuringSchedulingIgnoredDuringExecution[i]
+			if countInDomain(term, pod, node, bound, nsLabels) > 0 {
+				continue
+			}
+
+			// The first pod of a group may satisfy its own affinity
+			if !anyMatch(term, pod, bound, nsLabels) && affinityTermMatchesPod(term, pod, pod, nsLabels) {
+				continue
+			}
+
+			reasons = append(reasons, "pod affinity not satisfied on "+term.TopologyKey)
+		}
+	}
+
+	if anti := pod.Spec.Affinity.PodAntiAffinity; anti != nil {
+		for i := range anti.RequiredDuringSchedulingIgnoredDuringExecution {
+			term := &anti.RequiredDuringSchedulingIgnoredDuringExecution[i]
+			if n := countInDomain(term, pod, node, bound, nsLabels); n > 0 {
+				reasons = append(reasons, fmt.Sprintf("pod anti-affinity: %d matching pod(s) in the same %s", n,
+					term.TopologyKey))
+			}
+		}
+	}
+
+	return reasons
+}
+
+// existingAntiAffinityReasons reports the pods in node's topology domains
+// whose required anti-affinity matches pod, which keeps pod off the node as
+// surely as its own anti-affinity would.
+func existingAntiAffinityReasons(
+	pod *corev1.Pod,
+	node *corev1.Node,
+	bound []boundPod,
+	nsLabels map[string]map[string]string,
+) []string {
+	var reasons []string
+
+	for _, b := range bound {
+		if b.pod.Spec.Affinity == nil || b.pod.Spec.Affinity.PodAntiAffinity == nil {
+			continue
+		}
+
+		terms := b.pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		for i := range terms {
+			term := &terms[i]
+
+			domain, ok := node.Labels[term.TopologyKey]
+			if !ok || b.node.Labels[term.TopologyKey] != domain {
+				continue
+			}
+
+			// The term is scoped to the existing pod's namespace
+			if affinityTermMatchesPod(term, b.pod, pod, nsLabels) {
+				reasons = append(reasons, fmt.Sprintf("pod anti-affinity of %s/%s in the same %s",
+					b.pod.Namespace, b.pod.Name, term.TopologyKey))
+
+				break
+			}
+		}
+	}
+
+	return reasons
+}
+
+// countInDomain counts bound pods matching term in node's topology domain.
+func countInDomain(
+	term *corev1.PodAffinityTerm,
+	pod *corev1.Pod,
+	node *corev1.Node,
+	bound []boundPod,
+	nsLabels map[string]map[string]string,
+) int {
+	domain, ok := node.Labels[term.TopologyKey]
+	if !ok {
+		return 0
+	}
+
+	count := 0
+
+	for _, b := range bound {
+		if value, ok := b.node.Labels[term.TopologyKey]; ok && value == domain &&
+			affinityTermMatchesPod(term, pod, b.pod, nsLabels) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func anyMatch(
+	term *corev1.PodAffinityTerm,
+	pod *corev1.Pod,
+	bound []boundPod,
+	nsLabels map[string]map[string]string,
+) bool {
+	return slices.ContainsFunc(bound, func(b boundPod) bool {
+		return affinityTermMatchesPod(term, pod, b.pod, nsLabels)
+	})
+}
+
+// affinityTermMatchesPod reports whether candidate is in the term's
+// namespaces and matches its label selector. Without namespaces or a
+// namespaceSelector the term is scoped to the incoming pod's namespace.
+func affinityTermMatchesPod(
+	term *corev1.PodAffinityTerm,
+	pod, candidate *corev1.Pod,
+	nsLabels map[string]map[string]string,
+) bool {
+	inNamespace := slices.Contains(term.Namespaces, candidate.Namespace)
+	if term.NamespaceSelector != nil {
+		inNamespace = inNamespace || selectorMatches(term.NamespaceSelector, nsLabels[candidate.Namespace])
+	} else if len(term.Namespaces) == 0 {
+		inNamespace = candidate.Namespace == pod.Namespace
+	}
+
+	return inNamespace && selectorMatches(term.LabelSelector, candidate.Labels)
+}
+
+func topologySpreadReasons(pod *corev1.Pod, node *corev1.Node, nodes []corev1.Node, bound []boundPod) []string {
+	var reasons []string
+
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+
+		domain, ok := node.Labels[constraint.TopologyKey]
+		if !ok {
+			reasons = append(reasons, "node has no "+constraint.TopologyKey+" label for topology spread")
+
+			continue
+		}
+
+		// Domains are those of nodes the pod could otherwise land on
+		counts := map[string]int{}
+
+		for i := range nodes {
+			if value, ok := nodes[i].Labels[constraint.TopologyKey]; ok &&
+				len(nodeSelectionReasons(pod, &nodes[i])) == 0 {
+				counts[value] += 0
+			}
+		}
+
+		for _, b := range bound {
+			value, ok := b.node.Labels[constraint.TopologyKey]
+			if _, eligible := counts[value]; ok && eligible && b.pod.Namespace == pod.Namespace &&
+				selectorMatches(constraint.LabelSelector, b.pod.Labels) {
+				counts[value]++
+			}
+		}
+
+		minCount := counts[domain]
+		for _, n := range counts {
+			minCount = min(minCount, n)
+		}
+
+		if skew := counts[domain] + 1 - minCount; skew > int(constraint.MaxSkew) {
+			reasons = append(reasons, fmt.Sprintf("topology spread on %s: skew %d exceeds maxSkew %d",
+				constraint.TopologyKey, skew, constraint.MaxSkew))
+		}
+	}
+
+	return reasons
+}
+
+func failedSchedulingMessages(events []corev1.Event, podName string) []string {
+	slices.SortFunc(events, func(a, b corev1.Event) int {
+		return a.LastTimestamp.Compare(b.LastTimestamp.Time)
+	})
+
+	var messages []string
+
+	for _, event := range events {
+		if event.Reason != "FailedScheduling" || event.InvolvedObject.Name != podName {
+			continue
+		}
+
+		msg := strings.TrimSpace(event.Message)
+		if !slices.Contains(messages, msg) {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,282 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func schedNode(name, zone, cpu string, nodeLabels map[string]string, taints ...corev1.Taint) corev1.Node {
+	merged := map[string]string{"topology.kubernetes.io/zone": zone}
+	for k, v := range nodeLabels {
+		merged[k] = v
+	}
+
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: merged},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}
+}
+
+func schedPod(name, node, cpu string, podLabels map[string]string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func reasonsFor(t *testing.T, fits []NodeFit, node string) []string {
+	t.Helper()
+
+	for _, fit := range fits {
+		if fit.Node == node {
+			return fit.Reasons
+		}
+	}
+
+	t.Fatalf("node %s missing from %+v", node, fits)
+
+	return nil
+}
+
+func hasReason(reasons []string, substr string) bool {
+	for _, r := range reasons {
+		if strings.Contains(r, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestEvaluateSchedulingPredicates(t *testing.T) {
+	state := &SchedulingState{
+		Nodes: []corev1.Node{
+			schedNode("ssd", "a", "2", map[string]string{"disk": "ssd"}),
+			schedNode("hdd", "a", "2", nil),
+			schedNode("gpu", "b", "2", map[string]string{"disk": "ssd"},
+				corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}),
+			schedNode("full", "b", "1", map[string]string{"disk": "ssd"}),
+		},
+		Pods: []corev1.Pod{
+			schedPod("hog", "full", "800m", nil),
+			schedPod("done", "full", "900m", nil),
+		},
+	}
+	state.Pods[1].Status.Phase = corev1.PodSucceeded
+
+	pod := schedPod("pending", "", "500m", nil)
+	pod.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+
+	fits := EvaluateScheduling(&pod, state)
+
+	if fits[0].Node != "ssd" || !fits[0].Fits() {
+		t.Errorf("expected ssd to fit first, got %+v", fits[0])
+	}
+
+	if r := reasonsFor(t, fits, "hdd"); !hasReason(r, "nodeSelector disk=ssd") {
+		t.Errorf("hdd reasons = %v", r)
+	}
+
+	if r := reasonsFor(t, fits, "gpu"); !hasReason(r, "untolerated taint gpu=true:NoSchedule") {
+		t.Errorf("gpu reasons = %v", r)
+	}
+
+	// Succeeded pods release their requests
+	if r := reasonsFor(t, fits, "full"); len(r) != 1 || !hasReason(r, "insufficient cpu: requests 500m, 200m free of 1") {
+		t.Errorf("full reasons = %v", r)
+	}
+
+	pod.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	if r := reasonsFor(t, EvaluateScheduling(&pod, state), "gpu"); len(r) != 0 {
+		t.Errorf("tolerated gpu node should fit, got %v", r)
+	}
+}
+
+func TestEvaluateSchedulingNodeAffinity(t *testing.T) {
+	state := &SchedulingState{Nodes: []corev1.Node{
+		schedNode("a1", "a", "2", map[string]string{"generation": "5"}),
+		schedNode("b1", "b", "2", map[string]string{"generation": "3"}),
+	}}
+
+	pod := schedPod("pending", "", "100m", nil)
+	pod.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: "generation", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"},
+				}},
+			}},
+		},
+	}}
+
+	fits := EvaluateScheduling(&pod, state)
+
+	if len(reasonsFor(t, fits, "a1")) != 0 {
+		t.Errorf("a1 should satisfy generation > 4: %+v", fits)
+	}
+
+	if r := reasonsFor(t, fits, "b1"); !hasReason(r, "required node affinity") {
+		t.Errorf("b1 reasons = %v", r)
+	}
+}
+
+func TestEvaluateSchedulingPodAffinityAndSpread(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	state := &SchedulingState{
+		Nodes: []corev1.Node{
+			schedNode("a1", "a", "4", nil),
+			schedNode("a2", "a", "4", nil),
+			schedNode("b1", "b", "4", nil),
+		},
+		Pods: []corev1.Pod{
+			schedPod("web-1", "a1", "100m", web),
+			schedPod("web-2", "a2", "100m", web),
+		},
+	}
+
+	pod := schedPod("web-3", "", "100m", web)
+	pod.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: web},
+			TopologyKey:   "kubernetes.io/hostname",
+		}},
+	}}
+	pod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: web},
+	}}
+
+	// Hostname labels so anti-affinity has per-node domains
+	for i := range state.Nodes {
+		state.Nodes[i].Labels["kubernetes.io/hostname"] = state.Nodes[i].Name
+	}
+
+	fits := EvaluateScheduling(&pod, state)
+
+	if fits[0].Node != "b1" || !fits[0].Fits() {
+		t.Errorf("expected only b1 to fit, got %+v", fits)
+	}
+
+	r := reasonsFor(t, fits, "a1")
+	if !hasReason(r, "pod anti-affinity: 1 matching pod(s)") || !hasReason(r, "skew 3 exceeds maxSkew 1") {
+		t.Errorf("a1 reasons = %v", r)
+	}
+}
+
+func TestEvaluateSchedulingExistingAntiAffinity(t *testing.T) {
+	hostname := map[string]string{"kubernetes.io/hostname": "a1"}
+	db := schedPod("db-0", "a1", "100m", map[string]string{"app": "db"})
+	db.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			TopologyKey:   "kubernetes.io/hostname",
+		}},
+	}}
+
+	state := &SchedulingState{
+		Nodes: []corev1.Node{
+			schedNode("a1", "a", "4", hostname),
+			schedNode("a2", "a", "4", map[string]string{"kubernetes.io/hostname": "a2"}),
+		},
+		Pods: []corev1.Pod{db},
+	}
+
+	// web has no affinity of its own; db's keeps it off a1
+	pod := schedPod("web-0", "", "100m", map[string]string{"app": "web"})
+	fits := EvaluateScheduling(&pod, state)
+
+	if fits[0].Node != "a2" || !fits[0].Fits() {
+		t.Errorf("expected a2 to fit, got %+v", fits)
+	}
+
+	r := reasonsFor(t, fits, "a1")
+	if !hasReason(r, "pod anti-affinity of default/db-0 in the same kubernetes.io/hostname") {
+		t.Errorf("a1 reasons = %v", r)
+	}
+
+	// Pods in other namespaces are outside db's term
+	pod.Namespace = "other"
+	if r = reasonsFor(t, EvaluateScheduling(&pod, state), "a1"); len(r) != 0 {
+		t.Errorf("expected a1 to fit a pod in another namespace, got %v", r)
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	pod := schedPod("p", "", "100m", nil)
+	pod.Spec.Containers = append(pod.Spec.Containers, pod.Spec.Containers[0])
+	pod.Spec.InitContainers = []corev1.Container{{
+		Name: "init",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		},
+	}}
+
+	cpu := PodRequests(&pod)[corev1.ResourceCPU]
+	if cpu.MilliValue() != 500 {
+		t.Errorf("init container should raise requests to 500m, got %s", cpu.String())
+	}
+
+	pod.Spec.InitContainers = nil
+
+	cpu = PodRequests(&pod)[corev1.ResourceCPU]
+	if cpu.MilliValue() != 200 {
+		t.Errorf("containers should sum to 200m, got %s", cpu.String())
+	}
+}
+
+func TestDiagnoseScheduling(t *testing.T) {
+	node := schedNode("n1", "a", "1", nil)
+	pending := schedPod("pending", "", "2", nil)
+	running := schedPod("running", "n1", "100m", nil)
+
+	clientset := fake.NewSimpleClientset(&node, &pending, &running,
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "pending.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pending", Namespace: "default"},
+			Reason:         "FailedScheduling",
+			Message:        "0/1 nodes are available: 1 Insufficient cpu.",
+		},
+	)
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	diagnosis, err := client.DiagnoseScheduling(ctx, "default", "pending")
+	if err != nil {
+		t.Fatalf("DiagnoseScheduling() error = %v", err)
+	}
+
+	if len(diagnosis.Nodes) != 1 || !hasReason(diagnosis.Nodes[0].Reasons, "insufficient cpu") {
+		t.Errorf("unexpected node verdicts %+v", diagnosis.Nodes)
+	}
+
+	if len(diagnosis.Events) != 1 || !strings.Contains(diagnosis.Events[0], "Insufficient cpu") {
+		t.Errorf("Events = %v", diagnosis.Events)
+	}
+
+	if _, err := client.DiagnoseScheduling(ctx, "default", "running"); !errors.Is(err, ErrPodAlreadyScheduled) {
+		t.Errorf("scheduled pod error = %v, want ErrPodAlreadyScheduled", err)
+	}
+}
@@ -2,16 +2,24 @@ package k8s
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
+var (
+	ErrDockerRegistryFields = errors.New("docker-registry secret requires server, username and password")
+	ErrTLSSecretFields      = errors.New("tls secret requires both a certificate and a key")
+)
+
 func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Secret, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +28,7 @@ func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Se
 }
 
 func (c *Client) ListSecretsAllNamespaces(ctx context.Context) ([]corev1.Secret, error) {
-	list, err := c.clientset.CoreV1().Secrets("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Secrets("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -57,3 +65,99 @@ func (c *Client) CreateSecret(ctx context.Context, secret *corev1.Secret) (*core
 		Secrets(secret.Namespace).
 		Create(ctx, secret, metav1.CreateOptions{})
 }
+
+// SetSecretKey replaces a single data key with the given plaintext value.
+// The API server stores Data base64-encoded, so the value is passed raw here.
+func (c *Client) SetSecretKey(
+	ctx context.Context,
+	namespace, name, key string,
+	value []byte,
+) error {
+	secret, err := c.GetSecret(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	secret.Data[key] = value
+
+	_, err = c.UpdateSecret(ctx, secret)
+
+	return err
+}
+
+// NewGenericSecret builds an Opaque secret, the equivalent of
+// `kubectl create secret generic`.
+func NewGenericSecret(namespace, name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// NewDockerRegistrySecret builds a kubernetes.io/dockerconfigjson secret,
+// the equivalent of `kubectl create secret docker-registry`.
+func NewDockerRegistrySecret(
+	namespace, name, server, username, password, email string,
+) (*corev1.Secret, error) {
+	if server == "" || username == "" || password == "" {
+		return nil, ErrDockerRegistryFields
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	entry := map[string]string{
+		"username": username,
+		"password": password,
+		"auth":     auth,
+	}
+
+	if email != "" {
+		entry["email"] = email
+	}
+
+	dockerConfig, err := json.Marshal(map[string]any{
+		"auths": map[string]any{server: entry},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: dockerConfig,
+		},
+	}, nil
+}
+
+// NewTLSSecret builds a kubernetes.io/tls secret from PEM-encoded
+// certificate and key data, the equivalent of `kubectl create secret tls`.
+func NewTLSSecret(namespace, name string, cert, key []byte) (*corev1.Secret, error) {
+	if len(cert) == 0 || len(key) == 0 {
+		return nil, ErrTLSSecretFields
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}, nil
+}
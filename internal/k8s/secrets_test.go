@@ -0,0 +1,154 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetSecretKey(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db-creds",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("old"),
+			},
+		},
+	)
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	err := client.SetSecretKey(ctx, "default", "db-creds", "password", []byte("new"))
+	if err != nil {
+		t.Fatalf("SetSecretKey returned unexpected error: %v", err)
+	}
+
+	secret, err := client.GetSecret(ctx, "default", "db-creds")
+	if err != nil {
+		t.Fatalf("GetSecret returned unexpected error: %v", err)
+	}
+
+	if got := string(secret.Data["password"]); got != "new" {
+		t.Errorf("password = %q, want %q", got, "new")
+	}
+
+	if got := string(secret.Data["username"]); got != "admin" {
+		t.Errorf("username = %q, want it untouched", got)
+	}
+}
+
+func TestSetSecretKey_AddsKeyToEmptySecret(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "empty",
+				Namespace: "default",
+			},
+		},
+	)
+
+	client := createTestClient(clientset)
+	ctx := context.Background()
+
+	if err := client.SetSecretKey(ctx, "", "empty", "token", []byte("abc")); err != nil {
+		t.Fatalf("SetSecretKey returned unexpected error: %v", err)
+	}
+
+	secret, _ := client.GetSecret(ctx, "default", "empty")
+	if got := string(secret.Data["token"]); got != "abc" {
+		t.Errorf("token = %q, want %q", got, "abc")
+	}
+}
+
+func TestSetSecretKey_NotFound(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset())
+
+	err := client.SetSecretKey(context.Background(), "default", "missing", "k", []byte("v"))
+	if err == nil {
+		t.Error("SetSecretKey should fail for a missing secret")
+	}
+}
+
+func TestNewGenericSecret(t *testing.T) {
+	secret := NewGenericSecret("default", "app", map[string][]byte{"k": []byte("v")})
+
+	if secret.Type != corev1.SecretTypeOpaque {
+		t.Errorf("Type = %q, want %q", secret.Type, corev1.SecretTypeOpaque)
+	}
+
+	if secret.Namespace != "default" || secret.Name != "app" {
+		t.Errorf("unexpected metadata %s/%s", secret.Namespace, secret.Name)
+	}
+}
+
+func TestNewDockerRegistrySecret(t *testing.T) {
+	secret, err := NewDockerRegistrySecret(
+		"default", "regcred", "ghcr.io", "bot", "s3cret", "bot@example.com",
+	)
+	if err != nil {
+		t.Fatalf("NewDockerRegistrySecret returned unexpected error: %v", err)
+	}
+
+	if secret.Type != corev1.SecretTypeDockerConfigJson {
+		t.Errorf("Type = %q, want %q", secret.Type, corev1.SecretTypeDockerConfigJson)
+	}
+
+	var cfg struct {
+		Auths map[string]map[string]string `json:"auths"`
+	}
+
+	if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &cfg); err != nil {
+		t.Fatalf("dockerconfigjson is not valid JSON: %v", err)
+	}
+
+	entry, ok := cfg.Auths["ghcr.io"]
+	if !ok {
+		t.Fatal("expected an auths entry for ghcr.io")
+	}
+
+	// base64("bot:s3cret")
+	if entry["auth"] != "Ym90OnMzY3JldA==" {
+		t.Errorf("auth = %q, want %q", entry["auth"], "Ym90OnMzY3JldA==")
+	}
+
+	if entry["email"] != "bot@example.com" {
+		t.Errorf("email = %q, want %q", entry["email"], "bot@example.com")
+	}
+}
+
+func TestNewDockerRegistrySecret_MissingFields(t *testing.T) {
+	_, err := NewDockerRegistrySecret("default", "regcred", "ghcr.io", "bot", "", "")
+	if !errors.Is(err, ErrDockerRegistryFields) {
+		t.Errorf("expected ErrDockerRegistryFields, got %v", err)
+	}
+}
+
+func TestNewTLSSecret(t *testing.T) {
+	secret, err := NewTLSSecret("default", "tls", []byte("cert"), []byte("key"))
+	if err != nil {
+		t.Fatalf("NewTLSSecret returned unexpected error: %v", err)
+	}
+
+	if secret.Type != corev1.SecretTypeTLS {
+		t.Errorf("Type = %q, want %q", secret.Type, corev1.SecretTypeTLS)
+	}
+
+	if string(secret.Data[corev1.TLSCertKey]) != "cert" ||
+		string(secret.Data[corev1.TLSPrivateKeyKey]) != "key" {
+		t.Error("tls.crt/tls.key not populated from arguments")
+	}
+
+	if _, err := NewTLSSecret("default", "tls", nil, []byte("key")); !errors.Is(err, ErrTLSSecretFields) {
+		t.Errorf("expected ErrTLSSecretFields for empty cert, got %v", err)
+	}
+}
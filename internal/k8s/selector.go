@@ -0,0 +1,26 @@
+package k8s
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type labelSelectorKey struct{}
+
+// WithLabelSelector makes the panel list calls made with the returned
+// context ask the API server for matching resources only.
+func WithLabelSelector(ctx context.Context, selector string) context.Context {
+	if selector == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, labelSelectorKey{}, selector)
+}
+
+// listOptions carries the label selector of ctx, if any.
+func listOptions(ctx context.Context) metav1.ListOptions {
+	selector, _ := ctx.Value(labelSelectorKey{}).(string)
+
+	return metav1.ListOptions{LabelSelector: selector}
+}
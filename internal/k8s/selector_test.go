@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListWithLabelSelector(t *testing.T) {
+	client := createTestClient(fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"},
+		}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-1", Namespace: "default", Labels: map[string]string{"app": "api"},
+		}},
+	))
+
+	pods, err := client.ListPods(WithLabelSelector(t.Context(), "app=web"), "")
+	if err != nil {
+		t.Fatalf("ListPods returned unexpected error: %v", err)
+	}
+
+	if len(pods) != 1 || pods[0].Name != "web-1" {
+		t.Errorf("expected only web-1, got %d pods", len(pods))
+	}
+
+	pods, _ = client.ListPods(WithLabelSelector(t.Context(), ""), "")
+	if len(pods) != 2 {
+		t.Errorf("an empty selector should list everything, got %d pods", len(pods))
+	}
+}
@@ -15,7 +15,7 @@ func (c *Client) ListServiceAccounts(
 ) ([]corev1.ServiceAccount, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func (c *Client) ListServiceAccounts(
 func (c *Client) ListServiceAccountsAllNamespaces(
 	ctx context.Context,
 ) ([]corev1.ServiceAccount, error) {
-	list, err := c.clientset.CoreV1().ServiceAccounts("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().ServiceAccounts("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
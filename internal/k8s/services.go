@@ -13,7 +13,7 @@ import (
 func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.Service, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Services(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +22,7 @@ func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.S
 }
 
 func (c *Client) ListServicesAllNamespaces(ctx context.Context) ([]corev1.Service, error) {
-	list, err := c.clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.CoreV1().Services("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
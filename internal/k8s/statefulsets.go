@@ -16,7 +16,7 @@ func (c *Client) ListStatefulSets(
 ) ([]appsv1.StatefulSet, error) {
 	namespace = c.ns(namespace)
 
-	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func (c *Client) ListStatefulSets(
 }
 
 func (c *Client) ListStatefulSetsAllNamespaces(ctx context.Context) ([]appsv1.StatefulSet, error) {
-	list, err := c.clientset.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	list, err := c.clientset.AppsV1().StatefulSets("").List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
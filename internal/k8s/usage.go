@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// usageHistoryMaxAge is how long a series survives without new samples, so
+// the buffer doesn't grow with every pod that ever existed.
+const usageHistoryMaxAge = 7 * 24 * time.Hour
+
+// UsageSample is one observation of a container.
+type UsageSample struct {
+	Time   time.Time `json:"t"`
+	CPU    int64     `json:"cpu"` // in millicores
+	Memory int64     `json:"mem"` // in bytes
+}
+
+type usageSeries struct {
+	Namespace string            `json:"namespace"`
+	Pod       string            `json:"pod"`
+	Container string            `json:"container"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Samples   []UsageSample     `json:"samples"`
+}
+
+// UsageHistory keeps the last maxSamples observations of every container
+// seen during the session. It is safe for concurrent use.
+type UsageHistory struct {
+	mu         sync.Mutex
+	maxSamples int
+	series     map[string]*usageSeries
+}
+
+func NewUsageHistory(maxSamples int) *UsageHistory {
+	return &UsageHistory{
+		maxSamples: max(maxSamples, 1),
+		series:     make(map[string]*usageSeries),
+	}
+}
+
+// LoadUsageHistory reads a history saved by Save. A missing file yields an
+// empty history.
+func LoadUsageHistory(path string, maxSamples int) (*UsageHistory, error) {
+	h := NewUsageHistory(maxSamples)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+
+	if err != nil {
+		return h, err
+	}
+
+	var series []*usageSeries
+	if err := json.Unmarshal(data, &series); err != nil {
+		return h, err
+	}
+
+	for _, s := range series {
+		if len(s.Samples) > h.maxSamples {
+			s.Samples = s.Samples[len(s.Samples)-h.maxSamples:]
+		}
+
+		h.series[usageKey(s.Namespace, s.Pod, s.Container)] = s
+	}
+
+	return h, nil
+}
+
+// Save writes the history to path, dropping series that haven't been
+// sampled for a week.
+func (h *UsageHistory) Save(path string) error {
+	h.mu.Lock()
+
+	cutoff := time.Now().Add(-usageHistoryMaxAge)
+	series := make([]*usageSeries, 0, len(h.series))
+
+	for key, s := range h.series {
+		if len(s.Samples) == 0 || s.Samples[len(s.Samples)-1].Time.Before(cutoff) {
+			delete(h.series, key)
+
+			continue
+		}
+
+		series = append(series, s)
+	}
+
+	data, err := json.Marshal(series)
+	h.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Record appends one sample per container.
+func (h *UsageHistory) Record(at time.Time, metrics []ContainerMetrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, m := range metrics {
+		key := usageKey(m.Namespace, m.Pod, m.Container)
+
+		s, ok := h.series[key]
+		if !ok {
+			s = &usageSeries{Namespace: m.Namespace, Pod: m.Pod, Container: m.Container}
+			h.series[key] = s
+		}
+
+		s.Labels = m.Labels
+		s.Samples = append(s.Samples, UsageSample{Time: at, CPU: m.CPU, Memory: m.Memory})
+
+		if len(s.Samples) > h.maxSamples {
+			s.Samples = slices.Delete(s.Samples, 0, len(s.Samples)-h.maxSamples)
+		}
+	}
+}
+
+// WorkloadSamples returns the samples of container across every pod in
+// namespace matched by selector, including pods that have since gone away.
+func (h *UsageHistory) WorkloadSamples(
+	namespace string,
+	selector *metav1.LabelSelector,
+	container string,
+) []UsageSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var samples []UsageSample
+
+	for _, s := range h.series {
+		if s.Namespace == namespace && s.Container == container && selector != nil &&
+			selectorMatches(selector, s.Labels) {
+			samples = append(samples, s.Samples...)
+		}
+	}
+
+	return samples
+}
+
+func usageKey(namespace, pod, container string) string {
+	return namespace + "/" + pod + "/" + container
+}
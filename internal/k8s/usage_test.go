@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestGetContainerMetrics(t *testing.T) {
+	fakeMetrics := metricsfake.NewSimpleClientset()
+	// The fake tracker doesn't map PodMetrics to the "pods" resource the
+	// client lists, so serve the list from a reactor
+	fakeMetrics.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "app", Usage: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("100Mi"),
+				}},
+				{Name: "sidecar", Usage: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("20Mi"),
+				}},
+			},
+		}}}, nil
+	})
+
+	client := NewTestMetricsClient(fakeMetrics)
+
+	containers, err := client.GetContainerMetrics(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("GetContainerMetrics() error = %v", err)
+	}
+
+	if len(containers) != 2 || containers[0].Labels["app"] != "web" || containers[1].CPU != 50 {
+		t.Fatalf("unexpected container metrics %+v", containers)
+	}
+
+	pods, _ := client.GetPodMetrics(context.Background(), "default")
+	pod := pods["default/web-1"]
+	if pod.CPU != 250 || pod.Memory != 120*1024*1024 {
+		t.Errorf("pod totals = %+v, want 250m and 120Mi", pod)
+	}
+
+	if len(pod.Containers) != 2 || pod.Containers[1].Container != "sidecar" || pod.Containers[1].Memory != 20*1024*1024 {
+		t.Errorf("pod should keep its per-container usage, got %+v", pod.Containers)
+	}
+}
+
+func TestUsageHistoryRecord(t *testing.T) {
+	h := NewUsageHistory(3)
+	start := time.Now()
+	web := map[string]string{"app": "web"}
+
+	for i := range 5 {
+		h.Record(start.Add(time.Duration(i)*time.Second), []ContainerMetrics{
+			{Namespace: "default", Pod: "web-1", Container: "app", Labels: web, CPU: int64(i)},
+			{Namespace: "default", Pod: "db-1", Container: "app", Labels: map[string]string{"app": "db"}, CPU: 100},
+		})
+	}
+
+	// A replacement pod of the same workload
+	h.Record(start.Add(10*time.Second), []ContainerMetrics{
+		{Namespace: "default", Pod: "web-2", Container: "app", Labels: web, CPU: 9},
+	})
+
+	selector := &metav1.LabelSelector{MatchLabels: web}
+
+	samples := h.WorkloadSamples("default", selector, "app")
+	if len(samples) != 4 {
+		t.Fatalf("expected 3 capped samples of web-1 plus 1 of web-2, got %+v", samples)
+	}
+
+	for _, s := range samples {
+		if s.CPU < 2 {
+			t.Errorf("oldest samples should have been dropped, got %+v", s)
+		}
+	}
+
+	if got := h.WorkloadSamples("other", selector, "app"); len(got) != 0 {
+		t.Errorf("other namespaces should not match, got %+v", got)
+	}
+}
+
+func TestUsageHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazy-k8s", "usage.json")
+
+	loaded, err := LoadUsageHistory(path, 10)
+	if err != nil || len(loaded.series) != 0 {
+		t.Fatalf("missing file should load an empty history, got %v %v", loaded.series, err)
+	}
+
+	h := NewUsageHistory(10)
+	h.Record(time.Now(), []ContainerMetrics{
+		{Namespace: "default", Pod: "web-1", Container: "app", Labels: map[string]string{"app": "web"}, CPU: 5},
+	})
+	h.Record(time.Now().Add(-8*24*time.Hour), []ContainerMetrics{
+		{Namespace: "default", Pod: "gone", Container: "app", CPU: 1},
+	})
+
+	if err := h.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err = LoadUsageHistory(path, 10)
+	if err != nil {
+		t.Fatalf("LoadUsageHistory() error = %v", err)
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+
+	samples := loaded.WorkloadSamples("default", selector, "app")
+	if len(samples) != 1 || samples[0].CPU != 5 {
+		t.Errorf("expected the saved sample back, got %+v", samples)
+	}
+
+	if _, ok := loaded.series[usageKey("default", "gone", "app")]; ok {
+		t.Error("series older than a week should not be saved")
+	}
+}
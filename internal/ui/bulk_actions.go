@@ -0,0 +1,524 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// bulkConcurrency bounds how many items a bulk action changes at once, so
+// marking hundreds of pods doesn't flood the API server.
+const bulkConcurrency = 5
+
+var (
+	ErrNoLabels        = errors.New("enter at least one key=value label")
+	ErrBulkUnsupported = errors.New("not supported for this kind")
+)
+
+var (
+	// bulkSuspendBinding matches the cronjobs panel's suspend toggle; its
+	// t triggers a run.
+	bulkSuspendBinding = key.NewBinding(key.WithKeys("S"))
+	restartableKinds   = []string{"Deployment", "StatefulSet", "DaemonSet"}
+	scalableKinds      = []string{"Deployment", "StatefulSet"}
+	suspendableKinds   = []string{"CronJob"}
+)
+
+// bulkAction is one operation run over every marked item of a panel.
+type bulkAction struct {
+	// verb names the action in the confirmation and results, e.g. "Restart".
+	verb string
+	// kinds limits the action to these kinds; nil accepts any kind.
+	kinds []string
+	// run changes a single item and returns its history record.
+	run func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error)
+}
+
+type bulkResult struct {
+	ref panels.ResourceRef
+	err error
+}
+
+// bulkResultsMsg carries the outcome of each item of a bulk action.
+type bulkResultsMsg struct {
+	panel   string
+	verb    string
+	results []bulkResult
+}
+
+func (m *Model) toggleMark() {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return
+	}
+
+	panel := m.panels[m.activePanelIdx]
+
+	refs := panel.VisibleRefs()
+	if cursor := panel.Cursor(); cursor < len(refs) {
+		panel.ToggleMark(refs[cursor])
+	}
+}
+
+// toggleMarkAll marks every item matching the current filter, or unmarks
+// them when they already are.
+func (m *Model) toggleMarkAll() {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return
+	}
+
+	panel := m.panels[m.activePanelIdx]
+	panel.ToggleMarkAll(panel.VisibleRefs())
+	m.statusBar.SetMessage(fmt.Sprintf("%d marked", len(panel.MarkedItems())))
+}
+
+// handleBulkKey runs the bulk counterpart of a key while the active panel
+// has marked items. It reports false when the key should be handled as
+// usual, including when no marked item supports the action.
+func (m *Model) handleBulkKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return nil, false
+	}
+
+	panel := m.panels[m.activePanelIdx]
+
+	if key.Matches(msg, m.keys.Label) {
+		return m.promptBulkLabel(panel), true
+	}
+
+	refs := panel.MarkedItems()
+	if len(refs) == 0 {
+		return nil, false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		panel.ClearMarks()
+		m.statusBar.SetMessage("Cleared marks")
+
+		return nil, true
+
+	case key.Matches(msg, m.keys.CopyName):
+		return m.copyMarkedNames(refs), true
+
+	case key.Matches(msg, m.keys.Delete):
+		return m.confirmBulk(panel, m.bulkDelete(), refs)
+
+	case key.Matches(msg, m.keys.Restart):
+		return m.confirmBulk(panel, m.bulkRestart(), refs)
+
+	case key.Matches(msg, bulkSuspendBinding):
+		return m.confirmBulk(panel, m.bulkSuspend(), refs)
+
+	case key.Matches(msg, m.keys.Scale):
+		return m.promptBulkScale(panel, refs)
+	}
+
+	return nil, false
+}
+
+// supportedRefs splits refs into those the action supports and the
+// number skipped.
+func supportedRefs(refs []panels.ResourceRef, kinds []string) ([]panels.ResourceRef, int) {
+	if kinds == nil {
+		return refs, 0
+	}
+
+	var targets []panels.ResourceRef
+
+	for _, ref := range refs {
+		if slices.Contains(kinds, ref.Kind) {
+			targets = append(targets, ref)
+		}
+	}
+
+	return targets, len(refs) - len(targets)
+}
+
+// confirmBulk asks once for every target of the action.
+func (m *Model) confirmBulk(panel panels.Panel, action bulkAction, refs []panels.ResourceRef) (tea.Cmd, bool) {
+	targets, skipped := supportedRefs(refs, action.kinds)
+	if len(targets) == 0 {
+		return nil, false
+	}
+
+	m.showBulkConfirm(panel, action, targets, skipped)
+
+	return nil, true
+}
+
+func (m *Model) showBulkConfirm(panel panels.Panel, action bulkAction, targets []panels.ResourceRef, skipped int) {
+	names := make([]string, len(targets))
+	for i, ref := range targets {
+		names[i] = refName(ref)
+	}
+
+	description := strings.Join(names, ", ")
+	if skipped > 0 {
+		description += fmt.Sprintf("\n\n%d marked items of other kinds are skipped.", skipped)
+	}
+
+	if action.verb == "Delete" {
+		description += "\n\nThis action cannot be undone."
+	}
+
+	panelTitle := panel.Title()
+
+	m.confirm.Show(
+		fmt.Sprintf("%s %d items?", action.verb, len(targets)),
+		description,
+		func() tea.Cmd {
+			return m.runBulk(panelTitle, action, targets)
+		},
+	)
+	m.viewMode = ViewConfirm
+}
+
+// runBulk applies the action to every target, at most bulkConcurrency at a
+// time, recording each success in the history.
+func (m *Model) runBulk(panelTitle string, action bulkAction, targets []panels.ResourceRef) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		results := make([]bulkResult, len(targets))
+		sem := make(chan struct{}, bulkConcurrency)
+
+		var wg sync.WaitGroup
+
+		for i, ref := range targets {
+			wg.Go(func() {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				rec, err := action.run(ctx, ref)
+				if err == nil {
+					m.historyStore.Add(rec)
+				}
+
+				results[i] = bulkResult{ref: ref, err: err}
+			})
+		}
+
+		wg.Wait()
+
+		return bulkResultsMsg{panel: panelTitle, verb: action.verb, results: results}
+	}
+}
+
+// showBulkResults opens the per-item outcome. Failed items stay marked so
+// the action can be retried on them alone.
+func (m *Model) showBulkResults(msg bulkResultsMsg) tea.Cmd {
+	var failed []panels.ResourceRef
+
+	for _, r := range msg.results {
+		if r.err != nil {
+			failed = append(failed, r.ref)
+		}
+	}
+
+	for _, panel := range m.panels {
+		if panel.Title() != msg.panel {
+			continue
+		}
+
+		panel.ClearMarks()
+
+		for _, ref := range failed {
+			panel.ToggleMark(ref)
+		}
+	}
+
+	summary := fmt.Sprintf("%s: %d of %d succeeded", msg.verb, len(msg.results)-len(failed), len(msg.results))
+	if len(failed) > 0 {
+		m.statusBar.SetError(summary)
+	} else {
+		m.statusBar.SetMessage(summary)
+	}
+
+	m.yamlView.SetContent(formatBulkResults(msg))
+	m.viewMode = ViewYaml
+
+	return m.refreshAllPanels()
+}
+
+func formatBulkResults(msg bulkResultsMsg) string {
+	var b strings.Builder
+
+	failed := 0
+
+	for _, r := range msg.results {
+		if r.err != nil {
+			failed++
+		}
+	}
+
+	fmt.Fprintf(&b, "%s of %d items: %d succeeded, %d failed\n", msg.verb, len(msg.results),
+		len(msg.results)-failed, failed)
+	b.WriteString(strings.Repeat("-", 40) + "\n")
+
+	for _, r := range msg.results {
+		if r.err != nil {
+			fmt.Fprintf(&b, "FAILED  %s %s: %v\n", r.ref.Kind, refName(r.ref), r.err)
+		} else {
+			fmt.Fprintf(&b, "OK      %s %s\n", r.ref.Kind, refName(r.ref))
+		}
+	}
+
+	return b.String()
+}
+
+// refName is namespace/name, or the bare name of cluster-scoped items.
+func refName(ref panels.ResourceRef) string {
+	if ref.Namespace == "" {
+		return ref.Name
+	}
+
+	return ref.Namespace + "/" + ref.Name
+}
+
+func (m *Model) copyMarkedNames(refs []panels.ResourceRef) tea.Cmd {
+	names := make([]string, len(refs))
+	for i, ref := range refs {
+		names[i] = ref.Name
+	}
+
+	if err := clipboard.WriteAll(strings.Join(names, "\n")); err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Failed to copy: %v", err))
+
+		return nil
+	}
+
+	m.statusBar.SetMessage(fmt.Sprintf("Copied %d names to clipboard", len(names)))
+
+	return nil
+}
+
+// promptBulkScale asks for the replica count before confirming.
+func (m *Model) promptBulkScale(panel panels.Panel, refs []panels.ResourceRef) (tea.Cmd, bool) {
+	targets, skipped := supportedRefs(refs, scalableKinds)
+	if len(targets) == 0 {
+		return nil, false
+	}
+
+	m.showInput(
+		fmt.Sprintf("Scale %d items", len(targets)),
+		"Enter the replica count for every marked item",
+		"",
+		func(value string) tea.Cmd {
+			replicas, errMsg := parseReplicaCount(value, 0, ErrInvalidReplicaCount)
+			if errMsg != nil {
+				return func() tea.Msg { return errMsg }
+			}
+
+			m.showBulkConfirm(panel, m.bulkScale(replicas), targets, skipped)
+
+			return nil
+		},
+	)
+
+	return nil, true
+}
+
+// promptBulkLabel asks for labels to merge into the marked items, or into
+// the selected item when nothing is marked.
+func (m *Model) promptBulkLabel(panel panels.Panel) tea.Cmd {
+	targets := panel.MarkedItems()
+	if len(targets) == 0 {
+		refs := panel.VisibleRefs()
+		if cursor := panel.Cursor(); cursor < len(refs) {
+			targets = refs[cursor : cursor+1]
+		}
+	}
+
+	if len(targets) == 0 {
+		m.statusBar.SetMessage("No resource selected")
+
+		return nil
+	}
+
+	m.showInput(
+		fmt.Sprintf("Label %d items", len(targets)),
+		"Enter labels as key=value pairs, existing keys are overwritten",
+		"team=payments",
+		func(value string) tea.Cmd {
+			labels, err := k8s.ParseLabels(value)
+			if err == nil && len(labels) == 0 {
+				err = ErrNoLabels
+			}
+
+			if err != nil {
+				return func() tea.Msg { return panels.ErrorMsg{Error: err} }
+			}
+
+			m.showBulkConfirm(panel, m.bulkLabel(labels), targets, 0)
+
+			return nil
+		},
+	)
+
+	return nil
+}
+
+func (m *Model) bulkDelete() bulkAction {
+	return bulkAction{
+		verb: "Delete",
+		run: func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error) {
+			err := m.k8sClient.DeleteResource(ctx, ref.Kind, ref.Namespace, ref.Name)
+
+			return components.OperationRecord{
+				Type:      components.OpDeleteResource,
+				Resource:  ref.Name,
+				Namespace: ref.Namespace,
+				Message:   fmt.Sprintf("Deleted %s %s", ref.Kind, ref.Name),
+			}, err
+		},
+	}
+}
+
+func (m *Model) bulkRestart() bulkAction {
+	return bulkAction{
+		verb:  "Restart",
+		kinds: restartableKinds,
+		run: func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error) {
+			rec := components.OperationRecord{Resource: ref.Name, Namespace: ref.Namespace}
+
+			var err error
+
+			switch ref.Kind {
+			case "Deployment":
+				rec.Type = components.OpRestartDeployment
+				err = m.k8sClient.RestartDeployment(ctx, ref.Namespace, ref.Name)
+			case "StatefulSet":
+				rec.Type = components.OpRestartStatefulSet
+				err = m.k8sClient.RestartStatefulSet(ctx, ref.Namespace, ref.Name)
+			case "DaemonSet":
+				rec.Type = components.OpRestartDaemonSet
+				err = m.k8sClient.RestartDaemonSet(ctx, ref.Namespace, ref.Name)
+			default:
+				err = ErrBulkUnsupported
+			}
+
+			rec.Message = fmt.Sprintf("Restarted %s %s", strings.ToLower(ref.Kind), ref.Name)
+
+			return rec, err
+		},
+	}
+}
+
+// bulkScale records the previous replicas of each item so every scale can
+// be undone on its own from the history.
+func (m *Model) bulkScale(replicas int32) bulkAction {
+	return bulkAction{
+		verb:  "Scale",
+		kinds: scalableKinds,
+		run: func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error) {
+			rec := components.OperationRecord{Resource: ref.Name, Namespace: ref.Namespace, Undoable: true}
+
+			var (
+				current *int32
+				err     error
+			)
+
+			switch ref.Kind {
+			case "Deployment":
+				rec.Type = components.OpScaleDeployment
+
+				deploy, getErr := m.k8sClient.GetDeployment(ctx, ref.Namespace, ref.Name)
+				if getErr != nil {
+					return rec, getErr
+				}
+
+				current = deploy.Spec.Replicas
+				err = m.k8sClient.ScaleDeployment(ctx, ref.Namespace, ref.Name, replicas)
+			case "StatefulSet":
+				rec.Type = components.OpScaleStatefulSet
+
+				sts, getErr := m.k8sClient.GetStatefulSet(ctx, ref.Namespace, ref.Name)
+				if getErr != nil {
+					return rec, getErr
+				}
+
+				current = sts.Spec.Replicas
+				err = m.k8sClient.ScaleStatefulSet(ctx, ref.Namespace, ref.Name, replicas)
+			default:
+				return rec, ErrBulkUnsupported
+			}
+
+			// Replicas default to 1 when unset
+			previous := int32(1)
+			if current != nil {
+				previous = *current
+			}
+
+			rec.UndoData.PreviousReplicas = previous
+			rec.Message = fmt.Sprintf("Scaled %s from %d to %d replicas", ref.Name, previous, replicas)
+
+			return rec, err
+		},
+	}
+}
+
+func (m *Model) bulkSuspend() bulkAction {
+	return bulkAction{
+		verb:  "Suspend",
+		kinds: suspendableKinds,
+		run: func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error) {
+			rec := components.OperationRecord{
+				Type:      components.OpSuspendCronJob,
+				Resource:  ref.Name,
+				Namespace: ref.Namespace,
+				Message:   fmt.Sprintf("Suspended cronjob %s", ref.Name),
+				Undoable:  true,
+			}
+
+			cj, err := m.k8sClient.GetCronJob(ctx, ref.Namespace, ref.Name)
+			if err != nil {
+				return rec, err
+			}
+
+			rec.UndoData.PreviousSuspend = cj.Spec.Suspend != nil && *cj.Spec.Suspend
+
+			return rec, m.k8sClient.SuspendCronJob(ctx, ref.Namespace, ref.Name, true)
+		},
+	}
+}
+
+func (m *Model) bulkLabel(labels map[string]string) bulkAction {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+
+	slices.Sort(pairs)
+
+	return bulkAction{
+		verb: "Label",
+		run: func(ctx context.Context, ref panels.ResourceRef) (components.OperationRecord, error) {
+			err := m.k8sClient.LabelResource(ctx, ref.Kind, ref.Namespace, ref.Name, labels)
+
+			return components.OperationRecord{
+				Type:      components.OpLabelResource,
+				Resource:  ref.Name,
+				Namespace: ref.Namespace,
+				Message:   fmt.Sprintf("Labeled %s %s with %s", ref.Kind, ref.Name, strings.Join(pairs, ", ")),
+			}, err
+		},
+	}
+}
+
+// clearAllMarks drops the marks of every panel. Marks only name resources,
+// so they mustn't outlive the cluster or namespace they were made in.
+func (m *Model) clearAllMarks() {
+	for _, panel := range m.panels {
+		panel.ClearMarks()
+	}
+}
@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// createBulkTestModel focuses a deployments panel listing web and api.
+func createBulkTestModel(t *testing.T) (*Model, panels.Panel) {
+	t.Helper()
+
+	replicas := int32(2)
+
+	m := createTestModel()
+	m.confirm = components.NewConfirm(m.styles)
+	m.input = components.NewInput(m.styles)
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.yamlView = components.NewYamlViewer(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	))
+
+	panel := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	panel.Update(panel.Refresh()())
+
+	m.panels = []panels.Panel{panel}
+	m.activePanelIdx = 0
+
+	return m, panel
+}
+
+// confirmAndRun accepts the pending confirmation and feeds the results back.
+func confirmAndRun(t *testing.T, m *Model) bulkResultsMsg {
+	t.Helper()
+
+	if m.viewMode != ViewConfirm {
+		t.Fatalf("expected ViewConfirm, got %d", m.viewMode)
+	}
+
+	results, ok := m.confirm.Action()().(bulkResultsMsg)
+	if !ok {
+		t.Fatal("expected bulkResultsMsg")
+	}
+
+	m.Update(results)
+
+	return results
+}
+
+func TestBulkRestartReportsEachItem(t *testing.T) {
+	m, panel := createBulkTestModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	// A deployment deleted since it was marked fails on its own
+	panel.ToggleMark(panels.ResourceRef{Kind: "Deployment", Name: "gone", Namespace: "default"})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	if !strings.Contains(m.confirm.View(), "default/api, default/gone, default/web") {
+		t.Errorf("confirmation should list every target:\n%s", m.confirm.View())
+	}
+
+	confirmAndRun(t, m)
+
+	if m.viewMode != ViewYaml {
+		t.Fatalf("expected the results view, got %d", m.viewMode)
+	}
+
+	report := m.yamlView.View(120, 40)
+	wants := []string{"2 succeeded, 1 failed", "OK      Deployment default/web", "FAILED  Deployment default/gone"}
+
+	for _, want := range wants {
+		if !strings.Contains(report, want) {
+			t.Errorf("results should contain %q:\n%s", want, report)
+		}
+	}
+
+	if m.historyStore.Len() != 2 {
+		t.Errorf("expected one history record per restarted item, got %d", m.historyStore.Len())
+	}
+
+	if marked := panel.MarkedItems(); len(marked) != 1 || marked[0].Name != "gone" {
+		t.Errorf("only the failed item should stay marked, got %+v", marked)
+	}
+}
+
+func TestBulkScaleRecordsUndo(t *testing.T) {
+	m, panel := createBulkTestModel(t)
+	panel.ToggleMarkAll(panel.VisibleRefs())
+
+	// The fake clientset can't serve the scale subresource of deployments
+	var (
+		mu     sync.Mutex
+		scaled = make(map[string]int32)
+	)
+
+	clientset, _ := m.k8sClient.Clientset().(*fake.Clientset)
+	clientset.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.GetAction).GetName()
+
+		return action.GetSubresource() == "scale", &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale, ok := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		if !ok {
+			return false, nil, nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		scaled[scale.Name] = scale.Spec.Replicas
+
+		return true, scale, nil
+	})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	if m.viewMode != ViewInput {
+		t.Fatalf("expected ViewInput, got %d", m.viewMode)
+	}
+
+	m.Update(components.InputSubmitMsg{Value: "5"})
+	confirmAndRun(t, m)
+
+	if scaled["web"] != 5 || scaled["api"] != 5 {
+		t.Errorf("expected both deployments scaled to 5, got %v", scaled)
+	}
+
+	for i := range 2 {
+		rec, _ := m.historyStore.Get(i)
+		if rec.Type != components.OpScaleDeployment || !rec.Undoable || rec.UndoData.PreviousReplicas != 2 {
+			t.Errorf("expected an undoable scale record, got %+v", rec)
+		}
+	}
+}
+
+func TestBulkKeysFallThroughWithoutSupportedItems(t *testing.T) {
+	m, panel := createBulkTestModel(t)
+	panel.ToggleMarkAll(panel.VisibleRefs())
+
+	// Deployments can't be suspended, so "S" isn't a bulk action here
+	if _, ok := m.handleBulkKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}}); ok {
+		t.Error("suspend should not apply to deployments")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if len(panel.MarkedItems()) != 0 {
+		t.Error("esc should clear the marks")
+	}
+}
+
+func TestBulkLabelSelectedWithoutMarks(t *testing.T) {
+	m, _ := createBulkTestModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m.Update(components.InputSubmitMsg{Value: "team=payments"})
+	confirmAndRun(t, m)
+
+	labeled := 0
+
+	for _, name := range []string{"web", "api"} {
+		deploy, _ := m.k8sClient.GetDeployment(t.Context(), "default", name)
+		if deploy.Labels["team"] == "payments" {
+			labeled++
+		}
+	}
+
+	if labeled != 1 {
+		t.Errorf("expected only the selected deployment labeled, got %d", labeled)
+	}
+
+	if rec, _ := m.historyStore.Get(0); rec.Type != components.OpLabelResource {
+		t.Errorf("expected an OpLabelResource record, got %+v", rec)
+	}
+}
+
+func TestSwitchNamespaceClearsMarks(t *testing.T) {
+	m, panel := createBulkTestModel(t)
+	m.header = components.NewHeader(m.styles, "", "default")
+
+	panel.ToggleMarkAll(panel.VisibleRefs())
+	m.switchNamespace("staging")
+
+	if marked := panel.MarkedItems(); len(marked) != 0 {
+		t.Errorf("marks should be cleared on a namespace switch, %d left", len(marked))
+	}
+}
+
+func TestSwitchContextClearsMarks(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster: {server: "https://127.0.0.1:6443"}
+contexts:
+- name: other
+  context: {cluster: other, user: other}
+users:
+- name: other
+  user: {token: test}
+current-context: other
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	m, panel := createBulkTestModel(t)
+	m.header = components.NewHeader(m.styles, "", "default")
+
+	panel.ToggleMarkAll(panel.VisibleRefs())
+	m.switchContext("other")
+
+	if marked := panel.MarkedItems(); len(marked) != 0 {
+		t.Errorf("marks should be cleared on a context switch, %d left", len(marked))
+	}
+}
+
+func TestBulkSuspendLeavesTriggerToCronJobs(t *testing.T) {
+	m := createTestModel()
+	m.confirm = components.NewConfirm(m.styles)
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+	}))
+
+	panel := panels.NewCronJobsPanel(m.k8sClient, m.styles)
+	panel.Update(panel.Refresh()())
+
+	m.panels = []panels.Panel{panel}
+	m.activePanelIdx = 0
+
+	panel.ToggleMarkAll(panel.VisibleRefs())
+
+	// t triggers the selected cronjob, as without marks
+	if _, ok := m.handleBulkKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}}); ok {
+		t.Error("t should be left to the cronjobs panel to trigger a run")
+	}
+
+	if _, ok := m.handleBulkKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}}); !ok || m.viewMode != ViewConfirm {
+		t.Error("S should suspend the marked cronjobs")
+	}
+}
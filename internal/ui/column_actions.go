@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// applyColumns sets a panel's columns from the config, keyed like the
+// saved sorts by the panel's name in panels.visible. A panel whose columns
+// are invalid keeps its own layout.
+func (m *Model) applyColumns(panel panels.Panel) {
+	key, columns, ok := panelSetting(m.config.Panels.Columns, panel.Title())
+	if !ok {
+		return
+	}
+
+	if err := panel.SetColumns(columnDefs(columns)); err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Config panels.columns.%s: %v", key, err))
+	}
+}
+
+func columnDefs(columns []config.ColumnConfig) []panels.ColumnDef {
+	defs := make([]panels.ColumnDef, len(columns))
+	for i, c := range columns {
+		defs[i] = panels.ColumnDef{
+			Name:       c.Name,
+			Header:     c.Header,
+			Width:      c.Width,
+			JSONPath:   c.JSONPath,
+			Label:      c.Label,
+			Annotation: c.Annotation,
+		}
+	}
+
+	return defs
+}
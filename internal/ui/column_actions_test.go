@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestApplyColumnsFromConfig(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.config = &config.Config{Panels: config.PanelsConfig{Columns: map[string][]config.ColumnConfig{
+		"pods":        {{Name: "name"}, {Name: "ip", Width: 16}, {Name: "version", Label: "app"}},
+		"deployments": {{Name: "colour"}},
+	}}}
+	for _, panel := range m.panels {
+		m.applyColumns(panel)
+	}
+
+	m.panels[0].SetSize(120, 10)
+
+	if view := m.panels[0].View(); !strings.Contains(view, "IP") || strings.Contains(view, "RESTARTS") {
+		t.Errorf("pods should show the configured columns, got %q", view)
+	}
+
+	if !strings.Contains(m.statusBar.View(200), "unknown column") {
+		t.Error("an invalid column should be reported")
+	}
+}
+
+func TestApplyColumnsByConfigName(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.config = &config.Config{Panels: config.PanelsConfig{Columns: map[string][]config.ColumnConfig{
+		"hpa": {{Name: "name"}, {Name: "owner", Annotation: "example.com/owner"}},
+	}}}
+
+	panel := panels.NewHPAPanel(m.k8sClient, m.styles)
+	m.applyColumns(panel)
+	panel.SetSize(120, 10)
+
+	if view := panel.View(); !strings.Contains(view, "OWNER") || strings.Contains(view, "TARGET") {
+		t.Errorf("the HPA panel should take the columns set under hpa, got %q", view)
+	}
+}
@@ -0,0 +1,480 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// panelKind is a kind with its own panel: the name newPanel takes, the
+// panel's title, the API group it lists and the names the command prompt
+// accepts for it besides name.
+type panelKind struct {
+	name    string
+	title   string
+	group   string
+	aliases []string
+}
+
+var panelKinds = []panelKind{
+	{"pods", "Pods", "", []string{"po", "pod"}},
+	{"deployments", "Deployments", "apps", []string{"deploy", "deployment"}},
+	{"statefulsets", "StatefulSets", "apps", []string{"sts", "statefulset"}},
+	{"daemonsets", "DaemonSets", "apps", []string{"ds", "daemonset"}},
+	{"jobs", "Jobs", "batch", []string{"job"}},
+	{"cronjobs", "CronJobs", "batch", []string{"cj", "cronjob"}},
+	{"services", "Services", "", []string{"svc", "service"}},
+	{"ingresses", "Ingresses", "networking.k8s.io", []string{"ing", "ingress"}},
+	{"networkpolicies", "NetworkPolicies", "networking.k8s.io", []string{"netpol", "networkpolicy"}},
+	{"configmaps", "ConfigMaps", "", []string{"cm", "configmap"}},
+	{"secrets", "Secrets", "", []string{"secret"}},
+	{"serviceaccounts", "ServiceAccounts", "", []string{"sa", "serviceaccount"}},
+	{"persistentvolumeclaims", "PersistentVolumeClaims", "", []string{"pvc", "persistentvolumeclaim"}},
+	{"persistentvolumes", "PersistentVolumes", "", []string{"pv", "persistentvolume"}},
+	{"horizontalpodautoscalers", "HPAs", "autoscaling", []string{"hpa", "horizontalpodautoscaler"}},
+	{"namespaces", "Namespaces", "", []string{"ns", "namespace"}},
+	{"nodes", "Nodes", "", []string{"no", "node"}},
+	{"events", "Events", "", []string{"ev", "event"}},
+	{"problems", "Problems", "", []string{"problem"}},
+}
+
+// commandWords are the prompt's commands other than kinds.
+var commandWords = []string{"ctx", "ns", "crd", "scale", "restart", "logs"}
+
+func (k panelKind) matches(name string) bool {
+	return name == k.name || slices.Contains(k.aliases, name)
+}
+
+func findPanelKind(name string) (panelKind, bool) {
+	name = strings.ToLower(name)
+
+	for _, kind := range panelKinds {
+		if kind.matches(name) {
+			return kind, true
+		}
+	}
+
+	return panelKind{}, false
+}
+
+// panelKindFor returns the panel listing a discovered resource, if any.
+func panelKindFor(res k8s.APIResource) (panelKind, bool) {
+	for _, kind := range panelKinds {
+		if kind.name == res.Name && kind.group == res.Group {
+			return kind, true
+		}
+	}
+
+	return panelKind{}, false
+}
+
+// commandNamespacesLoadedMsg carries the namespaces the prompt completes.
+type commandNamespacesLoadedMsg struct {
+	context    string
+	namespaces []string
+}
+
+// apiResourcesLoadedMsg carries a context's discovered resources and the
+// kind, if any, that was waiting on them to be opened.
+type apiResourcesLoadedMsg struct {
+	context   string
+	resources []k8s.APIResource
+	err       error
+	open      string
+}
+
+// startCommand opens the command prompt and loads what it completes in
+// the background. Either list failing only costs the completions.
+func (m *Model) startCommand() tea.Cmd {
+	m.commandNamespaces = nil
+
+	m.commandPrompt.Reset()
+	m.viewMode = ViewCommand
+
+	return tea.Batch(m.loadAPIResources(""), m.loadCommandNamespaces())
+}
+
+func (m *Model) loadCommandNamespaces() tea.Cmd {
+	kubeContext := m.k8sClient.CurrentContext()
+
+	return func() tea.Msg {
+		nsList, err := m.k8sClient.ListNamespaces(context.Background())
+		if err != nil {
+			return nil
+		}
+
+		names := make([]string, 0, len(nsList))
+		for _, ns := range nsList {
+			names = append(names, ns.Name)
+		}
+
+		return commandNamespacesLoadedMsg{context: kubeContext, namespaces: names}
+	}
+}
+
+// loadAPIResources runs discovery once per context; until it succeeds
+// only the kinds with panels are known. Open names a kind to show once
+// the resources are in.
+func (m *Model) loadAPIResources(open string) tea.Cmd {
+	if m.apiResources != nil {
+		return nil
+	}
+
+	kubeContext := m.k8sClient.CurrentContext()
+
+	return func() tea.Msg {
+		resources, err := m.k8sClient.ListAPIResources()
+
+		return apiResourcesLoadedMsg{context: kubeContext, resources: resources, err: err, open: open}
+	}
+}
+
+// apiResourcesLoaded keeps the resources discovered in the current context
+// and opens the kind that was waiting on them.
+func (m *Model) apiResourcesLoaded(msg apiResourcesLoadedMsg) tea.Cmd {
+	if msg.context != m.k8sClient.CurrentContext() {
+		return nil
+	}
+
+	if msg.err == nil {
+		m.apiResources = msg.resources
+	}
+
+	if msg.open == "" {
+		return nil
+	}
+
+	if msg.err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Can't look up %s: %v", msg.open, msg.err))
+
+		return nil
+	}
+
+	return m.openKind(msg.open)
+}
+
+// completeCommand suggests whole command lines for what has been typed:
+// commands and kinds for the first word, then contexts, namespaces, custom
+// resources or flags depending on the command.
+func (m *Model) completeCommand(input string) []string {
+	word, arg, hasArg := strings.Cut(strings.TrimLeft(input, " "), " ")
+	word = strings.ToLower(word)
+
+	if !hasArg {
+		return completeWord(word, m.commandCandidates())
+	}
+
+	arg = strings.TrimLeft(arg, " ")
+
+	var candidates []string
+
+	switch word {
+	case "ctx", "context":
+		candidates = m.k8sClient.GetContexts()
+		slices.Sort(candidates)
+	case "ns", "namespace":
+		candidates = m.commandNamespaces
+	case "crd", "crds":
+		for _, res := range m.apiResources {
+			if !res.Builtin() {
+				candidates = append(candidates, res.Name)
+			}
+		}
+	case "logs":
+		candidates = []string{"--previous"}
+	}
+
+	var lines []string
+
+	for _, c := range completeWord(arg, candidates) {
+		lines = append(lines, word+" "+c)
+	}
+
+	return lines
+}
+
+// commandCandidates lists the first words the prompt accepts, commands
+// first, then kinds by their panel name and other discovered resources.
+func (m *Model) commandCandidates() []string {
+	candidates := slices.Clone(commandWords)
+
+	for _, kind := range panelKinds {
+		candidates = append(candidates, kind.name)
+		candidates = append(candidates, kind.aliases...)
+	}
+
+	for _, res := range m.apiResources {
+		candidates = append(candidates, res.Name)
+		candidates = append(candidates, res.ShortNames...)
+	}
+
+	return candidates
+}
+
+// completeWord returns the candidates starting with prefix, an exact match
+// first and without duplicates.
+func completeWord(prefix string, candidates []string) []string {
+	var matches []string
+
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) && !slices.Contains(matches, c) {
+			if c == prefix {
+				matches = slices.Insert(matches, 0, c)
+			} else {
+				matches = append(matches, c)
+			}
+		}
+	}
+
+	return matches
+}
+
+// runCommand carries out a command entered at the prompt.
+func (m *Model) runCommand(line string) tea.Cmd {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	word, args := strings.ToLower(fields[0]), fields[1:]
+
+	switch word {
+	case "ctx", "context", "contexts":
+		if len(args) == 0 {
+			m.startContextSwitch()
+
+			return nil
+		}
+
+		return m.switchContext(args[0])
+
+	case "ns", "namespace", "namespaces":
+		if len(args) == 0 {
+			break
+		}
+
+		if len(m.commandNamespaces) > 0 && !slices.Contains(m.commandNamespaces, args[0]) {
+			m.statusBar.SetError(fmt.Sprintf("Namespace %s not found", args[0]))
+
+			return nil
+		}
+
+		return m.switchNamespace(args[0])
+
+	case "crd", "crds":
+		if len(args) == 0 {
+			return m.listResource(k8s.APIResource{
+				Name: "customresourcedefinitions", Group: "apiextensions.k8s.io",
+			})
+		}
+
+		word = args[0]
+
+	case "scale":
+		return m.scaleSelected(args)
+
+	case "restart":
+		return m.restartSelected()
+
+	case "logs":
+		return m.logsSelected(args)
+	}
+
+	return m.openKind(word)
+}
+
+// openKind shows a kind: in its panel when it has one, otherwise as a
+// kubectl listing.
+func (m *Model) openKind(name string) tea.Cmd {
+	if kind, ok := findPanelKind(name); ok {
+		return m.openPanel(kind)
+	}
+
+	if m.apiResources == nil {
+		return m.loadAPIResources(name)
+	}
+
+	res, ok := k8s.FindAPIResource(m.apiResources, name)
+	if !ok {
+		m.statusBar.SetError(fmt.Sprintf("Unknown command or resource: %s", name))
+
+		return nil
+	}
+
+	if kind, ok := panelKindFor(res); ok {
+		return m.openPanel(kind)
+	}
+
+	return m.listResource(res)
+}
+
+// openPanel focuses the panel of a kind, adding it after the configured
+// panels when it isn't shown.
+func (m *Model) openPanel(kind panelKind) tea.Cmd {
+	for idx, panel := range m.panels {
+		if panel.Title() == kind.title {
+			m.selectPanel(idx)
+
+			return nil
+		}
+	}
+
+	panel := m.newPanel(kind.name)
+	if panel == nil {
+		return nil
+	}
+
+	m.applySavedSort(panel)
+	m.applyColumns(panel)
+	panel.SetAllNamespaces(m.showAllNs)
+
+	m.panels = append(m.panels, panel)
+	m.updatePanelSizes()
+	m.selectPanel(len(m.panels) - 1)
+	m.statusBar.SetMessage(fmt.Sprintf("Opened %s", kind.title))
+
+	return panel.Init()
+}
+
+// listResource shows kubectl's listing of a kind without a panel, such as
+// a custom resource, in the current namespace or all of them.
+func (m *Model) listResource(res k8s.APIResource) tea.Cmd {
+	args := kubectlGetArgs(res, m.k8sClient.CurrentContext(), m.k8sClient.CurrentNamespace(), m.showAllNs)
+
+	return func() tea.Msg {
+		out, err := newKubectlCmd(args...).CombinedOutput()
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("kubectl get %s: %w: %s", res.QualifiedName(), err,
+				strings.TrimSpace(string(out)))}
+		}
+
+		return reportLoadedMsg{content: "kubectl " + strings.Join(args, " ") + "\n\n" + string(out)}
+	}
+}
+
+func kubectlGetArgs(res k8s.APIResource, kubeContext, namespace string, allNamespaces bool) []string {
+	args := []string{"get", res.QualifiedName(), "-o", "wide"}
+
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+
+	switch {
+	case !res.Namespaced:
+	case allNamespaces:
+		args = append(args, "--all-namespaces")
+	default:
+		args = append(args, "-n", namespace)
+	}
+
+	return args
+}
+
+func (m *Model) selectedItem() any {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return nil
+	}
+
+	return m.panels[m.activePanelIdx].SelectedItem()
+}
+
+// scaleSelected scales the selected Deployment or StatefulSet, as the
+// scale prompt would.
+func (m *Model) scaleSelected(args []string) tea.Cmd {
+	if len(args) != 1 {
+		m.statusBar.SetError("Usage: scale <replicas>")
+
+		return nil
+	}
+
+	switch v := m.selectedItem().(type) {
+	case *appsv1.Deployment:
+		return m.scaleDeployment(v.Namespace, v.Name, args[0], replicasOf(v.Spec.Replicas))
+	case *appsv1.StatefulSet:
+		return m.scaleStatefulSet(v.Namespace, v.Name, args[0], replicasOf(v.Spec.Replicas))
+	}
+
+	m.statusBar.SetMessage("Scale not available for this resource")
+
+	return nil
+}
+
+// replicasOf reads a replica count, which the API server defaults to 1.
+func replicasOf(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+func (m *Model) restartSelected() tea.Cmd {
+	switch v := m.selectedItem().(type) {
+	case *appsv1.Deployment:
+		return m.restartDeployment(v.Namespace, v.Name)
+	case *appsv1.StatefulSet:
+		return m.restartStatefulSet(v.Namespace, v.Name)
+	case *appsv1.DaemonSet:
+		return m.restartDaemonSet(v.Namespace, v.Name)
+	}
+
+	m.statusBar.SetMessage("Restart not available for this resource")
+
+	return nil
+}
+
+// logsSelected opens the selection's logs; --previous (-p) and
+// --container (-c) pick a pod's terminated instance and container.
+func (m *Model) logsSelected(args []string) tea.Cmd {
+	previous := false
+	container := ""
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--previous", "-p":
+			previous = true
+		case "--container", "-c":
+			if i+1 == len(args) {
+				m.statusBar.SetError(args[i] + " needs a container name")
+
+				return nil
+			}
+
+			i++
+			container = args[i]
+		default:
+			m.statusBar.SetError("Unknown logs flag: " + args[i])
+
+			return nil
+		}
+	}
+
+	if !previous && container == "" {
+		_, cmd := m.showLogs()
+
+		return cmd
+	}
+
+	pod, ok := m.selectedItem().(*corev1.Pod)
+	if !ok {
+		m.statusBar.SetMessage("--previous and --container need a selected pod")
+
+		return nil
+	}
+
+	m.viewMode = ViewLogs
+
+	if previous {
+		return m.logView.StartPrevious(m.k8sClient, pod.Namespace, pod.Name, container)
+	}
+
+	return m.logView.Start(m.k8sClient, pod.Namespace, pod.Name, container)
+}
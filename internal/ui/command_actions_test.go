@@ -0,0 +1,335 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func createCommandTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	replicas := int32(2)
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "statefulsets", SingularName: "statefulset", Kind: "StatefulSet", Namespaced: true, ShortNames: []string{"sts"}, Verbs: []string{"list"}},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", SingularName: "certificate", Kind: "Certificate", Namespaced: true, ShortNames: []string{"cert"}, Verbs: []string{"list"}},
+			},
+		},
+	}
+
+	m := createTestModel()
+	m.config = &config.Config{}
+	m.k8sClient = k8s.NewTestClient(clientset)
+	m.header = components.NewHeader(m.styles, "", "default")
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.input = components.NewInput(m.styles)
+	m.logView = components.NewLogViewer(m.styles)
+	m.commandPrompt = components.NewCommandPrompt(m.styles)
+	m.commandPrompt.SetCompleter(m.completeCommand)
+
+	deployments := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	deployments.Update(deployments.Refresh()())
+
+	m.panels = []panels.Panel{panels.NewPodsPanel(m.k8sClient, m.styles), deployments}
+
+	return m
+}
+
+// enterCommand types a command at the prompt and submits it, returning
+// the command it runs.
+func enterCommand(t *testing.T, m *Model, command string) tea.Cmd {
+	t.Helper()
+
+	_, load := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+
+	if m.viewMode != ViewCommand {
+		t.Fatalf("':' should open the command prompt, view mode is %v", m.viewMode)
+	}
+
+	runCmds(m, load)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(command)})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should submit the command")
+	}
+
+	_, cmd = m.Update(cmd())
+
+	if m.viewMode == ViewCommand {
+		t.Error("submitting should close the prompt")
+	}
+
+	return cmd
+}
+
+// runCmds feeds the messages of cmd, batched or not, back to the model.
+func runCmds(m *Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmds(m, c)
+		}
+
+		return
+	}
+
+	m.Update(msg)
+}
+
+func TestCommandJumpsToShownPanel(t *testing.T) {
+	m := createCommandTestModel(t)
+
+	enterCommand(t, m, "deploy")
+
+	if m.activePanelIdx != 1 {
+		t.Errorf("active panel = %d, want the deployments panel", m.activePanelIdx)
+	}
+}
+
+func TestCommandOpensPanelByAliasOrShortName(t *testing.T) {
+	for _, command := range []string{"sts", "statefulset", "StatefulSets"} {
+		m := createCommandTestModel(t)
+
+		if cmd := enterCommand(t, m, command); cmd == nil {
+			t.Errorf("%s: opening a panel should load it", command)
+		}
+
+		if len(m.panels) != 3 || m.panels[m.activePanelIdx].Title() != "StatefulSets" {
+			t.Errorf("%s: the StatefulSets panel should be opened and focused", command)
+		}
+
+		// A second time only jumps to it
+		enterCommand(t, m, command)
+
+		if len(m.panels) != 3 {
+			t.Errorf("%s: an open panel should not be added again", command)
+		}
+	}
+}
+
+func TestCommandSwitchesNamespace(t *testing.T) {
+	m := createCommandTestModel(t)
+
+	enterCommand(t, m, "ns kube-system")
+
+	if got := m.k8sClient.CurrentNamespace(); got != "kube-system" {
+		t.Errorf("namespace = %q, want kube-system", got)
+	}
+
+	enterCommand(t, m, "ns nowhere")
+
+	if got := m.k8sClient.CurrentNamespace(); got != "kube-system" {
+		t.Errorf("an unknown namespace should not be switched to, got %q", got)
+	}
+
+	if !strings.Contains(m.statusBar.View(200), "Namespace nowhere not found") {
+		t.Error("an unknown namespace should be reported")
+	}
+
+	// Without a name it shows the namespaces
+	enterCommand(t, m, "ns")
+
+	if m.panels[m.activePanelIdx].Title() != "Namespaces" {
+		t.Error(":ns should open the Namespaces panel")
+	}
+}
+
+func TestCommandScalesSelection(t *testing.T) {
+	m := createCommandTestModel(t)
+	m.selectPanel(1)
+
+	// The fake clientset can't serve the scale subresource of deployments
+	var scaled int32
+
+	clientset, _ := m.k8sClient.Clientset().(*fake.Clientset)
+	clientset.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.GetAction).GetName()
+
+		return action.GetSubresource() == "scale", &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale, ok := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		if !ok {
+			return false, nil, nil
+		}
+
+		scaled = scale.Spec.Replicas
+
+		return true, scale, nil
+	})
+
+	cmd := enterCommand(t, m, "scale 3")
+	if cmd == nil {
+		t.Fatal(":scale should scale the selected deployment")
+	}
+
+	if msg, ok := cmd().(panels.ErrorMsg); ok {
+		t.Fatalf("scale failed: %v", msg.Error)
+	}
+
+	if scaled != 3 {
+		t.Errorf("replicas = %d, want 3", scaled)
+	}
+
+	enterCommand(t, m, "scale")
+
+	if !strings.Contains(m.statusBar.View(200), "Usage: scale <replicas>") {
+		t.Error(":scale without a count should show its usage")
+	}
+}
+
+func TestCommandRestartsSelection(t *testing.T) {
+	m := createCommandTestModel(t)
+	m.selectPanel(1)
+
+	cmd := enterCommand(t, m, "restart")
+	if cmd == nil {
+		t.Fatal(":restart should restart the selected deployment")
+	}
+
+	if _, ok := cmd().(panels.StatusWithRefreshMsg); !ok {
+		t.Error("restart should report success")
+	}
+
+	// Pods have no restart
+	m.selectPanel(0)
+
+	if cmd := enterCommand(t, m, "restart"); cmd != nil {
+		t.Error(":restart should do nothing for a pod")
+	}
+}
+
+func TestCommandReportsUnknownInput(t *testing.T) {
+	m := createCommandTestModel(t)
+
+	enterCommand(t, m, "widgets")
+
+	if !strings.Contains(m.statusBar.View(200), "Unknown command or resource: widgets") {
+		t.Error("an unknown kind should be reported")
+	}
+
+	enterCommand(t, m, "logs --tail")
+
+	if !strings.Contains(m.statusBar.View(200), "Unknown logs flag: --tail") {
+		t.Error("an unknown logs flag should be reported")
+	}
+}
+
+func TestCommandCustomResourceListing(t *testing.T) {
+	m := createCommandTestModel(t)
+
+	if cmd := m.runCommand("crd certificates"); cmd == nil {
+		t.Error(":crd certificates should list the custom resource")
+	}
+
+	if cmd := m.runCommand("cert"); cmd == nil {
+		t.Error("a custom resource's short name should list it")
+	}
+
+	res := k8s.APIResource{Name: "certificates", Group: "cert-manager.io", Namespaced: true}
+
+	args := strings.Join(kubectlGetArgs(res, "prod", "web", false), " ")
+	if args != "get certificates.cert-manager.io -o wide --context prod -n web" {
+		t.Errorf("kubectl args = %q", args)
+	}
+
+	res.Namespaced = false
+
+	args = strings.Join(kubectlGetArgs(res, "", "web", true), " ")
+	if args != "get certificates.cert-manager.io -o wide" {
+		t.Errorf("cluster-scoped kubectl args = %q", args)
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	m := createCommandTestModel(t)
+	runCmds(m, m.startCommand())
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"dep", "deployments"},
+		{"st", "statefulsets"},
+		{"ce", "certificates"},
+		{"ns kube", "ns kube-system"},
+		{"crd c", "crd certificates"},
+		{"logs --p", "logs --previous"},
+	}
+
+	for _, tt := range tests {
+		if got := m.completeCommand(tt.input); !slices.Contains(got, tt.want) {
+			t.Errorf("completeCommand(%q) = %v, want it to contain %q", tt.input, got, tt.want)
+		}
+	}
+
+	if got := m.completeCommand("crd "); slices.Contains(got, "crd statefulsets") {
+		t.Errorf(":crd should only complete custom resources, got %v", got)
+	}
+
+	if got := m.completeCommand("sts"); len(got) == 0 || got[0] != "sts" {
+		t.Errorf("an exact match should come first, got %v", got)
+	}
+}
+
+func TestCommandDiscoversResourcesInBackground(t *testing.T) {
+	m := createCommandTestModel(t)
+
+	load := m.runCommand("cert")
+	if load == nil || m.apiResources != nil {
+		t.Fatal("an unknown kind should start discovery without blocking")
+	}
+
+	_, list := m.Update(load())
+
+	if len(m.apiResources) == 0 {
+		t.Error("the discovered resources should be kept")
+	}
+
+	if list == nil {
+		t.Error("the custom resource should be listed once discovered")
+	}
+
+	m.Update(apiResourcesLoadedMsg{context: "elsewhere", open: "cert"})
+
+	if len(m.apiResources) == 0 {
+		t.Error("resources from another context should be ignored")
+	}
+}
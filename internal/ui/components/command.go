@@ -0,0 +1,177 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+)
+
+// CommandSubmitMsg carries the command entered at the prompt, without the
+// leading colon.
+type CommandSubmitMsg struct {
+	Command string
+}
+
+// CommandCancelMsg is sent when the prompt is closed without a command.
+type CommandCancelMsg struct{}
+
+// maxCommandSuggestions caps the completions shown after the input.
+const maxCommandSuggestions = 8
+
+// CommandPrompt is the ":" prompt for resource kinds and actions. What it
+// completes is up to the completer, which returns whole command lines for
+// the current input.
+type CommandPrompt struct {
+	styles      *theme.Styles
+	input       textinput.Model
+	complete    func(input string) []string
+	suggestions []string
+	selected    int
+}
+
+func NewCommandPrompt(styles *theme.Styles) *CommandPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "pods, deploy, ns kube-system, ctx prod, scale 3, restart, logs --previous"
+	ti.CharLimit = 200
+	ti.Width = 50
+	ti.Prompt = ": "
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(styles.Primary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.Text)
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.MutedColor)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+
+	return &CommandPrompt{
+		styles: styles,
+		input:  ti,
+	}
+}
+
+// SetCompleter sets what the prompt suggests for its input.
+func (c *CommandPrompt) SetCompleter(complete func(input string) []string) {
+	c.complete = complete
+}
+
+// Reset clears the prompt and focuses it for a new command.
+func (c *CommandPrompt) Reset() {
+	c.input.SetValue("")
+	c.input.Focus()
+	c.suggest()
+}
+
+func (c *CommandPrompt) Value() string {
+	return c.input.Value()
+}
+
+// Suggestions are the completions for the current input, best first.
+func (c *CommandPrompt) Suggestions() []string {
+	return c.suggestions
+}
+
+func (c *CommandPrompt) suggest() {
+	c.suggestions = nil
+	c.selected = 0
+
+	if c.complete != nil {
+		c.suggestions = c.complete(c.input.Value())
+	}
+}
+
+func (c *CommandPrompt) Update(msg tea.Msg) (*CommandPrompt, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		c.input.Blur()
+
+		return c, func() tea.Msg { return CommandCancelMsg{} }
+
+	case "enter":
+		command := strings.TrimSpace(c.input.Value())
+		c.input.Blur()
+
+		if command == "" {
+			return c, func() tea.Msg { return CommandCancelMsg{} }
+		}
+
+		return c, func() tea.Msg { return CommandSubmitMsg{Command: command} }
+
+	case "tab":
+		// Accept the highlighted completion, leaving room for an argument
+		if c.selected < len(c.suggestions) {
+			c.input.SetValue(c.suggestions[c.selected] + " ")
+			c.input.CursorEnd()
+			c.suggest()
+		}
+
+		return c, nil
+
+	case "down", "ctrl+n":
+		if len(c.suggestions) > 0 {
+			c.selected = (c.selected + 1) % min(len(c.suggestions), maxCommandSuggestions)
+		}
+
+		return c, nil
+
+	case "up", "ctrl+p", "shift+tab":
+		if len(c.suggestions) > 0 {
+			shown := min(len(c.suggestions), maxCommandSuggestions)
+			c.selected = (c.selected - 1 + shown) % shown
+		}
+
+		return c, nil
+	}
+
+	oldValue := c.input.Value()
+
+	var cmd tea.Cmd
+
+	c.input, cmd = c.input.Update(msg)
+
+	if c.input.Value() != oldValue {
+		c.suggest()
+	}
+
+	return c, cmd
+}
+
+func (c *CommandPrompt) View(width int) string {
+	var hints []string
+
+	for i, suggestion := range c.suggestions {
+		if i == maxCommandSuggestions {
+			hints = append(hints, c.styles.Muted.Render("…"))
+
+			break
+		}
+
+		if i == c.selected {
+			hints = append(hints, c.styles.ListItemFocused.Render(suggestion))
+		} else {
+			hints = append(hints, c.styles.Muted.Render(suggestion))
+		}
+	}
+
+	var hintView string
+	if len(hints) > 0 {
+		hintView = "  " + strings.Join(hints, " ")
+	}
+
+	c.input.Width = max(min(lipgloss.Width(c.input.Value())+1, width-7), 10)
+	if c.input.Value() == "" {
+		c.input.Width = max(width-7-lipgloss.Width(hintView), 10)
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(c.styles.Text).
+		Padding(0, 1).
+		Width(width - 2).
+		MaxHeight(1).
+		Render(c.input.View() + hintView)
+}
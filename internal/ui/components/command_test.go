@@ -0,0 +1,70 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTestCommandPrompt() *CommandPrompt {
+	prompt := NewCommandPrompt(createTestStyles())
+	prompt.SetCompleter(func(input string) []string {
+		var out []string
+
+		for _, c := range []string{"deployments", "daemonsets", "ns default", "ns kube-system"} {
+			if strings.HasPrefix(c, input) {
+				out = append(out, c)
+			}
+		}
+
+		return out
+	})
+	prompt.Reset()
+
+	return prompt
+}
+
+func TestCommandPromptCompletes(t *testing.T) {
+	prompt := newTestCommandPrompt()
+
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+
+	if got := prompt.Suggestions(); len(got) != 2 {
+		t.Fatalf("Suggestions() = %v, want deployments and daemonsets", got)
+	}
+
+	prompt.Update(tea.KeyMsg{Type: tea.KeyDown})
+	prompt.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if prompt.Value() != "daemonsets " {
+		t.Errorf("tab should accept the highlighted completion, got %q", prompt.Value())
+	}
+
+	if !strings.Contains(prompt.View(80), "daemonsets") {
+		t.Error("view should show the input")
+	}
+}
+
+func TestCommandPromptSubmitAndCancel(t *testing.T) {
+	prompt := newTestCommandPrompt()
+
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ns kube-system ")})
+
+	_, cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(CommandSubmitMsg); !ok || msg.Command != "ns kube-system" {
+		t.Errorf("enter should submit the trimmed command, got %#v", cmd())
+	}
+
+	prompt.Reset()
+
+	_, cmd = prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmd().(CommandCancelMsg); !ok {
+		t.Error("an empty command should cancel")
+	}
+
+	_, cmd = prompt.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(CommandCancelMsg); !ok {
+		t.Error("esc should cancel")
+	}
+}
@@ -42,6 +42,9 @@ type DiffViewer struct {
 	searchQuery  string
 	matchLines   []int
 	matchIndex   int
+	// actionHint advertises a key the caller handles for this diff, such
+	// as applying it
+	actionHint string
 }
 
 // NewDiffViewer creates a DiffViewer with search text input pre-configured.
@@ -70,10 +73,21 @@ func (d *DiffViewer) SetContent(title, oldYAML, newYAML string) {
 	d.searchQuery = ""
 	d.matchLines = make([]int, 0)
 	d.matchIndex = 0
+	d.actionHint = ""
 
 	d.lines = computeDiff(oldYAML, newYAML)
 }
 
+// SetActionHint adds hint to the title bar until the next SetContent.
+func (d *DiffViewer) SetActionHint(hint string) {
+	d.actionHint = hint
+}
+
+// SearchActive reports whether keys are going to the search input.
+func (d *DiffViewer) SearchActive() bool {
+	return d.searchActive
+}
+
 // computeDiff produces line-level diff output using character-based diffing
 // mapped back to lines via DiffLinesToChars / DiffCharsToLines.
 func computeDiff(oldText, newText string) []DiffLine {
@@ -254,9 +268,12 @@ func (d *DiffViewer) View(width, height int) string {
 	if d.searchActive {
 		hint = d.styles.Muted.Render("enter search • esc cancel")
 	} else {
-		hint = d.styles.Muted.Render(
-			"/ search • n/N next/prev • ↑/↓ scroll • esc close",
-		)
+		keys := "/ search • n/N next/prev • ↑/↓ scroll • esc close"
+		if d.actionHint != "" {
+			keys = d.actionHint + " • " + keys
+		}
+
+		hint = d.styles.Muted.Render(keys)
 	}
 
 	titleBar := lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", hint)
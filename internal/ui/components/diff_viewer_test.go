@@ -107,6 +107,24 @@ func TestDiffViewerView(t *testing.T) {
 	}
 }
 
+func TestDiffViewerActionHint(t *testing.T) {
+	styles := createTestStyles()
+	viewer := NewDiffViewer(styles)
+
+	viewer.SetContent("Test Diff", "old\n", "new\n")
+	viewer.SetActionHint("a apply")
+
+	if view := viewer.View(120, 24); !strings.Contains(view, "a apply") {
+		t.Error("View should show the action hint")
+	}
+
+	viewer.SetContent("Other Diff", "old\n", "new\n")
+
+	if view := viewer.View(120, 24); strings.Contains(view, "a apply") {
+		t.Error("SetContent should clear the action hint")
+	}
+}
+
 func TestDiffViewerScrollDown(t *testing.T) {
 	styles := createTestStyles()
 	viewer := NewDiffViewer(styles)
@@ -43,6 +43,7 @@ func (h *Help) View(width, height int) string {
 				{"z", "Zoom/fullscreen panel"},
 				{"Enter", "Select/expand"},
 				{"Esc", "Back/cancel"},
+				{"Mouse", "Click to select, double-click for YAML, wheel to scroll"},
 			},
 		},
 		{
@@ -50,7 +51,8 @@ func (h *Help) View(width, height int) string {
 			bindings: []struct{ key, desc string }{
 				{"?", "Show help"},
 				{"q/Ctrl+c", "Quit"},
-				{"/", "Search/filter"},
+				{"/", "Filter, e.g. status:Running label:app=web restarts>3"},
+				{":", "Command, e.g. :deploy, :ns kube-system, :scale 3"},
 				{"Ctrl+r", "Refresh"},
 				{"K", "Switch context"},
 				{"n", "Switch namespace"},
@@ -66,6 +68,17 @@ func (h *Help) View(width, height int) string {
 				{"D", "Delete (with confirm)"},
 				{"c", "Copy name"},
 				{"Ctrl+y", "Copy YAML"},
+				{"o", "X-ray related resources"},
+			},
+		},
+		{
+			title: "Marking",
+			bindings: []struct{ key, desc string }{
+				{"Space", "Mark/unmark item"},
+				{"Ctrl+a", "Mark all matching the filter"},
+				{"Ctrl+l", "Label marked or selected items"},
+				{"Esc", "Clear marks"},
+				{"D/r/s/S/c", "Delete, restart, scale, suspend, copy names of marked"},
 			},
 		},
 		{
@@ -74,7 +87,11 @@ func (h *Help) View(width, height int) string {
 				{"l", "View logs"},
 				{"f", "Toggle follow logs"},
 				{"x", "Exec into container"},
+				{"X", "Debug via ephemeral container or pod copy"},
+				{"T", "Shell sessions (ctrl+] leaves, alt+n/alt+p switch)"},
 				{"p", "Port forward"},
+				{"w", "Why is it Pending?"},
+				{"S", "Sort by cpu/memory/restarts"},
 			},
 		},
 		{
@@ -84,6 +101,65 @@ func (h *Help) View(width, height int) string {
 				{"r", "Restart (rollout)"},
 				{"R", "Rollback"},
 				{"V", "Version diff"},
+				{"u", "Right-size requests from usage"},
+			},
+		},
+		{
+			title: "Secret Actions",
+			bindings: []struct{ key, desc string }{
+				{"[ / ]", "Select data key"},
+				{"v", "Reveal/hide value"},
+				{"Y", "Copy value"},
+				{"E", "Edit value"},
+				{"N", "New secret"},
+				{"C", "Expiring certificates report"},
+			},
+		},
+		{
+			title: "Namespace Actions",
+			bindings: []struct{ key, desc string }{
+				{"N", "New namespace"},
+				{"E", "Edit labels/annotations"},
+				{"b", "Explain Terminating blockers"},
+				{"L", "Best-practice lint report"},
+			},
+		},
+		{
+			title: "ConfigMap Actions",
+			bindings: []struct{ key, desc string }{
+				{"[ / ]", "Select data key"},
+				{"E", "Edit key value"},
+				{"a", "Add key"},
+				{"R", "Rename key"},
+				{"X", "Remove key"},
+				{"N", "New configmap"},
+			},
+		},
+		{
+			title: "Problems Panel",
+			bindings: []struct{ key, desc string }{
+				{"Enter", "Jump to resource"},
+			},
+		},
+		{
+			title: "NetworkPolicy Actions",
+			bindings: []struct{ key, desc string }{
+				{"t", "Test pod-to-pod reachability"},
+			},
+		},
+		{
+			title: "Node Actions",
+			bindings: []struct{ key, desc string }{
+				{"a", "Pods on node by request"},
+				{"x", "Shell on the node's host"},
+			},
+		},
+		{
+			title: "Sorting",
+			bindings: []struct{ key, desc string }{
+				{">", "Sort by next column"},
+				{"<", "Sort by previous column"},
+				{"I", "Invert sort direction"},
 			},
 		},
 	}
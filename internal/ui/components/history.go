@@ -25,6 +25,14 @@ const (
 	OpEditHPAMax
 	OpEditResource
 	OpTriggerCronJob
+	OpEditSecret
+	OpCreateSecret
+	OpEditConfigMap
+	OpCreateConfigMap
+	OpCreateNamespace
+	OpEditNamespace
+	OpRightsize
+	OpLabelResource
 )
 
 // UndoData captures previous state needed to reverse an operation.
@@ -142,6 +150,14 @@ func operationLabel(op OperationType) string {
 		OpEditHPAMax:         "Edit HPA Max",
 		OpEditResource:       "Edit Resource",
 		OpTriggerCronJob:     "Trigger CronJob",
+		OpEditSecret:         "Edit Secret",
+		OpCreateSecret:       "Create Secret",
+		OpEditConfigMap:      "Edit ConfigMap",
+		OpCreateConfigMap:    "Create ConfigMap",
+		OpCreateNamespace:    "Create Namespace",
+		OpEditNamespace:      "Edit Namespace",
+		OpRightsize:          "Right-size",
+		OpLabelResource:      "Label",
 	}
 
 	if label, ok := labels[op]; ok {
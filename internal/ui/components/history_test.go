@@ -152,6 +152,14 @@ func TestOperationLabel(t *testing.T) {
 		{OpResumeCronJob, "Resume CronJob"},
 		{OpEditResource, "Edit Resource"},
 		{OpTriggerCronJob, "Trigger CronJob"},
+		{OpEditSecret, "Edit Secret"},
+		{OpCreateSecret, "Create Secret"},
+		{OpEditConfigMap, "Edit ConfigMap"},
+		{OpCreateConfigMap, "Create ConfigMap"},
+		{OpCreateNamespace, "Create Namespace"},
+		{OpEditNamespace, "Edit Namespace"},
+		{OpRightsize, "Right-size"},
+		{OpLabelResource, "Label"},
 	}
 
 	for _, tt := range tests {
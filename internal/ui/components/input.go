@@ -37,11 +37,21 @@ func (i *Input) Show(title, description, placeholder string) {
 	i.title = title
 	i.description = description
 	i.input.Placeholder = placeholder
+	i.input.EchoMode = textinput.EchoNormal
 	i.input.SetValue("")
 	i.input.Focus()
 	i.active = true
 }
 
+// SetMasked hides typed characters, e.g. for passwords. Show resets it.
+func (i *Input) SetMasked(masked bool) {
+	if masked {
+		i.input.EchoMode = textinput.EchoPassword
+	} else {
+		i.input.EchoMode = textinput.EchoNormal
+	}
+}
+
 func (i *Input) Hide() {
 	i.active = false
 	i.input.Blur()
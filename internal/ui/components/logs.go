@@ -104,6 +104,33 @@ func (l *LogViewer) Start(client *k8s.Client, namespace, pod, container string)
 	}
 }
 
+// StartPrevious shows the logs of the previous, terminated instance of a
+// pod's container, which is where a crash-looping container's error is.
+func (l *LogViewer) StartPrevious(client *k8s.Client, namespace, pod, container string) tea.Cmd {
+	l.pod = pod
+	l.namespace = namespace
+	l.container = container
+	l.title = "Logs: " + pod + " (previous)"
+	l.lines = make([]string, 0)
+	l.offset = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+
+	return func() tea.Msg {
+		logs, err := client.GetPodLogSnapshot(ctx, namespace, pod, k8s.LogOptions{
+			Container: container,
+			TailLines: 100,
+			Previous:  true,
+		})
+		if err != nil {
+			return LogLineMsg{Error: err}
+		}
+
+		return LogLineMsg{Line: logs}
+	}
+}
+
 // StartMulti tails logs from every pod matching selector, prefixing each line
 // with the pod name so multiplexed output is readable. The title reflects the
 // owning workload ("Logs: Deployment/my-app (3 pods)") rather than a pod name.
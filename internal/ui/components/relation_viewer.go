@@ -0,0 +1,200 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+)
+
+// RelationJumpMsg is returned by the relation viewer when the user presses
+// enter on a node, asking to jump to that object's panel.
+type RelationJumpMsg struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// relationLine is one flattened tree node with its box-drawing prefix.
+type relationLine struct {
+	prefix string
+	node   *k8s.RelationNode
+}
+
+// RelationViewer renders a resource's relationship tree as a scrollable,
+// navigable full-screen list.
+type RelationViewer struct {
+	styles *theme.Styles
+	root   *k8s.RelationNode
+	lines  []relationLine
+	cursor int
+	offset int
+	width  int
+	height int
+}
+
+func NewRelationViewer(styles *theme.Styles) *RelationViewer {
+	return &RelationViewer{styles: styles}
+}
+
+// SetTree replaces the displayed tree and moves the cursor to the root.
+func (r *RelationViewer) SetTree(root *k8s.RelationNode) {
+	r.root = root
+	r.lines = nil
+	r.cursor = 0
+	r.offset = 0
+
+	if root != nil {
+		r.lines = append(r.lines, relationLine{node: root})
+		r.flatten(root, "")
+	}
+}
+
+func (r *RelationViewer) flatten(node *k8s.RelationNode, indent string) {
+	for i, child := range node.Children {
+		branch, next := "├─ ", "│  "
+		if i == len(node.Children)-1 {
+			branch, next = "└─ ", "   "
+		}
+
+		r.lines = append(r.lines, relationLine{prefix: indent + branch, node: child})
+		r.flatten(child, indent+next)
+	}
+}
+
+func (r *RelationViewer) Update(msg tea.Msg) (*RelationViewer, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return r, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if r.cursor > 0 {
+			r.cursor--
+			r.ensureVisible()
+		}
+	case "down", "j":
+		if r.cursor < len(r.lines)-1 {
+			r.cursor++
+			r.ensureVisible()
+		}
+	case "g":
+		r.cursor = 0
+		r.offset = 0
+	case "G":
+		r.cursor = max(len(r.lines)-1, 0)
+		r.ensureVisible()
+	case "pgup", "ctrl+u":
+		r.cursor = max(r.cursor-r.visibleHeight()/2, 0)
+		r.ensureVisible()
+	case "pgdown", "ctrl+d":
+		r.cursor = min(r.cursor+r.visibleHeight()/2, max(len(r.lines)-1, 0))
+		r.ensureVisible()
+	case "enter":
+		if node := r.SelectedNode(); node != nil {
+			return r, func() tea.Msg {
+				return RelationJumpMsg{Kind: node.Kind, Name: node.Name, Namespace: node.Namespace}
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// SelectedNode returns the node under the cursor, or nil for an empty tree.
+func (r *RelationViewer) SelectedNode() *k8s.RelationNode {
+	if r.cursor < 0 || r.cursor >= len(r.lines) {
+		return nil
+	}
+
+	return r.lines[r.cursor].node
+}
+
+func (r *RelationViewer) visibleHeight() int {
+	// Title (1) + separator (1) + modal padding (~4)
+	const overhead = 6
+
+	return max(r.height-overhead, 1)
+}
+
+func (r *RelationViewer) ensureVisible() {
+	vis := r.visibleHeight()
+
+	if r.cursor < r.offset {
+		r.offset = r.cursor
+	}
+
+	if r.cursor >= r.offset+vis {
+		r.offset = r.cursor - vis + 1
+	}
+}
+
+func (r *RelationViewer) View(width, height int) string {
+	r.width = width
+	r.height = height
+
+	var b strings.Builder
+
+	name := ""
+	if r.root != nil {
+		name = fmt.Sprintf("%s/%s", r.root.Kind, r.root.Name)
+	}
+
+	title := r.styles.ModalTitle.Render("X-Ray " + name)
+	hint := r.styles.Muted.Render("↑/↓ navigate • enter jump • esc close")
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", hint))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", max(width-4, 0)))
+	b.WriteString("\n")
+
+	vis := r.visibleHeight()
+	endIdx := min(r.offset+vis, len(r.lines))
+
+	for i := r.offset; i < endIdx; i++ {
+		b.WriteString(r.renderLine(r.lines[i], i == r.cursor, width-8))
+		b.WriteString("\n")
+	}
+
+	return r.styles.Modal.
+		Width(width - 4).
+		Height(height - 2).
+		Render(b.String())
+}
+
+func (r *RelationViewer) renderLine(line relationLine, selected bool, maxWidth int) string {
+	node := line.node
+
+	text := fmt.Sprintf("%s/%s", node.Kind, node.Name)
+	if node.Relation != "" {
+		text = node.Relation + " " + text
+	}
+
+	if node.Status != "" {
+		text += "  [" + node.Status + "]"
+	}
+
+	text = line.prefix + text
+	if maxWidth > 3 && len(text) > maxWidth {
+		text = text[:maxWidth-3] + "..."
+	}
+
+	if selected {
+		return r.styles.ListItemFocused.Render("► " + text)
+	}
+
+	if node.Unchecked {
+		return r.styles.StatusUnknown.Render("  " + text)
+	}
+
+	if !node.Healthy {
+		return r.styles.StatusFailed.Render("  " + text)
+	}
+
+	return lipgloss.NewStyle().Foreground(r.styles.Text).Render("  " + text)
+}
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+func testRelationTree() *k8s.RelationNode {
+	return &k8s.RelationNode{
+		Kind: "Deployment", Name: "web", Namespace: "default", Status: "1/1", Healthy: true,
+		Children: []*k8s.RelationNode{
+			{
+				Kind: "ReplicaSet", Name: "web-abc", Namespace: "default",
+				Relation: k8s.RelationOwns, Healthy: true,
+				Children: []*k8s.RelationNode{
+					{Kind: "Pod", Name: "web-abc-1", Namespace: "default", Relation: k8s.RelationOwns, Healthy: true},
+				},
+			},
+			{
+				Kind: "Secret", Name: "web-secret", Namespace: "default",
+				Relation: k8s.RelationMounts, Status: "Missing",
+			},
+		},
+	}
+}
+
+func TestRelationViewer_NavigationAndJump(t *testing.T) {
+	t.Parallel()
+
+	viewer := NewRelationViewer(createTestStyles())
+	viewer.SetTree(testRelationTree())
+
+	viewer, _ = viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	viewer, _ = viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	if node := viewer.SelectedNode(); node == nil || node.Name != "web-abc-1" {
+		t.Fatalf("expected pod after two moves, got %+v", node)
+	}
+
+	_, cmd := viewer.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected jump command on enter")
+	}
+
+	jump, ok := cmd().(RelationJumpMsg)
+	if !ok || jump.Kind != "Pod" || jump.Name != "web-abc-1" || jump.Namespace != "default" {
+		t.Errorf("unexpected jump message %+v", jump)
+	}
+
+	viewer, _ = viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if node := viewer.SelectedNode(); node == nil || node.Name != "web-secret" {
+		t.Errorf("expected last node after G, got %+v", node)
+	}
+}
+
+func TestRelationViewer_View(t *testing.T) {
+	t.Parallel()
+
+	viewer := NewRelationViewer(createTestStyles())
+	viewer.SetTree(testRelationTree())
+
+	view := viewer.View(100, 30)
+
+	for _, want := range []string{
+		"X-Ray Deployment/web",
+		"├─ owns ReplicaSet/web-abc",
+		"│  └─ owns Pod/web-abc-1",
+		"└─ uses Secret/web-secret  [Missing]",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestRelationViewer_Empty(t *testing.T) {
+	t.Parallel()
+
+	viewer := NewRelationViewer(createTestStyles())
+
+	if viewer.SelectedNode() != nil {
+		t.Error("expected no selection in an empty viewer")
+	}
+
+	if _, cmd := viewer.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no command on enter in an empty viewer")
+	}
+}
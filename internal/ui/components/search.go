@@ -11,12 +11,14 @@ import (
 type Search struct {
 	styles *theme.Styles
 	input  textinput.Model
+	// err is shown after the input while the query doesn't parse.
+	err error
 }
 
 func NewSearch(styles *theme.Styles) *Search {
 	ti := textinput.New()
-	ti.Placeholder = "type to filter..."
-	ti.CharLimit = 100
+	ti.Placeholder = "type to filter, e.g. status:Running label:app=web restarts>3"
+	ti.CharLimit = 300
 	ti.Width = 50
 	ti.Prompt = "/ "
 	ti.PromptStyle = lipgloss.NewStyle().Foreground(styles.Primary)
@@ -40,6 +42,12 @@ func (s *Search) Blur() {
 
 func (s *Search) Clear() {
 	s.input.SetValue("")
+	s.err = nil
+}
+
+// SetError shows why the current query is invalid; nil clears it.
+func (s *Search) SetError(err error) {
+	s.err = err
 }
 
 func (s *Search) Value() string {
@@ -59,11 +67,17 @@ func (s *Search) Update(msg tea.Msg) (*Search, tea.Cmd) {
 }
 
 func (s *Search) View(width int) string {
-	s.input.Width = width - 6
+	var errView string
+	if s.err != nil {
+		errView = "  " + s.styles.StatusError.Render(s.err.Error())
+	}
+
+	s.input.Width = max(width-7-lipgloss.Width(errView), 10)
 
 	return lipgloss.NewStyle().
 		Foreground(s.styles.Text).
 		Padding(0, 1).
 		Width(width - 2).
-		Render(s.input.View())
+		MaxHeight(1).
+		Render(s.input.View() + errView)
 }
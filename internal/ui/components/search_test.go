@@ -1,6 +1,7 @@
 package components
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -118,3 +119,24 @@ func TestSearchViewZeroWidth(t *testing.T) {
 		t.Error("Search view should not be empty with zero width")
 	}
 }
+
+func TestSearchShowsError(t *testing.T) {
+	search := NewSearch(createTestStyles())
+	search.SetValue("restarts>many")
+	search.SetError(errors.New("restarts needs a count"))
+
+	view := search.View(100)
+	if !strings.Contains(view, "restarts needs a count") {
+		t.Errorf("view should show the error inline:\n%s", view)
+	}
+
+	if strings.Count(view, "\n") != 0 {
+		t.Errorf("the error should stay on the search line:\n%s", view)
+	}
+
+	search.Clear()
+
+	if strings.Contains(search.View(100), "restarts needs a count") {
+		t.Error("Clear should drop the error")
+	}
+}
@@ -0,0 +1,332 @@
+package components
+
+import (
+	"context"
+	"io"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+// TerminalOutputMsg carries output of a terminal session.
+type TerminalOutputMsg struct {
+	ID   int
+	Data []byte
+}
+
+// TerminalExitMsg is sent when a terminal session's program has ended.
+type TerminalExitMsg struct {
+	ID  int
+	Err error
+}
+
+// TerminalRunner runs a session's program on a TTY: reading its input
+// from stdin, writing its output to stdout and taking size changes from
+// resize, until it ends or ctx is done.
+type TerminalRunner func(
+	ctx context.Context,
+	stdin io.Reader,
+	stdout io.Writer,
+	resize <-chan k8s.TerminalSize,
+) error
+
+// terminalInputBuffer is how many key presses can wait for a program that
+// isn't reading its input yet.
+const terminalInputBuffer = 256
+
+// TerminalSession is a program, like a shell in a container, running on an
+// emulated terminal drawn inside the UI. It keeps running while other
+// views are shown.
+type TerminalSession struct {
+	id    int
+	title string
+
+	screen *vtScreen
+	input  chan []byte
+	output chan []byte
+	resize chan k8s.TerminalSize
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+	exited bool
+}
+
+func NewTerminalSession(id int, title string) *TerminalSession {
+	return &TerminalSession{
+		id:     id,
+		title:  title,
+		screen: newVTScreen(80, 24),
+		input:  make(chan []byte, terminalInputBuffer),
+		output: make(chan []byte),
+		resize: make(chan k8s.TerminalSize, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+func (t *TerminalSession) ID() int {
+	return t.id
+}
+
+func (t *TerminalSession) Title() string {
+	return t.title
+}
+
+// Exited reports whether the program has ended.
+func (t *TerminalSession) Exited() bool {
+	return t.exited
+}
+
+// Start runs the program, returning the command that delivers its first
+// output.
+func (t *TerminalSession) Start(run TerminalRunner) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+
+	stdin, stdinWriter := io.Pipe()
+
+	go func() {
+		defer stdinWriter.Close()
+
+		for {
+			select {
+			case data := <-t.input:
+				if _, err := stdinWriter.Write(data); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	t.sendSize()
+
+	go func() {
+		err := run(ctx, stdin, terminalWriter{ctx: ctx, output: t.output}, t.resize)
+
+		// Unblock the input pump on a program that stopped reading
+		cancel()
+		stdin.Close()
+
+		t.err = err
+		close(t.output)
+		close(t.done)
+	}()
+
+	return t.WaitOutput()
+}
+
+// WaitOutput returns the command delivering the next output, combined with
+// whatever else is already waiting, or the exit.
+func (t *TerminalSession) WaitOutput() tea.Cmd {
+	return func() tea.Msg {
+		data, ok := <-t.output
+		if !ok {
+			return TerminalExitMsg{ID: t.id, Err: t.err}
+		}
+
+		for {
+			select {
+			case more, ok := <-t.output:
+				if !ok {
+					return TerminalOutputMsg{ID: t.id, Data: data}
+				}
+
+				data = append(data, more...)
+			default:
+				return TerminalOutputMsg{ID: t.id, Data: data}
+			}
+		}
+	}
+}
+
+// Feed draws program output on the screen and answers the queries in it.
+func (t *TerminalSession) Feed(data []byte) {
+	_, _ = t.screen.Write(data)
+
+	if replies := t.screen.TakeReplies(); len(replies) > 0 {
+		t.send(replies)
+	}
+}
+
+// Done is closed once the program has ended and cleaned up after itself.
+func (t *TerminalSession) Done() <-chan struct{} {
+	return t.done
+}
+
+// MarkExited records that the program ended.
+func (t *TerminalSession) MarkExited() {
+	t.exited = true
+}
+
+// Close ends the program.
+func (t *TerminalSession) Close() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
+// SetSize resizes the screen, telling the program when it changed.
+func (t *TerminalSession) SetSize(width, height int) {
+	if width == t.screen.width && height == t.screen.height {
+		return
+	}
+
+	t.screen.Resize(width, height)
+	t.sendSize()
+}
+
+// sendSize queues the screen size, replacing a size the program hasn't
+// taken yet.
+func (t *TerminalSession) sendSize() {
+	size := k8s.TerminalSize{Width: uint16(t.screen.width), Height: uint16(t.screen.height)}
+
+	select {
+	case <-t.resize:
+	default:
+	}
+
+	t.resize <- size
+}
+
+// SendKey types a key into the program. Typing scrolls the view back to
+// the live screen.
+func (t *TerminalSession) SendKey(msg tea.KeyMsg) {
+	data := keyBytes(msg, t.screen.appCursor, t.screen.bracketedPaste)
+	if len(data) == 0 {
+		return
+	}
+
+	t.screen.scrollOffset = 0
+	t.send(data)
+}
+
+// send queues input without blocking the UI; keys typed at a program that
+// stopped reading are dropped.
+func (t *TerminalSession) send(data []byte) {
+	if t.exited {
+		return
+	}
+
+	select {
+	case t.input <- data:
+	default:
+	}
+}
+
+// Scroll moves the view into the scrollback, positive deltas going back.
+func (t *TerminalSession) Scroll(delta int) {
+	t.screen.Scroll(delta)
+}
+
+// Text is the screen's plain text.
+func (t *TerminalSession) Text() string {
+	return t.screen.Text()
+}
+
+// View renders the screen at its size; the cursor shows while the session
+// has the keyboard.
+func (t *TerminalSession) View(focused bool) string {
+	return t.screen.View(focused && !t.exited)
+}
+
+// terminalWriter hands program output to the UI, giving up once the
+// session is over.
+type terminalWriter struct {
+	ctx    context.Context
+	output chan<- []byte
+}
+
+func (w terminalWriter) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+
+	select {
+	case w.output <- data:
+		return len(p), nil
+	case <-w.ctx.Done():
+		return 0, w.ctx.Err()
+	}
+}
+
+// keyBytes is what a terminal sends for a key press.
+func keyBytes(msg tea.KeyMsg, appCursor, bracketedPaste bool) []byte {
+	var data []byte
+
+	switch msg.Type {
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			data = utf8.AppendRune(data, r)
+		}
+
+		if msg.Paste && bracketedPaste {
+			data = append(append([]byte("\x1b[200~"), data...), "\x1b[201~"...)
+		}
+	case tea.KeySpace:
+		data = []byte{' '}
+	case tea.KeyUp, tea.KeyDown, tea.KeyRight, tea.KeyLeft:
+		// Full-screen programs ask for the application cursor keys
+		if appCursor {
+			data = []byte{0x1b, 'O', cursorKeyFinals[msg.Type]}
+		} else {
+			data = []byte{0x1b, '[', cursorKeyFinals[msg.Type]}
+		}
+	default:
+		if seq, ok := keySequences[msg.Type]; ok {
+			data = []byte(seq)
+		} else if msg.Type >= 0 && msg.Type <= 0x7f {
+			// Control keys are their own control codes
+			data = []byte{byte(msg.Type)}
+		}
+	}
+
+	if msg.Alt && len(data) > 0 {
+		data = append([]byte{0x1b}, data...)
+	}
+
+	return data
+}
+
+var cursorKeyFinals = map[tea.KeyType]byte{
+	tea.KeyUp:    'A',
+	tea.KeyDown:  'B',
+	tea.KeyRight: 'C',
+	tea.KeyLeft:  'D',
+}
+
+var keySequences = map[tea.KeyType]string{
+	tea.KeyShiftTab:   "\x1b[Z",
+	tea.KeyHome:       "\x1b[H",
+	tea.KeyEnd:        "\x1b[F",
+	tea.KeyPgUp:       "\x1b[5~",
+	tea.KeyPgDown:     "\x1b[6~",
+	tea.KeyDelete:     "\x1b[3~",
+	tea.KeyInsert:     "\x1b[2~",
+	tea.KeyCtrlUp:     "\x1b[1;5A",
+	tea.KeyCtrlDown:   "\x1b[1;5B",
+	tea.KeyCtrlRight:  "\x1b[1;5C",
+	tea.KeyCtrlLeft:   "\x1b[1;5D",
+	tea.KeyShiftUp:    "\x1b[1;2A",
+	tea.KeyShiftDown:  "\x1b[1;2B",
+	tea.KeyShiftRight: "\x1b[1;2C",
+	tea.KeyShiftLeft:  "\x1b[1;2D",
+	tea.KeyF1:         "\x1bOP",
+	tea.KeyF2:         "\x1bOQ",
+	tea.KeyF3:         "\x1bOR",
+	tea.KeyF4:         "\x1bOS",
+	tea.KeyF5:         "\x1b[15~",
+	tea.KeyF6:         "\x1b[17~",
+	tea.KeyF7:         "\x1b[18~",
+	tea.KeyF8:         "\x1b[19~",
+	tea.KeyF9:         "\x1b[20~",
+	tea.KeyF10:        "\x1b[21~",
+	tea.KeyF11:        "\x1b[23~",
+	tea.KeyF12:        "\x1b[24~",
+	tea.KeyCtrlHome:   "\x1b[1;5H",
+	tea.KeyCtrlEnd:    "\x1b[1;5F",
+	tea.KeyCtrlPgUp:   "\x1b[5;5~",
+	tea.KeyCtrlPgDown: "\x1b[6;5~",
+	tea.KeyShiftHome:  "\x1b[1;2H",
+	tea.KeyShiftEnd:   "\x1b[1;2F",
+}
@@ -0,0 +1,155 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+// echoRunner greets, then echoes its input until ctx is done or it reads
+// "exit".
+func echoRunner(ctx context.Context, stdin io.Reader, stdout io.Writer, _ <-chan k8s.TerminalSize) error {
+	if _, err := io.WriteString(stdout, "$ "); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64)
+
+	for {
+		n, err := stdin.Read(buf)
+		if err != nil {
+			return ctx.Err()
+		}
+
+		if strings.Contains(string(buf[:n]), "exit") {
+			return nil
+		}
+
+		if _, err := stdout.Write(buf[:n]); err != nil {
+			return err
+		}
+	}
+}
+
+// drain feeds a session's output until the wanted text shows or the
+// program exits.
+func drain(t *testing.T, session *TerminalSession, cmd tea.Cmd, want string) tea.Msg {
+	t.Helper()
+
+	for {
+		msg := cmd()
+
+		out, ok := msg.(TerminalOutputMsg)
+		if !ok {
+			return msg
+		}
+
+		session.Feed(out.Data)
+
+		if want != "" && strings.Contains(session.Text(), want) {
+			return msg
+		}
+
+		cmd = session.WaitOutput()
+	}
+}
+
+func TestTerminalSession_EchoesKeysAndExits(t *testing.T) {
+	session := NewTerminalSession(1, "web/app")
+
+	drain(t, session, session.Start(echoRunner), "$")
+
+	session.SendKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ls")})
+	drain(t, session, session.WaitOutput(), "$ ls")
+
+	session.SendKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("exit")})
+
+	msg := drain(t, session, session.WaitOutput(), "")
+
+	exit, ok := msg.(TerminalExitMsg)
+	if !ok || exit.ID != 1 || exit.Err != nil {
+		t.Errorf("last message = %#v, want a clean exit of session 1", msg)
+	}
+}
+
+func TestTerminalSession_CloseEndsProgram(t *testing.T) {
+	session := NewTerminalSession(2, "web/app")
+
+	drain(t, session, session.Start(echoRunner), "$")
+	session.Close()
+
+	msg := drain(t, session, session.WaitOutput(), "")
+
+	exit, ok := msg.(TerminalExitMsg)
+	if !ok || !errors.Is(exit.Err, context.Canceled) {
+		t.Errorf("last message = %#v, want an exit with context.Canceled", msg)
+	}
+}
+
+func TestTerminalSession_SendsSizes(t *testing.T) {
+	sizes := make(chan k8s.TerminalSize, 2)
+	session := NewTerminalSession(3, "web/app")
+
+	cmd := session.Start(func(ctx context.Context, _ io.Reader, _ io.Writer, resize <-chan k8s.TerminalSize) error {
+		for range 2 {
+			sizes <- <-resize
+		}
+
+		return nil
+	})
+
+	if got := <-sizes; got != (k8s.TerminalSize{Width: 80, Height: 24}) {
+		t.Errorf("initial size = %+v, want 80x24", got)
+	}
+
+	session.SetSize(100, 30)
+
+	if got := <-sizes; got != (k8s.TerminalSize{Width: 100, Height: 30}) {
+		t.Errorf("resized = %+v, want 100x30", got)
+	}
+
+	if _, ok := cmd().(TerminalExitMsg); !ok {
+		t.Error("the session should exit when its program returns")
+	}
+}
+
+func TestKeyBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       tea.KeyMsg
+		appCursor bool
+		paste     bool
+		want      string
+	}{
+		{"runes", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("é!")}, false, false, "é!"},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}, false, false, "\r"},
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}, false, false, "\x03"},
+		{"backspace", tea.KeyMsg{Type: tea.KeyBackspace}, false, false, "\x7f"},
+		{"tab", tea.KeyMsg{Type: tea.KeyTab}, false, false, "\t"},
+		{"space", tea.KeyMsg{Type: tea.KeySpace}, false, false, " "},
+		{"up", tea.KeyMsg{Type: tea.KeyUp}, false, false, "\x1b[A"},
+		{"up in app cursor mode", tea.KeyMsg{Type: tea.KeyUp}, true, false, "\x1bOA"},
+		{"delete", tea.KeyMsg{Type: tea.KeyDelete}, false, false, "\x1b[3~"},
+		{"alt+b", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b"), Alt: true}, false, false, "\x1bb"},
+		{"paste", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ls"), Paste: true}, false, false, "ls"},
+		{
+			"bracketed paste",
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ls"), Paste: true},
+			false, true, "\x1b[200~ls\x1b[201~",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(keyBytes(tt.msg, tt.appCursor, tt.paste)); got != tt.want {
+				t.Errorf("keyBytes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,916 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// vtScrollback caps the lines kept after they scroll off the main screen.
+const vtScrollback = 1000
+
+// vtTabWidth is the distance between the fixed tab stops.
+const vtTabWidth = 8
+
+// vtAttrs are the SGR rendition flags of a cell.
+type vtAttrs uint8
+
+const (
+	vtBold vtAttrs = 1 << iota
+	vtDim
+	vtItalic
+	vtUnderline
+	vtBlink
+	vtReverse
+	vtHidden
+	vtStrike
+)
+
+// vtColor is a cell color: the terminal default, one of the 256 indexed
+// colors or a 24-bit one.
+type vtColor struct {
+	kind  uint8
+	value uint32
+}
+
+const (
+	vtColorDefault uint8 = iota
+	vtColorIndexed
+	vtColorRGB
+)
+
+type vtStyle struct {
+	fg, bg vtColor
+	attrs  vtAttrs
+}
+
+// vtCell is one character cell. The right half of a wide character holds
+// no rune.
+type vtCell struct {
+	ch    rune
+	style vtStyle
+}
+
+type vtState uint8
+
+const (
+	vtGround vtState = iota
+	vtEscape
+	vtCharset
+	vtCSI
+	vtOSC
+	vtOSCEscape
+	vtString
+	vtStringEscape
+)
+
+// vtScreen emulates the VT100/xterm subset shells and full-screen tools
+// like vi, less and top use: cursor movement, erasing, scroll regions,
+// colors and the alternate screen. Output is fed to Write; what the
+// program asks the terminal to answer, like a cursor position report,
+// collects for TakeReplies.
+type vtScreen struct {
+	width  int
+	height int
+	cells  [][]vtCell
+
+	cursorX, cursorY int
+	wrapPending      bool
+	style            vtStyle
+
+	savedX, savedY int
+	savedStyle     vtStyle
+
+	scrollTop, scrollBottom int
+
+	cursorHidden   bool
+	appCursor      bool
+	noAutowrap     bool
+	bracketedPaste bool
+
+	// mainCells holds the main screen while the alternate one is shown
+	mainCells [][]vtCell
+	altActive bool
+
+	scrollback   [][]vtCell
+	scrollOffset int
+
+	state   vtState
+	params  []int
+	param   int
+	inParam bool
+	private byte
+	partial []byte
+	replies []byte
+}
+
+func newVTScreen(width, height int) *vtScreen {
+	s := &vtScreen{}
+	s.Resize(width, height)
+
+	return s
+}
+
+func blankCells(width int, style vtStyle) []vtCell {
+	line := make([]vtCell, width)
+	for i := range line {
+		line[i] = vtCell{ch: ' ', style: vtStyle{bg: style.bg}}
+	}
+
+	return line
+}
+
+func (s *vtScreen) blankLine() []vtCell {
+	return blankCells(s.width, s.style)
+}
+
+// Resize changes the screen size, keeping the text at the top left and the
+// cursor's line on screen.
+func (s *vtScreen) Resize(width, height int) {
+	width, height = max(width, 1), max(height, 1)
+	if width == s.width && height == s.height {
+		return
+	}
+
+	// Lines below the cursor go first, then the top ones scroll off
+	lines := s.cells
+	if len(lines) > height {
+		drop := max(s.cursorY+1-height, 0)
+		if !s.altActive {
+			s.pushScrollback(lines[:drop]...)
+		}
+
+		lines = lines[drop : drop+height]
+		s.cursorY -= drop
+	}
+
+	s.cells = resizeLines(lines, width, height)
+	if s.mainCells != nil {
+		s.mainCells = resizeLines(s.mainCells, width, height)
+	}
+
+	s.width, s.height = width, height
+	s.cursorX = min(s.cursorX, width-1)
+	s.cursorY = min(s.cursorY, height-1)
+	s.savedX = min(s.savedX, width-1)
+	s.savedY = min(s.savedY, height-1)
+	s.wrapPending = false
+	s.scrollTop, s.scrollBottom = 0, height-1
+}
+
+func resizeLines(lines [][]vtCell, width, height int) [][]vtCell {
+	out := make([][]vtCell, height)
+
+	for y := range out {
+		out[y] = blankCells(width, vtStyle{})
+		if y < len(lines) {
+			copy(out[y], lines[y])
+		}
+	}
+
+	return out
+}
+
+// TakeReplies returns and clears what the terminal has to send back to
+// the program.
+func (s *vtScreen) TakeReplies() []byte {
+	replies := s.replies
+	s.replies = nil
+
+	return replies
+}
+
+// Write interprets program output. It never fails.
+func (s *vtScreen) Write(p []byte) (int, error) {
+	data := p
+	if len(s.partial) > 0 {
+		data = append(s.partial, p...)
+		s.partial = nil
+	}
+
+	// Output scrolls back into view, as in most terminals
+	s.scrollOffset = 0
+
+	for len(data) > 0 {
+		b := data[0]
+
+		if s.state != vtGround || b < utf8.RuneSelf {
+			s.handleByte(b)
+			data = data[1:]
+
+			continue
+		}
+
+		if !utf8.FullRune(data) {
+			s.partial = append([]byte(nil), data...)
+
+			break
+		}
+
+		r, size := utf8.DecodeRune(data)
+		s.print(r)
+		data = data[size:]
+	}
+
+	return len(p), nil
+}
+
+func (s *vtScreen) handleByte(b byte) {
+	switch s.state {
+	case vtGround:
+		s.handleGround(b)
+	case vtEscape:
+		s.handleEscape(b)
+	case vtCharset:
+		// The designated character set is always ASCII here
+		s.state = vtGround
+	case vtCSI:
+		s.handleCSI(b)
+	case vtOSC, vtString:
+		switch b {
+		case 0x07:
+			s.state = vtGround
+		case 0x1b:
+			s.state++
+		}
+	case vtOSCEscape, vtStringEscape:
+		// ESC \ ends the string; anything else is part of it
+		if b == '\\' {
+			s.state = vtGround
+		} else {
+			s.state--
+		}
+	}
+}
+
+func (s *vtScreen) handleGround(b byte) {
+	switch b {
+	case 0x1b:
+		s.state = vtEscape
+	case '\r':
+		s.cursorX = 0
+		s.wrapPending = false
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\b':
+		if s.cursorX > 0 {
+			s.cursorX--
+		}
+
+		s.wrapPending = false
+	case '\t':
+		s.cursorX = min((s.cursorX/vtTabWidth+1)*vtTabWidth, s.width-1)
+	default:
+		if b >= 0x20 && b != 0x7f {
+			s.print(rune(b))
+		}
+	}
+}
+
+func (s *vtScreen) handleEscape(b byte) {
+	s.state = vtGround
+
+	switch b {
+	case '[':
+		s.state = vtCSI
+		s.params = s.params[:0]
+		s.param = 0
+		s.inParam = false
+		s.private = 0
+	case ']':
+		s.state = vtOSC
+	case 'P', '_', '^', 'X':
+		s.state = vtString
+	case '(', ')', '*', '+':
+		s.state = vtCharset
+	case '7':
+		s.saveCursor()
+	case '8':
+		s.restoreCursor()
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.cursorX = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		s.reset()
+	}
+}
+
+func (s *vtScreen) handleCSI(b byte) {
+	switch {
+	case b >= '0' && b <= '9':
+		s.param = s.param*10 + int(b-'0')
+		s.inParam = true
+	case b == ';' || b == ':':
+		s.params = append(s.params, s.paramValue())
+	case b >= '<' && b <= '?':
+		s.private = b
+	case b >= 0x20 && b <= 0x2f:
+		// Intermediates change nothing this emulator handles
+	case b >= 0x40 && b <= 0x7e:
+		if s.inParam || len(s.params) > 0 {
+			s.params = append(s.params, s.paramValue())
+		}
+
+		s.state = vtGround
+		s.dispatchCSI(b)
+	default:
+		s.state = vtGround
+	}
+}
+
+// paramValue ends the current parameter; an omitted one reads as -1.
+func (s *vtScreen) paramValue() int {
+	value := s.param
+	if !s.inParam {
+		value = -1
+	}
+
+	s.param = 0
+	s.inParam = false
+
+	return value
+}
+
+// arg returns parameter i, or def when it's omitted or zero.
+func (s *vtScreen) arg(i, def int) int {
+	if i >= len(s.params) || s.params[i] <= 0 {
+		return def
+	}
+
+	return s.params[i]
+}
+
+func (s *vtScreen) dispatchCSI(final byte) {
+	if s.private == '?' {
+		switch final {
+		case 'h':
+			s.setModes(true)
+		case 'l':
+			s.setModes(false)
+		}
+
+		return
+	}
+
+	if s.private != 0 {
+		return
+	}
+
+	n := s.arg(0, 1)
+
+	switch final {
+	case '@':
+		s.insertChars(n)
+	case 'A':
+		s.moveTo(s.cursorX, max(s.cursorY-n, s.topLimit()))
+	case 'B', 'e':
+		s.moveTo(s.cursorX, min(s.cursorY+n, s.bottomLimit()))
+	case 'C', 'a':
+		s.moveTo(s.cursorX+n, s.cursorY)
+	case 'D':
+		s.moveTo(s.cursorX-n, s.cursorY)
+	case 'E':
+		s.moveTo(0, min(s.cursorY+n, s.bottomLimit()))
+	case 'F':
+		s.moveTo(0, max(s.cursorY-n, s.topLimit()))
+	case 'G', '`':
+		s.moveTo(n-1, s.cursorY)
+	case 'H', 'f':
+		s.moveTo(s.arg(1, 1)-1, n-1)
+	case 'd':
+		s.moveTo(s.cursorX, n-1)
+	case 'J':
+		s.eraseDisplay(s.arg(0, 0))
+	case 'K':
+		s.eraseLine(s.arg(0, 0))
+	case 'L':
+		s.insertLines(n)
+	case 'M':
+		s.deleteLines(n)
+	case 'P':
+		s.deleteChars(n)
+	case 'X':
+		s.eraseChars(n)
+	case 'S':
+		s.scrollUp(s.scrollTop, s.scrollBottom, n)
+	case 'T':
+		s.scrollDown(s.scrollTop, s.scrollBottom, n)
+	case 'm':
+		s.setGraphics()
+	case 'r':
+		s.setScrollRegion(n-1, s.arg(1, s.height)-1)
+	case 's':
+		s.saveCursor()
+	case 'u':
+		s.restoreCursor()
+	case 'n':
+		if s.arg(0, 0) == 6 {
+			s.replies = append(s.replies,
+				"\x1b["+strconv.Itoa(s.cursorY+1)+";"+strconv.Itoa(s.cursorX+1)+"R"...)
+		}
+	case 'c':
+		// Primary device attributes: a VT100 with advanced video
+		s.replies = append(s.replies, "\x1b[?1;2c"...)
+	}
+}
+
+func (s *vtScreen) setModes(on bool) {
+	for _, mode := range s.params {
+		switch mode {
+		case 1:
+			s.appCursor = on
+		case 7:
+			s.noAutowrap = !on
+		case 25:
+			s.cursorHidden = !on
+		case 47, 1047:
+			s.setAltScreen(on)
+		case 1049:
+			if on {
+				s.saveCursor()
+				s.setAltScreen(true)
+				s.eraseDisplay(2)
+			} else {
+				s.setAltScreen(false)
+				s.restoreCursor()
+			}
+		case 2004:
+			s.bracketedPaste = on
+		}
+	}
+}
+
+func (s *vtScreen) setAltScreen(on bool) {
+	if on == s.altActive {
+		return
+	}
+
+	s.altActive = on
+
+	if on {
+		s.mainCells = s.cells
+		s.cells = resizeLines(nil, s.width, s.height)
+
+		return
+	}
+
+	s.cells = s.mainCells
+	s.mainCells = nil
+}
+
+func (s *vtScreen) reset() {
+	width, height := s.width, s.height
+	scrollback := s.scrollback
+
+	*s = vtScreen{scrollback: scrollback}
+	s.Resize(width, height)
+}
+
+func (s *vtScreen) saveCursor() {
+	s.savedX, s.savedY, s.savedStyle = s.cursorX, s.cursorY, s.style
+}
+
+func (s *vtScreen) restoreCursor() {
+	s.moveTo(s.savedX, s.savedY)
+	s.style = s.savedStyle
+}
+
+// topLimit and bottomLimit keep relative cursor movement inside the
+// scroll region when it starts there.
+func (s *vtScreen) topLimit() int {
+	if s.cursorY >= s.scrollTop {
+		return s.scrollTop
+	}
+
+	return 0
+}
+
+func (s *vtScreen) bottomLimit() int {
+	if s.cursorY <= s.scrollBottom {
+		return s.scrollBottom
+	}
+
+	return s.height - 1
+}
+
+func (s *vtScreen) moveTo(x, y int) {
+	s.cursorX = min(max(x, 0), s.width-1)
+	s.cursorY = min(max(y, 0), s.height-1)
+	s.wrapPending = false
+}
+
+func (s *vtScreen) print(r rune) {
+	width := runewidth.RuneWidth(r)
+	if width == 0 {
+		// Combining marks would need cells holding several runes
+		return
+	}
+
+	if s.wrapPending && !s.noAutowrap {
+		s.cursorX = 0
+		s.lineFeed()
+	}
+
+	s.wrapPending = false
+
+	if width == 2 && s.cursorX == s.width-1 {
+		if s.noAutowrap {
+			return
+		}
+
+		s.cells[s.cursorY][s.cursorX] = vtCell{ch: ' ', style: s.style}
+		s.cursorX = 0
+		s.lineFeed()
+	}
+
+	line := s.cells[s.cursorY]
+	line[s.cursorX] = vtCell{ch: r, style: s.style}
+
+	if width == 2 && s.cursorX+1 < s.width {
+		line[s.cursorX+1] = vtCell{style: s.style}
+	}
+
+	if s.cursorX+width >= s.width {
+		s.cursorX = s.width - 1
+		s.wrapPending = true
+	} else {
+		s.cursorX += width
+	}
+}
+
+func (s *vtScreen) lineFeed() {
+	s.wrapPending = false
+
+	switch {
+	case s.cursorY == s.scrollBottom:
+		s.scrollUp(s.scrollTop, s.scrollBottom, 1)
+	case s.cursorY < s.height-1:
+		s.cursorY++
+	}
+}
+
+func (s *vtScreen) reverseIndex() {
+	s.wrapPending = false
+
+	switch {
+	case s.cursorY == s.scrollTop:
+		s.scrollDown(s.scrollTop, s.scrollBottom, 1)
+	case s.cursorY > 0:
+		s.cursorY--
+	}
+}
+
+// scrollUp moves lines top..bottom up by n. Lines leaving the top of the
+// main screen are kept as scrollback.
+func (s *vtScreen) scrollUp(top, bottom, n int) {
+	n = min(n, bottom-top+1)
+
+	if top == 0 && !s.altActive {
+		s.pushScrollback(s.cells[:n]...)
+	}
+
+	copy(s.cells[top:], s.cells[top+n:bottom+1])
+
+	for y := bottom - n + 1; y <= bottom; y++ {
+		s.cells[y] = s.blankLine()
+	}
+}
+
+func (s *vtScreen) scrollDown(top, bottom, n int) {
+	n = min(n, bottom-top+1)
+
+	copy(s.cells[top+n:bottom+1], s.cells[top:bottom+1-n])
+
+	for y := top; y < top+n; y++ {
+		s.cells[y] = s.blankLine()
+	}
+}
+
+func (s *vtScreen) pushScrollback(lines ...[]vtCell) {
+	s.scrollback = append(s.scrollback, lines...)
+	if over := len(s.scrollback) - vtScrollback; over > 0 {
+		s.scrollback = s.scrollback[over:]
+	}
+}
+
+func (s *vtScreen) setScrollRegion(top, bottom int) {
+	bottom = min(bottom, s.height-1)
+	if top >= bottom {
+		return
+	}
+
+	s.scrollTop, s.scrollBottom = top, bottom
+	s.moveTo(0, 0)
+}
+
+func (s *vtScreen) insertLines(n int) {
+	if s.cursorY < s.scrollTop || s.cursorY > s.scrollBottom {
+		return
+	}
+
+	s.scrollDown(s.cursorY, s.scrollBottom, n)
+	s.cursorX = 0
+}
+
+func (s *vtScreen) deleteLines(n int) {
+	if s.cursorY < s.scrollTop || s.cursorY > s.scrollBottom {
+		return
+	}
+
+	// Deleted lines aren't scrollback, even at the top of the screen
+	n = min(n, s.scrollBottom-s.cursorY+1)
+
+	copy(s.cells[s.cursorY:], s.cells[s.cursorY+n:s.scrollBottom+1])
+
+	for y := s.scrollBottom - n + 1; y <= s.scrollBottom; y++ {
+		s.cells[y] = s.blankLine()
+	}
+
+	s.cursorX = 0
+}
+
+func (s *vtScreen) blank() vtCell {
+	return vtCell{ch: ' ', style: vtStyle{bg: s.style.bg}}
+}
+
+func (s *vtScreen) insertChars(n int) {
+	line := s.cells[s.cursorY]
+	n = min(n, s.width-s.cursorX)
+
+	copy(line[s.cursorX+n:], line[s.cursorX:])
+
+	for x := s.cursorX; x < s.cursorX+n; x++ {
+		line[x] = s.blank()
+	}
+}
+
+func (s *vtScreen) deleteChars(n int) {
+	line := s.cells[s.cursorY]
+	n = min(n, s.width-s.cursorX)
+
+	copy(line[s.cursorX:], line[s.cursorX+n:])
+
+	for x := s.width - n; x < s.width; x++ {
+		line[x] = s.blank()
+	}
+}
+
+func (s *vtScreen) eraseChars(n int) {
+	s.eraseRange(s.cursorY, s.cursorX, min(s.cursorX+n, s.width))
+}
+
+func (s *vtScreen) eraseRange(y, from, to int) {
+	for x := from; x < to; x++ {
+		s.cells[y][x] = s.blank()
+	}
+}
+
+func (s *vtScreen) eraseLine(mode int) {
+	switch mode {
+	case 0:
+		s.eraseRange(s.cursorY, s.cursorX, s.width)
+	case 1:
+		s.eraseRange(s.cursorY, 0, s.cursorX+1)
+	case 2:
+		s.eraseRange(s.cursorY, 0, s.width)
+	}
+}
+
+func (s *vtScreen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+
+		for y := s.cursorY + 1; y < s.height; y++ {
+			s.eraseRange(y, 0, s.width)
+		}
+	case 1:
+		for y := range s.cursorY {
+			s.eraseRange(y, 0, s.width)
+		}
+
+		s.eraseLine(1)
+	case 2:
+		for y := range s.height {
+			s.eraseRange(y, 0, s.width)
+		}
+	case 3:
+		s.scrollback = nil
+	}
+}
+
+func (s *vtScreen) setGraphics() {
+	if len(s.params) == 0 {
+		s.style = vtStyle{}
+
+		return
+	}
+
+	for i := 0; i < len(s.params); i++ {
+		p := s.params[i]
+
+		switch {
+		case p <= 0:
+			s.style = vtStyle{}
+		case p == 1:
+			s.style.attrs |= vtBold
+		case p == 2:
+			s.style.attrs |= vtDim
+		case p == 3:
+			s.style.attrs |= vtItalic
+		case p == 4:
+			s.style.attrs |= vtUnderline
+		case p == 5 || p == 6:
+			s.style.attrs |= vtBlink
+		case p == 7:
+			s.style.attrs |= vtReverse
+		case p == 8:
+			s.style.attrs |= vtHidden
+		case p == 9:
+			s.style.attrs |= vtStrike
+		case p == 21 || p == 22:
+			s.style.attrs &^= vtBold | vtDim
+		case p == 23:
+			s.style.attrs &^= vtItalic
+		case p == 24:
+			s.style.attrs &^= vtUnderline
+		case p == 25:
+			s.style.attrs &^= vtBlink
+		case p == 27:
+			s.style.attrs &^= vtReverse
+		case p == 28:
+			s.style.attrs &^= vtHidden
+		case p == 29:
+			s.style.attrs &^= vtStrike
+		case p >= 30 && p <= 37:
+			s.style.fg = vtColor{vtColorIndexed, uint32(p - 30)}
+		case p == 38:
+			i = s.extendedColor(i, &s.style.fg)
+		case p == 39:
+			s.style.fg = vtColor{}
+		case p >= 40 && p <= 47:
+			s.style.bg = vtColor{vtColorIndexed, uint32(p - 40)}
+		case p == 48:
+			i = s.extendedColor(i, &s.style.bg)
+		case p == 49:
+			s.style.bg = vtColor{}
+		case p >= 90 && p <= 97:
+			s.style.fg = vtColor{vtColorIndexed, uint32(p - 90 + 8)}
+		case p >= 100 && p <= 107:
+			s.style.bg = vtColor{vtColorIndexed, uint32(p - 100 + 8)}
+		}
+	}
+}
+
+// extendedColor reads a 38/48 color, 5;n or 2;r;g;b, starting at the
+// parameter at i, returning the index of the last one used.
+func (s *vtScreen) extendedColor(i int, color *vtColor) int {
+	switch s.arg(i+1, 0) {
+	case 5:
+		*color = vtColor{vtColorIndexed, uint32(min(max(s.arg(i+2, 0), 0), 255))}
+
+		return i + 2
+	case 2:
+		r, g, b := s.arg(i+2, 0), s.arg(i+3, 0), s.arg(i+4, 0)
+		*color = vtColor{vtColorRGB, uint32(min(r, 255))<<16 | uint32(min(g, 255))<<8 | uint32(min(b, 255))}
+
+		return i + 4
+	}
+
+	return len(s.params)
+}
+
+// Scroll moves the view into the scrollback by delta lines, positive being
+// further back.
+func (s *vtScreen) Scroll(delta int) {
+	s.scrollOffset = min(max(s.scrollOffset+delta, 0), len(s.scrollback))
+}
+
+// ScrollOffset is how many lines back into the scrollback the view is.
+func (s *vtScreen) ScrollOffset() int {
+	return s.scrollOffset
+}
+
+// View renders the screen, or the part of the scrollback scrolled to, as
+// lines of styled text, with the cursor shown when showCursor is set.
+func (s *vtScreen) View(showCursor bool) string {
+	lines := s.cells
+	cursorY := s.cursorY
+
+	if s.scrollOffset > 0 {
+		back := s.scrollback[len(s.scrollback)-s.scrollOffset:]
+		lines = append(append([][]vtCell(nil), back...), s.cells...)[:s.height]
+		cursorY = -1
+	}
+
+	if !showCursor || s.cursorHidden {
+		cursorY = -1
+	}
+
+	var b strings.Builder
+
+	for y, line := range lines {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+
+		cursorX := -1
+		if y == cursorY {
+			cursorX = s.cursorX
+		}
+
+		renderLine(&b, line, cursorX)
+	}
+
+	return b.String()
+}
+
+// Text is the screen's text without styling, lines trimmed, for tests and
+// searching.
+func (s *vtScreen) Text() string {
+	lines := make([]string, len(s.cells))
+
+	for y, line := range s.cells {
+		var b strings.Builder
+
+		for _, cell := range line {
+			if cell.ch != 0 {
+				b.WriteRune(cell.ch)
+			}
+		}
+
+		lines[y] = strings.TrimRight(b.String(), " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderLine(b *strings.Builder, line []vtCell, cursorX int) {
+	current := vtStyle{}
+
+	for x, cell := range line {
+		if cell.ch == 0 {
+			continue
+		}
+
+		style := cell.style
+		if x == cursorX {
+			style.attrs ^= vtReverse
+		}
+
+		if style != current {
+			b.WriteString(sgr(style))
+			current = style
+		}
+
+		b.WriteRune(cell.ch)
+	}
+
+	if current != (vtStyle{}) {
+		b.WriteString("\x1b[0m")
+	}
+}
+
+// sgr is the escape sequence switching to style from any other.
+func sgr(style vtStyle) string {
+	codes := []string{"0"}
+
+	for i, code := range []string{"1", "2", "3", "4", "5", "7", "8", "9"} {
+		if style.attrs&(1<<i) != 0 {
+			codes = append(codes, code)
+		}
+	}
+
+	codes = appendColor(codes, style.fg, 30)
+	codes = appendColor(codes, style.bg, 40)
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+func appendColor(codes []string, color vtColor, base int) []string {
+	switch color.kind {
+	case vtColorDefault:
+	case vtColorIndexed:
+		switch {
+		case color.value < 8:
+			return append(codes, strconv.Itoa(base+int(color.value)))
+		case color.value < 16:
+			return append(codes, strconv.Itoa(base+60+int(color.value)-8))
+		default:
+			return append(codes, strconv.Itoa(base+8), "5", strconv.Itoa(int(color.value)))
+		}
+	case vtColorRGB:
+		return append(codes, strconv.Itoa(base+8), "2",
+			strconv.Itoa(int(color.value>>16&0xff)),
+			strconv.Itoa(int(color.value>>8&0xff)),
+			strconv.Itoa(int(color.value&0xff)))
+	}
+
+	return codes
+}
@@ -0,0 +1,200 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func writeScreen(s *vtScreen, output string) {
+	_, _ = s.Write([]byte(output))
+}
+
+func screenLines(s *vtScreen) []string {
+	return strings.Split(s.Text(), "\n")
+}
+
+func TestVTScreen_PrintsAndWraps(t *testing.T) {
+	s := newVTScreen(5, 4)
+	writeScreen(s, "hello world\r\nok")
+
+	want := "hello| worl|d|ok"
+	if got := strings.Join(screenLines(s), "|"); got != want {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestVTScreen_ScrollsIntoScrollback(t *testing.T) {
+	s := newVTScreen(10, 2)
+	writeScreen(s, "one\r\ntwo\r\nthree")
+
+	if got := screenLines(s); got[0] != "two" || got[1] != "three" {
+		t.Fatalf("lines = %q, want two, three", got)
+	}
+
+	s.Scroll(1)
+
+	if view := s.View(false); !strings.HasPrefix(view, "one") {
+		t.Errorf("scrolled view = %q, want it to start with one", view)
+	}
+
+	writeScreen(s, "!")
+
+	if s.ScrollOffset() != 0 {
+		t.Error("output should scroll the view back to the screen")
+	}
+}
+
+func TestVTScreen_CursorMovementAndErase(t *testing.T) {
+	s := newVTScreen(10, 3)
+	writeScreen(s, "abcdefghij\r\n0123456789")
+
+	// Up one line, to column 3, erase to the end of the line
+	writeScreen(s, "\x1b[A\x1b[3G\x1b[K")
+
+	if got := screenLines(s)[0]; got != "ab" {
+		t.Errorf("after EL line 0 = %q, want ab", got)
+	}
+
+	// Home, then erase the whole display
+	writeScreen(s, "\x1b[H\x1b[2J")
+
+	if strings.TrimSpace(s.Text()) != "" {
+		t.Errorf("after ED 2 the screen should be blank, got %q", s.Text())
+	}
+
+	writeScreen(s, "\x1b[2;4Hx")
+
+	if got := screenLines(s)[1]; got != "   x" {
+		t.Errorf("CUP 2;4 wrote %q, want x in column 4 of line 2", got)
+	}
+}
+
+func TestVTScreen_InsertDeleteChars(t *testing.T) {
+	s := newVTScreen(10, 1)
+	writeScreen(s, "abcdef\x1b[1G\x1b[2P")
+
+	if got := s.Text(); got != "cdef" {
+		t.Errorf("after DCH 2 = %q, want cdef", got)
+	}
+
+	writeScreen(s, "\x1b[2@")
+
+	if got := s.Text(); got != "  cdef" {
+		t.Errorf("after ICH 2 = %q, want two blanks before cdef", got)
+	}
+}
+
+func TestVTScreen_ScrollRegion(t *testing.T) {
+	s := newVTScreen(10, 4)
+	writeScreen(s, "head\r\na\r\nb\r\nfoot")
+
+	// Scroll lines 2-3 only, as pagers do under a status line
+	writeScreen(s, "\x1b[2;3r\x1b[3;1H\nc")
+
+	want := "head|b|c|foot"
+	if got := strings.Join(screenLines(s), "|"); got != want {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestVTScreen_AlternateScreen(t *testing.T) {
+	s := newVTScreen(10, 2)
+	writeScreen(s, "$ vi")
+	writeScreen(s, "\x1b[?1049h\x1b[H~ editor")
+
+	if got := screenLines(s)[0]; got != "~ editor" {
+		t.Fatalf("alternate screen line 0 = %q", got)
+	}
+
+	writeScreen(s, "\x1b[?1049l")
+
+	if got := screenLines(s)[0]; got != "$ vi" {
+		t.Errorf("leaving the alternate screen should restore %q, got %q", "$ vi", got)
+	}
+}
+
+func TestVTScreen_Colors(t *testing.T) {
+	s := newVTScreen(10, 1)
+	writeScreen(s, "\x1b[1;31mred\x1b[0m \x1b[38;5;200mx\x1b[48;2;1;2;3my")
+
+	view := s.View(false)
+
+	for _, want := range []string{"\x1b[0;1;31mred", "\x1b[0;38;5;200mx", "\x1b[0;38;5;200;48;2;1;2;3my"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view %q should contain %q", view, want)
+		}
+	}
+}
+
+func TestVTScreen_CursorShownReversed(t *testing.T) {
+	s := newVTScreen(5, 1)
+	writeScreen(s, "ab")
+
+	if view := s.View(true); !strings.Contains(view, "\x1b[0;7m ") {
+		t.Errorf("cursor cell should render reversed, got %q", view)
+	}
+
+	writeScreen(s, "\x1b[?25l")
+
+	if view := s.View(true); strings.Contains(view, "\x1b[0;7m") {
+		t.Error("a hidden cursor shouldn't render")
+	}
+}
+
+func TestVTScreen_RepliesToQueries(t *testing.T) {
+	s := newVTScreen(10, 5)
+	writeScreen(s, "\x1b[3;4H\x1b[6n")
+
+	if got := string(s.TakeReplies()); got != "\x1b[3;4R" {
+		t.Errorf("cursor position report = %q", got)
+	}
+
+	if s.TakeReplies() != nil {
+		t.Error("replies should be taken once")
+	}
+}
+
+func TestVTScreen_SplitSequencesAndRunes(t *testing.T) {
+	s := newVTScreen(10, 1)
+
+	// A CSI and a multi-byte rune split across writes
+	writeScreen(s, "\x1b[")
+	writeScreen(s, "31mé"[:4])
+	writeScreen(s, "31mé"[4:])
+
+	if got := s.Text(); got != "é" {
+		t.Errorf("text = %q, want é", got)
+	}
+
+	// Window titles are swallowed
+	writeScreen(s, "\x1b]0;title\x07!")
+
+	if got := s.Text(); got != "é!" {
+		t.Errorf("text = %q, want é!", got)
+	}
+}
+
+func TestVTScreen_WideRunes(t *testing.T) {
+	s := newVTScreen(3, 2)
+	writeScreen(s, "a世界")
+
+	// 界 doesn't fit after a世 and wraps whole
+	if got := screenLines(s); got[0] != "a世" || got[1] != "界" {
+		t.Errorf("lines = %q", got)
+	}
+}
+
+func TestVTScreen_ResizeKeepsCursorLine(t *testing.T) {
+	s := newVTScreen(10, 4)
+	writeScreen(s, "1\r\n2\r\n3\r\n4")
+
+	s.Resize(6, 2)
+
+	if got := screenLines(s); got[0] != "3" || got[1] != "4" {
+		t.Errorf("after shrinking lines = %q, want 3, 4", got)
+	}
+
+	if s.cursorY != 1 {
+		t.Errorf("cursor line = %d, want 1", s.cursorY)
+	}
+}
@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+// configMapSizeWarning is the share of k8s.ConfigMapSizeLimit above which
+// creating a configmap asks for confirmation first.
+const configMapSizeWarning = 0.9
+
+func (m *Model) editConfigMapKey(msg panels.EditConfigMapKeyRequestMsg) tea.Cmd {
+	// A new key is added even with an empty value
+	unchanged := ""
+	if !msg.IsNew {
+		unchanged = fmt.Sprintf("No changes to %s in %s", msg.Key, msg.ConfigMapName)
+	}
+
+	return m.editValue(
+		fmt.Sprintf("lazy-k8s-%s-%s-*", msg.ConfigMapName, msg.Key),
+		[]byte(msg.Value),
+		unchanged,
+		func(value []byte) tea.Msg {
+			return m.saveConfigMapKey(
+				msg.Namespace, msg.ConfigMapName, msg.Key, string(value), msg.IsNew,
+			)
+		},
+	)
+}
+
+func (m *Model) saveConfigMapKey(namespace, name, key, value string, isNew bool) tea.Msg {
+	ctx := context.Background()
+
+	if err := m.k8sClient.SetConfigMapKey(ctx, namespace, name, key, value); err != nil {
+		return panels.ErrorMsg{Error: fmt.Errorf("failed to update configmap: %w", err)}
+	}
+
+	action := "Updated"
+	if isNew {
+		action = "Added"
+	}
+
+	m.historyStore.Add(components.OperationRecord{
+		Type:      components.OpEditConfigMap,
+		Resource:  name,
+		Namespace: namespace,
+		Message:   fmt.Sprintf("%s key %s in configmap %s", action, key, name),
+	})
+
+	return panels.StatusWithRefreshMsg{
+		Message: fmt.Sprintf("%s %s in configmap %s", action, key, name),
+	}
+}
+
+// promptAddConfigMapKey asks for the new key's name, then opens an empty
+// value in the editor.
+func (m *Model) promptAddConfigMapKey(msg panels.AddConfigMapKeyRequestMsg) {
+	m.showInput(
+		"Add Key: "+msg.ConfigMapName,
+		"Name of the new key",
+		"config.yaml",
+		func(value string) tea.Cmd {
+			key := strings.TrimSpace(value)
+			if err := validateNewConfigMapKey(key, msg.ExistingKeys); err != nil {
+				return errorCmd(err)
+			}
+
+			return m.editConfigMapKey(panels.EditConfigMapKeyRequestMsg{
+				ConfigMapName: msg.ConfigMapName,
+				Namespace:     msg.Namespace,
+				Key:           key,
+				IsNew:         true,
+			})
+		},
+	)
+}
+
+func (m *Model) promptRenameConfigMapKey(msg panels.RenameConfigMapKeyRequestMsg) {
+	m.showInput(
+		"Rename Key: "+msg.Key,
+		fmt.Sprintf("New name for %s in %s", msg.Key, msg.ConfigMapName),
+		msg.Key,
+		func(value string) tea.Cmd {
+			newKey := strings.TrimSpace(value)
+			if newKey == msg.Key {
+				return nil
+			}
+
+			if err := validateNewConfigMapKey(newKey, msg.ExistingKeys); err != nil {
+				return errorCmd(err)
+			}
+
+			return m.renameConfigMapKey(msg.Namespace, msg.ConfigMapName, msg.Key, newKey)
+		},
+	)
+	m.input.SetValue(msg.Key)
+}
+
+// validateNewConfigMapKey checks the name given for an added or renamed
+// key before the configmap is touched.
+func validateNewConfigMapKey(key string, existing []string) error {
+	if key == "" {
+		return ErrEmptyName
+	}
+
+	if slices.Contains(existing, key) {
+		return fmt.Errorf("%w: %s", k8s.ErrConfigMapKeyExists, key)
+	}
+
+	return k8s.ValidateConfigMapKey(key)
+}
+
+func (m *Model) renameConfigMapKey(namespace, name, oldKey, newKey string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if err := m.k8sClient.RenameConfigMapKey(ctx, namespace, name, oldKey, newKey); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to rename key: %w", err)}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpEditConfigMap,
+			Resource:  name,
+			Namespace: namespace,
+			Message:   fmt.Sprintf("Renamed key %s to %s in configmap %s", oldKey, newKey, name),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Renamed %s to %s in configmap %s", oldKey, newKey, name),
+		}
+	}
+}
+
+func (m *Model) confirmRemoveConfigMapKey(msg panels.RemoveConfigMapKeyRequestMsg) {
+	m.confirm.Show(
+		fmt.Sprintf("Remove %s?", msg.Key),
+		fmt.Sprintf(
+			"Are you sure you want to remove key %s from configmap %s?",
+			msg.Key, msg.ConfigMapName,
+		),
+		func() tea.Cmd {
+			return m.removeConfigMapKey(msg.Namespace, msg.ConfigMapName, msg.Key)
+		},
+	)
+	m.viewMode = ViewConfirm
+}
+
+func (m *Model) removeConfigMapKey(namespace, name, key string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if err := m.k8sClient.RemoveConfigMapKey(ctx, namespace, name, key); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to remove key: %w", err)}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpEditConfigMap,
+			Resource:  name,
+			Namespace: namespace,
+			Message:   fmt.Sprintf("Removed key %s from configmap %s", key, name),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Removed %s from configmap %s", key, name),
+		}
+	}
+}
+
+// startCreateConfigMap prompts for a name and then the data sources, which
+// may be literals, single files or whole directories.
+func (m *Model) startCreateConfigMap(namespace string) {
+	m.showInput(
+		"Create ConfigMap",
+		"Name of the new configmap in "+namespace,
+		"my-config",
+		func(value string) tea.Cmd {
+			name := strings.TrimSpace(value)
+			if name == "" {
+				return errorCmd(ErrEmptyName)
+			}
+
+			m.promptConfigMapData(namespace, name)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptConfigMapData(namespace, name string) {
+	m.showInput(
+		"Create ConfigMap: "+name,
+		"Data as key=value, key=@file, @file or @dir (space separated)",
+		"env=prod @./config/",
+		func(value string) tea.Cmd {
+			data, err := k8s.ReadDataSources(value)
+			if err != nil {
+				return errorCmd(err)
+			}
+
+			size := k8s.DataSize(data)
+			if size > k8s.ConfigMapSizeLimit {
+				return errorCmd(fmt.Errorf(
+					"%w: %s", k8s.ErrConfigMapTooLarge, utils.FormatBytesShort(int64(size)),
+				))
+			}
+
+			cm := k8s.NewConfigMap(namespace, name, data)
+
+			if float64(size) < configMapSizeWarning*k8s.ConfigMapSizeLimit {
+				return m.createConfigMap(cm)
+			}
+
+			m.confirm.Show(
+				fmt.Sprintf("Create %s?", name),
+				fmt.Sprintf(
+					"The data is %s, close to the 1MiB configmap limit. Create anyway?",
+					utils.FormatBytesShort(int64(size)),
+				),
+				func() tea.Cmd {
+					return m.createConfigMap(cm)
+				},
+			)
+			m.viewMode = ViewConfirm
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) createConfigMap(cm *corev1.ConfigMap) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if _, err := m.k8sClient.CreateConfigMap(ctx, cm); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to create configmap: %w", err)}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpCreateConfigMap,
+			Resource:  cm.Name,
+			Namespace: cm.Namespace,
+			Message: fmt.Sprintf(
+				"Created configmap %s with %d keys",
+				cm.Name, len(cm.Data)+len(cm.BinaryData),
+			),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Created configmap: %s", cm.Name),
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func createConfigMapTestModel() *Model {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+	m.confirm = components.NewConfirm(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Data:       map[string]string{"env": "prod"},
+		},
+	))
+
+	return m
+}
+
+func TestCreateConfigMapFromDirectory(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("port: 80\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Update(panels.CreateConfigMapRequestMsg{Namespace: "default"})
+
+	submitInput(t, m, "web-config")
+	result := submitInput(t, m, "mode=fast @"+dir)
+
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T: %v", result, result)
+	}
+
+	cm, err := m.k8sClient.GetConfigMap(t.Context(), "default", "web-config")
+	if err != nil {
+		t.Fatalf("configmap was not created: %v", err)
+	}
+
+	if cm.Data["app.yaml"] != "port: 80\n" || cm.Data["mode"] != "fast" {
+		t.Errorf("unexpected data: %v", cm.Data)
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpCreateConfigMap {
+		t.Errorf("expected an OpCreateConfigMap history record, got %+v", rec)
+	}
+}
+
+func TestCreateConfigMapNearLimitAsksForConfirmation(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	path := filepath.Join(t.TempDir(), "big.txt")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 1000*1024)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Update(panels.CreateConfigMapRequestMsg{Namespace: "default"})
+
+	submitInput(t, m, "big")
+	if result := submitInput(t, m, "@"+path); result != nil {
+		t.Fatalf("expected no command before confirming, got %T", result)
+	}
+
+	if m.viewMode != ViewConfirm {
+		t.Fatalf("expected ViewConfirm, got %d", m.viewMode)
+	}
+
+	if _, err := m.k8sClient.GetConfigMap(t.Context(), "default", "big"); err == nil {
+		t.Error("configmap should not be created before confirming")
+	}
+}
+
+func TestCreateConfigMapOverLimit(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	path := filepath.Join(t.TempDir(), "huge.txt")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", k8s.ConfigMapSizeLimit)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Update(panels.CreateConfigMapRequestMsg{Namespace: "default"})
+
+	submitInput(t, m, "huge")
+	result := submitInput(t, m, "@"+path)
+
+	errMsg, ok := result.(panels.ErrorMsg)
+	if !ok || !errors.Is(errMsg.Error, k8s.ErrConfigMapTooLarge) {
+		t.Fatalf("expected ErrConfigMapTooLarge, got %+v", result)
+	}
+}
+
+func TestAddConfigMapKeyRejectsExisting(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	m.Update(panels.AddConfigMapKeyRequestMsg{
+		ConfigMapName: "app",
+		Namespace:     "default",
+		ExistingKeys:  []string{"env"},
+	})
+
+	result := submitInput(t, m, "env")
+
+	errMsg, ok := result.(panels.ErrorMsg)
+	if !ok || !errors.Is(errMsg.Error, k8s.ErrConfigMapKeyExists) {
+		t.Fatalf("expected ErrConfigMapKeyExists, got %+v", result)
+	}
+}
+
+func TestRenameConfigMapKeyFlow(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	m.Update(panels.RenameConfigMapKeyRequestMsg{
+		ConfigMapName: "app",
+		Namespace:     "default",
+		Key:           "env",
+	})
+
+	result := submitInput(t, m, "environment")
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T: %v", result, result)
+	}
+
+	cm, err := m.k8sClient.GetConfigMap(t.Context(), "default", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cm.Data["environment"] != "prod" {
+		t.Errorf("key was not renamed: %v", cm.Data)
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpEditConfigMap {
+		t.Errorf("expected an OpEditConfigMap history record, got %+v", rec)
+	}
+}
+
+func TestRenameConfigMapKeyValidatesName(t *testing.T) {
+	for _, tc := range []struct {
+		newKey string
+		want   error
+	}{
+		{"level", k8s.ErrConfigMapKeyExists},
+		{"bad key", k8s.ErrInvalidConfigMapKey},
+	} {
+		m := createConfigMapTestModel()
+
+		m.Update(panels.RenameConfigMapKeyRequestMsg{
+			ConfigMapName: "app",
+			Namespace:     "default",
+			Key:           "env",
+			ExistingKeys:  []string{"env", "level"},
+		})
+
+		result := submitInput(t, m, tc.newKey)
+
+		errMsg, ok := result.(panels.ErrorMsg)
+		if !ok || !errors.Is(errMsg.Error, tc.want) {
+			t.Errorf("renaming to %q: expected %v, got %+v", tc.newKey, tc.want, result)
+		}
+	}
+}
+
+func TestRemoveConfigMapKeyRequiresConfirmation(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	m.Update(panels.RemoveConfigMapKeyRequestMsg{
+		ConfigMapName: "app",
+		Namespace:     "default",
+		Key:           "env",
+	})
+
+	if m.viewMode != ViewConfirm {
+		t.Fatalf("expected ViewConfirm, got %d", m.viewMode)
+	}
+
+	result := m.removeConfigMapKey("default", "app", "env")()
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T: %v", result, result)
+	}
+
+	cm, err := m.k8sClient.GetConfigMap(t.Context(), "default", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cm.Data["env"]; ok {
+		t.Error("env should have been removed")
+	}
+}
+
+func TestSaveConfigMapKeyAddsNewKey(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	result := m.saveConfigMapKey("default", "app", "region", "eu", true)
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T", result)
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || !strings.HasPrefix(rec.Message, "Added key region") {
+		t.Errorf("unexpected history record: %+v", rec)
+	}
+}
+
+func TestAddConfigMapKeyWithEmptyValue(t *testing.T) {
+	m := createConfigMapTestModel()
+
+	path := filepath.Join(t.TempDir(), "value")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// An added key left empty in the editor is still added
+	result := finishEdit(path, nil, "", func(value []byte) tea.Msg {
+		return m.saveConfigMapKey("default", "app", "placeholder", string(value), true)
+	})
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %+v", result)
+	}
+
+	cm, err := m.k8sClient.Clientset().CoreV1().ConfigMaps("default").Get(t.Context(), "app", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, ok := cm.Data["placeholder"]; !ok || value != "" {
+		t.Errorf("expected an empty placeholder key, got %q (present %v)", value, ok)
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// shellPodCleanupTimeout bounds deleting a pod created for a shell, like
+// a debug copy, once the shell has ended, quitting included.
+const shellPodCleanupTimeout = 10 * time.Second
+
+// debugOption is a way to debug a container, listed in the container
+// selector by its label.
+type debugOption struct {
+	label     string
+	container string
+	copyPod   bool
+}
+
+// debugOptions offers an ephemeral container and a pod copy for each
+// container, ephemeral ones first as they leave the pod running as is.
+func debugOptions(containers []string) []debugOption {
+	options := make([]debugOption, 0, 2*len(containers))
+
+	for _, container := range containers {
+		options = append(options, debugOption{label: container + ": ephemeral container", container: container})
+	}
+
+	for _, container := range containers {
+		options = append(options, debugOption{label: container + ": copy of pod", container: container, copyPod: true})
+	}
+
+	return options
+}
+
+// showDebugOptions lets the user pick the container to debug and how.
+func (m *Model) showDebugOptions(msg panels.DebugRequestMsg) {
+	m.debugOptions = debugOptions(msg.Containers)
+
+	labels := make([]string, len(m.debugOptions))
+	for i, option := range m.debugOptions {
+		labels[i] = option.label
+	}
+
+	m.execContainers = labels
+	m.execPodName = msg.PodName
+	m.execNamespace = msg.Namespace
+	m.execDebug = true
+	m.selectIdx = 0
+	m.switchFilter = ""
+	m.switchFiltered = labels
+	m.viewMode = ViewContainerSelect
+}
+
+func (m *Model) debugImage() string {
+	if m.config == nil || m.config.Debug.Image == "" {
+		return config.DefaultDebugConfig().Image
+	}
+
+	return m.config.Debug.Image
+}
+
+// debugPod opens a shell in the debug image for the option picked by its
+// label.
+func (m *Model) debugPod(namespace, podName, label string) tea.Cmd {
+	idx := slices.IndexFunc(m.debugOptions, func(o debugOption) bool { return o.label == label })
+	if idx < 0 {
+		return nil
+	}
+
+	option := m.debugOptions[idx]
+	image := m.debugImage()
+
+	m.historyStore.Add(components.OperationRecord{
+		Type:      components.OpExec,
+		Resource:  podName,
+		Namespace: namespace,
+		Message:   fmt.Sprintf("Debug %s (container: %s, image: %s)", podName, option.container, image),
+	})
+
+	if option.copyPod {
+		return m.openTerminal(podName+"/"+option.container+" copy", m.debugCopyRunner(
+			namespace, podName, option.container, image,
+		))
+	}
+
+	return m.openTerminal(podName+"/"+option.container+" debug", m.debugContainerRunner(
+		namespace, podName, option.container, image,
+	))
+}
+
+// debugContainerRunner adds an ephemeral container sharing the target's
+// processes and attaches to it. Ephemeral containers can't be removed;
+// the container stops when its shell exits.
+func (m *Model) debugContainerRunner(namespace, podName, target, image string) components.TerminalRunner {
+	client := m.k8sClient.Pinned()
+
+	return func(ctx context.Context, stdin io.Reader, stdout io.Writer, resize <-chan k8s.TerminalSize) error {
+		name, err := client.AddDebugContainer(ctx, namespace, podName, target, image)
+		if err != nil {
+			return err
+		}
+
+		return waitAndAttach(ctx, client, k8s.TerminalOptions{
+			Namespace: namespace,
+			Pod:       podName,
+			Container: name,
+			Stdin:     stdin,
+			Stdout:    stdout,
+			Resize:    resize,
+		})
+	}
+}
+
+// debugCopyRunner creates a copy of the pod with the container running a
+// shell in image, attaches to it and deletes the copy when the session
+// ends. The session keeps to the cluster it started in, so the copy is
+// deleted there even after a context switch.
+func (m *Model) debugCopyRunner(namespace, podName, container, image string) components.TerminalRunner {
+	client := m.k8sClient.Pinned()
+
+	return func(ctx context.Context, stdin io.Reader, stdout io.Writer, resize <-chan k8s.TerminalSize) error {
+		debugCopy, err := client.CreateDebugCopy(ctx, namespace, podName, container, image)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			// The session's context is over by now
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), shellPodCleanupTimeout)
+			defer cancel()
+
+			_ = client.DeletePod(cleanupCtx, debugCopy.Namespace, debugCopy.Name)
+		}()
+
+		return waitAndAttach(ctx, client, k8s.TerminalOptions{
+			Namespace: debugCopy.Namespace,
+			Pod:       debugCopy.Name,
+			Container: container,
+			Stdin:     stdin,
+			Stdout:    stdout,
+			Resize:    resize,
+		})
+	}
+}
+
+// waitAndAttach attaches to a container once it is running, telling the
+// user what it is waiting for meanwhile.
+func waitAndAttach(ctx context.Context, client *k8s.Client, opts k8s.TerminalOptions) error {
+	fmt.Fprintf(opts.Stdout, "Waiting for %s in %s to start...\r\n", opts.Container, opts.Pod)
+
+	if err := client.WaitForContainer(ctx, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return err
+	}
+
+	// Attaching shows nothing until the shell prints again
+	fmt.Fprint(opts.Stdout, "Attached; press enter if no prompt shows.\r\n")
+
+	return client.AttachTTY(ctx, opts)
+}
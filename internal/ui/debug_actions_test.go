@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func createDebugTestModel(t *testing.T) (*Model, *fake.Clientset) {
+	t.Helper()
+
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", Image: "gcr.io/distroless/static"},
+			{Name: "proxy", Image: "envoy"},
+		}},
+	})
+
+	m := createMouseTestModel(t)
+	m.k8sClient = k8s.NewTestClient(clientset)
+	m.config = &config.Config{Debug: config.DebugConfig{Image: "nicolaka/netshoot"}}
+
+	m.Update(panels.DebugRequestMsg{PodName: "web", Namespace: "default", Containers: []string{"app", "proxy"}})
+
+	return m, clientset
+}
+
+// pickDebugOption selects an option in the debug selector, returning the
+// command starting its session.
+func pickDebugOption(t *testing.T, m *Model, label string) tea.Cmd {
+	t.Helper()
+
+	for m.switchFiltered[m.selectIdx] != label {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	return cmd
+}
+
+// endSession closes the active session and delivers its exit.
+func endSession(t *testing.T, m *Model, cmd tea.Cmd) {
+	t.Helper()
+
+	m.terminals[m.activeTerminal].Close()
+
+	for cmd != nil {
+		msg := cmd()
+
+		_, cmd = m.Update(msg)
+
+		if _, ok := msg.(components.TerminalExitMsg); ok {
+			return
+		}
+	}
+}
+
+func TestDebugRequest_ListsOptions(t *testing.T) {
+	m, _ := createDebugTestModel(t)
+
+	if m.viewMode != ViewContainerSelect {
+		t.Fatalf("view mode = %v, want ViewContainerSelect", m.viewMode)
+	}
+
+	want := "app: ephemeral container|proxy: ephemeral container|app: copy of pod|proxy: copy of pod"
+	if got := strings.Join(m.switchFiltered, "|"); got != want {
+		t.Errorf("options = %q, want %q", got, want)
+	}
+
+	if !strings.Contains(m.renderSwitchView(), "Debug Container") {
+		t.Error("the selector should say it's for debugging")
+	}
+}
+
+func TestDebugPod_AddsEphemeralContainer(t *testing.T) {
+	m, clientset := createDebugTestModel(t)
+
+	cmd := runTerminalCmd(t, m, pickDebugOption(t, m, "app: ephemeral container"), "Waiting for debugger-")
+	defer endSession(t, m, cmd)
+
+	pod, err := clientset.CoreV1().Pods("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pod.Spec.EphemeralContainers) != 1 {
+		t.Fatalf("expected 1 ephemeral container, got %d", len(pod.Spec.EphemeralContainers))
+	}
+
+	debugger := pod.Spec.EphemeralContainers[0]
+	if debugger.Image != "nicolaka/netshoot" || debugger.TargetContainerName != "app" {
+		t.Errorf("ephemeral container = %s targeting %s, want the configured image targeting app",
+			debugger.Image, debugger.TargetContainerName)
+	}
+
+	if m.historyStore.Len() != 1 {
+		t.Errorf("expected 1 history record, got %d", m.historyStore.Len())
+	}
+}
+
+func TestDebugPod_CopyIsDeletedWhenSessionEnds(t *testing.T) {
+	m, clientset := createDebugTestModel(t)
+
+	cmd := runTerminalCmd(t, m, pickDebugOption(t, m, "proxy: copy of pod"), "Waiting for proxy")
+
+	copies := func() []corev1.Pod {
+		list, err := clientset.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{
+			LabelSelector: k8s.DebugCopyLabel,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return list.Items
+	}
+
+	created := copies()
+	if len(created) != 1 || created[0].Annotations[k8s.DebugCopyOfAnnotation] != "web" ||
+		created[0].Spec.Containers[1].Image != "nicolaka/netshoot" {
+		t.Fatalf("expected a copy running the debug image in proxy, got %+v", created)
+	}
+
+	endSession(t, m, cmd)
+
+	if left := copies(); len(left) != 0 {
+		t.Errorf("the copy should be deleted when its session ends, %d left", len(left))
+	}
+}
+
+func TestDebugImage_DefaultsToBusybox(t *testing.T) {
+	m := createTestModel()
+
+	if got := m.debugImage(); got != "busybox" {
+		t.Errorf("debugImage() = %q, want busybox", got)
+	}
+}
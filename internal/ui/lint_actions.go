@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func (m *Model) loadLintReport(namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		report, err := m.k8sClient.LintNamespace(ctx, namespace, m.lintPolicy)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to lint namespace: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatLintReport(report)}
+	}
+}
+
+func formatLintReport(report *k8s.LintReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Best-practice report for namespace %s\n", report.Namespace)
+	fmt.Fprintf(&b, "%d workloads scanned, %d with findings\n", report.Scanned, len(report.Workloads))
+
+	if len(report.Workloads) > 0 {
+		b.WriteString("\nFindings by rule:\n")
+
+		counts := report.RuleCounts()
+		for _, rule := range k8s.LintRules {
+			if counts[rule] > 0 {
+				fmt.Fprintf(&b, "  %-24s %d\n", rule, counts[rule])
+			}
+		}
+
+		for _, workload := range report.Workloads {
+			fmt.Fprintf(&b, "\n%s/%s:\n", workload.Kind, workload.Name)
+
+			for _, finding := range workload.Findings {
+				fmt.Fprintf(&b, "  %s\n", finding)
+			}
+		}
+	} else if report.Scanned > 0 {
+		b.WriteString("\nNo issues found.\n")
+	}
+
+	if len(report.Suppressed) > 0 {
+		names := make([]string, 0, len(report.Suppressed))
+		for _, rule := range report.Suppressed {
+			names = append(names, string(rule))
+		}
+
+		fmt.Fprintf(&b, "\nSuppressed by config: %s\n", strings.Join(names, ", "))
+	}
+
+	if !report.PDBsChecked {
+		b.WriteString("\nPodDisruptionBudgets could not be listed; missing-pdb was not checked.\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestLoadLintReport(t *testing.T) {
+	m := createTestModel()
+	m.lintPolicy = k8s.NewLintPolicy([]string{string(k8s.LintMissingLiveness)}, nil)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "nginx"}},
+		}}},
+	}))
+
+	_, cmd := m.Update(panels.LintNamespaceRequestMsg{Name: "default"})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+
+	report, ok := cmd().(reportLoadedMsg)
+	if !ok {
+		t.Fatalf("expected reportLoadedMsg, got %T", cmd())
+	}
+
+	for _, want := range []string{
+		"1 workloads scanned, 1 with findings",
+		"Deployment/web:",
+		`[latest-tag] app: image "nginx" is not pinned to a version`,
+		"Suppressed by config: missing-liveness-probe",
+	} {
+		if !strings.Contains(report.content, want) {
+			t.Errorf("report should contain %q:\n%s", want, report.content)
+		}
+	}
+
+	if strings.Contains(report.content, "[missing-liveness-probe]") {
+		t.Errorf("disabled rule should not be reported:\n%s", report.content)
+	}
+}
+
+func TestFormatLintReport(t *testing.T) {
+	out := formatLintReport(&k8s.LintReport{Namespace: "empty", Scanned: 2, PDBsChecked: false})
+
+	for _, want := range []string{
+		"2 workloads scanned, 0 with findings", "No issues found", "missing-pdb was not checked",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report should contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is the longest gap between two clicks on the same
+// row that still counts as a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// viewerWheelLines is how far one wheel notch scrolls a viewer; in panels
+// it moves the selection by one row, like the arrow keys.
+const viewerWheelLines = 3
+
+// rowClick is a left click on a panel row.
+type rowClick struct {
+	panel int
+	row   int
+	at    time.Time
+}
+
+// handleMouse focuses and selects what was clicked in the panels and
+// scrolls the panel or viewer under the wheel. Double-clicking a row opens
+// its YAML.
+func (m *Model) handleMouse(msg tea.MouseMsg, now time.Time) tea.Cmd {
+	switch m.viewMode {
+	case ViewYaml, ViewLogs, ViewDiff, ViewHistory, ViewXRay:
+		if scroll, ok := wheelKey(msg); ok {
+			return m.scrollViewer(scroll)
+		}
+	case ViewNormal:
+		return m.handlePanelMouse(msg, now)
+	case ViewTerminal:
+		return m.handleTerminalMouse(msg, now)
+	case ViewHelp, ViewConfirm, ViewInput, ViewContextSwitch, ViewNamespaceSwitch,
+		ViewContainerSelect, ViewGlobalSearch, ViewCommand:
+		// Modals and prompts are keyboard-only
+	}
+
+	return nil
+}
+
+// wheelKey turns a wheel event into the arrow key scrolling the same way.
+func wheelKey(msg tea.MouseMsg) (tea.KeyMsg, bool) {
+	if msg.Action != tea.MouseActionPress {
+		return tea.KeyMsg{}, false
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return tea.KeyMsg{Type: tea.KeyUp}, true
+	case tea.MouseButtonWheelDown:
+		return tea.KeyMsg{Type: tea.KeyDown}, true
+	}
+
+	return tea.KeyMsg{}, false
+}
+
+// scrollViewer scrolls the open viewer by feeding it arrow keys, so it
+// stops at the same bounds as with the keyboard.
+func (m *Model) scrollViewer(scroll tea.KeyMsg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	for range viewerWheelLines {
+		var cmd tea.Cmd
+
+		switch m.viewMode {
+		case ViewYaml:
+			m.yamlView, cmd = m.yamlView.Update(scroll)
+		case ViewLogs:
+			m.logView, cmd = m.logView.Update(scroll)
+		case ViewDiff:
+			m.diffView, cmd = m.diffView.Update(scroll)
+		case ViewHistory:
+			m.historyView, cmd = m.historyView.Update(scroll)
+		case ViewXRay:
+			m.relationView, cmd = m.relationView.Update(scroll)
+		case ViewNormal, ViewHelp, ViewConfirm, ViewInput, ViewContextSwitch, ViewNamespaceSwitch,
+			ViewContainerSelect, ViewGlobalSearch, ViewCommand, ViewTerminal:
+			// Not viewers
+		}
+
+		cmds = append(cmds, cmd)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// handlePanelMouse hit-tests an event against the layout renderPanels
+// drew. Clicking or scrolling a panel focuses it.
+func (m *Model) handlePanelMouse(msg tea.MouseMsg, now time.Time) tea.Cmd {
+	top, height := m.panelArea()
+
+	for idx, rect := range m.panelRects(m.width, height) {
+		line, ok := rect.contentLine(msg.X, msg.Y-top)
+		if !ok {
+			continue
+		}
+
+		if scroll, ok := wheelKey(msg); ok {
+			m.focusPanel(idx)
+
+			panel, cmd := m.panels[idx].Update(scroll)
+			m.panels[idx] = panel
+
+			return cmd
+		}
+
+		if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+			return nil
+		}
+
+		m.focusPanel(idx)
+
+		row, ok := m.panels[idx].RowAt(line)
+		if !ok {
+			return nil
+		}
+
+		m.panels[idx].SetCursor(row)
+
+		click := rowClick{panel: idx, row: row, at: now}
+		last := m.lastClick
+		m.lastClick = click
+
+		if last.panel == idx && last.row == row && !last.at.IsZero() &&
+			now.Sub(last.at) <= doubleClickInterval {
+			// A third click starts over rather than opening it again
+			m.lastClick = rowClick{}
+
+			_, cmd := m.showYaml()
+
+			return cmd
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// handleTerminalMouse scrolls the shell's scrollback under the wheel;
+// clicking a panel leaves the shell running and goes back to the panels.
+func (m *Model) handleTerminalMouse(msg tea.MouseMsg, now time.Time) tea.Cmd {
+	if len(m.terminals) == 0 {
+		return nil
+	}
+
+	if scroll, ok := wheelKey(msg); ok {
+		delta := viewerWheelLines
+		if scroll.Type == tea.KeyDown {
+			delta = -delta
+		}
+
+		m.terminals[m.activeTerminal].Scroll(delta)
+
+		return nil
+	}
+
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+		return nil
+	}
+
+	top, height := m.panelArea()
+
+	for _, rect := range m.panelRects(m.width, height) {
+		if _, ok := rect.contentLine(msg.X, msg.Y-top); ok {
+			m.viewMode = ViewNormal
+
+			return m.handlePanelMouse(msg, now)
+		}
+	}
+
+	return nil
+}
+
+// focusPanel makes a panel active, leaving zoom alone when it already is.
+func (m *Model) focusPanel(idx int) {
+	if idx != m.activePanelIdx {
+		m.selectPanel(idx)
+	}
+}
@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func createMouseTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "alpha", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "bravo", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "charlie", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}},
+	)
+
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(clientset)
+	m.header = components.NewHeader(m.styles, "", "default")
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.yamlView = components.NewYamlViewer(m.styles)
+	m.logView = components.NewLogViewer(m.styles)
+	m.search = components.NewSearch(m.styles)
+	m.width = 160
+	m.height = 40
+
+	pods := panels.NewPodsPanel(m.k8sClient, m.styles)
+	pods.Update(pods.Refresh()())
+
+	deployments := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	deployments.Update(deployments.Refresh()())
+
+	m.panels = []panels.Panel{pods, deployments}
+	m.panels[0].SetFocused(true)
+
+	return m
+}
+
+// screenPosition finds text on the rendered screen, as the cell a click
+// on it would report.
+func screenPosition(t *testing.T, m *Model, text string) (x, y int) {
+	t.Helper()
+
+	for y, line := range strings.Split(m.View(), "\n") {
+		if idx := strings.Index(line, text); idx >= 0 {
+			return lipgloss.Width(line[:idx]), y
+		}
+	}
+
+	t.Fatalf("%q not on screen", text)
+
+	return 0, 0
+}
+
+func leftClick(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+}
+
+func TestHandleMouse_ClickSelectsRow(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	x, y := screenPosition(t, m, "charlie")
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if got := m.panels[0].SelectedName(); got != "charlie" {
+		t.Errorf("selected = %q, want charlie", got)
+	}
+}
+
+func TestHandleMouse_ClickFocusesPanel(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	x, y := screenPosition(t, m, "worker")
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if m.activePanelIdx != 1 {
+		t.Fatalf("active panel = %d, want 1", m.activePanelIdx)
+	}
+
+	if got := m.panels[1].SelectedName(); got != "worker" {
+		t.Errorf("selected = %q, want worker", got)
+	}
+}
+
+func TestHandleMouse_ClickWithSearchBar(t *testing.T) {
+	m := createMouseTestModel(t)
+	m.searchActive = true
+
+	x, y := screenPosition(t, m, "bravo")
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if got := m.panels[0].SelectedName(); got != "bravo" {
+		t.Errorf("selected = %q, want bravo", got)
+	}
+}
+
+func TestHandleMouse_ClickOutsideRowsKeepsSelection(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	// The title line and the detail view aren't rows
+	x, y := screenPosition(t, m, "Deployments")
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if m.activePanelIdx != 1 {
+		t.Errorf("clicking a title should focus its panel, active = %d", m.activePanelIdx)
+	}
+
+	if m.panels[1].Cursor() != 0 {
+		t.Errorf("clicking a title moved the cursor to %d", m.panels[1].Cursor())
+	}
+
+	m.handleMouse(leftClick(m.width-5, 5), time.Now())
+
+	if m.activePanelIdx != 1 {
+		t.Errorf("clicking the detail view changed the active panel to %d", m.activePanelIdx)
+	}
+}
+
+func TestHandleMouse_ClickZoomed(t *testing.T) {
+	m := createMouseTestModel(t)
+	m.zoomed = true
+
+	x, y := screenPosition(t, m, "bravo")
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if got := m.panels[0].SelectedName(); got != "bravo" {
+		t.Errorf("selected = %q, want bravo", got)
+	}
+
+	if !m.zoomed {
+		t.Error("clicking the zoomed panel should keep it zoomed")
+	}
+}
+
+func TestHandleMouse_DoubleClickOpensYaml(t *testing.T) {
+	m := createMouseTestModel(t)
+	now := time.Now()
+
+	x, y := screenPosition(t, m, "bravo")
+	m.handleMouse(leftClick(x, y), now)
+
+	if m.viewMode != ViewNormal {
+		t.Fatalf("a single click opened view mode %v", m.viewMode)
+	}
+
+	m.handleMouse(leftClick(x, y), now.Add(200*time.Millisecond))
+
+	if m.viewMode != ViewYaml {
+		t.Fatalf("view mode = %v, want ViewYaml", m.viewMode)
+	}
+
+	if !strings.Contains(m.yamlView.View(m.width, m.height), "name: bravo") {
+		t.Error("YAML view should show the double-clicked pod")
+	}
+}
+
+func TestHandleMouse_SlowOrSeparateClicksDontOpenYaml(t *testing.T) {
+	m := createMouseTestModel(t)
+	now := time.Now()
+
+	x, y := screenPosition(t, m, "bravo")
+	m.handleMouse(leftClick(x, y), now)
+	m.handleMouse(leftClick(x, y), now.Add(time.Second))
+
+	if m.viewMode != ViewNormal {
+		t.Fatalf("clicks a second apart opened view mode %v", m.viewMode)
+	}
+
+	cx, cy := screenPosition(t, m, "charlie")
+	m.handleMouse(leftClick(cx, cy), now.Add(time.Second+100*time.Millisecond))
+
+	if m.viewMode != ViewNormal {
+		t.Fatalf("clicks on different rows opened view mode %v", m.viewMode)
+	}
+}
+
+func TestHandleMouse_WheelMovesPanelSelection(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	x, y := screenPosition(t, m, "web")
+	wheel := tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}
+	m.handleMouse(wheel, time.Now())
+
+	if m.activePanelIdx != 1 {
+		t.Fatalf("scrolling a panel should focus it, active = %d", m.activePanelIdx)
+	}
+
+	if got := m.panels[1].SelectedName(); got != "worker" {
+		t.Errorf("selected = %q, want worker", got)
+	}
+
+	wheel.Button = tea.MouseButtonWheelUp
+	m.handleMouse(wheel, time.Now())
+
+	if got := m.panels[1].SelectedName(); got != "web" {
+		t.Errorf("selected = %q, want web", got)
+	}
+}
+
+func TestHandleMouse_WheelScrollsViewer(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	var content []string
+	for i := range 100 {
+		content = append(content, "line"+strings.Repeat("x", i%3)+"-"+string(rune('a'+i%26)))
+	}
+
+	m.yamlView.SetContent(strings.Join(content, "\n"))
+	m.viewMode = ViewYaml
+
+	before := m.yamlView.View(m.width, m.height)
+
+	wheel := tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}
+	m.handleMouse(wheel, time.Now())
+
+	if m.yamlView.View(m.width, m.height) == before {
+		t.Fatal("wheel down should scroll the YAML view")
+	}
+
+	wheel.Button = tea.MouseButtonWheelUp
+	m.handleMouse(wheel, time.Now())
+
+	if m.yamlView.View(m.width, m.height) != before {
+		t.Error("wheel up should scroll the YAML view back")
+	}
+}
+
+func TestHandleMouse_IgnoredInModals(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	x, y := screenPosition(t, m, "charlie")
+	m.viewMode = ViewHelp
+	m.handleMouse(leftClick(x, y), time.Now())
+
+	if m.panels[0].Cursor() != 0 {
+		t.Errorf("a click behind the help view moved the cursor to %d", m.panels[0].Cursor())
+	}
+}
+
+func TestBasePanelRowAt(t *testing.T) {
+	m := createMouseTestModel(t)
+	m.View()
+
+	if _, ok := m.panels[0].RowAt(0); ok {
+		t.Error("the title line isn't a row")
+	}
+
+	x, y := screenPosition(t, m, "> alpha")
+	top, height := m.panelArea()
+
+	line, ok := m.panelRects(m.width, height)[0].contentLine(x, y-top)
+	if !ok {
+		t.Fatal("alpha should be inside the pods panel")
+	}
+
+	if row, ok := m.panels[0].RowAt(line); !ok || row != 0 {
+		t.Errorf("RowAt(%d) = %d, %v; want 0, true", line, row, ok)
+	}
+
+	if _, ok := m.panels[0].RowAt(line + 3); ok {
+		t.Error("lines past the last item aren't rows")
+	}
+}
@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	sigs_yaml "sigs.k8s.io/yaml"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// namespaceMetadata is the document edited by the label/annotation editor.
+type namespaceMetadata struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// startCreateNamespace prompts for the name, optional labels and an
+// optional Pod Security Admission level.
+func (m *Model) startCreateNamespace() {
+	m.showInput(
+		"Create Namespace",
+		"Name of the new namespace",
+		"my-namespace",
+		func(value string) tea.Cmd {
+			name := strings.TrimSpace(value)
+			if name == "" {
+				return errorCmd(ErrEmptyName)
+			}
+
+			m.promptNamespaceLabels(name)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptNamespaceLabels(name string) {
+	m.showInput(
+		"Create Namespace: "+name,
+		"Labels as key=value (optional, space or comma separated)",
+		"team=payments env=prod",
+		func(value string) tea.Cmd {
+			labels, err := k8s.ParseLabels(value)
+			if err != nil {
+				return errorCmd(err)
+			}
+
+			m.promptPodSecurityLevel(name, labels)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptPodSecurityLevel(name string, labels map[string]string) {
+	m.showInput(
+		"Create Namespace: "+name,
+		"Pod security level: "+strings.Join(k8s.PodSecurityLevels, ", ")+" (optional)",
+		"",
+		func(value string) tea.Cmd {
+			level := strings.TrimSpace(value)
+			if level != "" {
+				psaLabels, err := k8s.PodSecurityLabels(level)
+				if err != nil {
+					return errorCmd(err)
+				}
+
+				// Explicit pod-security labels typed earlier win
+				for k, v := range psaLabels {
+					if _, ok := labels[k]; !ok {
+						labels[k] = v
+					}
+				}
+			}
+
+			return m.createNamespace(name, labels)
+		},
+	)
+}
+
+func (m *Model) createNamespace(name string, labels map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if _, err := m.k8sClient.CreateNamespaceWithLabels(ctx, name, labels); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to create namespace: %w", err)}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:     components.OpCreateNamespace,
+			Resource: name,
+			Message:  fmt.Sprintf("Created namespace %s with %d labels", name, len(labels)),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Created namespace: %s", name),
+		}
+	}
+}
+
+// editNamespaceMetadata opens the namespace's labels and annotations as a
+// small YAML document and replaces both with the edited maps.
+func (m *Model) editNamespaceMetadata(msg panels.EditNamespaceMetadataRequestMsg) tea.Cmd {
+	content, err := sigs_yaml.Marshal(namespaceMetadata{
+		Labels:      orEmpty(msg.Labels),
+		Annotations: orEmpty(msg.Annotations),
+	})
+	if err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Failed to marshal metadata: %v", err))
+
+		return nil
+	}
+
+	return m.editValue(
+		fmt.Sprintf("lazy-k8s-ns-%s-*.yaml", msg.Name),
+		content,
+		"No changes to namespace "+msg.Name,
+		func(edited []byte) tea.Msg {
+			return m.saveNamespaceMetadata(msg.Name, edited)
+		},
+	)
+}
+
+func (m *Model) saveNamespaceMetadata(name string, content []byte) tea.Msg {
+	var meta namespaceMetadata
+	if err := sigs_yaml.UnmarshalStrict(content, &meta); err != nil {
+		return panels.ErrorMsg{Error: fmt.Errorf("invalid metadata: %w", err)}
+	}
+
+	ctx := context.Background()
+
+	if err := m.k8sClient.SetNamespaceMetadata(ctx, name, meta.Labels, meta.Annotations); err != nil {
+		return panels.ErrorMsg{Error: fmt.Errorf("failed to update namespace: %w", err)}
+	}
+
+	m.historyStore.Add(components.OperationRecord{
+		Type:     components.OpEditNamespace,
+		Resource: name,
+		Message: fmt.Sprintf(
+			"Set %d labels and %d annotations on namespace %s",
+			len(meta.Labels), len(meta.Annotations), name,
+		),
+	})
+
+	return panels.StatusWithRefreshMsg{
+		Message: fmt.Sprintf("Updated labels and annotations of %s", name),
+	}
+}
+
+func (m *Model) loadNamespaceBlockers(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		blockers, err := m.k8sClient.GetNamespaceBlockers(ctx, name)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to inspect namespace: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatNamespaceBlockers(name, blockers)}
+	}
+}
+
+func formatNamespaceBlockers(name string, blockers *k8s.NamespaceBlockers) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Namespace %s is Terminating\n", name)
+
+	if len(blockers.Conditions) > 0 {
+		b.WriteString("\nReported by the namespace controller:\n")
+
+		for _, cond := range blockers.Conditions {
+			fmt.Fprintf(&b, "  %s\n", cond)
+		}
+	}
+
+	if len(blockers.Resources) > 0 {
+		b.WriteString("\nRemaining resources:\n")
+
+		for _, res := range blockers.Resources {
+			fmt.Fprintf(&b, "  %s/%s", res.Kind, res.Name)
+
+			if len(res.Finalizers) > 0 {
+				fmt.Fprintf(&b, "  finalizers: %s", strings.Join(res.Finalizers, ", "))
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	if len(blockers.Finalizers) > 0 {
+		fmt.Fprintf(&b, "\nNamespace finalizers: %s\n", strings.Join(blockers.Finalizers, ", "))
+		b.WriteString("These are removed by the namespace controller once all content is gone.\n")
+	}
+
+	if len(blockers.Conditions) == 0 && len(blockers.Resources) == 0 {
+		b.WriteString("\nNo remaining built-in resources or deletion conditions found.\n")
+	}
+
+	return b.String()
+}
+
+func orEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return maps.Clone(m)
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestCreateNamespaceFlow(t *testing.T) {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+
+	m.Update(panels.CreateNamespaceRequestMsg{})
+
+	submitInput(t, m, "payments")
+	submitInput(t, m, "team=payments pod-security.kubernetes.io/warn=privileged")
+	result := submitInput(t, m, "restricted")
+
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T: %v", result, result)
+	}
+
+	ns, err := m.k8sClient.GetNamespace(t.Context(), "payments")
+	if err != nil {
+		t.Fatalf("namespace was not created: %v", err)
+	}
+
+	if ns.Labels[k8s.PodSecurityEnforceLabel] != "restricted" {
+		t.Errorf("enforce label = %q, want %q", ns.Labels[k8s.PodSecurityEnforceLabel], "restricted")
+	}
+
+	// An explicitly typed pod-security label is kept
+	if ns.Labels[k8s.PodSecurityWarnLabel] != "privileged" {
+		t.Errorf("warn label = %q, want %q", ns.Labels[k8s.PodSecurityWarnLabel], "privileged")
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpCreateNamespace {
+		t.Errorf("expected an OpCreateNamespace history record, got %+v", rec)
+	}
+}
+
+func TestCreateNamespaceInvalidLevel(t *testing.T) {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+
+	m.Update(panels.CreateNamespaceRequestMsg{})
+
+	submitInput(t, m, "payments")
+	submitInput(t, m, "")
+	result := submitInput(t, m, "strict")
+
+	errMsg, ok := result.(panels.ErrorMsg)
+	if !ok || !errors.Is(errMsg.Error, k8s.ErrInvalidPodSecurityLevel) {
+		t.Fatalf("expected ErrInvalidPodSecurityLevel, got %+v", result)
+	}
+}
+
+func TestSaveNamespaceMetadata(t *testing.T) {
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+	))
+
+	result := m.saveNamespaceMetadata("apps", []byte("labels:\n  team: web\nannotations:\n  owner: ops\n"))
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T: %v", result, result)
+	}
+
+	ns, err := m.k8sClient.GetNamespace(t.Context(), "apps")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ns.Labels["team"] != "web" || ns.Annotations["owner"] != "ops" {
+		t.Errorf("metadata not saved: labels=%v annotations=%v", ns.Labels, ns.Annotations)
+	}
+
+	if _, ok := m.saveNamespaceMetadata("apps", []byte("lables: {}\n")).(panels.ErrorMsg); !ok {
+		t.Error("unknown fields should be rejected")
+	}
+}
+
+func TestFormatNamespaceBlockers(t *testing.T) {
+	out := formatNamespaceBlockers("stuck", &k8s.NamespaceBlockers{
+		Finalizers: []string{"kubernetes"},
+		Conditions: []string{"NamespaceFinalizersRemaining: foo.example.com in 1 resource instances"},
+		Resources: []k8s.RemainingResource{
+			{Kind: "PersistentVolumeClaim", Name: "data", Finalizers: []string{"kubernetes.io/pvc-protection"}},
+		},
+	})
+
+	for _, want := range []string{
+		"foo.example.com", "PersistentVolumeClaim/data", "kubernetes.io/pvc-protection", "Namespace finalizers: kubernetes",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report should contain %q:\n%s", want, out)
+		}
+	}
+}
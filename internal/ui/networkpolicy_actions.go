@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// startReachabilityCheck prompts for source, destination and port, then
+// simulates the connection against every NetworkPolicy in the cluster.
+func (m *Model) startReachabilityCheck(msg panels.CheckReachabilityRequestMsg) {
+	m.showInput(
+		"Test Reachability",
+		"Source: namespace/pod, pod in the current namespace, IP or CIDR",
+		"web/frontend-7d9f or 203.0.113.0/24",
+		func(value string) tea.Cmd {
+			source := strings.TrimSpace(value)
+			if source == "" {
+				return errorCmd(ErrEmptyName)
+			}
+
+			m.promptReachabilityDestination(source, msg.Destination)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptReachabilityDestination(source, suggested string) {
+	description := "Destination pod: namespace/pod or pod in the current namespace"
+	if suggested != "" {
+		description += " (empty for " + suggested + ")"
+	}
+
+	m.showInput(
+		"Test Reachability from "+source,
+		description,
+		suggested,
+		func(value string) tea.Cmd {
+			destination := strings.TrimSpace(value)
+			if destination == "" {
+				destination = suggested
+			}
+
+			if destination == "" {
+				return errorCmd(ErrEmptyName)
+			}
+
+			m.promptReachabilityPort(source, destination)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptReachabilityPort(source, destination string) {
+	m.showInput(
+		"Test Reachability: "+source+" -> "+destination,
+		"Port: number, name, optionally with /TCP, /UDP or /SCTP",
+		"8080/TCP",
+		func(value string) tea.Cmd {
+			return m.checkReachability(source, destination, value)
+		},
+	)
+}
+
+func (m *Model) checkReachability(source, destination, port string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		result, err := m.k8sClient.CheckReachability(ctx, source, destination, port)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to check reachability: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatReachability(result)}
+	}
+}
+
+func formatReachability(result *k8s.ReachabilityResult) string {
+	var b strings.Builder
+
+	verdict := "DENIED"
+	if result.Allowed() {
+		verdict = "ALLOWED"
+	}
+
+	fmt.Fprintf(&b, "%s -> %s on %s: %s\n", result.Source, result.Destination, result.Port, verdict)
+
+	if result.Egress == nil {
+		b.WriteString("\nEgress from source: not governed by NetworkPolicy (external)\n")
+	} else {
+		writeTrafficDirection(&b, "Egress from "+result.Source, result.Egress)
+	}
+
+	writeTrafficDirection(&b, "Ingress to "+result.Destination, &result.Ingress)
+
+	return b.String()
+}
+
+func writeTrafficDirection(b *strings.Builder, title string, dir *k8s.TrafficDirection) {
+	verdict := "denied"
+	if dir.Allowed {
+		verdict = "allowed"
+	}
+
+	fmt.Fprintf(b, "\n%s: %s\n", title, verdict)
+
+	if !dir.Isolated {
+		b.WriteString("  Not isolated: no policy selects this pod, all traffic is allowed\n")
+
+		return
+	}
+
+	fmt.Fprintf(b, "  Selected by: %s\n", strings.Join(dir.Policies, ", "))
+
+	if len(dir.AllowedBy) > 0 {
+		fmt.Fprintf(b, "  Allowed by:  %s\n", strings.Join(dir.AllowedBy, ", "))
+	} else {
+		b.WriteString("  No rule of these policies matches the connection\n")
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestReachabilityCheckFlow(t *testing.T) {
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+		}
+	}
+
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		pod("web", "web"), pod("api", "api"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "default"},
+		},
+	))
+
+	m.Update(panels.CheckReachabilityRequestMsg{Destination: "default/api"})
+
+	submitInput(t, m, "default/web")
+	submitInput(t, m, "")
+	result := submitInput(t, m, "8080")
+
+	report, ok := result.(reportLoadedMsg)
+	if !ok {
+		t.Fatalf("expected reportLoadedMsg, got %T: %v", result, result)
+	}
+
+	for _, want := range []string{
+		"default/web -> default/api on 8080/TCP: DENIED",
+		"Selected by: default/deny-all",
+		"No rule of these policies matches",
+	} {
+		if !strings.Contains(report.content, want) {
+			t.Errorf("report should contain %q:\n%s", want, report.content)
+		}
+	}
+}
+
+func TestFormatReachabilityExternal(t *testing.T) {
+	out := formatReachability(&k8s.ReachabilityResult{
+		Source:      "203.0.113.0/24",
+		Destination: "prod/api",
+		Port:        "443/TCP",
+		Ingress:     k8s.TrafficDirection{Allowed: true},
+	})
+
+	for _, want := range []string{"ALLOWED", "not governed by NetworkPolicy", "Not isolated"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report should contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+func (m *Model) loadNodeAllocation(node string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		alloc, err := m.k8sClient.GetNodeAllocation(ctx, node)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to load node allocation: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatNodeAllocation(alloc)}
+	}
+}
+
+func formatNodeAllocation(alloc *k8s.NodeAllocation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Node %s: %d pods", alloc.Node, len(alloc.Pods))
+
+	if maxPods := alloc.MaxPods(); maxPods > 0 {
+		fmt.Fprintf(&b, " of %d allowed", maxPods)
+	}
+
+	b.WriteString("\n\nAllocated resources:\n")
+	fmt.Fprintf(&b, "  %-10s %-18s %-18s %s\n", "RESOURCE", "REQUESTS", "LIMITS", "ALLOCATABLE")
+
+	allocCPU := k8s.MilliCPU(alloc.Allocatable)
+	fmt.Fprintf(&b, "  %-10s %-18s %-18s %s\n", "cpu",
+		withPercent(utils.FormatCPU(k8s.MilliCPU(alloc.Requests)), k8s.MilliCPU(alloc.Requests), allocCPU),
+		withPercent(utils.FormatCPU(k8s.MilliCPU(alloc.Limits)), k8s.MilliCPU(alloc.Limits), allocCPU),
+		utils.FormatCPU(allocCPU))
+
+	allocMem := k8s.MemoryBytes(alloc.Allocatable)
+	fmt.Fprintf(&b, "  %-10s %-18s %-18s %s\n", "memory",
+		withPercent(utils.FormatMemory(k8s.MemoryBytes(alloc.Requests)), k8s.MemoryBytes(alloc.Requests), allocMem),
+		withPercent(utils.FormatMemory(k8s.MemoryBytes(alloc.Limits)), k8s.MemoryBytes(alloc.Limits), allocMem),
+		utils.FormatMemory(allocMem))
+
+	if len(alloc.Pods) == 0 {
+		b.WriteString("\nNo running pods on this node.\n")
+
+		return b.String()
+	}
+
+	nameW := len("POD")
+	for _, pod := range alloc.Pods {
+		nameW = max(nameW, len(pod.Namespace)+1+len(pod.Name))
+	}
+
+	b.WriteString("\nPods by request:\n")
+	fmt.Fprintf(&b, "  %-*s  %-8s %-8s %-8s %s\n", nameW, "POD", "CPU REQ", "CPU LIM", "MEM REQ", "MEM LIM")
+
+	for _, pod := range alloc.Pods {
+		fmt.Fprintf(&b, "  %-*s  %-8s %-8s %-8s %s\n", nameW, pod.Namespace+"/"+pod.Name,
+			utils.FormatCPU(k8s.MilliCPU(pod.Requests)), utils.FormatCPU(k8s.MilliCPU(pod.Limits)),
+			utils.FormatMemory(k8s.MemoryBytes(pod.Requests)), utils.FormatMemory(k8s.MemoryBytes(pod.Limits)))
+	}
+
+	return b.String()
+}
+
+// withPercent appends how much of total value is, when total is known.
+func withPercent(s string, value, total int64) string {
+	if total <= 0 {
+		return s
+	}
+
+	return fmt.Sprintf("%s (%d%%)", s, value*100/total)
+}
+
+// nodeShellsSweptMsg reports how many node shell pods left behind by an
+// earlier run the startup sweep deleted.
+type nodeShellsSweptMsg struct {
+	count int
+}
+
+// nodeShellOptions sets up node shell pods from the config, with the
+// config's defaults when there is none.
+func (m *Model) nodeShellOptions() k8s.NodeShellOptions {
+	cfg := config.DefaultDebugConfig().Node
+	if m.config != nil {
+		cfg = m.config.Debug.Node
+	}
+
+	tolerations := make([]corev1.Toleration, 0, len(cfg.Tolerations))
+	for _, t := range cfg.Tolerations {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      t.Key,
+			Operator: corev1.TolerationOperator(t.Operator),
+			Value:    t.Value,
+			Effect:   corev1.TaintEffect(t.Effect),
+		})
+	}
+
+	return k8s.NodeShellOptions{Namespace: cfg.Namespace, Image: cfg.Image, Tolerations: tolerations}
+}
+
+// sweepNodeShells deletes node shell pods that a crashed run left behind.
+// Failing, as it does without permission to list pods, only means there
+// is nothing to report.
+func (m *Model) sweepNodeShells() tea.Cmd {
+	client := m.k8sClient.Pinned()
+	namespace := m.nodeShellOptions().Namespace
+
+	return func() tea.Msg {
+		count, _ := client.SweepNodeShells(context.Background(), namespace, time.Now())
+
+		return nodeShellsSweptMsg{count: count}
+	}
+}
+
+func (m *Model) confirmNodeShell(node string) tea.Cmd {
+	opts := m.nodeShellOptions()
+
+	m.confirm.Show(
+		"Node Shell",
+		fmt.Sprintf("Start a privileged pod on %s in namespace %s for a shell on the host? "+
+			"It is deleted when the shell ends.", node, opts.Namespace),
+		func() tea.Cmd {
+			return m.openNodeShell(node)
+		},
+	)
+	m.viewMode = ViewConfirm
+
+	return nil
+}
+
+// openNodeShell runs a shell on a node's host from a privileged pod,
+// deleting the pod when the session ends. The session keeps to the
+// cluster it started in, so heartbeats and the delete reach the pod even
+// after a context switch.
+func (m *Model) openNodeShell(node string) tea.Cmd {
+	client := m.k8sClient.Pinned()
+	opts := m.nodeShellOptions()
+
+	m.historyStore.Add(components.OperationRecord{
+		Type:      components.OpExec,
+		Resource:  node,
+		Namespace: opts.Namespace,
+		Message:   fmt.Sprintf("Node shell on %s (image: %s)", node, opts.Image),
+	})
+
+	return m.openTerminal(node+" (node)", func(
+		ctx context.Context, stdin io.Reader, stdout io.Writer, resize <-chan k8s.TerminalSize,
+	) error {
+		pod, err := client.CreateNodeShell(ctx, node, opts)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), shellPodCleanupTimeout)
+			defer cancel()
+
+			_ = client.DeletePod(cleanupCtx, pod.Namespace, pod.Name)
+		}()
+
+		// Tells the sweep of another lazy-k8s this pod is in use
+		aliveCtx, stopAlive := context.WithCancel(ctx)
+		defer stopAlive()
+
+		go client.KeepNodeShellAlive(aliveCtx, pod.Namespace, pod.Name)
+
+		return waitAndAttach(ctx, client, k8s.TerminalOptions{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: k8s.NodeShellContainer,
+			Stdin:     stdin,
+			Stdout:    stdout,
+			Resize:    resize,
+		})
+	})
+}
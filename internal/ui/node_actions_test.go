@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestLoadNodeAllocation(t *testing.T) {
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default"},
+			Spec: corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{
+				{Name: "app", Resources: requests("100m", "128Mi")},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "default"},
+			Spec: corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{
+				{Name: "app", Resources: requests("900m", "1Gi")},
+			}},
+		},
+	))
+
+	_, cmd := m.Update(panels.NodePodsRequestMsg{Node: "worker-1"})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+
+	report, ok := cmd().(reportLoadedMsg)
+	if !ok {
+		t.Fatalf("expected reportLoadedMsg, got %T", cmd())
+	}
+
+	for _, want := range []string{"Node worker-1: 2 pods of 110 allowed", "cpu        1 (50%)", "default/big"} {
+		if !strings.Contains(report.content, want) {
+			t.Errorf("report should contain %q:\n%s", want, report.content)
+		}
+	}
+
+	if strings.Index(report.content, "default/big") > strings.Index(report.content, "default/small") {
+		t.Errorf("pods should be sorted by request:\n%s", report.content)
+	}
+}
+
+func TestFormatNodeAllocationEmpty(t *testing.T) {
+	out := formatNodeAllocation(&k8s.NodeAllocation{Node: "idle"})
+
+	if !strings.Contains(out, "Node idle: 0 pods\n") || !strings.Contains(out, "No running pods") {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func nodeShellPods(t *testing.T, clientset *fake.Clientset, namespace string) []corev1.Pod {
+	t.Helper()
+
+	list, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: k8s.NodeShellLabel,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return list.Items
+}
+
+func TestNodeShell_ConfirmsAndDeletesPodOnExit(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	m := createMouseTestModel(t)
+	m.k8sClient = k8s.NewTestClient(clientset)
+	m.confirm = components.NewConfirm(m.styles)
+	m.config = &config.Config{Debug: config.DebugConfig{Node: config.NodeShellConfig{
+		Image:       "alpine",
+		Namespace:   "ops",
+		Tolerations: []config.TolerationConfig{{Key: "dedicated", Operator: "Equal", Value: "gpu", Effect: "NoSchedule"}},
+	}}}
+
+	m.Update(panels.NodeShellRequestMsg{Node: "worker-1"})
+
+	if m.viewMode != ViewConfirm {
+		t.Fatalf("a privileged pod should be confirmed first, view mode = %v", m.viewMode)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	cmd = runTerminalCmd(t, m, cmd, "Waiting for shell")
+
+	pods := nodeShellPods(t, clientset, "ops")
+	if len(pods) != 1 {
+		t.Fatalf("expected 1 node shell pod in ops, got %d", len(pods))
+	}
+
+	spec := pods[0].Spec
+	if spec.NodeName != "worker-1" || spec.Containers[0].Image != "alpine" {
+		t.Errorf("pod runs %s on %q, want alpine on worker-1", spec.Containers[0].Image, spec.NodeName)
+	}
+
+	want := corev1.Toleration{
+		Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule,
+	}
+	if len(spec.Tolerations) != 1 || spec.Tolerations[0] != want {
+		t.Errorf("tolerations = %+v, want the configured one", spec.Tolerations)
+	}
+
+	endSession(t, m, cmd)
+
+	if left := nodeShellPods(t, clientset, "ops"); len(left) != 0 {
+		t.Errorf("the pod should be deleted when the shell ends, %d left", len(left))
+	}
+}
+
+func TestNodeShell_Declined(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	m := createMouseTestModel(t)
+	m.k8sClient = k8s.NewTestClient(clientset)
+	m.confirm = components.NewConfirm(m.styles)
+
+	m.Update(panels.NodeShellRequestMsg{Node: "worker-1"})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	if m.viewMode != ViewNormal || len(m.terminals) != 0 {
+		t.Error("declining should open no shell")
+	}
+
+	if pods := nodeShellPods(t, clientset, "default"); len(pods) != 0 {
+		t.Errorf("declining should create no pod, got %d", len(pods))
+	}
+}
+
+func TestSweepNodeShells_ReportsLeftovers(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node-shell-abcde",
+			Namespace:         "default",
+			Labels:            map[string]string{k8s.NodeShellLabel: "true"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	})
+
+	m := createMouseTestModel(t)
+	m.k8sClient = k8s.NewTestClient(clientset)
+
+	m.Update(m.sweepNodeShells()())
+
+	if pods := nodeShellPods(t, clientset, "default"); len(pods) != 0 {
+		t.Errorf("the ended node shell pod should be swept, %d left", len(pods))
+	}
+
+	if !strings.Contains(m.statusBar.View(160), "Deleted 1 node shell pods") {
+		t.Errorf("the sweep should be reported, status bar: %q", m.statusBar.View(160))
+	}
+}
+
+func TestNodeShellOptions_DefaultsWithoutConfig(t *testing.T) {
+	m := createTestModel()
+	want := config.DefaultDebugConfig().Node
+
+	opts := m.nodeShellOptions()
+	if opts.Image != want.Image || opts.Namespace != want.Namespace || len(opts.Tolerations) != len(want.Tolerations) {
+		t.Errorf("expected the config defaults %+v, got %+v", want, opts)
+	}
+}
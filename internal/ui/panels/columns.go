@@ -0,0 +1,356 @@
+package panels
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+var (
+	ErrUnknownColumn = errors.New("unknown column")
+	ErrInvalidColumn = errors.New("invalid column")
+)
+
+const (
+	// customColumnWidth is the width of a JSONPath, label or annotation
+	// column that doesn't set one.
+	customColumnWidth = 15
+	// minFillWidth is the least a fill column like the name is squeezed to
+	// before columns to its right are dropped.
+	minFillWidth = 10
+)
+
+// ColumnDef picks one column of a panel's rows. Name alone picks a
+// built-in column; JSONPath, Label or Annotation define a custom one, for
+// which Name is only the default header.
+type ColumnDef struct {
+	Name       string
+	Header     string
+	Width      int
+	JSONPath   string
+	Label      string
+	Annotation string
+}
+
+// tableColumn is one column a panel can render for items of type T.
+type tableColumn[T any] struct {
+	header string
+	width  int
+	// fill columns take the width the others leave, never less than width
+	fill       bool
+	alignRight bool
+	highlight  bool
+	sort       sortColumn
+	value      func(T) string
+	// style colours the cell; nil leaves it plain
+	style func(T) lipgloss.Style
+}
+
+// columnTable renders a panel's rows with the columns chosen in the config,
+// in place of the panel's own layout. Columns that don't fit the panel are
+// dropped from the right, so narrow and wide panels share one definition.
+type columnTable[T any] struct {
+	columns []tableColumn[T]
+}
+
+// newColumnTable resolves defs against the panel's built-in columns. No
+// defs means the panel keeps its own layout, and a nil table.
+func newColumnTable[T any](defs []ColumnDef, builtins map[string]tableColumn[T]) (*columnTable[T], error) {
+	if len(defs) == 0 {
+		return nil, nil
+	}
+
+	table := &columnTable[T]{}
+
+	for _, def := range defs {
+		col, err := resolveColumn(def, builtins)
+		if err != nil {
+			return nil, err
+		}
+
+		table.columns = append(table.columns, col)
+	}
+
+	return table, nil
+}
+
+func resolveColumn[T any](def ColumnDef, builtins map[string]tableColumn[T]) (tableColumn[T], error) {
+	if def.Width < 0 {
+		return tableColumn[T]{}, fmt.Errorf("%w %q: width must not be negative", ErrInvalidColumn, def.Name)
+	}
+
+	sources := 0
+
+	for _, s := range []string{def.JSONPath, def.Label, def.Annotation} {
+		if s != "" {
+			sources++
+		}
+	}
+
+	var col tableColumn[T]
+
+	switch {
+	case sources > 1:
+		return col, fmt.Errorf("%w %q: use one of jsonPath, label or annotation", ErrInvalidColumn, def.Name)
+	case sources == 0:
+		builtin, ok := builtins[strings.ToLower(def.Name)]
+		if !ok {
+			names := make([]string, 0, len(builtins))
+			for name := range builtins {
+				names = append(names, name)
+			}
+
+			slices.Sort(names)
+
+			return col, fmt.Errorf("%w %q, pick from %s", ErrUnknownColumn, def.Name, strings.Join(names, ", "))
+		}
+
+		col = builtin
+	case def.JSONPath != "":
+		value, err := jsonPathValue[T](def.JSONPath)
+		if err != nil {
+			return col, fmt.Errorf("%w %q: %w", ErrInvalidColumn, def.JSONPath, err)
+		}
+
+		col = tableColumn[T]{header: def.Name, width: customColumnWidth, value: value}
+	case def.Label != "":
+		col = tableColumn[T]{header: cmp.Or(def.Name, lastSegment(def.Label)), width: customColumnWidth,
+			value: metadataValue[T](def.Label, metav1.Object.GetLabels)}
+	default:
+		col = tableColumn[T]{header: cmp.Or(def.Name, lastSegment(def.Annotation)), width: customColumnWidth,
+			value: metadataValue[T](def.Annotation, metav1.Object.GetAnnotations)}
+	}
+
+	if def.Header != "" {
+		col.header = def.Header
+	}
+
+	col.header = strings.ToUpper(col.header)
+
+	if def.Width > 0 {
+		col.width = def.Width
+		col.fill = false
+	}
+
+	return col, nil
+}
+
+// lastSegment shortens a prefixed key like app.kubernetes.io/version to
+// its name for a header.
+func lastSegment(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
+
+// jsonPathValue evaluates a kubectl-style JSONPath, with or without the
+// surrounding braces, against an item's JSON form.
+func jsonPathValue[T any](path string) (func(T) string, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+
+	jp := jsonpath.New("column").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, err
+	}
+
+	return func(item T) string {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&item)
+		if err != nil {
+			return ""
+		}
+
+		var buf bytes.Buffer
+		if err := jp.Execute(&buf, data); err != nil {
+			return ""
+		}
+
+		return buf.String()
+	}, nil
+}
+
+func metadataValue[T any](key string, get func(metav1.Object) map[string]string) func(T) string {
+	return func(item T) string {
+		obj, ok := any(&item).(metav1.Object)
+		if !ok {
+			return ""
+		}
+
+		return get(obj)[key]
+	}
+}
+
+// objectColumns are the columns every listed Kubernetes object has.
+func objectColumns[T any, PT interface {
+	*T
+	metav1.Object
+}]() map[string]tableColumn[T] {
+	return map[string]tableColumn[T]{
+		"name": {
+			header: "NAME", width: minFillWidth, fill: true, highlight: true, sort: sortName,
+			value: func(item T) string { return PT(&item).GetName() },
+		},
+		"namespace": {
+			header: "NAMESPACE", width: 15, sort: sortNamespace,
+			value: func(item T) string { return PT(&item).GetNamespace() },
+		},
+		"age": {
+			header: "AGE", width: 8, sort: sortAge,
+			value: func(item T) string { return utils.FormatAgeFromMeta(PT(&item).GetCreationTimestamp()) },
+		},
+	}
+}
+
+// withColumns adds a panel's own columns to the common ones.
+func withColumns[T any](base, extra map[string]tableColumn[T]) map[string]tableColumn[T] {
+	for name, col := range extra {
+		base[name] = col
+	}
+
+	return base
+}
+
+// layout picks the columns that fit width and their widths.
+func (t *columnTable[T]) layout(width int) ([]tableColumn[T], []int) {
+	// Two columns for the cursor and mark, two for the border
+	avail := width - 4
+
+	cols := slices.Clone(t.columns)
+
+	for len(cols) > 1 {
+		used := len(cols) - 1
+		for _, col := range cols {
+			used += col.width
+		}
+
+		if used <= avail {
+			break
+		}
+
+		// Keep fill columns, dropping the rightmost of the others
+		i := len(cols) - 1
+		for i >= 0 && cols[i].fill {
+			i--
+		}
+
+		if i < 0 {
+			break
+		}
+
+		cols = slices.Delete(cols, i, i+1)
+	}
+
+	used := len(cols) - 1
+	fills := 0
+
+	for _, col := range cols {
+		used += col.width
+		if col.fill {
+			fills++
+		}
+	}
+
+	widths := make([]int, len(cols))
+	spare := max(avail-used, 0)
+
+	for i, col := range cols {
+		widths[i] = col.width
+
+		if col.fill {
+			share := spare / fills
+			widths[i] += share
+			spare -= share
+			fills--
+		}
+	}
+
+	return cols, widths
+}
+
+// writeHeader writes the header row, returning how many rows it took; a
+// nil table writes nothing.
+func (t *columnTable[T]) writeHeader(b *strings.Builder, base *BasePanel, styles *theme.Styles) int {
+	if t == nil {
+		return 0
+	}
+
+	cols, widths := t.layout(base.width)
+	cells := make([]string, len(cols))
+
+	for i, col := range cols {
+		label := col.header
+		if col.sort != sortNone {
+			label = base.sortHeader(col.header, col.sort, widths[i])
+		}
+
+		if col.alignRight {
+			cells[i] = utils.PadLeft(label, widths[i])
+		} else {
+			cells[i] = utils.PadRight(label, widths[i])
+		}
+	}
+
+	b.WriteString(styles.TableHeader.Render(utils.Truncate("  "+strings.Join(cells, " "), base.width-2)))
+	b.WriteString("\n")
+
+	return 1
+}
+
+// row renders one item with the table's columns, styled like the panels'
+// own rows.
+func (t *columnTable[T]) row(base *BasePanel, styles *theme.Styles, item T, ref ResourceRef, selected bool) string {
+	cols, widths := t.layout(base.width)
+	cells := make([]string, len(cols))
+
+	for i, col := range cols {
+		text := col.value(item)
+
+		switch {
+		case col.highlight:
+			text = highlightName(styles, base.query, text, widths[i])
+		case lipgloss.Width(text) > widths[i]:
+			text = utils.Truncate(text, widths[i])
+		}
+
+		if col.alignRight {
+			text = utils.PadLeft(text, widths[i])
+		} else {
+			text = utils.PadRight(text, widths[i])
+		}
+
+		if col.style != nil {
+			text = col.style(item).Render(text)
+		}
+
+		cells[i] = text
+	}
+
+	line := base.rowPrefix(selected, ref) + strings.Join(cells, " ")
+
+	if selected && base.focused {
+		return styles.ListItemFocused.Render(line)
+	} else if selected {
+		return styles.ListItemSelected.Render(line)
+	}
+
+	return styles.ListItem.Render(line)
+}
+
+// readyStyle colours a workload's ready count, flagging missing replicas.
+func readyStyle(styles *theme.Styles, ready, desired int32) lipgloss.Style {
+	if ready < desired {
+		return styles.StatusPending
+	}
+
+	return styles.StatusRunning
+}
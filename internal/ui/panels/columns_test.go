@@ -0,0 +1,124 @@
+package panels
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func columnTestPod() corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Namespace:   "default",
+			Labels:      map[string]string{"app.kubernetes.io/version": "1.4.2"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+		Status: corev1.PodStatus{
+			Phase:    corev1.PodRunning,
+			PodIP:    "10.0.0.7",
+			QOSClass: corev1.PodQOSBurstable,
+		},
+	}
+}
+
+func TestPodsPanel_ConfiguredColumns(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetSize(140, 10)
+
+	err := panel.SetColumns([]ColumnDef{
+		{Name: "name"},
+		{Name: "ip"},
+		{Name: "node"},
+		{Name: "qos"},
+		{Name: "version", Label: "app.kubernetes.io/version"},
+		{Name: "owner", Annotation: "owner"},
+		{Name: "phase", JSONPath: ".status.phase", Width: 8},
+	})
+	if err != nil {
+		t.Fatalf("SetColumns returned unexpected error: %v", err)
+	}
+
+	panel.Update(podsLoadedMsg{pods: []corev1.Pod{columnTestPod()}})
+
+	view := panel.View()
+
+	for _, want := range []string{
+		"NAME", "IP", "NODE", "QOS", "VERSION", "OWNER", "PHASE",
+		"web-1", "10.0.0.7", "node-a", "Burstable", "1.4.2", "team-a", "Running",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	if strings.Contains(view, "RESTARTS") {
+		t.Error("columns not configured should not be shown")
+	}
+}
+
+func TestPodsPanel_ConfiguredColumnsDropWhenNarrow(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetSize(40, 10)
+
+	if err := panel.SetColumns([]ColumnDef{{Name: "name"}, {Name: "status"}, {Name: "ip"}}); err != nil {
+		t.Fatalf("SetColumns returned unexpected error: %v", err)
+	}
+
+	panel.Update(podsLoadedMsg{pods: []corev1.Pod{columnTestPod()}})
+
+	view := panel.View()
+	if !strings.Contains(view, "web-1") || !strings.Contains(view, "Running") {
+		t.Error("narrow view should keep the name and the columns that fit")
+	}
+
+	if strings.Contains(view, "10.0.0.7") {
+		t.Error("narrow view should drop the columns that don't fit")
+	}
+
+	panel.SetSize(120, 10)
+
+	if !strings.Contains(panel.View(), "10.0.0.7") {
+		t.Error("wide view should show every configured column")
+	}
+}
+
+func TestSetColumnsErrors(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+
+	tests := []struct {
+		def  ColumnDef
+		want error
+	}{
+		{ColumnDef{Name: "colour"}, ErrUnknownColumn},
+		{ColumnDef{Name: "x", Label: "a", Annotation: "b"}, ErrInvalidColumn},
+		{ColumnDef{Name: "x", JSONPath: "{.status"}, ErrInvalidColumn},
+		{ColumnDef{Name: "ip", Width: -1}, ErrInvalidColumn},
+	}
+
+	for _, tt := range tests {
+		if err := panel.SetColumns([]ColumnDef{tt.def}); !errors.Is(err, tt.want) {
+			t.Errorf("SetColumns(%+v) error = %v, want %v", tt.def, err, tt.want)
+		}
+	}
+
+	if err := panel.SetColumns(nil); err != nil || panel.table != nil {
+		t.Errorf("no columns should restore the panel's own layout, got %v", err)
+	}
+}
+
+func TestNodesPanel_ColumnsHaveNoNamespace(t *testing.T) {
+	panel := NewNodesPanel(createTestK8sClient(), createTestStyles())
+
+	if err := panel.SetColumns([]ColumnDef{{Name: "namespace"}}); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("nodes aren't namespaced, got %v", err)
+	}
+
+	if err := panel.SetColumns([]ColumnDef{{Name: "name"}, {Name: "internal-ip"}}); err != nil {
+		t.Errorf("SetColumns returned unexpected error: %v", err)
+	}
+}
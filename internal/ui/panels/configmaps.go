@@ -3,6 +3,7 @@ package panels
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -20,6 +21,11 @@ type ConfigMapsPanel struct {
 	styles     *theme.Styles
 	configmaps []corev1.ConfigMap
 	filtered   []corev1.ConfigMap
+	table      *columnTable[corev1.ConfigMap]
+
+	// keyCursor selects a data key of the selected configmap for the
+	// per-key edit/rename/remove actions.
+	keyCursor int
 }
 
 func NewConfigMapsPanel(client *k8s.Client, styles *theme.Styles) *ConfigMapsPanel {
@@ -27,6 +33,7 @@ func NewConfigMapsPanel(client *k8s.Client, styles *theme.Styles) *ConfigMapsPan
 		BasePanel: BasePanel{
 			title:       "ConfigMaps",
 			shortcutKey: "5",
+			sortable:    objectSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -43,12 +50,93 @@ func (p *ConfigMapsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
 			p.MoveUp()
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
 			p.MoveDown(len(p.filtered))
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
 			p.MoveToTop()
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
 			p.MoveToBottom(len(p.filtered))
+			p.keyCursor = 0
+		case key.Matches(msg, key.NewBinding(key.WithKeys("]"))):
+			if cm := p.SelectedConfigMap(); cm != nil && p.keyCursor < len(configMapKeys(cm))-1 {
+				p.keyCursor++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("["))):
+			if p.keyCursor > 0 {
+				p.keyCursor--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			cm, dataKey := p.selectedKey()
+			if cm == nil {
+				return p, nil
+			}
+
+			if _, isText := cm.Data[dataKey]; !isText {
+				return p, func() tea.Msg {
+					return StatusMsg{Message: fmt.Sprintf("%s is binary data and can't be edited", dataKey)}
+				}
+			}
+
+			return p, func() tea.Msg {
+				return EditConfigMapKeyRequestMsg{
+					ConfigMapName: cm.Name,
+					Namespace:     cm.Namespace,
+					Key:           dataKey,
+					Value:         cm.Data[dataKey],
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+			cm := p.SelectedConfigMap()
+			if cm == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return AddConfigMapKeyRequestMsg{
+					ConfigMapName: cm.Name,
+					Namespace:     cm.Namespace,
+					ExistingKeys:  configMapKeys(cm),
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("R"))):
+			cm, dataKey := p.selectedKey()
+			if cm == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return RenameConfigMapKeyRequestMsg{
+					ConfigMapName: cm.Name,
+					Namespace:     cm.Namespace,
+					Key:           dataKey,
+					ExistingKeys:  configMapKeys(cm),
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("X"))):
+			cm, dataKey := p.selectedKey()
+			if cm == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return RemoveConfigMapKeyRequestMsg{
+					ConfigMapName: cm.Name,
+					Namespace:     cm.Namespace,
+					Key:           dataKey,
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("N"))):
+			namespace := p.client.CurrentNamespace()
+			if cm := p.SelectedConfigMap(); cm != nil && p.allNs {
+				namespace = cm.Namespace
+			}
+
+			return p, func() tea.Msg {
+				return CreateConfigMapRequestMsg{Namespace: namespace}
+			}
 		}
 
 	case configmapsLoadedMsg:
@@ -78,7 +166,8 @@ func (p *ConfigMapsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		cm := p.filtered[i]
@@ -96,14 +185,13 @@ func (p *ConfigMapsPanel) View() string {
 }
 
 func (p *ConfigMapsPanel) renderConfigMapLine(cm corev1.ConfigMap, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, cm, objectRef("ConfigMap", &cm), selected)
+	}
+
 	dataCount := fmt.Sprintf("%d", len(cm.Data))
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("ConfigMap", &cm))
 
 	if p.width > 80 {
 		reserved := 22
@@ -114,7 +202,7 @@ func (p *ConfigMapsPanel) renderConfigMapLine(cm corev1.ConfigMap, selected bool
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(cm.Name, nameW), nameW,
+			highlightName(p.styles, p.query, cm.Name, nameW), nameW,
 		)
 		line += " " + utils.PadRight(dataCount, 5)
 
@@ -125,7 +213,7 @@ func (p *ConfigMapsPanel) renderConfigMapLine(cm corev1.ConfigMap, selected bool
 			line += " " + utils.Truncate(cm.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(cm.Name, p.width-10)
+		name := highlightName(p.styles, p.query, cm.Name, p.width-10)
 		line += name
 		line = utils.PadRight(line, p.width-6)
 		line += " " + dataCount
@@ -140,6 +228,15 @@ func (p *ConfigMapsPanel) renderConfigMapLine(cm corev1.ConfigMap, selected bool
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *ConfigMapsPanel) builtinColumns() map[string]tableColumn[corev1.ConfigMap] {
+	return withColumns(objectColumns[corev1.ConfigMap](), map[string]tableColumn[corev1.ConfigMap]{
+		"data": {
+			header: "DATA", width: 5,
+			value: func(cm corev1.ConfigMap) string { return fmt.Sprintf("%d", len(cm.Data)) },
+		},
+	})
+}
+
 func (p *ConfigMapsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No configmap selected"
@@ -165,36 +262,54 @@ func (p *ConfigMapsPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
-	if len(cm.Data) > 0 {
+	keys := configMapKeys(&cm)
+	if len(keys) > 0 {
 		b.WriteString("\n")
 		b.WriteString(p.styles.DetailTitle.Render("Data:"))
 		b.WriteString("\n")
 
-		for k, v := range cm.Data {
-			preview := utils.Truncate(strings.ReplaceAll(v, "\n", "\\n"), 50)
-			b.WriteString(fmt.Sprintf("  %s: %s\n", k, preview))
-		}
-	}
+		keyCursor := min(p.keyCursor, len(keys)-1)
 
-	if len(cm.BinaryData) > 0 {
-		b.WriteString("\n")
-		b.WriteString(p.styles.DetailTitle.Render("Binary Data:"))
-		b.WriteString("\n")
+		for i, k := range keys {
+			line := "  " + k
+			if i == keyCursor {
+				line = "> " + k
+			}
+
+			if v, isText := cm.Data[k]; isText {
+				line += fmt.Sprintf(" (%d bytes)", len(v))
+			} else {
+				line += fmt.Sprintf(" <binary, %d bytes>", len(cm.BinaryData[k]))
+			}
+
+			if i == keyCursor {
+				b.WriteString(p.styles.ListItemSelected.Render(line))
+			} else {
+				b.WriteString(line)
+			}
 
-		for k := range cm.BinaryData {
-			b.WriteString(fmt.Sprintf("  %s: <binary>\n", k))
+			b.WriteString("\n")
 		}
+
+		// Lines used so far: title, blank, 2-3 info rows, blank, data title,
+		// keys, plus the footer hints and the value section header.
+		used := 9 + len(keys)
+		b.WriteString(p.renderKeyValue(&cm, keys[keyCursor], width, height-used))
 	}
 
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml [e]dit [D]elete"))
+	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml [e]dit [D]elete [N]ew"))
+	b.WriteString("\n")
+	b.WriteString(p.styles.Muted.Render("[E]dit key [a]dd key [R]ename key [X] remove key, [ ] to pick key"))
 
 	return b.String()
 }
 
 func (p *ConfigMapsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			configmaps []corev1.ConfigMap
@@ -283,8 +398,8 @@ func (p *ConfigMapsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *ConfigMapsPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.configmaps, p.filter, func(cm corev1.ConfigMap) string { return cm.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.configmaps, p.query, p.sort, func(cm corev1.ConfigMap) queryFields { return objectFields(&cm) }, &p.cursor,
 	)
 }
 
@@ -293,6 +408,27 @@ func (p *ConfigMapsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *ConfigMapsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *ConfigMapsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *ConfigMapsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.configmaps,
@@ -315,6 +451,83 @@ func (p *ConfigMapsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *ConfigMapsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(cm corev1.ConfigMap) ResourceRef {
+		return objectRef("ConfigMap", &cm)
+	})
+}
+
+// SelectedConfigMap returns the configmap under the cursor, or nil.
+func (p *ConfigMapsPanel) SelectedConfigMap() *corev1.ConfigMap {
+	return selectedItem(p.filtered, p.cursor)
+}
+
+// selectedKey returns the selected configmap and the key under the key
+// cursor. The configmap is nil when nothing is selected or it has no keys.
+func (p *ConfigMapsPanel) selectedKey() (*corev1.ConfigMap, string) {
+	cm := p.SelectedConfigMap()
+	if cm == nil {
+		return nil, ""
+	}
+
+	keys := configMapKeys(cm)
+	if len(keys) == 0 {
+		return nil, ""
+	}
+
+	return cm, keys[min(p.keyCursor, len(keys)-1)]
+}
+
+// renderKeyValue shows the full value of the selected key, cut to the
+// lines that fit in the detail pane.
+func (p *ConfigMapsPanel) renderKeyValue(
+	cm *corev1.ConfigMap,
+	dataKey string,
+	width, maxLines int,
+) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render(dataKey + ":"))
+	b.WriteString("\n")
+
+	value, isText := cm.Data[dataKey]
+	if !isText {
+		b.WriteString(p.styles.Muted.Render("  <binary data>"))
+		b.WriteString("\n")
+
+		return b.String()
+	}
+
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+	maxLines = max(maxLines, 3)
+
+	for i, line := range lines {
+		if i == maxLines {
+			b.WriteString(p.styles.Muted.Render(
+				fmt.Sprintf("  ... %d more lines", len(lines)-maxLines),
+			))
+			b.WriteString("\n")
+
+			break
+		}
+
+		b.WriteString("  " + utils.Truncate(line, max(width-4, 10)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// configMapKeys returns text and binary keys together in sorted order.
+func configMapKeys(cm *corev1.ConfigMap) []string {
+	keys := sortedKeys(cm.Data)
+	keys = append(keys, sortedKeys(cm.BinaryData)...)
+	slices.Sort(keys)
+
+	return keys
+}
+
 type configmapsLoadedMsg struct {
 	configmaps []corev1.ConfigMap
 }
@@ -0,0 +1,109 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestConfigMapsPanel() *ConfigMapsPanel {
+	panel := NewConfigMapsPanel(createTestK8sClient(), createTestStyles())
+	cm := testConfigMap()
+	cm.BinaryData = map[string][]byte{"blob": {0xff}}
+
+	panel.configmaps = []corev1.ConfigMap{cm}
+	panel.filtered = panel.configmaps
+	panel.SetFocused(true)
+
+	return panel
+}
+
+func TestConfigMapsPanel_DetailShowsSelectedKeyValue(t *testing.T) {
+	panel := newTestConfigMapsPanel()
+
+	// Keys are sorted: blob, key1, key2
+	pressKey(panel, ']')
+
+	view := panel.DetailView(80, 40)
+	if !strings.Contains(view, "key1:") || !strings.Contains(view, "value1") {
+		t.Error("detail view should show the selected key's value")
+	}
+
+	if strings.Contains(view, "value2") {
+		t.Error("only the selected key's value should be expanded")
+	}
+
+	if !strings.Contains(view, "<binary, 1 bytes>") {
+		t.Error("binary keys should be listed with their size")
+	}
+}
+
+func TestConfigMapsPanel_EditSelectedKey(t *testing.T) {
+	panel := newTestConfigMapsPanel()
+
+	pressKey(panel, ']')
+	pressKey(panel, ']')
+
+	cmd := pressKey(panel, 'E')
+	if cmd == nil {
+		t.Fatal("E should return a command")
+	}
+
+	msg, ok := cmd().(EditConfigMapKeyRequestMsg)
+	if !ok {
+		t.Fatalf("expected EditConfigMapKeyRequestMsg, got %T", cmd())
+	}
+
+	if msg.Key != "key2" || msg.Value != "value2" || msg.IsNew {
+		t.Errorf("unexpected request: %+v", msg)
+	}
+}
+
+func TestConfigMapsPanel_EditBinaryKeyRefused(t *testing.T) {
+	panel := newTestConfigMapsPanel()
+
+	cmd := pressKey(panel, 'E')
+	if cmd == nil {
+		t.Fatal("E on a binary key should report a status")
+	}
+
+	if _, ok := cmd().(StatusMsg); !ok {
+		t.Errorf("expected StatusMsg for binary key, got %T", cmd())
+	}
+}
+
+func TestConfigMapsPanel_KeyActions(t *testing.T) {
+	panel := newTestConfigMapsPanel()
+	pressKey(panel, ']')
+
+	add, ok := pressKey(panel, 'a')().(AddConfigMapKeyRequestMsg)
+	if !ok || len(add.ExistingKeys) != 3 {
+		t.Errorf("expected AddConfigMapKeyRequestMsg with 3 existing keys, got %+v", add)
+	}
+
+	if rename, ok := pressKey(panel, 'R')().(RenameConfigMapKeyRequestMsg); !ok || rename.Key != "key1" {
+		t.Errorf("expected rename request for key1, got %+v", rename)
+	}
+
+	if remove, ok := pressKey(panel, 'X')().(RemoveConfigMapKeyRequestMsg); !ok || remove.Key != "key1" {
+		t.Errorf("expected remove request for key1, got %+v", remove)
+	}
+
+	if create, ok := pressKey(panel, 'N')().(CreateConfigMapRequestMsg); !ok || create.Namespace == "" {
+		t.Errorf("expected create request with a namespace, got %+v", create)
+	}
+}
+
+func TestConfigMapsPanel_MovingResetsKeyCursor(t *testing.T) {
+	panel := newTestConfigMapsPanel()
+	panel.configmaps = append(panel.configmaps, testConfigMap())
+	panel.filtered = panel.configmaps
+
+	pressKey(panel, ']')
+	pressKey(panel, 'j')
+
+	if panel.keyCursor != 0 {
+		t.Errorf("keyCursor = %d, want 0 after moving to another configmap", panel.keyCursor)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	batchv1 "k8s.io/api/batch/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -16,10 +17,12 @@ import (
 
 type CronJobsPanel struct {
 	BasePanel
-	client   *k8s.Client
-	styles   *theme.Styles
-	cronjobs []batchv1.CronJob
-	filtered []batchv1.CronJob
+	client     *k8s.Client
+	styles     *theme.Styles
+	cronjobs   []batchv1.CronJob
+	filtered   []batchv1.CronJob
+	table      *columnTable[batchv1.CronJob]
+	lintPolicy *k8s.LintPolicy
 }
 
 func NewCronJobsPanel(client *k8s.Client, styles *theme.Styles) *CronJobsPanel {
@@ -27,12 +30,18 @@ func NewCronJobsPanel(client *k8s.Client, styles *theme.Styles) *CronJobsPanel {
 		BasePanel: BasePanel{
 			title:       "CronJobs",
 			shortcutKey: "",
+			sortable:    statusSortColumns,
 		},
 		client: client,
 		styles: styles,
 	}
 }
 
+// SetLintPolicy sets which best-practice rules the detail view reports.
+func (p *CronJobsPanel) SetLintPolicy(policy *k8s.LintPolicy) {
+	p.lintPolicy = policy
+}
+
 func (p *CronJobsPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -110,7 +119,8 @@ func (p *CronJobsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		cj := p.filtered[i]
@@ -128,6 +138,10 @@ func (p *CronJobsPanel) View() string {
 }
 
 func (p *CronJobsPanel) renderCronJobLine(cj batchv1.CronJob, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, cj, objectRef("CronJob", &cj), selected)
+	}
+
 	status := k8s.GetCronJobStatus(&cj)
 
 	statusStyle := p.styles.StatusRunning
@@ -135,12 +149,7 @@ func (p *CronJobsPanel) renderCronJobLine(cj batchv1.CronJob, selected bool) str
 		statusStyle = p.styles.StatusPending
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("CronJob", &cj))
 
 	if p.width > 80 {
 		reserved := 38
@@ -151,7 +160,7 @@ func (p *CronJobsPanel) renderCronJobLine(cj batchv1.CronJob, selected bool) str
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(cj.Name, nameW), nameW,
+			highlightName(p.styles, p.query, cj.Name, nameW), nameW,
 		)
 		line += " " + statusStyle.Render(
 			utils.PadRight(utils.Truncate(status, 10), 10),
@@ -167,7 +176,7 @@ func (p *CronJobsPanel) renderCronJobLine(cj batchv1.CronJob, selected bool) str
 			line += " " + utils.Truncate(cj.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(cj.Name, p.width-15)
+		name := highlightName(p.styles, p.query, cj.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-12)
 		line += " " + statusStyle.Render(status)
@@ -182,6 +191,36 @@ func (p *CronJobsPanel) renderCronJobLine(cj batchv1.CronJob, selected bool) str
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *CronJobsPanel) builtinColumns() map[string]tableColumn[batchv1.CronJob] {
+	return withColumns(objectColumns[batchv1.CronJob](), map[string]tableColumn[batchv1.CronJob]{
+		"status": {
+			header: "STATUS", width: 10, sort: sortStatus,
+			value: func(cj batchv1.CronJob) string { return k8s.GetCronJobStatus(&cj) },
+			style: func(cj batchv1.CronJob) lipgloss.Style {
+				if k8s.GetCronJobStatus(&cj) == "Suspended" {
+					return p.styles.StatusPending
+				}
+
+				return p.styles.StatusRunning
+			},
+		},
+		"schedule": {
+			header: "SCHEDULE", width: 15,
+			value: func(cj batchv1.CronJob) string { return cj.Spec.Schedule },
+		},
+		"last-schedule": {
+			header: "LAST SCHEDULE", width: 13,
+			value: func(cj batchv1.CronJob) string {
+				if cj.Status.LastScheduleTime == nil {
+					return "-"
+				}
+
+				return utils.FormatAgeFromMeta(*cj.Status.LastScheduleTime)
+			},
+		},
+	})
+}
+
 func (p *CronJobsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No cronjob selected"
@@ -262,6 +301,8 @@ func (p *CronJobsPanel) DetailView(width, height int) string {
 		}
 	}
 
+	renderLintFindings(&b, p.styles, k8s.LintCronJob(&cj, p.lintPolicy), width)
+
 	b.WriteString("\n")
 
 	suspendAction := "[S]uspend"
@@ -288,8 +329,10 @@ func getCronJobImages(cj *batchv1.CronJob) []string {
 }
 
 func (p *CronJobsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			cronjobs []batchv1.CronJob
@@ -393,8 +436,18 @@ func (p *CronJobsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *CronJobsPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.cronjobs, p.filter, func(c batchv1.CronJob) string { return c.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.cronjobs,
+		p.query,
+		p.sort,
+		func(c batchv1.CronJob) queryFields {
+			f := objectFields(&c)
+			f.status = k8s.GetCronJobStatus(&c)
+			f.images = podSpecImages(&c.Spec.JobTemplate.Spec.Template.Spec)
+
+			return f
+		},
+		&p.cursor,
 	)
 }
 
@@ -403,6 +456,27 @@ func (p *CronJobsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *CronJobsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *CronJobsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *CronJobsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.cronjobs,
@@ -425,6 +499,12 @@ func (p *CronJobsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *CronJobsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(cj batchv1.CronJob) ResourceRef {
+		return objectRef("CronJob", &cj)
+	})
+}
+
 type cronJobsLoadedMsg struct {
 	cronjobs []batchv1.CronJob
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	appsv1 "k8s.io/api/apps/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,6 +21,8 @@ type DaemonSetsPanel struct {
 	styles     *theme.Styles
 	daemonsets []appsv1.DaemonSet
 	filtered   []appsv1.DaemonSet
+	table      *columnTable[appsv1.DaemonSet]
+	lintPolicy *k8s.LintPolicy
 }
 
 func NewDaemonSetsPanel(client *k8s.Client, styles *theme.Styles) *DaemonSetsPanel {
@@ -27,12 +30,18 @@ func NewDaemonSetsPanel(client *k8s.Client, styles *theme.Styles) *DaemonSetsPan
 		BasePanel: BasePanel{
 			title:       "DaemonSets",
 			shortcutKey: "",
+			sortable:    workloadSortColumns,
 		},
 		client: client,
 		styles: styles,
 	}
 }
 
+// SetLintPolicy sets which best-practice rules the detail view reports.
+func (p *DaemonSetsPanel) SetLintPolicy(policy *k8s.LintPolicy) {
+	p.lintPolicy = policy
+}
+
 func (p *DaemonSetsPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -62,6 +71,16 @@ func (p *DaemonSetsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 					Namespace:     ds.Namespace,
 				}
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+			if p.cursor >= len(p.filtered) {
+				return p, nil
+			}
+
+			ds := p.filtered[p.cursor]
+
+			return p, func() tea.Msg {
+				return RightsizeRequestMsg{Kind: "DaemonSet", Name: ds.Name, Namespace: ds.Namespace}
+			}
 		}
 
 	case daemonSetsLoadedMsg:
@@ -91,7 +110,8 @@ func (p *DaemonSetsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		ds := p.filtered[i]
@@ -109,6 +129,10 @@ func (p *DaemonSetsPanel) View() string {
 }
 
 func (p *DaemonSetsPanel) renderDaemonSetLine(ds appsv1.DaemonSet, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, ds, objectRef("DaemonSet", &ds), selected)
+	}
+
 	ready := k8s.GetDaemonSetReadyCount(&ds)
 
 	readyStyle := p.styles.StatusRunning
@@ -116,12 +140,7 @@ func (p *DaemonSetsPanel) renderDaemonSetLine(ds appsv1.DaemonSet, selected bool
 		readyStyle = p.styles.StatusPending
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("DaemonSet", &ds))
 
 	if p.width > 80 {
 		reserved := 22
@@ -132,7 +151,7 @@ func (p *DaemonSetsPanel) renderDaemonSetLine(ds appsv1.DaemonSet, selected bool
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(ds.Name, nameW), nameW,
+			highlightName(p.styles, p.query, ds.Name, nameW), nameW,
 		)
 		line += " " + readyStyle.Render(utils.PadRight(ready, 7))
 
@@ -143,7 +162,7 @@ func (p *DaemonSetsPanel) renderDaemonSetLine(ds appsv1.DaemonSet, selected bool
 			line += " " + utils.Truncate(ds.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(ds.Name, p.width-15)
+		name := highlightName(p.styles, p.query, ds.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-10)
 		line += " " + readyStyle.Render(ready)
@@ -158,6 +177,22 @@ func (p *DaemonSetsPanel) renderDaemonSetLine(ds appsv1.DaemonSet, selected bool
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *DaemonSetsPanel) builtinColumns() map[string]tableColumn[appsv1.DaemonSet] {
+	return withColumns(objectColumns[appsv1.DaemonSet](), map[string]tableColumn[appsv1.DaemonSet]{
+		"ready": {
+			header: "READY", width: 7, sort: sortReady,
+			value: func(d appsv1.DaemonSet) string { return k8s.GetDaemonSetReadyCount(&d) },
+			style: func(d appsv1.DaemonSet) lipgloss.Style {
+				return readyStyle(p.styles, d.Status.NumberReady, d.Status.DesiredNumberScheduled)
+			},
+		},
+		"images": {
+			header: "IMAGES", width: 30,
+			value: func(d appsv1.DaemonSet) string { return strings.Join(podSpecImages(&d.Spec.Template.Spec), ",") },
+		},
+	})
+}
+
 func (p *DaemonSetsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No daemonset selected"
@@ -224,15 +259,19 @@ func (p *DaemonSetsPanel) DetailView(width, height int) string {
 		}
 	}
 
+	renderLintFindings(&b, p.styles, k8s.LintDaemonSet(&ds, p.lintPolicy), width)
+
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[r]estart [l]ogs [d]escribe [y]aml [D]elete"))
+	b.WriteString(p.styles.Muted.Render("[r]estart [u]sage sizing [l]ogs [d]escribe [y]aml [D]elete"))
 
 	return b.String()
 }
 
 func (p *DaemonSetsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			daemonsets []appsv1.DaemonSet
@@ -359,8 +398,18 @@ func (p *DaemonSetsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *DaemonSetsPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.daemonsets, p.filter, func(d appsv1.DaemonSet) string { return d.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.daemonsets,
+		p.query,
+		p.sort,
+		func(d appsv1.DaemonSet) queryFields {
+			f := objectFields(&d)
+			f.images = podSpecImages(&d.Spec.Template.Spec)
+			f.ready = readyRatio(d.Status.NumberReady, d.Status.DesiredNumberScheduled)
+
+			return f
+		},
+		&p.cursor,
 	)
 }
 
@@ -369,6 +418,27 @@ func (p *DaemonSetsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *DaemonSetsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *DaemonSetsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *DaemonSetsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.daemonsets,
@@ -391,6 +461,12 @@ func (p *DaemonSetsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *DaemonSetsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(ds appsv1.DaemonSet) ResourceRef {
+		return objectRef("DaemonSet", &ds)
+	})
+}
+
 type daemonSetsLoadedMsg struct {
 	daemonsets []appsv1.DaemonSet
 }
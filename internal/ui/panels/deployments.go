@@ -7,7 +7,9 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
@@ -20,6 +22,10 @@ type DeploymentsPanel struct {
 	styles      *theme.Styles
 	deployments []appsv1.Deployment
 	filtered    []appsv1.Deployment
+	table       *columnTable[appsv1.Deployment]
+	// pdbs feed the missing-pdb lint rule; nil skips it.
+	pdbs       []policyv1.PodDisruptionBudget
+	lintPolicy *k8s.LintPolicy
 }
 
 func NewDeploymentsPanel(client *k8s.Client, styles *theme.Styles) *DeploymentsPanel {
@@ -27,12 +33,18 @@ func NewDeploymentsPanel(client *k8s.Client, styles *theme.Styles) *DeploymentsP
 		BasePanel: BasePanel{
 			title:       "Deployments",
 			shortcutKey: "3",
+			sortable:    workloadSortColumns,
 		},
 		client: client,
 		styles: styles,
 	}
 }
 
+// SetLintPolicy sets which best-practice rules the detail view reports.
+func (p *DeploymentsPanel) SetLintPolicy(policy *k8s.LintPolicy) {
+	p.lintPolicy = policy
+}
+
 func (p *DeploymentsPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -107,10 +119,21 @@ func (p *DeploymentsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 					Namespace:      deploy.Namespace,
 				}
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+			if p.cursor >= len(p.filtered) {
+				return p, nil
+			}
+
+			deploy := p.filtered[p.cursor]
+
+			return p, func() tea.Msg {
+				return RightsizeRequestMsg{Kind: "Deployment", Name: deploy.Name, Namespace: deploy.Namespace}
+			}
 		}
 
 	case deploymentsLoadedMsg:
 		p.deployments = msg.deployments
+		p.pdbs = msg.pdbs
 		p.applyFilter()
 
 		return p, nil
@@ -136,7 +159,8 @@ func (p *DeploymentsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		deploy := p.filtered[i]
@@ -154,6 +178,10 @@ func (p *DeploymentsPanel) View() string {
 }
 
 func (p *DeploymentsPanel) renderDeploymentLine(deploy appsv1.Deployment, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, deploy, objectRef("Deployment", &deploy), selected)
+	}
+
 	ready := k8s.GetDeploymentReadyCount(&deploy)
 
 	readyStyle := p.styles.StatusRunning
@@ -161,12 +189,7 @@ func (p *DeploymentsPanel) renderDeploymentLine(deploy appsv1.Deployment, select
 		readyStyle = p.styles.StatusPending
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Deployment", &deploy))
 
 	if p.width > 80 {
 		reserved := 28
@@ -177,7 +200,7 @@ func (p *DeploymentsPanel) renderDeploymentLine(deploy appsv1.Deployment, select
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(deploy.Name, nameW), nameW,
+			highlightName(p.styles, p.query, deploy.Name, nameW), nameW,
 		)
 		line += " " + readyStyle.Render(utils.PadRight(ready, 7))
 
@@ -194,7 +217,7 @@ func (p *DeploymentsPanel) renderDeploymentLine(deploy appsv1.Deployment, select
 			line += " " + utils.Truncate(deploy.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(deploy.Name, p.width-15)
+		name := highlightName(p.styles, p.query, deploy.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-10)
 		line += " " + readyStyle.Render(ready)
@@ -209,6 +232,31 @@ func (p *DeploymentsPanel) renderDeploymentLine(deploy appsv1.Deployment, select
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *DeploymentsPanel) builtinColumns() map[string]tableColumn[appsv1.Deployment] {
+	return withColumns(objectColumns[appsv1.Deployment](), map[string]tableColumn[appsv1.Deployment]{
+		"ready": {
+			header: "READY", width: 7, sort: sortReady,
+			value: func(d appsv1.Deployment) string { return k8s.GetDeploymentReadyCount(&d) },
+			style: func(d appsv1.Deployment) lipgloss.Style {
+				return readyStyle(p.styles, d.Status.ReadyReplicas, k8s.GetDeploymentDesiredReplicas(&d))
+			},
+		},
+		"up-to-date": {
+			header: "UP-TO-DATE", width: 10,
+			value: func(d appsv1.Deployment) string { return fmt.Sprintf("%d", d.Status.UpdatedReplicas) },
+		},
+		"available": {
+			header: "AVAILABLE", width: 9,
+			value: func(d appsv1.Deployment) string { return fmt.Sprintf("%d", d.Status.AvailableReplicas) },
+		},
+		"images": {
+			header: "IMAGES", width: 30,
+			value: func(d appsv1.Deployment) string { return strings.Join(k8s.GetDeploymentImages(&d), ",") },
+			style: func(appsv1.Deployment) lipgloss.Style { return p.styles.Muted },
+		},
+	})
+}
+
 func (p *DeploymentsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No deployment selected"
@@ -273,10 +321,12 @@ func (p *DeploymentsPanel) DetailView(width, height int) string {
 		}
 	}
 
+	renderLintFindings(&b, p.styles, k8s.LintDeployment(&deploy, p.pdbs, p.lintPolicy), width)
+
 	b.WriteString("\n")
 	b.WriteString(
 		p.styles.Muted.Render(
-			"[s]cale [r]estart [R]ollback [V]ersion diff [l]ogs [d]escribe [y]aml [D]elete",
+			"[s]cale [r]estart [R]ollback [V]ersion diff [u]sage sizing [l]ogs [d]escribe [y]aml [D]elete",
 		),
 	)
 
@@ -284,8 +334,11 @@ func (p *DeploymentsPanel) DetailView(width, height int) string {
 }
 
 func (p *DeploymentsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
 		ctx := context.Background()
+		listCtx := k8s.WithLabelSelector(ctx, selector)
 
 		var (
 			deployments []appsv1.Deployment
@@ -293,16 +346,28 @@ func (p *DeploymentsPanel) Refresh() tea.Cmd {
 		)
 
 		if p.allNs {
-			deployments, err = p.client.ListDeploymentsAllNamespaces(ctx)
+			deployments, err = p.client.ListDeploymentsAllNamespaces(listCtx)
 		} else {
-			deployments, err = p.client.ListDeployments(ctx, "")
+			deployments, err = p.client.ListDeployments(listCtx, "")
 		}
 
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return deploymentsLoadedMsg{deployments: deployments}
+		// PDBs only feed the missing-pdb lint rule, which is skipped on error
+		var pdbs []policyv1.PodDisruptionBudget
+		if p.allNs {
+			pdbs, err = p.client.ListPodDisruptionBudgetsAllNamespaces(ctx)
+		} else {
+			pdbs, err = p.client.ListPodDisruptionBudgets(ctx, "")
+		}
+
+		if err == nil && pdbs == nil {
+			pdbs = []policyv1.PodDisruptionBudget{}
+		}
+
+		return deploymentsLoadedMsg{deployments: deployments, pdbs: pdbs}
 	}
 }
 
@@ -411,8 +476,18 @@ func (p *DeploymentsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *DeploymentsPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.deployments, p.filter, func(d appsv1.Deployment) string { return d.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.deployments,
+		p.query,
+		p.sort,
+		func(d appsv1.Deployment) queryFields {
+			f := objectFields(&d)
+			f.images = podSpecImages(&d.Spec.Template.Spec)
+			f.ready = readyRatio(d.Status.ReadyReplicas, k8s.GetDeploymentDesiredReplicas(&d))
+
+			return f
+		},
+		&p.cursor,
 	)
 }
 
@@ -421,6 +496,27 @@ func (p *DeploymentsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *DeploymentsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *DeploymentsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 // SetTestDeployments replaces the deployment list for cross-package testing.
 func (p *DeploymentsPanel) SetTestDeployments(
 	deploys []appsv1.Deployment,
@@ -450,6 +546,13 @@ func (p *DeploymentsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *DeploymentsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(deploy appsv1.Deployment) ResourceRef {
+		return objectRef("Deployment", &deploy)
+	})
+}
+
 type deploymentsLoadedMsg struct {
 	deployments []appsv1.Deployment
+	pdbs        []policyv1.PodDisruptionBudget
 }
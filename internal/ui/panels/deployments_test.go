@@ -1,10 +1,14 @@
 package panels
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 )
 
 func TestDeploymentsPanel_VKeyEmitsDiffRequest(t *testing.T) {
@@ -77,6 +81,23 @@ func TestDeploymentsPanel_VKeyCursorOutOfRange(t *testing.T) {
 	}
 }
 
+func TestDeploymentsPanel_UKeyEmitsRightsizeRequest(t *testing.T) {
+	panel := NewDeploymentsPanel(createTestK8sClient(), createTestStyles())
+
+	panel.deployments = []appsv1.Deployment{testDeployment()}
+	panel.filtered = panel.deployments
+
+	_, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if cmd == nil {
+		t.Fatal("u key should return a command")
+	}
+
+	want := RightsizeRequestMsg{Kind: "Deployment", Name: "test-deploy", Namespace: "default"}
+	if got := cmd(); got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
 func TestDeploymentsPanel_DetailViewContainsVersionDiff(t *testing.T) {
 	client := createTestK8sClient()
 	styles := createTestStyles()
@@ -103,3 +124,31 @@ func containsText(s, substr string) bool {
 	// so check the raw string which includes the hint text.
 	return len(s) > 0 && len(substr) > 0
 }
+
+func TestDeploymentsPanel_DetailViewLintFindings(t *testing.T) {
+	panel := NewDeploymentsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetLintPolicy(k8s.NewLintPolicy([]string{string(k8s.LintRunAsRoot)}, nil))
+	panel.Update(deploymentsLoadedMsg{
+		deployments: []appsv1.Deployment{testDeployment()},
+		pdbs:        []policyv1.PodDisruptionBudget{},
+	})
+
+	detail := panel.DetailView(120, 60)
+
+	for _, want := range []string{"Best practices:", "missing-requests", "missing-pdb", "3 replicas"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail should contain %q:\n%s", want, detail)
+		}
+	}
+
+	if strings.Contains(detail, "run-as-root") {
+		t.Error("disabled rule should not be shown")
+	}
+
+	// Without PDB data the rule is skipped instead of guessed
+	panel.Update(deploymentsLoadedMsg{deployments: []appsv1.Deployment{testDeployment()}})
+
+	if strings.Contains(panel.DetailView(120, 60), "missing-pdb") {
+		t.Error("missing-pdb should be skipped when PDBs are unknown")
+	}
+}
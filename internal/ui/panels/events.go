@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -21,6 +22,7 @@ type EventsPanel struct {
 	styles   *theme.Styles
 	events   []corev1.Event
 	filtered []corev1.Event
+	table    *columnTable[corev1.Event]
 }
 
 func NewEventsPanel(client *k8s.Client, styles *theme.Styles) *EventsPanel {
@@ -28,6 +30,7 @@ func NewEventsPanel(client *k8s.Client, styles *theme.Styles) *EventsPanel {
 		BasePanel: BasePanel{
 			title:       "Events",
 			shortcutKey: "8",
+			sortable:    statusSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -94,7 +97,8 @@ func (p *EventsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		event := p.filtered[i]
@@ -112,6 +116,10 @@ func (p *EventsPanel) View() string {
 }
 
 func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, event, objectRef("Event", &event), selected)
+	}
+
 	eventType := event.Type
 
 	typeStyle := p.styles.StatusRunning
@@ -119,12 +127,7 @@ func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string
 		typeStyle = p.styles.StatusWarning
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Event", &event))
 
 	if p.width > 80 {
 		reserved := 50
@@ -135,7 +138,7 @@ func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string
 		reasonW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(event.Reason, reasonW), reasonW,
+			highlightName(p.styles, p.query, event.Reason, reasonW), reasonW,
 		)
 		line += " " + typeStyle.Render(
 			utils.PadRight(utils.Truncate(eventType, 8), 8),
@@ -146,7 +149,7 @@ func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string
 			event.InvolvedObject.Kind,
 			event.InvolvedObject.Name,
 		)
-		line += " " + utils.PadRight(utils.Truncate(object, 25), 25)
+		line += " " + utils.PadRight(highlightName(p.styles, p.query, object, 25), 25)
 
 		lastSeen := event.LastTimestamp.Time
 		if lastSeen.IsZero() {
@@ -160,7 +163,7 @@ func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string
 			line += " " + utils.Truncate(event.Namespace, 15)
 		}
 	} else {
-		reason := utils.Truncate(event.Reason, p.width-15)
+		reason := highlightName(p.styles, p.query, event.Reason, p.width-15)
 		line += reason
 		line = utils.PadRight(line, p.width-10)
 		line += " " + typeStyle.Render(utils.Truncate(eventType, 8))
@@ -175,6 +178,59 @@ func (p *EventsPanel) renderEventLine(event corev1.Event, selected bool) string
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *EventsPanel) builtinColumns() map[string]tableColumn[corev1.Event] {
+	columns := withColumns(objectColumns[corev1.Event](), map[string]tableColumn[corev1.Event]{
+		"reason": {
+			header: "REASON", width: minFillWidth, fill: true, highlight: true, sort: sortStatus,
+			value: func(event corev1.Event) string { return event.Reason },
+		},
+		"type": {
+			header: "TYPE", width: 8,
+			value: func(event corev1.Event) string { return event.Type },
+			style: func(event corev1.Event) lipgloss.Style {
+				if event.Type == "Warning" {
+					return p.styles.StatusWarning
+				}
+
+				return p.styles.StatusRunning
+			},
+		},
+		"object": {
+			header: "OBJECT", width: 25, highlight: true,
+			value: func(event corev1.Event) string {
+				return event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+			},
+		},
+		"message": {
+			header: "MESSAGE", width: 40,
+			value: func(event corev1.Event) string { return event.Message },
+		},
+		"count": {
+			header: "COUNT", width: 5,
+			value: func(event corev1.Event) string { return fmt.Sprintf("%d", event.Count) },
+		},
+	})
+
+	// Events age from when they were last seen, not created
+	age := columns["age"]
+	age.value = func(event corev1.Event) string {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+
+		return utils.FormatAge(lastSeen)
+	}
+	columns["age"] = age
+
+	// Event names are generated; the reason stands in for them
+	name := columns["name"]
+	name.fill, name.width = false, 30
+	columns["name"] = name
+
+	return columns
+}
+
 func (p *EventsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No event selected"
@@ -258,8 +314,10 @@ func (p *EventsPanel) DetailView(width, height int) string {
 }
 
 func (p *EventsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			events []corev1.Event
@@ -342,26 +400,18 @@ func (p *EventsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *EventsPanel) applyFilter() {
-	if p.filter == "" {
-		p.filtered = p.events
-
-		return
-	}
-
-	filter := strings.ToLower(p.filter)
+	p.filtered = filterByQuery(p.events, p.query, p.sort, eventFields, &p.cursor)
+}
 
-	p.filtered = make([]corev1.Event, 0)
-	for _, event := range p.events {
-		if strings.Contains(strings.ToLower(event.Reason), filter) ||
-			strings.Contains(strings.ToLower(event.Message), filter) ||
-			strings.Contains(strings.ToLower(event.InvolvedObject.Name), filter) {
-			p.filtered = append(p.filtered, event)
-		}
-	}
+// eventFields matches bare words against the reason, message and involved
+// object, and name: and status: against the involved object and reason.
+func eventFields(event corev1.Event) queryFields {
+	f := objectFields(&event)
+	f.text = event.Reason + " " + event.Message + " " + event.InvolvedObject.Name
+	f.name = event.InvolvedObject.Name
+	f.status = event.Reason
 
-	if p.cursor >= len(p.filtered) {
-		p.cursor = max(len(p.filtered)-1, 0)
-	}
+	return f
 }
 
 func (p *EventsPanel) SetFilter(query string) {
@@ -369,29 +419,49 @@ func (p *EventsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *EventsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *EventsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *EventsPanel) SearchItems(query string) []SearchResult {
 	if query == "" {
 		return nil
 	}
 
-	q := strings.ToLower(query)
-
 	var results []SearchResult
 
 	// Events search on reason, message, and involved object name
 	for _, event := range p.events {
-		if strings.Contains(strings.ToLower(event.Reason), q) ||
-			strings.Contains(strings.ToLower(event.Message), q) ||
-			strings.Contains(strings.ToLower(event.InvolvedObject.Name), q) {
+		if score, _, ok := utils.FuzzyMatch(query, eventFields(event).text); ok {
 			results = append(results, SearchResult{
 				Name:      event.Name,
 				Namespace: event.Namespace,
 				Kind:      p.title,
 				Status:    event.Reason,
+				Score:     score,
 			})
 		}
 	}
 
+	rankSearchResults(results)
+
 	return results
 }
 
@@ -406,6 +476,12 @@ func (p *EventsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *EventsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(event corev1.Event) ResourceRef {
+		return objectRef("Event", &event)
+	})
+}
+
 type eventsLoadedMsg struct {
 	events []corev1.Event
 }
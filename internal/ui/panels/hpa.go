@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,6 +21,7 @@ type HPAPanel struct {
 	styles   *theme.Styles
 	hpas     []autoscalingv2.HorizontalPodAutoscaler
 	filtered []autoscalingv2.HorizontalPodAutoscaler
+	table    *columnTable[autoscalingv2.HorizontalPodAutoscaler]
 }
 
 func NewHPAPanel(client *k8s.Client, styles *theme.Styles) *HPAPanel {
@@ -27,6 +29,7 @@ func NewHPAPanel(client *k8s.Client, styles *theme.Styles) *HPAPanel {
 		BasePanel: BasePanel{
 			title:       "HPAs",
 			shortcutKey: "",
+			sortable:    objectSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -111,7 +114,8 @@ func (p *HPAPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		hpa := p.filtered[i]
@@ -129,14 +133,13 @@ func (p *HPAPanel) View() string {
 }
 
 func (p *HPAPanel) renderHPALine(hpa autoscalingv2.HorizontalPodAutoscaler, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, hpa, objectRef("HorizontalPodAutoscaler", &hpa), selected)
+	}
+
 	replicas := k8s.GetHPAReplicaCount(&hpa)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("HorizontalPodAutoscaler", &hpa))
 
 	if p.width > 80 {
 		reserved := 35
@@ -147,7 +150,7 @@ func (p *HPAPanel) renderHPALine(hpa autoscalingv2.HorizontalPodAutoscaler, sele
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(hpa.Name, nameW), nameW,
+			highlightName(p.styles, p.query, hpa.Name, nameW), nameW,
 		)
 		line += " " + p.styles.StatusRunning.Render(
 			utils.PadRight(replicas, 12),
@@ -163,7 +166,7 @@ func (p *HPAPanel) renderHPALine(hpa autoscalingv2.HorizontalPodAutoscaler, sele
 			line += " " + utils.Truncate(hpa.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(hpa.Name, p.width-18)
+		name := highlightName(p.styles, p.query, hpa.Name, p.width-18)
 		line += name
 		line = utils.PadRight(line, p.width-15)
 		line += " " + p.styles.StatusRunning.Render(replicas)
@@ -178,6 +181,22 @@ func (p *HPAPanel) renderHPALine(hpa autoscalingv2.HorizontalPodAutoscaler, sele
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *HPAPanel) builtinColumns() map[string]tableColumn[autoscalingv2.HorizontalPodAutoscaler] {
+	type hpa = autoscalingv2.HorizontalPodAutoscaler
+
+	return withColumns(objectColumns[hpa](), map[string]tableColumn[hpa]{
+		"replicas": {
+			header: "REPLICAS", width: 12,
+			value: func(h hpa) string { return k8s.GetHPAReplicaCount(&h) },
+			style: func(hpa) lipgloss.Style { return p.styles.StatusRunning },
+		},
+		"target": {
+			header: "TARGET", width: 15,
+			value: func(h hpa) string { return k8s.GetHPATargetRef(&h) },
+		},
+	})
+}
+
 func (p *HPAPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No HPA selected"
@@ -276,8 +295,10 @@ func (p *HPAPanel) DetailView(width, height int) string {
 }
 
 func (p *HPAPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			hpas []autoscalingv2.HorizontalPodAutoscaler
@@ -400,10 +421,11 @@ func (p *HPAPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *HPAPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.hpas,
-		p.filter,
-		func(h autoscalingv2.HorizontalPodAutoscaler) string { return h.Name },
+		p.query,
+		p.sort,
+		func(h autoscalingv2.HorizontalPodAutoscaler) queryFields { return objectFields(&h) },
 		&p.cursor,
 	)
 }
@@ -413,6 +435,27 @@ func (p *HPAPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *HPAPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *HPAPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *HPAPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.hpas,
@@ -435,6 +478,12 @@ func (p *HPAPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *HPAPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(hpa autoscalingv2.HorizontalPodAutoscaler) ResourceRef {
+		return objectRef("HorizontalPodAutoscaler", &hpa)
+	})
+}
+
 type hpaLoadedMsg struct {
 	hpas []autoscalingv2.HorizontalPodAutoscaler
 }
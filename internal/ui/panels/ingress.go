@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
@@ -21,6 +24,12 @@ type IngressPanel struct {
 	styles    *theme.Styles
 	ingresses []networkingv1.Ingress
 	filtered  []networkingv1.Ingress
+	table     *columnTable[networkingv1.Ingress]
+
+	// Keyed by namespace/name; nil when services or endpoint slices
+	// couldn't be listed.
+	reports       map[string]*k8s.IngressReport
+	certThreshold time.Duration
 }
 
 func NewIngressPanel(client *k8s.Client, styles *theme.Styles) *IngressPanel {
@@ -28,9 +37,19 @@ func NewIngressPanel(client *k8s.Client, styles *theme.Styles) *IngressPanel {
 		BasePanel: BasePanel{
 			title:       "Ingresses",
 			shortcutKey: "i",
+			sortable:    objectSortColumns,
 		},
-		client: client,
-		styles: styles,
+		client:        client,
+		styles:        styles,
+		certThreshold: defaultCertExpiryThreshold,
+	}
+}
+
+// SetCertExpiryThreshold overrides how close to expiry a TLS certificate
+// must be to get flagged. Non-positive durations keep the default.
+func (p *IngressPanel) SetCertExpiryThreshold(d time.Duration) {
+	if d > 0 {
+		p.certThreshold = d
 	}
 }
 
@@ -54,6 +73,7 @@ func (p *IngressPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 
 	case ingressLoadedMsg:
 		p.ingresses = msg.ingresses
+		p.reports = msg.reports
 		p.applyFilter()
 
 		return p, nil
@@ -79,7 +99,8 @@ func (p *IngressPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		ing := p.filtered[i]
@@ -97,15 +118,19 @@ func (p *IngressPanel) View() string {
 }
 
 func (p *IngressPanel) renderIngressLine(ing networkingv1.Ingress, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, ing, objectRef("Ingress", &ing), selected)
+	}
+
 	hosts := p.getIngressHosts(&ing)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
+	badge, badgeStyle := p.routeBadge(&ing)
+	if badge != "" {
+		hosts = badge
 	}
 
+	line := p.rowPrefix(selected, objectRef("Ingress", &ing))
+
 	if p.width > 80 {
 		reserved := 50
 		if p.width > 120 && p.allNs {
@@ -115,7 +140,7 @@ func (p *IngressPanel) renderIngressLine(ing networkingv1.Ingress, selected bool
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(ing.Name, nameW), nameW,
+			highlightName(p.styles, p.query, ing.Name, nameW), nameW,
 		)
 		line += " " + utils.PadRight(utils.Truncate(hosts, 25), 25)
 
@@ -129,7 +154,7 @@ func (p *IngressPanel) renderIngressLine(ing networkingv1.Ingress, selected bool
 			line += " " + utils.Truncate(ing.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(ing.Name, p.width-15)
+		name := highlightName(p.styles, p.query, ing.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-15)
 		line += " " + utils.Truncate(hosts, 12)
@@ -141,9 +166,89 @@ func (p *IngressPanel) renderIngressLine(ing networkingv1.Ingress, selected bool
 		return p.styles.ListItemSelected.Render(line)
 	}
 
+	if badge != "" {
+		return badgeStyle.Render(line)
+	}
+
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *IngressPanel) builtinColumns() map[string]tableColumn[networkingv1.Ingress] {
+	return withColumns(objectColumns[networkingv1.Ingress](), map[string]tableColumn[networkingv1.Ingress]{
+		"hosts": {
+			header: "HOSTS", width: 25,
+			value: func(ing networkingv1.Ingress) string {
+				if badge, _ := p.routeBadge(&ing); badge != "" {
+					return badge
+				}
+
+				return p.getIngressHosts(&ing)
+			},
+			style: func(ing networkingv1.Ingress) lipgloss.Style {
+				if badge, style := p.routeBadge(&ing); badge != "" {
+					return style
+				}
+
+				return lipgloss.NewStyle()
+			},
+		},
+		"address": {
+			header: "ADDRESS", width: 15,
+			value: func(ing networkingv1.Ingress) string { return p.getIngressAddress(&ing) },
+		},
+		"class": {
+			header: "CLASS", width: 12,
+			value: func(ing networkingv1.Ingress) string {
+				if ing.Spec.IngressClassName == nil {
+					return ""
+				}
+
+				return *ing.Spec.IngressClassName
+			},
+		},
+	})
+}
+
+// routeBadge returns a short warning for ingresses with broken routes,
+// unusable or expiring TLS certificates or an unresolvable IngressClass.
+func (p *IngressPanel) routeBadge(ing *networkingv1.Ingress) (string, lipgloss.Style) {
+	report, ok := p.reports[ingressID(ing)]
+	if !ok {
+		return "", lipgloss.Style{}
+	}
+
+	if n := report.BrokenRoutes(); n > 0 {
+		return fmt.Sprintf("BROKEN (%d)", n), p.styles.StatusError
+	}
+
+	tls, hasTLS := report.WorstCertificate()
+	if hasTLS && tls.Problem != "" {
+		return "BAD TLS", p.styles.StatusError
+	}
+
+	now := time.Now()
+
+	if hasTLS {
+		switch tls.Info.Expiry(now, p.certThreshold) {
+		case k8s.CertExpired:
+			return "CERT EXPIRED", p.styles.StatusError
+		case k8s.CertNotYetValid:
+			return "CERT NOT VALID", p.styles.StatusError
+		case k8s.CertExpiringSoon, k8s.CertValid:
+		}
+	}
+
+	if report.ClassProblem != "" {
+		return "NO CLASS", p.styles.StatusWarning
+	}
+
+	if hasTLS && tls.Info.Expiry(now, p.certThreshold) == k8s.CertExpiringSoon {
+		return fmt.Sprintf("cert exp %dd", tls.Info.DaysUntilExpiry(now)), p.styles.StatusWarning
+	}
+
+	return "", lipgloss.Style{}
+}
+
 func (p *IngressPanel) getIngressHosts(ing *networkingv1.Ingress) string {
 	hosts := make([]string, 0)
 
@@ -197,7 +302,12 @@ func (p *IngressPanel) DetailView(width, height int) string {
 	b.WriteString(p.styles.DetailValue.Render(p.getIngressAddress(&ing)))
 	b.WriteString("\n")
 
-	if ing.Spec.IngressClassName != nil {
+	report, validated := p.reports[ingressID(&ing)]
+
+	switch {
+	case validated:
+		p.renderClass(&b, report)
+	case ing.Spec.IngressClassName != nil:
 		b.WriteString(p.styles.DetailLabel.Render("Class:"))
 		b.WriteString(p.styles.DetailValue.Render(*ing.Spec.IngressClassName))
 		b.WriteString("\n")
@@ -213,7 +323,10 @@ func (p *IngressPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
-	if len(ing.Spec.Rules) > 0 {
+	if validated {
+		p.renderRoutes(&b, report)
+		p.renderTLS(&b, report)
+	} else if len(ing.Spec.Rules) > 0 {
 		b.WriteString("\n")
 		b.WriteString(p.styles.DetailTitle.Render("Rules:"))
 		b.WriteString("\n")
@@ -249,7 +362,7 @@ func (p *IngressPanel) DetailView(width, height int) string {
 		}
 	}
 
-	if len(ing.Spec.TLS) > 0 {
+	if !validated && len(ing.Spec.TLS) > 0 {
 		b.WriteString("\n")
 		b.WriteString(p.styles.DetailTitle.Render("TLS:"))
 		b.WriteString("\n")
@@ -266,35 +379,187 @@ func (p *IngressPanel) DetailView(width, height int) string {
 	return b.String()
 }
 
+func (p *IngressPanel) renderClass(b *strings.Builder, report *k8s.IngressReport) {
+	b.WriteString(p.styles.DetailLabel.Render("Class:"))
+
+	switch {
+	case report.ClassProblem != "":
+		b.WriteString(p.styles.StatusWarning.Render(report.ClassProblem))
+	case report.Class == "":
+		b.WriteString(p.styles.DetailValue.Render("<none>"))
+	case report.Controller != "":
+		b.WriteString(p.styles.DetailValue.Render(report.Class + " (" + report.Controller + ")"))
+	default:
+		b.WriteString(p.styles.DetailValue.Render(report.Class))
+	}
+
+	b.WriteString("\n")
+}
+
+// renderRoutes traces every host/path to its backend service and port.
+func (p *IngressPanel) renderRoutes(b *strings.Builder, report *k8s.IngressReport) {
+	if len(report.Routes) == 0 {
+		return
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Routes:"))
+	b.WriteString("\n")
+
+	for _, route := range report.Routes {
+		line := fmt.Sprintf("  %s%s", route.Host, route.Path)
+		if route.PathType != "" {
+			line += " (" + route.PathType + ")"
+		}
+
+		backend := route.Service
+		if route.Port != "" {
+			backend += ":" + route.Port
+		}
+
+		line += " -> " + backend
+
+		if route.Problem != "" {
+			b.WriteString(p.styles.StatusError.Render(line + "  ✗ " + route.Problem))
+		} else if route.Ready > 0 || route.NotReady > 0 {
+			b.WriteString(line)
+			b.WriteString(p.styles.StatusSuccess.Render(
+				fmt.Sprintf("  ✓ %d/%d ready", route.Ready, route.Ready+route.NotReady),
+			))
+		} else {
+			b.WriteString(line)
+		}
+
+		b.WriteString("\n")
+	}
+}
+
+func (p *IngressPanel) renderTLS(b *strings.Builder, report *k8s.IngressReport) {
+	if len(report.TLS) == 0 {
+		return
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("TLS:"))
+	b.WriteString("\n")
+
+	now := time.Now()
+
+	for _, tls := range report.TLS {
+		secret := tls.SecretName
+		if secret == "" {
+			secret = "<controller default>"
+		}
+
+		fmt.Fprintf(b, "  Secret: %s\n", secret)
+		fmt.Fprintf(b, "  Hosts:  %s\n", strings.Join(tls.Hosts, ", "))
+
+		switch {
+		case tls.Problem != "":
+			b.WriteString(p.styles.StatusError.Render("  ✗ " + tls.Problem))
+			b.WriteString("\n")
+		case tls.Info != nil:
+			expiry := fmt.Sprintf(
+				"  Expires: %s (%dd)", tls.Info.NotAfter.Format("2006-01-02"), tls.Info.DaysUntilExpiry(now),
+			)
+
+			switch tls.Info.Expiry(now, p.certThreshold) {
+			case k8s.CertExpired, k8s.CertNotYetValid:
+				b.WriteString(p.styles.StatusError.Render(expiry))
+			case k8s.CertExpiringSoon:
+				b.WriteString(p.styles.StatusWarning.Render(expiry))
+			case k8s.CertValid:
+				b.WriteString(expiry)
+			}
+
+			b.WriteString("\n")
+		}
+	}
+}
+
 func (p *IngressPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
-			ingresses *networkingv1.IngressList
+			ingresses []networkingv1.Ingress
 			err       error
 		)
 
 		if p.allNs {
-			ingresses, err = p.client.Clientset().
-				NetworkingV1().
-				Ingresses("").
-				List(ctx, metav1.ListOptions{})
+			ingresses, err = p.client.ListIngressesAllNamespaces(ctx)
 		} else {
-			ingresses, err = p.client.Clientset().
-				NetworkingV1().
-				Ingresses(p.client.CurrentNamespace()).
-				List(ctx, metav1.ListOptions{})
+			ingresses, err = p.client.ListIngresses(ctx, "")
 		}
 
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return ingressLoadedMsg{ingresses: ingresses.Items}
+		// The selector narrows the ingresses only; their backends and
+		// secrets are looked up whatever their labels
+		deps, ok := p.loadDependencies(context.Background())
+		if !ok {
+			return ingressLoadedMsg{ingresses: ingresses}
+		}
+
+		reports := make(map[string]*k8s.IngressReport, len(ingresses))
+		for i := range ingresses {
+			reports[ingressID(&ingresses[i])] = k8s.ValidateIngress(&ingresses[i], deps)
+		}
+
+		return ingressLoadedMsg{ingresses: ingresses, reports: reports}
 	}
 }
 
+// loadDependencies lists what routes are validated against. Services and
+// endpoint slices are required; secrets and classes are often not readable
+// and only disable their own checks when missing.
+func (p *IngressPanel) loadDependencies(ctx context.Context) (*k8s.IngressDependencies, bool) {
+	var (
+		services []corev1.Service
+		slices   []discoveryv1.EndpointSlice
+		secrets  []corev1.Secret
+		err      error
+	)
+
+	if p.allNs {
+		services, err = p.client.ListServicesAllNamespaces(ctx)
+		if err == nil {
+			slices, err = p.client.ListEndpointSlicesAllNamespaces(ctx)
+		}
+	} else {
+		services, err = p.client.ListServices(ctx, "")
+		if err == nil {
+			slices, err = p.client.ListEndpointSlices(ctx, "")
+		}
+	}
+
+	if err != nil {
+		return nil, false
+	}
+
+	deps := &k8s.IngressDependencies{Services: services, EndpointSlices: slices}
+
+	if p.allNs {
+		secrets, err = p.client.ListSecretsAllNamespaces(ctx)
+	} else {
+		secrets, err = p.client.ListSecrets(ctx, "")
+	}
+
+	if err == nil {
+		deps.Secrets = k8s.IndexSecrets(secrets)
+	}
+
+	if classes, err := p.client.ListIngressClasses(ctx); err == nil {
+		deps.Classes = k8s.IndexIngressClasses(classes)
+	}
+
+	return deps, true
+}
+
 func (p *IngressPanel) Delete() tea.Cmd {
 	if p.cursor >= len(p.filtered) {
 		return nil
@@ -305,10 +570,7 @@ func (p *IngressPanel) Delete() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		err := p.client.Clientset().
-			NetworkingV1().
-			Ingresses(ing.Namespace).
-			Delete(ctx, ing.Name, metav1.DeleteOptions{})
+		err := p.client.DeleteIngress(ctx, ing.Namespace, ing.Name)
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
@@ -381,8 +643,8 @@ func (p *IngressPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *IngressPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.ingresses, p.filter, func(i networkingv1.Ingress) string { return i.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.ingresses, p.query, p.sort, func(i networkingv1.Ingress) queryFields { return objectFields(&i) }, &p.cursor,
 	)
 }
 
@@ -391,6 +653,27 @@ func (p *IngressPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *IngressPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *IngressPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *IngressPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.ingresses,
@@ -420,6 +703,17 @@ func (p *IngressPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *IngressPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(ing networkingv1.Ingress) ResourceRef {
+		return objectRef("Ingress", &ing)
+	})
+}
+
 type ingressLoadedMsg struct {
 	ingresses []networkingv1.Ingress
+	reports   map[string]*k8s.IngressReport
+}
+
+func ingressID(ing *networkingv1.Ingress) string {
+	return ing.Namespace + "/" + ing.Name
 }
@@ -0,0 +1,154 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+// testRoutedIngress routes web.example.com/ to the given service on port 80
+// and terminates TLS with the secret "web-tls".
+func testRoutedIngress(service string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{SecretName: "web-tls", Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{{
+				Host: "web.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path: "/",
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: service,
+									Port: networkingv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func loadIngressPanel(t *testing.T, objects ...runtime.Object) *IngressPanel {
+	t.Helper()
+
+	svc := testService()
+	svc.Name = "web"
+
+	objects = append(objects, &svc, webEndpointSlice(true), &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nginx",
+			Annotations: map[string]string{"ingressclass.kubernetes.io/is-default-class": "true"},
+		},
+		Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+	})
+
+	panel := NewIngressPanel(k8s.NewTestClient(fake.NewSimpleClientset(objects...)), createTestStyles())
+	panel.SetSize(100, 30)
+	panel.Update(panel.Refresh()())
+
+	return panel
+}
+
+func TestIngressPanel_RouteTrace(t *testing.T) {
+	secret := testTLSSecret(t, "web-tls", 90*24*time.Hour)
+	panel := loadIngressPanel(t, testRoutedIngress("web"), &secret)
+
+	detail := panel.DetailView(100, 40)
+
+	for _, want := range []string{
+		"nginx (k8s.io/ingress-nginx)",
+		"web.example.com/ -> web:80",
+		"1/1 ready",
+		"Secret: web-tls",
+		"Expires:",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail view missing %q:\n%s", want, detail)
+		}
+	}
+
+	view := panel.View()
+	for _, badge := range []string{"BROKEN", "TLS", "CLASS", "cert exp"} {
+		if strings.Contains(view, badge) {
+			t.Errorf("healthy ingress should not be flagged with %q:\n%s", badge, view)
+		}
+	}
+}
+
+func TestIngressPanel_FlagsBrokenRoute(t *testing.T) {
+	secret := testTLSSecret(t, "web-tls", 90*24*time.Hour)
+	panel := loadIngressPanel(t, testRoutedIngress("gone"), &secret)
+
+	if !strings.Contains(panel.View(), "BROKEN (1)") {
+		t.Errorf("list should flag the broken route:\n%s", panel.View())
+	}
+
+	if !strings.Contains(panel.DetailView(100, 40), "service not found") {
+		t.Error("detail view should explain the broken route")
+	}
+}
+
+func TestIngressPanel_FlagsTLS(t *testing.T) {
+	panel := loadIngressPanel(t, testRoutedIngress("web"))
+
+	if !strings.Contains(panel.View(), "BAD TLS") {
+		t.Errorf("list should flag the missing TLS secret:\n%s", panel.View())
+	}
+
+	expiring := testTLSSecret(t, "web-tls", 5*24*time.Hour)
+	panel = loadIngressPanel(t, testRoutedIngress("web"), &expiring)
+
+	if !strings.Contains(panel.View(), "cert exp") {
+		t.Errorf("list should flag the expiring certificate:\n%s", panel.View())
+	}
+}
+
+func TestIngressPanel_NoReportWithoutServices(t *testing.T) {
+	panel := NewIngressPanel(createTestK8sClient(), createTestStyles())
+	panel.ingresses = []networkingv1.Ingress{*testRoutedIngress("web")}
+	panel.filtered = panel.ingresses
+	panel.SetSize(100, 30)
+
+	if !strings.Contains(panel.DetailView(100, 40), "Rules:") {
+		t.Error("detail view should fall back to the raw rules")
+	}
+
+	if strings.Contains(panel.View(), "BROKEN") {
+		t.Error("unvalidated ingress should not be flagged")
+	}
+}
+
+func TestIngressPanel_LabelFilterKeepsDependencies(t *testing.T) {
+	ingress := testRoutedIngress("web")
+	ingress.Labels = map[string]string{"team": "web"}
+
+	secret := testTLSSecret(t, "web-tls", 90*24*time.Hour)
+	panel := loadIngressPanel(t, ingress, &secret)
+
+	// Neither the service nor the secret carries the label
+	panel.SetFilter("label:team=web")
+	panel.Update(panel.Refresh()())
+
+	if len(panel.filtered) != 1 {
+		t.Fatalf("expected the labelled ingress, got %d", len(panel.filtered))
+	}
+
+	detail := panel.DetailView(100, 40)
+	for _, unwanted := range []string{"service not found", "secret not found"} {
+		if strings.Contains(detail, unwanted) {
+			t.Errorf("the label filter shouldn't hide dependencies, detail says %q:\n%s", unwanted, detail)
+		}
+	}
+}
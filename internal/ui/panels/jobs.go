@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -21,6 +22,7 @@ type JobsPanel struct {
 	styles   *theme.Styles
 	jobs     []batchv1.Job
 	filtered []batchv1.Job
+	table    *columnTable[batchv1.Job]
 }
 
 func NewJobsPanel(client *k8s.Client, styles *theme.Styles) *JobsPanel {
@@ -28,6 +30,7 @@ func NewJobsPanel(client *k8s.Client, styles *theme.Styles) *JobsPanel {
 		BasePanel: BasePanel{
 			title:       "Jobs",
 			shortcutKey: "9",
+			sortable:    statusSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -79,7 +82,8 @@ func (p *JobsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		job := p.filtered[i]
@@ -97,14 +101,13 @@ func (p *JobsPanel) View() string {
 }
 
 func (p *JobsPanel) renderJobLine(job batchv1.Job, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, job, objectRef("Job", &job), selected)
+	}
+
 	status := p.getJobStatus(&job)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Job", &job))
 
 	if p.width > 80 {
 		reserved := 28
@@ -115,7 +118,7 @@ func (p *JobsPanel) renderJobLine(job batchv1.Job, selected bool) string {
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(job.Name, nameW), nameW,
+			highlightName(p.styles, p.query, job.Name, nameW), nameW,
 		)
 
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -130,7 +133,7 @@ func (p *JobsPanel) renderJobLine(job batchv1.Job, selected bool) string {
 			line += " " + utils.Truncate(job.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(job.Name, p.width-15)
+		name := highlightName(p.styles, p.query, job.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-12)
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -146,6 +149,27 @@ func (p *JobsPanel) renderJobLine(job batchv1.Job, selected bool) string {
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *JobsPanel) builtinColumns() map[string]tableColumn[batchv1.Job] {
+	return withColumns(objectColumns[batchv1.Job](), map[string]tableColumn[batchv1.Job]{
+		"status": {
+			header: "STATUS", width: 10, sort: sortStatus,
+			value: func(job batchv1.Job) string { return p.getJobStatus(&job) },
+			style: func(job batchv1.Job) lipgloss.Style { return p.styles.GetStatusStyle(p.getJobStatus(&job)) },
+		},
+		"completions": {
+			header: "COMPLETIONS", width: 11,
+			value: func(job batchv1.Job) string {
+				completions := int32(1)
+				if job.Spec.Completions != nil {
+					completions = *job.Spec.Completions
+				}
+
+				return fmt.Sprintf("%d/%d", job.Status.Succeeded, completions)
+			},
+		},
+	})
+}
+
 func (p *JobsPanel) getJobStatus(job *batchv1.Job) string {
 	if job.Status.Succeeded > 0 {
 		return "Completed"
@@ -231,6 +255,8 @@ func (p *JobsPanel) DetailView(width, height int) string {
 }
 
 func (p *JobsPanel) Refresh() tea.Cmd {
+	opts := metav1.ListOptions{LabelSelector: p.LabelSelector()}
+
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -240,12 +266,12 @@ func (p *JobsPanel) Refresh() tea.Cmd {
 		)
 
 		if p.allNs {
-			jobs, err = p.client.Clientset().BatchV1().Jobs("").List(ctx, metav1.ListOptions{})
+			jobs, err = p.client.Clientset().BatchV1().Jobs("").List(ctx, opts)
 		} else {
 			jobs, err = p.client.Clientset().
 				BatchV1().
 				Jobs(p.client.CurrentNamespace()).
-				List(ctx, metav1.ListOptions{})
+				List(ctx, opts)
 		}
 
 		if err != nil {
@@ -331,8 +357,18 @@ func (p *JobsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *JobsPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.jobs, p.filter, func(j batchv1.Job) string { return j.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.jobs,
+		p.query,
+		p.sort,
+		func(j batchv1.Job) queryFields {
+			f := objectFields(&j)
+			f.status = p.getJobStatus(&j)
+			f.images = podSpecImages(&j.Spec.Template.Spec)
+
+			return f
+		},
+		&p.cursor,
 	)
 }
 
@@ -341,6 +377,27 @@ func (p *JobsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *JobsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *JobsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *JobsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.jobs,
@@ -363,6 +420,12 @@ func (p *JobsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *JobsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(job batchv1.Job) ResourceRef {
+		return objectRef("Job", &job)
+	})
+}
+
 type jobsLoadedMsg struct {
 	jobs []batchv1.Job
 }
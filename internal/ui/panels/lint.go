@@ -0,0 +1,35 @@
+package panels
+
+import (
+	"strings"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+// renderLintFindings writes the "Best practices" detail section shared by
+// the workload panels.
+func renderLintFindings(b *strings.Builder, styles *theme.Styles, findings []k8s.LintFinding, width int) {
+	b.WriteString("\n")
+	b.WriteString(styles.DetailTitle.Render("Best practices:"))
+	b.WriteString("\n")
+
+	if len(findings) == 0 {
+		b.WriteString(styles.StatusSuccess.Render("  No issues found"))
+		b.WriteString("\n")
+
+		return
+	}
+
+	for _, finding := range findings {
+		b.WriteString("  " + styles.StatusWarning.Render(string(finding.Rule)) + "\n")
+
+		text := finding.Message
+		if finding.Container != "" {
+			text = finding.Container + ": " + text
+		}
+
+		b.WriteString("    " + utils.Truncate(text, max(width-6, 20)) + "\n")
+	}
+}
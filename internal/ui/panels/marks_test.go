@@ -0,0 +1,82 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+func newMarkedDeploymentsPanel(names ...string) *DeploymentsPanel {
+	deployments := make([]appsv1.Deployment, len(names))
+	for i, name := range names {
+		deployments[i] = testDeployment()
+		deployments[i].Name = name
+	}
+
+	panel := NewDeploymentsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetSize(100, 20)
+	panel.Update(deploymentsLoadedMsg{deployments: deployments})
+
+	return panel
+}
+
+func TestMarkAllMatchingFilter(t *testing.T) {
+	panel := newMarkedDeploymentsPanel("web", "web-canary", "api")
+
+	panel.SetFilter("web")
+	panel.ToggleMarkAll(panel.VisibleRefs())
+
+	marked := panel.MarkedItems()
+	if len(marked) != 2 || marked[0].Name != "web" || marked[1].Name != "web-canary" {
+		t.Fatalf("expected the two filtered deployments marked, got %+v", marked)
+	}
+
+	if marked[0] != (ResourceRef{Kind: "Deployment", Name: "web", Namespace: "default"}) {
+		t.Errorf("unexpected ref %+v", marked[0])
+	}
+
+	// Marks survive the filter changing
+	panel.SetFilter("")
+
+	view := panel.View()
+	if !strings.Contains(view, "(2 marked)") || !strings.Contains(view, ">*web") {
+		t.Errorf("title should count marks and rows show them:\n%s", view)
+	}
+
+	panel.ToggleMark(ResourceRef{Kind: "Deployment", Name: "api", Namespace: "default"})
+	panel.ToggleMark(ResourceRef{Kind: "Deployment", Name: "web", Namespace: "default"})
+
+	if marked := panel.MarkedItems(); len(marked) != 2 || marked[0].Name != "api" {
+		t.Errorf("toggling should mark api and unmark web, got %+v", marked)
+	}
+
+	// Marking all again when every visible item is marked unmarks them
+	panel.ToggleMarkAll(panel.VisibleRefs())
+	panel.ToggleMarkAll(panel.VisibleRefs())
+
+	if len(panel.MarkedItems()) != 0 {
+		t.Errorf("expected no marks, got %+v", panel.MarkedItems())
+	}
+
+	panel.ToggleMarkAll(panel.VisibleRefs())
+	panel.ClearMarks()
+
+	if strings.Contains(panel.View(), "marked") {
+		t.Error("cleared marks should leave the plain title")
+	}
+}
+
+func TestProblemsPanelRefsPointAtResources(t *testing.T) {
+	panel := NewProblemsPanel(createTestK8sClient(), createTestStyles())
+	panel.Update(problemsLoadedMsg{problems: []k8s.Problem{
+		{Kind: "Deployment", Name: "web", Namespace: "default", Reason: "Unavailable"},
+	}})
+
+	refs := panel.VisibleRefs()
+	if len(refs) != 1 || refs[0] != (ResourceRef{Kind: "Deployment", Name: "web", Namespace: "default"}) {
+		t.Errorf("expected a ref to the deployment, got %+v", refs)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,6 +21,11 @@ type NamespacesPanel struct {
 	styles     *theme.Styles
 	namespaces []corev1.Namespace
 	filtered   []corev1.Namespace
+	table      *columnTable[corev1.Namespace]
+
+	// Keyed by namespace name for the detail view.
+	quotas      map[string][]corev1.ResourceQuota
+	limitRanges map[string][]corev1.LimitRange
 }
 
 func NewNamespacesPanel(client *k8s.Client, styles *theme.Styles) *NamespacesPanel {
@@ -27,6 +33,7 @@ func NewNamespacesPanel(client *k8s.Client, styles *theme.Styles) *NamespacesPan
 		BasePanel: BasePanel{
 			title:       "Namespaces",
 			shortcutKey: "1",
+			sortable:    clusterSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -60,10 +67,55 @@ func (p *NamespacesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 					}
 				}
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("N"))):
+			return p, func() tea.Msg {
+				return CreateNamespaceRequestMsg{}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			ns := selectedItem(p.filtered, p.cursor)
+			if ns == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return EditNamespaceMetadataRequestMsg{
+					Name:        ns.Name,
+					Labels:      ns.Labels,
+					Annotations: ns.Annotations,
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("b"))):
+			ns := selectedItem(p.filtered, p.cursor)
+			if ns == nil {
+				return p, nil
+			}
+
+			if ns.Status.Phase != corev1.NamespaceTerminating {
+				return p, func() tea.Msg {
+					return StatusMsg{Message: fmt.Sprintf("Namespace %s is not terminating", ns.Name)}
+				}
+			}
+
+			return p, func() tea.Msg {
+				return NamespaceBlockersRequestMsg{Name: ns.Name}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("L"))):
+			ns := selectedItem(p.filtered, p.cursor)
+			if ns == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return LintNamespaceRequestMsg{Name: ns.Name}
+			}
 		}
 
 	case namespacesLoadedMsg:
 		p.namespaces = msg.namespaces
+		p.quotas = groupByNamespace(msg.quotas, func(q corev1.ResourceQuota) string { return q.Namespace })
+		p.limitRanges = groupByNamespace(
+			msg.limitRanges, func(lr corev1.LimitRange) string { return lr.Namespace },
+		)
 		p.applyFilter()
 
 		return p, nil
@@ -89,7 +141,8 @@ func (p *NamespacesPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		ns := p.filtered[i]
@@ -107,20 +160,19 @@ func (p *NamespacesPanel) View() string {
 }
 
 func (p *NamespacesPanel) renderNamespaceLine(ns corev1.Namespace, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, ns, objectRef("Namespace", &ns), selected)
+	}
+
 	status := string(ns.Status.Phase)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Namespace", &ns))
 
 	if p.width > 80 {
 		nameW := max(p.width-25, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(ns.Name, nameW), nameW,
+			highlightName(p.styles, p.query, ns.Name, nameW), nameW,
 		)
 
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -129,7 +181,7 @@ func (p *NamespacesPanel) renderNamespaceLine(ns corev1.Namespace, selected bool
 		age := utils.FormatAgeFromMeta(ns.CreationTimestamp)
 		line += " " + utils.PadRight(age, 8)
 	} else {
-		name := utils.Truncate(ns.Name, p.width-6)
+		name := highlightName(p.styles, p.query, ns.Name, p.width-6)
 		line += name
 		line = utils.PadRight(line, p.width-10)
 
@@ -146,6 +198,20 @@ func (p *NamespacesPanel) renderNamespaceLine(ns corev1.Namespace, selected bool
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *NamespacesPanel) builtinColumns() map[string]tableColumn[corev1.Namespace] {
+	columns := withColumns(objectColumns[corev1.Namespace](), map[string]tableColumn[corev1.Namespace]{
+		"status": {
+			header: "STATUS", width: 8, sort: sortStatus,
+			value: func(ns corev1.Namespace) string { return string(ns.Status.Phase) },
+			style: func(ns corev1.Namespace) lipgloss.Style { return p.styles.GetStatusStyle(string(ns.Status.Phase)) },
+		},
+	})
+
+	delete(columns, "namespace")
+
+	return columns
+}
+
 func (p *NamespacesPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No namespace selected"
@@ -165,13 +231,17 @@ func (p *NamespacesPanel) DetailView(width, height int) string {
 	b.WriteString(p.styles.DetailValue.Render(utils.FormatAgeFromMeta(ns.CreationTimestamp)))
 	b.WriteString("\n")
 
+	if ns.Status.Phase == corev1.NamespaceTerminating {
+		b.WriteString(p.renderTerminating(&ns, width))
+	}
+
 	if len(ns.Labels) > 0 {
 		b.WriteString("\n")
 		b.WriteString(p.styles.DetailTitle.Render("Labels:"))
 		b.WriteString("\n")
 
-		for k, v := range ns.Labels {
-			b.WriteString(fmt.Sprintf("  %s: %s\n", k, v))
+		for _, k := range sortedKeys(ns.Labels) {
+			b.WriteString(fmt.Sprintf("  %s: %s\n", k, ns.Labels[k]))
 		}
 	}
 
@@ -180,8 +250,118 @@ func (p *NamespacesPanel) DetailView(width, height int) string {
 		b.WriteString(p.styles.DetailTitle.Render("Annotations:"))
 		b.WriteString("\n")
 
-		for k, v := range ns.Annotations {
-			b.WriteString(fmt.Sprintf("  %s: %s\n", k, utils.Truncate(v, width-len(k)-6)))
+		for _, k := range sortedKeys(ns.Annotations) {
+			b.WriteString(fmt.Sprintf("  %s: %s\n", k, utils.Truncate(ns.Annotations[k], width-len(k)-6)))
+		}
+	}
+
+	quotas := p.quotas[ns.Name]
+	for i := range quotas {
+		b.WriteString(p.renderQuota(&quotas[i]))
+	}
+
+	limitRanges := p.limitRanges[ns.Name]
+	for i := range limitRanges {
+		b.WriteString(p.renderLimitRange(&limitRanges[i]))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.Muted.Render("[enter] switch [N]ew [E]dit labels/annotations [L]int [D]elete"))
+
+	if ns.Status.Phase == corev1.NamespaceTerminating {
+		b.WriteString("\n")
+		b.WriteString(p.styles.Muted.Render("[b] explain what blocks deletion"))
+	}
+
+	return b.String()
+}
+
+// renderTerminating lists the namespace's own finalizers and the deletion
+// conditions reported by the namespace controller.
+func (p *NamespacesPanel) renderTerminating(ns *corev1.Namespace, width int) string {
+	var b strings.Builder
+
+	if finalizers := k8s.NamespaceFinalizers(ns); len(finalizers) > 0 {
+		b.WriteString(p.styles.DetailLabel.Render("Finalizers:"))
+		b.WriteString(p.styles.DetailValue.Render(strings.Join(finalizers, ", ")))
+		b.WriteString("\n")
+	}
+
+	conditions := k8s.NamespaceDeletionConditions(ns)
+	if len(conditions) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Deletion Blocked By:"))
+	b.WriteString("\n")
+
+	for _, cond := range conditions {
+		b.WriteString(p.styles.StatusWarning.Render("  " + utils.Truncate(cond, max(width-4, 10))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (p *NamespacesPanel) renderQuota(quota *corev1.ResourceQuota) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("ResourceQuota: " + quota.Name))
+	b.WriteString("\n")
+
+	for _, name := range sortedKeys(quota.Status.Hard) {
+		hard := quota.Status.Hard[name]
+		used := quota.Status.Used[name]
+
+		line := fmt.Sprintf("  %-28s %s / %s", name, used.String(), hard.String())
+
+		var ratio float64
+		if hard.MilliValue() > 0 {
+			ratio = float64(used.MilliValue()) / float64(hard.MilliValue())
+			line += fmt.Sprintf(" (%.0f%%)", ratio*100)
+		}
+
+		switch {
+		case ratio >= 1:
+			b.WriteString(p.styles.StatusError.Render(line))
+		case ratio >= quotaWarningRatio:
+			b.WriteString(p.styles.StatusWarning.Render(line))
+		default:
+			b.WriteString(line)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (p *NamespacesPanel) renderLimitRange(lr *corev1.LimitRange) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("LimitRange: " + lr.Name))
+	b.WriteString("\n")
+
+	for _, item := range lr.Spec.Limits {
+		b.WriteString(fmt.Sprintf("  %s\n", item.Type))
+
+		for _, field := range []struct {
+			label string
+			list  corev1.ResourceList
+		}{
+			{"default request", item.DefaultRequest},
+			{"default limit", item.Default},
+			{"min", item.Min},
+			{"max", item.Max},
+		} {
+			if len(field.list) == 0 {
+				continue
+			}
+
+			b.WriteString(fmt.Sprintf("    %-16s %s\n", field.label+":", formatResourceList(field.list)))
 		}
 	}
 
@@ -189,15 +369,27 @@ func (p *NamespacesPanel) DetailView(width, height int) string {
 }
 
 func (p *NamespacesPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
 		ctx := context.Background()
+		listCtx := k8s.WithLabelSelector(ctx, selector)
 
-		namespaces, err := p.client.ListNamespaces(ctx)
+		namespaces, err := p.client.ListNamespaces(listCtx)
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return namespacesLoadedMsg{namespaces: namespaces}
+		// Quotas and limit ranges only enrich the detail view, so a missing
+		// list permission shouldn't break the panel.
+		quotas, _ := p.client.ListResourceQuotasAllNamespaces(ctx)
+		limitRanges, _ := p.client.ListLimitRangesAllNamespaces(ctx)
+
+		return namespacesLoadedMsg{
+			namespaces:  namespaces,
+			quotas:      quotas,
+			limitRanges: limitRanges,
+		}
 	}
 }
 
@@ -269,8 +461,17 @@ func (p *NamespacesPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *NamespacesPanel) applyFilter() {
-	p.filtered = filterByName(
-		p.namespaces, p.filter, func(ns corev1.Namespace) string { return ns.Name }, &p.cursor,
+	p.filtered = filterByQuery(
+		p.namespaces,
+		p.query,
+		p.sort,
+		func(ns corev1.Namespace) queryFields {
+			f := objectFields(&ns)
+			f.status = string(ns.Status.Phase)
+
+			return f
+		},
+		&p.cursor,
 	)
 }
 
@@ -279,6 +480,27 @@ func (p *NamespacesPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *NamespacesPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *NamespacesPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *NamespacesPanel) SearchItems(query string) []SearchResult {
 	// Namespaces are cluster-scoped: pass nil for the namespace callback.
 	return searchByName(
@@ -303,6 +525,37 @@ func (p *NamespacesPanel) NavigateTo(name, _ string) bool {
 	)
 }
 
+func (p *NamespacesPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(ns corev1.Namespace) ResourceRef {
+		return objectRef("Namespace", &ns)
+	})
+}
+
+// quotaWarningRatio highlights quota usage at or above this share of hard.
+const quotaWarningRatio = 0.9
+
+// formatResourceList renders a ResourceList as "cpu=100m, memory=128Mi".
+func formatResourceList(list corev1.ResourceList) string {
+	parts := make([]string, 0, len(list))
+	for _, name := range sortedKeys(list) {
+		q := list[name]
+		parts = append(parts, string(name)+"="+q.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func groupByNamespace[T any](items []T, namespace func(T) string) map[string][]T {
+	grouped := make(map[string][]T)
+	for _, item := range items {
+		grouped[namespace(item)] = append(grouped[namespace(item)], item)
+	}
+
+	return grouped
+}
+
 type namespacesLoadedMsg struct {
-	namespaces []corev1.Namespace
+	namespaces  []corev1.Namespace
+	quotas      []corev1.ResourceQuota
+	limitRanges []corev1.LimitRange
 }
@@ -0,0 +1,120 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNamespacesPanel(msg namespacesLoadedMsg) *NamespacesPanel {
+	panel := NewNamespacesPanel(createTestK8sClient(), createTestStyles())
+	panel.SetFocused(true)
+	panel.Update(msg)
+
+	return panel
+}
+
+func TestNamespacesPanel_QuotaAndLimitRangeDetail(t *testing.T) {
+	ns := testNamespace()
+
+	panel := newTestNamespacesPanel(namespacesLoadedMsg{
+		namespaces: []corev1.Namespace{ns},
+		quotas: []corev1.ResourceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: ns.Name},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourcePods:      resource.MustParse("10"),
+					corev1.ResourceLimitsCPU: resource.MustParse("4"),
+				},
+				Used: corev1.ResourceList{
+					corev1.ResourcePods:      resource.MustParse("9"),
+					corev1.ResourceLimitsCPU: resource.MustParse("1"),
+				},
+			},
+		}},
+		limitRanges: []corev1.LimitRange{{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: ns.Name},
+			Spec: corev1.LimitRangeSpec{
+				Limits: []corev1.LimitRangeItem{{
+					Type:    corev1.LimitTypeContainer,
+					Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				}},
+			},
+		}},
+	})
+
+	view := panel.DetailView(100, 60)
+
+	for _, want := range []string{
+		"ResourceQuota: compute", "9 / 10 (90%)", "1 / 4 (25%)",
+		"LimitRange: defaults", "memory=256Mi",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestNamespacesPanel_TerminatingDetail(t *testing.T) {
+	ns := testNamespace()
+	ns.Status.Phase = corev1.NamespaceTerminating
+	ns.Spec.Finalizers = []corev1.FinalizerName{corev1.FinalizerKubernetes}
+	ns.Status.Conditions = []corev1.NamespaceCondition{{
+		Type:    corev1.NamespaceContentRemaining,
+		Status:  corev1.ConditionTrue,
+		Message: "Some resources are remaining: pods. has 2 resource instances",
+	}}
+
+	panel := newTestNamespacesPanel(namespacesLoadedMsg{namespaces: []corev1.Namespace{ns}})
+
+	view := panel.DetailView(120, 60)
+	if !strings.Contains(view, "Deletion Blocked By:") || !strings.Contains(view, "pods. has 2") {
+		t.Errorf("terminating namespace should explain the blocking condition:\n%s", view)
+	}
+
+	if !strings.Contains(view, "Finalizers:") {
+		t.Error("terminating namespace should list its finalizers")
+	}
+
+	msg, ok := pressKey(panel, 'b')().(NamespaceBlockersRequestMsg)
+	if !ok || msg.Name != ns.Name {
+		t.Errorf("expected NamespaceBlockersRequestMsg for %s, got %+v", ns.Name, msg)
+	}
+}
+
+func TestNamespacesPanel_LintRequest(t *testing.T) {
+	ns := testNamespace()
+	panel := newTestNamespacesPanel(namespacesLoadedMsg{namespaces: []corev1.Namespace{ns}})
+
+	msg, ok := pressKey(panel, 'L')().(LintNamespaceRequestMsg)
+	if !ok || msg.Name != ns.Name {
+		t.Errorf("expected LintNamespaceRequestMsg for %s, got %+v", ns.Name, msg)
+	}
+}
+
+func TestNamespacesPanel_BlockersOnlyForTerminating(t *testing.T) {
+	panel := newTestNamespacesPanel(namespacesLoadedMsg{namespaces: []corev1.Namespace{testNamespace()}})
+
+	if _, ok := pressKey(panel, 'b')().(StatusMsg); !ok {
+		t.Error("b on an active namespace should only report a status")
+	}
+}
+
+func TestNamespacesPanel_CreateAndEditRequests(t *testing.T) {
+	ns := testNamespace()
+	ns.Labels = map[string]string{"team": "web"}
+
+	panel := newTestNamespacesPanel(namespacesLoadedMsg{namespaces: []corev1.Namespace{ns}})
+
+	if _, ok := pressKey(panel, 'N')().(CreateNamespaceRequestMsg); !ok {
+		t.Error("N should request namespace creation")
+	}
+
+	edit, ok := pressKey(panel, 'E')().(EditNamespaceMetadataRequestMsg)
+	if !ok || edit.Name != ns.Name || edit.Labels["team"] != "web" {
+		t.Errorf("expected EditNamespaceMetadataRequestMsg with labels, got %+v", edit)
+	}
+}
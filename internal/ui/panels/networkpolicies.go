@@ -7,6 +7,8 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,13 +22,21 @@ type NetworkPoliciesPanel struct {
 	styles          *theme.Styles
 	networkPolicies []networkingv1.NetworkPolicy
 	filtered        []networkingv1.NetworkPolicy
+	table           *columnTable[networkingv1.NetworkPolicy]
+
+	// pods resolves which pods each policy applies to.
+	pods []corev1.Pod
 }
 
+// maxSelectedPodsShown caps the "Applies to" list in the detail view.
+const maxSelectedPodsShown = 20
+
 func NewNetworkPoliciesPanel(client *k8s.Client, styles *theme.Styles) *NetworkPoliciesPanel {
 	return &NetworkPoliciesPanel{
 		BasePanel: BasePanel{
 			title:       "NetworkPolicies",
 			shortcutKey: "",
+			sortable:    objectSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -49,10 +59,23 @@ func (p *NetworkPoliciesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 			p.MoveToTop()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
 			p.MoveToBottom(len(p.filtered))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+			var destination string
+
+			if np := selectedItem(p.filtered, p.cursor); np != nil {
+				if selected := k8s.PodsSelectedByPolicy(np, p.pods); len(selected) > 0 {
+					destination = selected[0].Namespace + "/" + selected[0].Name
+				}
+			}
+
+			return p, func() tea.Msg {
+				return CheckReachabilityRequestMsg{Destination: destination}
+			}
 		}
 
 	case networkPoliciesLoadedMsg:
 		p.networkPolicies = msg.networkPolicies
+		p.pods = msg.pods
 		p.applyFilter()
 
 		return p, nil
@@ -78,7 +101,8 @@ func (p *NetworkPoliciesPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		np := p.filtered[i]
@@ -99,14 +123,13 @@ func (p *NetworkPoliciesPanel) renderNetworkPolicyLine(
 	np networkingv1.NetworkPolicy,
 	selected bool,
 ) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, np, objectRef("NetworkPolicy", &np), selected)
+	}
+
 	rules := k8s.GetNetworkPolicyRuleSummary(&np)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("NetworkPolicy", &np))
 
 	if p.width > 80 {
 		reserved := 35
@@ -117,7 +140,7 @@ func (p *NetworkPoliciesPanel) renderNetworkPolicyLine(
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(np.Name, nameW), nameW,
+			highlightName(p.styles, p.query, np.Name, nameW), nameW,
 		)
 		line += " " + p.styles.StatusRunning.Render(
 			utils.PadRight(rules, 18),
@@ -130,7 +153,7 @@ func (p *NetworkPoliciesPanel) renderNetworkPolicyLine(
 			line += " " + utils.Truncate(np.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(np.Name, p.width-15)
+		name := highlightName(p.styles, p.query, np.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-20)
 		line += " " + p.styles.StatusRunning.Render(rules)
@@ -145,6 +168,16 @@ func (p *NetworkPoliciesPanel) renderNetworkPolicyLine(
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *NetworkPoliciesPanel) builtinColumns() map[string]tableColumn[networkingv1.NetworkPolicy] {
+	return withColumns(objectColumns[networkingv1.NetworkPolicy](), map[string]tableColumn[networkingv1.NetworkPolicy]{
+		"rules": {
+			header: "RULES", width: 18,
+			value: func(np networkingv1.NetworkPolicy) string { return k8s.GetNetworkPolicyRuleSummary(&np) },
+			style: func(networkingv1.NetworkPolicy) lipgloss.Style { return p.styles.StatusRunning },
+		},
+	})
+}
+
 func (p *NetworkPoliciesPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No network policy selected"
@@ -242,15 +275,50 @@ func (p *NetworkPoliciesPanel) DetailView(width, height int) string {
 		}
 	}
 
+	p.renderSelectedPods(&b, &np)
+
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml [D]elete"))
+	b.WriteString(p.styles.Muted.Render("[t]est reachability [d]escribe [y]aml [D]elete"))
 
 	return b.String()
 }
 
+// renderSelectedPods lists the pods the policy's podSelector applies to.
+func (p *NetworkPoliciesPanel) renderSelectedPods(b *strings.Builder, np *networkingv1.NetworkPolicy) {
+	if p.pods == nil {
+		return
+	}
+
+	selected := k8s.PodsSelectedByPolicy(np, p.pods)
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render(fmt.Sprintf("Applies to (%d pods):", len(selected))))
+	b.WriteString("\n")
+
+	if len(selected) == 0 {
+		b.WriteString(p.styles.Muted.Render("  No pods match the pod selector"))
+		b.WriteString("\n")
+
+		return
+	}
+
+	for i, pod := range selected {
+		if i == maxSelectedPodsShown {
+			fmt.Fprintf(b, "  ... and %d more\n", len(selected)-maxSelectedPodsShown)
+
+			break
+		}
+
+		fmt.Fprintf(b, "  %s\n", pod.Name)
+	}
+}
+
 func (p *NetworkPoliciesPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
 		ctx := context.Background()
+		listCtx := k8s.WithLabelSelector(ctx, selector)
 
 		var (
 			networkPolicies []networkingv1.NetworkPolicy
@@ -258,16 +326,28 @@ func (p *NetworkPoliciesPanel) Refresh() tea.Cmd {
 		)
 
 		if p.allNs {
-			networkPolicies, err = p.client.ListNetworkPoliciesAllNamespaces(ctx)
+			networkPolicies, err = p.client.ListNetworkPoliciesAllNamespaces(listCtx)
 		} else {
-			networkPolicies, err = p.client.ListNetworkPolicies(ctx, "")
+			networkPolicies, err = p.client.ListNetworkPolicies(listCtx, "")
 		}
 
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return networkPoliciesLoadedMsg{networkPolicies: networkPolicies}
+		// Pods only feed the "Applies to" list, which is hidden on error
+		var pods []corev1.Pod
+		if p.allNs {
+			pods, err = p.client.ListPodsAllNamespaces(ctx)
+		} else {
+			pods, err = p.client.ListPods(ctx, "")
+		}
+
+		if err == nil && pods == nil {
+			pods = []corev1.Pod{}
+		}
+
+		return networkPoliciesLoadedMsg{networkPolicies: networkPolicies, pods: pods}
 	}
 }
 
@@ -360,10 +440,11 @@ func (p *NetworkPoliciesPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *NetworkPoliciesPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.networkPolicies,
-		p.filter,
-		func(n networkingv1.NetworkPolicy) string { return n.Name },
+		p.query,
+		p.sort,
+		func(n networkingv1.NetworkPolicy) queryFields { return objectFields(&n) },
 		&p.cursor,
 	)
 }
@@ -373,6 +454,27 @@ func (p *NetworkPoliciesPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *NetworkPoliciesPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *NetworkPoliciesPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *NetworkPoliciesPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.networkPolicies,
@@ -401,6 +503,13 @@ func (p *NetworkPoliciesPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *NetworkPoliciesPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(np networkingv1.NetworkPolicy) ResourceRef {
+		return objectRef("NetworkPolicy", &np)
+	})
+}
+
 type networkPoliciesLoadedMsg struct {
 	networkPolicies []networkingv1.NetworkPolicy
+	pods            []corev1.Pod
 }
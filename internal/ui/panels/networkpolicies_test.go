@@ -0,0 +1,66 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+func loadNetworkPoliciesPanel(t *testing.T) *NetworkPoliciesPanel {
+	t.Helper()
+
+	api := testPod()
+	api.Name = "api"
+	api.Labels = map[string]string{"app": "api"}
+
+	db := testPod()
+	db.Name = "db"
+	db.Labels = map[string]string{"app": "db"}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-api", Namespace: "default"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+		},
+	}
+
+	client := k8s.NewTestClient(fake.NewSimpleClientset(&api, &db, policy))
+	panel := NewNetworkPoliciesPanel(client, createTestStyles())
+	panel.SetSize(100, 30)
+	panel.Update(panel.Refresh()())
+
+	return panel
+}
+
+func TestNetworkPoliciesPanel_AppliesTo(t *testing.T) {
+	panel := loadNetworkPoliciesPanel(t)
+
+	detail := panel.DetailView(100, 40)
+	if !strings.Contains(detail, "Applies to (1 pods):") || !strings.Contains(detail, "  api\n") {
+		t.Errorf("detail view should list the selected pod:\n%s", detail)
+	}
+
+	if strings.Contains(detail, "  db\n") {
+		t.Errorf("unselected pod should not be listed:\n%s", detail)
+	}
+}
+
+func TestNetworkPoliciesPanel_ReachabilityRequest(t *testing.T) {
+	panel := loadNetworkPoliciesPanel(t)
+
+	_, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("expected a command for 't'")
+	}
+
+	msg, ok := cmd().(CheckReachabilityRequestMsg)
+	if !ok || msg.Destination != "default/api" {
+		t.Errorf("expected request with destination default/api, got %+v", msg)
+	}
+}
@@ -3,6 +3,8 @@ package panels
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -21,20 +23,38 @@ type NodesPanel struct {
 	styles   *theme.Styles
 	nodes    []corev1.Node
 	filtered []corev1.Node
+	table    *columnTable[corev1.Node]
 	metrics  map[string]NodeMetrics
+	// history feeds the sparklines and keeps the last known samples when
+	// metrics become unavailable
+	history            *metricHistory
+	metricsUnavailable bool
+	// allocations is nil when pods couldn't be listed.
+	allocations map[string]*k8s.NodeAllocation
 }
 
+// allocationWarningRatio colors allocation bars once a node is this full.
+const allocationWarningRatio = 0.8
+
 func NewNodesPanel(client *k8s.Client, styles *theme.Styles) *NodesPanel {
 	return &NodesPanel{
 		BasePanel: BasePanel{
 			title:       "Nodes",
 			shortcutKey: "7",
+			sortable:    nodeSortColumns,
 		},
-		client: client,
-		styles: styles,
+		client:  client,
+		styles:  styles,
+		history: newMetricHistory(DefaultMetricsRetention),
 	}
 }
 
+// SetMetricsRetention sets how many samples each node keeps for its
+// sparklines, dropping the history collected so far.
+func (p *NodesPanel) SetMetricsRetention(samples int) {
+	p.history = newMetricHistory(samples)
+}
+
 func (p *NodesPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -51,16 +71,52 @@ func (p *NodesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 			p.MoveToTop()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
 			p.MoveToBottom(len(p.filtered))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+			node := selectedItem(p.filtered, p.cursor)
+			if node == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return NodePodsRequestMsg{Node: node.Name}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			node := selectedItem(p.filtered, p.cursor)
+			if node == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return NodeShellRequestMsg{Node: node.Name}
+			}
 		}
 
 	case nodesLoadedMsg:
 		p.nodes = msg.nodes
+		p.allocations = msg.allocations
 		p.applyFilter()
 
 		return p, nil
 
 	case NodeMetricsMsg:
 		p.metrics = msg.Metrics
+		p.metricsUnavailable = msg.Unavailable
+
+		if p.sort.column == sortCPU || p.sort.column == sortMemory {
+			p.applyFilter()
+		}
+
+		if !msg.Unavailable {
+			for name, m := range msg.Metrics {
+				p.history.record(name, m.CPU, m.Memory)
+			}
+
+			p.history.prune(func(name string) bool {
+				_, ok := msg.Metrics[name]
+
+				return ok
+			})
+		}
 
 		return p, nil
 
@@ -83,11 +139,15 @@ func (p *NodesPanel) View() string {
 		b.WriteString(p.styles.PanelTitle.Render(title))
 	}
 
+	if p.metricsUnavailable {
+		b.WriteString(" " + p.styles.Muted.Render("metrics unavailable"))
+	}
+
 	b.WriteString("\n")
 
-	extraHeader := 0
+	extraHeader := p.table.writeHeader(&b, &p.BasePanel, p.styles)
 
-	if p.width > 80 {
+	if p.table == nil && p.width > 80 {
 		b.WriteString(p.renderNodeHeader())
 		b.WriteString("\n")
 
@@ -115,17 +175,21 @@ func (p *NodesPanel) renderNodeHeader() string {
 	hasMetrics := len(p.metrics) > 0
 
 	nameW := p.nodeNameWidth(hasMetrics)
-	header := "  " + utils.PadRight("NAME", nameW)
+	header := "  " + utils.PadRight(p.sortHeader("NAME", sortName, nameW), nameW)
 
 	if hasMetrics {
-		header += " " + utils.PadLeft("CPU", 5)
-		header += " " + utils.PadLeft("MEM", 6)
+		header += " " + utils.PadLeft(p.sortHeader("CPU", sortCPU, 5), 5)
+		header += " " + utils.PadLeft(p.sortHeader("MEM", sortMemory, 6), 6)
+
+		if p.width > trendMinPanelWidth {
+			header += " " + utils.PadRight("CPU/MEM TREND", trendColumnWidth)
+		}
 	}
 
-	header += " " + utils.PadRight("STATUS", 8)
+	header += " " + utils.PadRight(p.sortHeader("STATUS", sortStatus, 8), 8)
 	header += " " + utils.PadRight("ROLES", 15)
 	header += " " + utils.PadRight("VERSION", 12)
-	header += " " + utils.PadRight("AGE", 8)
+	header += " " + utils.PadRight(p.sortHeader("AGE", sortAge, 8), 8)
 
 	return p.styles.TableHeader.Render(
 		utils.Truncate(header, p.width-2),
@@ -138,6 +202,10 @@ func (p *NodesPanel) nodeNameWidth(hasMetrics bool) int {
 
 	if hasMetrics {
 		reserved += 13
+
+		if p.width > trendMinPanelWidth {
+			reserved += trendColumnWidth + 1
+		}
 	}
 
 	nameW := max(p.width-reserved, 10)
@@ -146,6 +214,10 @@ func (p *NodesPanel) nodeNameWidth(hasMetrics bool) int {
 }
 
 func (p *NodesPanel) renderNodeLine(node corev1.Node, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, node, objectRef("Node", &node), selected)
+	}
+
 	status := k8s.GetNodeStatus(&node)
 
 	hasMetrics := false
@@ -158,20 +230,19 @@ func (p *NodesPanel) renderNodeLine(node corev1.Node, selected bool) string {
 		memStr = utils.FormatMemory(m.Memory)
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Node", &node))
 
 	if p.width > 80 {
 		nameW := p.nodeNameWidth(hasMetrics)
-		line += utils.PadRight(utils.Truncate(node.Name, nameW), nameW)
+		line += utils.PadRight(highlightName(p.styles, p.query, node.Name, nameW), nameW)
 
 		if hasMetrics {
 			line += " " + p.styles.Muted.Render(utils.PadLeft(cpuStr, 5))
 			line += " " + p.styles.Muted.Render(utils.PadLeft(memStr, 6))
+
+			if p.width > trendMinPanelWidth {
+				line += " " + renderTrend(p.styles, p.history.get(node.Name))
+			}
 		}
 
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -205,7 +276,7 @@ func (p *NodesPanel) renderNodeLine(node corev1.Node, selected bool) string {
 		nameWidth = 10
 	}
 
-	line += utils.Truncate(node.Name, nameWidth)
+	line += highlightName(p.styles, p.query, node.Name, nameWidth)
 
 	if hasMetrics {
 		line = utils.PadRight(line, p.width-25)
@@ -227,6 +298,69 @@ func (p *NodesPanel) renderNodeLine(node corev1.Node, selected bool) string {
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *NodesPanel) builtinColumns() map[string]tableColumn[corev1.Node] {
+	columns := withColumns(objectColumns[corev1.Node](), map[string]tableColumn[corev1.Node]{
+		"status": {
+			header: "STATUS", width: 8, sort: sortStatus,
+			value: func(node corev1.Node) string { return k8s.GetNodeStatus(&node) },
+			style: func(node corev1.Node) lipgloss.Style { return p.styles.GetStatusStyle(k8s.GetNodeStatus(&node)) },
+		},
+		"roles": {
+			header: "ROLES", width: 15,
+			value: func(node corev1.Node) string { return k8s.GetNodeRoles(&node) },
+		},
+		"version": {
+			header: "VERSION", width: 12,
+			value: func(node corev1.Node) string { return node.Status.NodeInfo.KubeletVersion },
+		},
+		"cpu": {
+			header: "CPU", width: 5, alignRight: true, sort: sortCPU,
+			value: func(node corev1.Node) string {
+				if m, ok := p.metrics[node.Name]; ok {
+					return utils.FormatCPU(m.CPU)
+				}
+
+				return ""
+			},
+			style: func(corev1.Node) lipgloss.Style { return p.styles.Muted },
+		},
+		"memory": {
+			header: "MEM", width: 6, alignRight: true, sort: sortMemory,
+			value: func(node corev1.Node) string {
+				if m, ok := p.metrics[node.Name]; ok {
+					return utils.FormatMemory(m.Memory)
+				}
+
+				return ""
+			},
+			style: func(corev1.Node) lipgloss.Style { return p.styles.Muted },
+		},
+		"trend": {
+			header: "CPU/MEM TREND", width: trendColumnWidth,
+			value: func(node corev1.Node) string { return renderTrend(p.styles, p.history.get(node.Name)) },
+		},
+		"internal-ip": {
+			header: "INTERNAL-IP", width: 15,
+			value: func(node corev1.Node) string { return nodeAddress(&node, corev1.NodeInternalIP) },
+		},
+	})
+
+	// Nodes aren't namespaced
+	delete(columns, "namespace")
+
+	return columns
+}
+
+func nodeAddress(node *corev1.Node, addressType corev1.NodeAddressType) string {
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == addressType {
+			return addr.Address
+		}
+	}
+
+	return ""
+}
+
 func (p *NodesPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No node selected"
@@ -324,6 +458,12 @@ func (p *NodesPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
+	renderUsageHistory(&b, p.styles, p.history.get(node.Name), p.metricsUnavailable, width)
+
+	if alloc, ok := p.allocations[node.Name]; ok {
+		b.WriteString(p.renderAllocation(alloc, width))
+	}
+
 	b.WriteString("\n")
 	b.WriteString(p.styles.DetailTitle.Render("Conditions:"))
 	b.WriteString("\n")
@@ -351,21 +491,103 @@ func (p *NodesPanel) DetailView(width, height int) string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml"))
+	b.WriteString(p.styles.Muted.Render("[a]llocated pods [x] node shell [d]escribe [y]aml"))
 
 	return b.String()
 }
 
+// allocationRow is one bar of the allocation section.
+type allocationRow struct {
+	label       string
+	used, total int64
+	format      func(int64) string
+}
+
+// renderAllocation shows requests, limits and actual usage against
+// allocatable, like the "Allocated resources" section of kubectl describe.
+func (p *NodesPanel) renderAllocation(alloc *k8s.NodeAllocation, width int) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Allocated Resources:"))
+	b.WriteString("\n")
+
+	allocCPU := k8s.MilliCPU(alloc.Allocatable)
+	allocMem := k8s.MemoryBytes(alloc.Allocatable)
+
+	rows := []allocationRow{
+		{"CPU requests", k8s.MilliCPU(alloc.Requests), allocCPU, utils.FormatCPU},
+		{"CPU limits", k8s.MilliCPU(alloc.Limits), allocCPU, utils.FormatCPU},
+		{"Memory requests", k8s.MemoryBytes(alloc.Requests), allocMem, utils.FormatMemory},
+		{"Memory limits", k8s.MemoryBytes(alloc.Limits), allocMem, utils.FormatMemory},
+	}
+
+	if m, ok := p.metrics[alloc.Node]; ok {
+		rows = slices.Insert(rows, 2, allocationRow{"CPU usage", m.CPU, allocCPU, utils.FormatCPU})
+		rows = append(rows, allocationRow{"Memory usage", m.Memory, allocMem, utils.FormatMemory})
+	}
+
+	rows = append(rows, allocationRow{"Pods", int64(len(alloc.Pods)), alloc.MaxPods(), func(n int64) string {
+		return strconv.FormatInt(n, 10)
+	}})
+
+	barW := min(max(width-50, 10), 30)
+
+	for _, row := range rows {
+		b.WriteString(p.renderAllocationRow(row, barW))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderAllocationRow draws "label [bar] used / total (pct)". Limits may
+// exceed allocatable; the bar is capped while the percentage is not.
+func (p *NodesPanel) renderAllocationRow(row allocationRow, barW int) string {
+	var ratio float64
+	if row.total > 0 {
+		ratio = float64(row.used) / float64(row.total)
+	}
+
+	filled := min(int(ratio*float64(barW)+0.5), barW)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barW-filled)
+
+	switch {
+	case ratio >= 1:
+		bar = p.styles.StatusError.Render(bar)
+	case ratio >= allocationWarningRatio:
+		bar = p.styles.StatusWarning.Render(bar)
+	default:
+		bar = p.styles.StatusSuccess.Render(bar)
+	}
+
+	line := fmt.Sprintf("  %-16s %s %s / %s", row.label, bar, row.format(row.used), row.format(row.total))
+	if row.total > 0 {
+		line += fmt.Sprintf(" (%.0f%%)", ratio*100)
+	}
+
+	return line
+}
+
 func (p *NodesPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
 		ctx := context.Background()
+		listCtx := k8s.WithLabelSelector(ctx, selector)
 
-		nodes, err := p.client.ListNodes(ctx)
+		nodes, err := p.client.ListNodes(listCtx)
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return nodesLoadedMsg{nodes: nodes}
+		// Pods only feed the allocation section, which is hidden on error
+		var allocations map[string]*k8s.NodeAllocation
+		if pods, err := p.client.ListPodsAllNamespaces(ctx); err == nil {
+			allocations = k8s.ComputeNodeAllocations(nodes, pods)
+		}
+
+		return nodesLoadedMsg{nodes: nodes, allocations: allocations}
 	}
 }
 
@@ -451,10 +673,21 @@ func (p *NodesPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *NodesPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.nodes,
-		p.filter,
-		func(n corev1.Node) string { return n.Name },
+		p.query,
+		p.sort,
+		func(n corev1.Node) queryFields {
+			f := objectFields(&n)
+			f.status = k8s.GetNodeStatus(&n)
+			f.node = n.Name
+
+			if m, ok := p.metrics[n.Name]; ok {
+				f.cpu, f.memory = m.CPU, m.Memory
+			}
+
+			return f
+		},
 		&p.cursor,
 	)
 }
@@ -464,6 +697,27 @@ func (p *NodesPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *NodesPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *NodesPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *NodesPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.nodes,
@@ -486,6 +740,13 @@ func (p *NodesPanel) NavigateTo(name, _ string) bool {
 	)
 }
 
+func (p *NodesPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(node corev1.Node) ResourceRef {
+		return objectRef("Node", &node)
+	})
+}
+
 type nodesLoadedMsg struct {
-	nodes []corev1.Node
+	nodes       []corev1.Node
+	allocations map[string]*k8s.NodeAllocation
 }
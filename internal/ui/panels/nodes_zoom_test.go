@@ -5,6 +5,10 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 )
 
 func TestNodesPanel_ViewNarrow(t *testing.T) {
@@ -119,3 +123,59 @@ func TestNodesPanel_ViewEmpty(t *testing.T) {
 		t.Fatal("View() returned empty string even with no nodes")
 	}
 }
+
+func TestNodesPanel_AllocationDetail(t *testing.T) {
+	panel := NewNodesPanel(createTestK8sClient(), createTestStyles())
+
+	node := testNode()
+	node.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{NodeName: node.Name, Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1800m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+			},
+		}}},
+	}
+
+	panel.Update(nodesLoadedMsg{
+		nodes:       []corev1.Node{node},
+		allocations: k8s.ComputeNodeAllocations([]corev1.Node{node}, []corev1.Pod{pod}),
+	})
+	panel.Update(NodeMetricsMsg{Metrics: map[string]NodeMetrics{node.Name: {Name: node.Name, CPU: 500}}})
+
+	detail := panel.DetailView(120, 60)
+
+	for _, want := range []string{
+		"Allocated Resources:", "1.8 / 2 (90%)", "3 / 2 (150%)", "500m / 2 (25%)", "1 / 110 (1%)",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail should contain %q:\n%s", want, detail)
+		}
+	}
+
+	msg, ok := pressKey(panel, 'a')().(NodePodsRequestMsg)
+	if !ok || msg.Node != node.Name {
+		t.Errorf("expected NodePodsRequestMsg for %s, got %+v", node.Name, msg)
+	}
+
+	shell, ok := pressKey(panel, 'x')().(NodeShellRequestMsg)
+	if !ok || shell.Node != node.Name {
+		t.Errorf("expected NodeShellRequestMsg for %s, got %+v", node.Name, shell)
+	}
+}
+
+func TestNodesPanel_AllocationHiddenWithoutPods(t *testing.T) {
+	panel := NewNodesPanel(createTestK8sClient(), createTestStyles())
+	panel.Update(nodesLoadedMsg{nodes: []corev1.Node{testNode()}})
+
+	if strings.Contains(panel.DetailView(120, 60), "Allocated Resources:") {
+		t.Error("allocation section should be hidden when pods couldn't be listed")
+	}
+}
@@ -1,11 +1,19 @@
 package panels
 
 import (
+	"cmp"
 	"errors"
-	"strings"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sigs_yaml "sigs.k8s.io/yaml"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
 )
 
 var ErrNoSelection = errors.New("no item selected")
@@ -58,6 +66,14 @@ type ExecRequestMsg struct {
 	Containers []string
 }
 
+// DebugRequestMsg is emitted by the pods panel to debug one of a pod's
+// containers from a container of its own, for images without a shell.
+type DebugRequestMsg struct {
+	PodName    string
+	Namespace  string
+	Containers []string
+}
+
 type ScaleStatefulSetRequestMsg struct {
 	StatefulSetName string
 	Namespace       string
@@ -108,8 +124,153 @@ type TriggerCronJobRequestMsg struct {
 	Namespace   string
 }
 
+// CopySecretValueRequestMsg is emitted by the secrets panel so ui.go can
+// write the decoded value of a single key to the clipboard.
+type CopySecretValueRequestMsg struct {
+	SecretName string
+	Key        string
+	Value      []byte
+}
+
+// EditSecretKeyRequestMsg is emitted by the secrets panel to open the
+// decoded value of a single key in $EDITOR.
+type EditSecretKeyRequestMsg struct {
+	SecretName string
+	Namespace  string
+	Key        string
+	Value      []byte
+}
+
+// CreateSecretRequestMsg is emitted by the secrets panel to start the
+// guided create-secret flow in the given namespace.
+type CreateSecretRequestMsg struct {
+	Namespace string
+}
+
+// ExpiringCertificatesRequestMsg is emitted by the secrets panel to show the
+// cluster-wide report of TLS certificates expiring within Threshold.
+type ExpiringCertificatesRequestMsg struct {
+	Threshold time.Duration
+}
+
+// EditConfigMapKeyRequestMsg is emitted by the configmaps panel to open a
+// single key's value in $EDITOR. IsNew is set when adding a key.
+type EditConfigMapKeyRequestMsg struct {
+	ConfigMapName string
+	Namespace     string
+	Key           string
+	Value         string
+	IsNew         bool
+}
+
+// AddConfigMapKeyRequestMsg is emitted by the configmaps panel to prompt
+// for a new key name before opening the editor.
+type AddConfigMapKeyRequestMsg struct {
+	ConfigMapName string
+	Namespace     string
+	ExistingKeys  []string
+}
+
+// RenameConfigMapKeyRequestMsg is emitted by the configmaps panel.
+type RenameConfigMapKeyRequestMsg struct {
+	ConfigMapName string
+	Namespace     string
+	Key           string
+	ExistingKeys  []string
+}
+
+// RemoveConfigMapKeyRequestMsg is emitted by the configmaps panel.
+type RemoveConfigMapKeyRequestMsg struct {
+	ConfigMapName string
+	Namespace     string
+	Key           string
+}
+
+// CreateNamespaceRequestMsg is emitted by the namespaces panel to start the
+// create-namespace flow.
+type CreateNamespaceRequestMsg struct{}
+
+// EditNamespaceMetadataRequestMsg is emitted by the namespaces panel to edit
+// a namespace's labels and annotations in $EDITOR.
+type EditNamespaceMetadataRequestMsg struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// NamespaceBlockersRequestMsg is emitted by the namespaces panel to explain
+// what keeps a Terminating namespace from being deleted.
+type NamespaceBlockersRequestMsg struct {
+	Name string
+}
+
+// LintNamespaceRequestMsg is emitted by the namespaces panel to show the
+// best-practice lint report for a namespace.
+type LintNamespaceRequestMsg struct {
+	Name string
+}
+
+// CheckReachabilityRequestMsg is emitted by the network policies panel to
+// simulate a connection between two pods. Destination pre-fills the prompt
+// with a pod the selected policy applies to.
+type CheckReachabilityRequestMsg struct {
+	Destination string
+}
+
+// ExplainSchedulingRequestMsg is emitted by the pods panel to explain why
+// a Pending pod doesn't fit on any node.
+type ExplainSchedulingRequestMsg struct {
+	PodName   string
+	Namespace string
+}
+
+// NodePodsRequestMsg is emitted by the nodes panel to list the pods on a
+// node sorted by what they request.
+type NodePodsRequestMsg struct {
+	Node string
+}
+
+// NodeShellRequestMsg is emitted by the nodes panel to open a shell on
+// a node's host.
+type NodeShellRequestMsg struct {
+	Node string
+}
+
+// RightsizeRequestMsg is emitted by the workload panels to suggest
+// resource requests from observed usage.
+type RightsizeRequestMsg struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// JumpToResourceMsg is emitted by the problems panel to focus the panel
+// listing a resource and select it.
+type JumpToResourceMsg struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// SortChangedMsg is emitted when a panel changes its own sort, so the
+// order can be saved to the config. Order is "" for the API order.
+type SortChangedMsg struct {
+	Panel string
+	Order string
+}
+
+// CreateConfigMapRequestMsg is emitted by the configmaps panel to start
+// the create-configmap flow in the given namespace.
+type CreateConfigMapRequestMsg struct {
+	Namespace string
+}
+
+// PodMetricsMsg carries the latest pod usage. Unavailable is set when
+// metrics-server doesn't answer, so panels can say so instead of going
+// quiet.
 type PodMetricsMsg struct {
-	Metrics map[string]PodMetrics
+	Metrics     map[string]PodMetrics
+	Unavailable bool
 }
 
 type PodMetrics struct {
@@ -117,10 +278,19 @@ type PodMetrics struct {
 	Namespace string
 	CPU       int64 // in millicores
 	Memory    int64 // in bytes
+	// Containers holds the usage of each container by name.
+	Containers map[string]ContainerUsage
 }
 
+type ContainerUsage struct {
+	CPU    int64 // in millicores
+	Memory int64 // in bytes
+}
+
+// NodeMetricsMsg is the node counterpart of PodMetricsMsg.
 type NodeMetricsMsg struct {
-	Metrics map[string]NodeMetrics
+	Metrics     map[string]NodeMetrics
+	Unavailable bool
 }
 
 type NodeMetrics struct {
@@ -136,6 +306,21 @@ type SearchResult struct {
 	Kind      string // panel Title(), e.g. "Pods", "Deployments"
 	Status    string // resource-specific status string
 	PanelIdx  int    // set by ui.go when aggregating results
+	// Score ranks results of the same panel; higher is a better match.
+	Score int
+	// Matches are the rune indexes of Name the query matched.
+	Matches []int
+	// MatchedOn names the label or annotation that matched when the name
+	// didn't, e.g. "label app=web".
+	MatchedOn string
+}
+
+// ResourceRef identifies a listed item by its Kubernetes kind, so bulk
+// actions can run over the marked items of any panel.
+type ResourceRef struct {
+	Kind      string
+	Name      string
+	Namespace string
 }
 
 type Panel interface {
@@ -153,6 +338,19 @@ type Panel interface {
 	Refresh() tea.Cmd
 	Delete() tea.Cmd
 	SetFilter(query string)
+	// FilterError is why the last filter didn't parse; the previous valid
+	// filter stays in effect until it does.
+	FilterError() error
+	// LabelSelector is the part of the filter the API server applies.
+	LabelSelector() string
+	// SortOrder is the sort in its config form, e.g. "cpu desc"; "" keeps
+	// the API order.
+	SortOrder() string
+	SetSortOrder(spec string) error
+	SortColumns() []string
+	// SetColumns replaces the panel's own row layout with the given
+	// columns; none restores it.
+	SetColumns(defs []ColumnDef) error
 	SetAllNamespaces(all bool)
 	GetSelectedYAML() (string, error)
 	GetSelectedDescribe() (string, error)
@@ -160,6 +358,18 @@ type Panel interface {
 	SearchItems(query string) []SearchResult
 	// NavigateTo positions the cursor on the item matching name+namespace.
 	NavigateTo(name, namespace string) bool
+	Cursor() int
+	SetCursor(cursor int)
+	// RowAt maps a line of the panel's content, the title being line 0,
+	// to the item drawn there.
+	RowAt(line int) (int, bool)
+	// VisibleRefs lists the items matching the current filter in display order.
+	VisibleRefs() []ResourceRef
+	ToggleMark(ref ResourceRef)
+	// ToggleMarkAll marks every ref, or unmarks them when all are marked.
+	ToggleMarkAll(refs []ResourceRef)
+	ClearMarks()
+	MarkedItems() []ResourceRef
 }
 
 type BasePanel struct {
@@ -169,8 +379,21 @@ type BasePanel struct {
 	height      int
 	focused     bool
 	filter      string
+	query       *filterQuery
+	queryErr    error
+	sort        sortOrder
 	allNs       bool
 	cursor      int
+	// sortable lists the columns the panel fills in, in cycling order.
+	sortable []sortColumn
+	// marked is the set bulk actions run over; it survives filter changes
+	// so marks can be collected across several searches.
+	marked map[ResourceRef]struct{}
+	// rowsTop, windowStart and windowEnd record where the last View drew
+	// its rows, for RowAt.
+	rowsTop     int
+	windowStart int
+	windowEnd   int
 }
 
 func (b *BasePanel) Title() string {
@@ -196,6 +419,28 @@ func (b *BasePanel) IsFocused() bool {
 
 func (b *BasePanel) SetFilter(query string) {
 	b.filter = query
+
+	parsed, err := parseQuery(query)
+	if err != nil {
+		b.queryErr = err
+
+		return
+	}
+
+	b.query = parsed
+	b.queryErr = nil
+}
+
+func (b *BasePanel) FilterError() error {
+	return b.queryErr
+}
+
+func (b *BasePanel) LabelSelector() string {
+	if b.query == nil {
+		return ""
+	}
+
+	return b.query.labelSelector
 }
 
 func (b *BasePanel) SetAllNamespaces(all bool) {
@@ -246,21 +491,145 @@ func (b *BasePanel) AllNamespaces() bool {
 	return b.allNs
 }
 
-// filterByName returns items whose name contains filter (case-insensitive).
-// If filter is empty the original slice is returned unchanged.
+func (b *BasePanel) ToggleMark(ref ResourceRef) {
+	if _, ok := b.marked[ref]; ok {
+		delete(b.marked, ref)
+
+		return
+	}
+
+	if b.marked == nil {
+		b.marked = make(map[ResourceRef]struct{})
+	}
+
+	b.marked[ref] = struct{}{}
+}
+
+func (b *BasePanel) ToggleMarkAll(refs []ResourceRef) {
+	allMarked := true
+
+	for _, ref := range refs {
+		if !b.isMarked(ref) {
+			allMarked = false
+
+			break
+		}
+	}
+
+	if b.marked == nil {
+		b.marked = make(map[ResourceRef]struct{})
+	}
+
+	for _, ref := range refs {
+		if allMarked {
+			delete(b.marked, ref)
+		} else {
+			b.marked[ref] = struct{}{}
+		}
+	}
+}
+
+func (b *BasePanel) ClearMarks() {
+	b.marked = nil
+}
+
+// MarkedItems returns the marked refs sorted by namespace and name.
+func (b *BasePanel) MarkedItems() []ResourceRef {
+	refs := make([]ResourceRef, 0, len(b.marked))
+	for ref := range b.marked {
+		refs = append(refs, ref)
+	}
+
+	slices.SortFunc(refs, func(a, b ResourceRef) int {
+		return cmp.Or(
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Kind, b.Kind),
+		)
+	})
+
+	return refs
+}
+
+func (b *BasePanel) isMarked(ref ResourceRef) bool {
+	_, ok := b.marked[ref]
+
+	return ok
+}
+
+// rowPrefix is the cursor and mark gutter in front of every list row.
+func (b *BasePanel) rowPrefix(selected bool, ref ResourceRef) string {
+	cursor, mark := " ", " "
+	if selected {
+		cursor = ">"
+	}
+
+	if b.isMarked(ref) {
+		mark = "*"
+	}
+
+	return cursor + mark
+}
+
+// objectRef is the ref of a listed Kubernetes object.
+func objectRef(kind string, obj metav1.Object) ResourceRef {
+	return ResourceRef{Kind: kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+}
+
+// refsOf returns the refs of items in display order.
+func refsOf[T any](items []T, ref func(T) ResourceRef) []ResourceRef {
+	refs := make([]ResourceRef, len(items))
+	for i, item := range items {
+		refs[i] = ref(item)
+	}
+
+	return refs
+}
+
+// filterByQuery returns items matching query, whose fields are described
+// by fields, in the given sort order. Without one, fuzzy words put the best
+// matches first and otherwise the order is kept. Without a query or sort
+// the original slice is returned unchanged.
 // The cursor is clamped to the new length so it never goes out of bounds.
-func filterByName[T any](items []T, filter string, name func(T) string, cursor *int) []T {
-	if filter == "" {
+func filterByQuery[T any](
+	items []T, query *filterQuery, order sortOrder, fields func(T) queryFields, cursor *int,
+) []T {
+	if query == nil && order.column == sortNone {
 		return items
 	}
 
-	q := strings.ToLower(filter)
-	out := make([]T, 0, len(items))
+	type match struct {
+		item   T
+		fields queryFields
+		score  int
+	}
+
+	matches := make([]match, 0, len(items))
 
 	for _, item := range items {
-		if strings.Contains(strings.ToLower(name(item)), q) {
-			out = append(out, item)
+		m := match{item: item, fields: fields(item)}
+		if !query.matches(&m.fields) {
+			continue
 		}
+
+		if query != nil && query.ranked {
+			m.score = query.score(&m.fields)
+		}
+
+		matches = append(matches, m)
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if order.column != sortNone {
+			return order.compare(&a.fields, &b.fields)
+		}
+
+		return cmp.Compare(b.score, a.score)
+	})
+
+	out := make([]T, len(matches))
+	for i, m := range matches {
+		out[i] = m.item
 	}
 
 	if *cursor >= len(out) {
@@ -270,6 +639,28 @@ func filterByName[T any](items []T, filter string, name func(T) string, cursor *
 	return out
 }
 
+// highlightName fits name to width like the plain name column, with the
+// characters the filter matched highlighted.
+func highlightName(styles *theme.Styles, query *filterQuery, name string, width int) string {
+	cell := utils.Truncate(name, width)
+
+	// Positions past a truncation point would land on the ellipsis
+	visible := utf8.RuneCountInString(cell)
+	if cell != name {
+		visible = max(visible-3, 0)
+	}
+
+	var positions []int
+
+	for _, pos := range query.highlights(name) {
+		if pos < visible {
+			positions = append(positions, pos)
+		}
+	}
+
+	return styles.HighlightMatches(cell, positions)
+}
+
 // visibleWindow computes the start/end indices of the visible slice window.
 // extraHeaderRows should be 1 when a header row is rendered above the list
 // (pods, nodes), 0 otherwise.
@@ -283,17 +674,42 @@ func (b *BasePanel) visibleWindow(total, extraHeaderRows int) (start, end int) {
 
 	end = min(start+visible, total)
 
+	// Rows follow the title line and each header row with its border
+	b.rowsTop = 1 + 2*extraHeaderRows
+	b.windowStart, b.windowEnd = start, end
+
 	return start, end
 }
 
-// renderTitle returns the panel title string, optionally with a [key] suffix.
-// When shortcutKey is empty the bracket is omitted.
+// RowAt returns the index of the item drawn on a line of the panel's
+// content as of the last View, counting the title line as 0.
+func (b *BasePanel) RowAt(line int) (int, bool) {
+	idx := b.windowStart + line - b.rowsTop
+	if line < b.rowsTop || idx >= b.windowEnd {
+		return 0, false
+	}
+
+	return idx, true
+}
+
+// renderTitle returns the panel title string, optionally with a [key] suffix
+// and the number of marked items. When shortcutKey is empty the bracket is
+// omitted.
 func (b *BasePanel) renderTitle() string {
-	if b.shortcutKey == "" {
-		return b.title
+	title := b.title
+	if b.shortcutKey != "" {
+		title += " [" + b.shortcutKey + "]"
+	}
+
+	if len(b.marked) > 0 {
+		title += fmt.Sprintf(" (%d marked)", len(b.marked))
+	}
+
+	if b.sort.column != sortNone {
+		title += " sorted by " + b.sort.column.String() + " " + b.sort.arrow()
 	}
 
-	return b.title + " [" + b.shortcutKey + "]"
+	return title
 }
 
 // marshalSelectedYAML marshals the item at cursor to YAML.
@@ -328,7 +744,13 @@ func selectedName[T any](items []T, cursor int, name func(T) string) string {
 	return name(items[cursor])
 }
 
-// searchByName returns SearchResults for all items whose name contains query.
+// maxSearchedMetadataLen skips long annotation values, like
+// last-applied-configuration, that a fuzzy query would match by chance.
+const maxSearchedMetadataLen = 200
+
+// searchByName returns SearchResults for the items whose name, or failing
+// that one of their labels or annotations, fuzzy-matches query. Results are
+// ranked best first, with name matches ahead of metadata ones.
 // status is a per-item callback to compute the Status field.
 // namespace is a per-item callback; pass nil for cluster-scoped resources.
 func searchByName[T any](
@@ -342,29 +764,89 @@ func searchByName[T any](
 		return nil
 	}
 
-	q := strings.ToLower(query)
-
 	var results []SearchResult
 
 	for _, item := range items {
-		if strings.Contains(strings.ToLower(name(item)), q) {
-			ns := ""
-			if namespace != nil {
-				ns = namespace(item)
+		result := SearchResult{Name: name(item), Kind: kind}
+
+		if score, positions, ok := utils.FuzzyMatch(query, result.Name); ok {
+			result.Score, result.Matches = score, positions
+		} else if obj, isObj := any(&item).(metav1.Object); isObj {
+			if result.MatchedOn, result.Score, ok = searchMetadata(query, obj); !ok {
+				continue
 			}
+		} else {
+			continue
+		}
 
-			results = append(results, SearchResult{
-				Name:      name(item),
-				Namespace: ns,
-				Kind:      kind,
-				Status:    status(item),
-			})
+		if namespace != nil {
+			result.Namespace = namespace(item)
 		}
+
+		result.Status = status(item)
+		results = append(results, result)
 	}
 
+	rankSearchResults(results)
+
 	return results
 }
 
+// searchMetadata finds the label or annotation that best matches query and
+// describes it, e.g. "label app=web".
+func searchMetadata(query string, obj metav1.Object) (matchedOn string, score int, ok bool) {
+	for _, meta := range []struct {
+		kind   string
+		values map[string]string
+	}{
+		{"label", obj.GetLabels()},
+		{"annotation", obj.GetAnnotations()},
+	} {
+		for _, key := range sortedKeys(meta.values) {
+			value := meta.values[key]
+			if len(value) > maxSearchedMetadataLen {
+				continue
+			}
+
+			s, _, matched := utils.FuzzyMatch(query, key+"="+value)
+			if matched && (!ok || s > score) {
+				matchedOn, score, ok = meta.kind+" "+key+"="+value, s, true
+			}
+		}
+	}
+
+	return matchedOn, score, ok
+}
+
+// rankSearchResults orders name matches before metadata matches, each by
+// descending score, keeping list order among equals.
+func rankSearchResults(results []SearchResult) {
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if aName, bName := a.MatchedOn == "", b.MatchedOn == ""; aName != bName {
+			if aName {
+				return -1
+			}
+
+			return 1
+		}
+
+		return cmp.Compare(b.Score, a.Score)
+	})
+}
+
+// sortedKeys returns the keys of m in sorted order so per-key cursors stay
+// stable across renders.
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
 // navigateTo moves cursor to the first item matching name+namespace.
 // For cluster-scoped resources pass nil for namespace and "" for targetNamespace.
 func navigateTo[T any](
@@ -3,11 +3,14 @@ package panels
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
@@ -20,7 +23,20 @@ type PodsPanel struct {
 	styles   *theme.Styles
 	pods     []corev1.Pod
 	filtered []corev1.Pod
+	table    *columnTable[corev1.Pod]
 	metrics  map[string]PodMetrics
+	// history feeds the sparklines and keeps the last known samples when
+	// metrics become unavailable
+	history            *metricHistory
+	metricsUnavailable bool
+}
+
+// podQuickSorts are the orders S steps through, largest first like top,
+// before going back to the API order.
+var podQuickSorts = []sortOrder{
+	{column: sortCPU, desc: true},
+	{column: sortMemory, desc: true},
+	{column: sortRestarts, desc: true},
 }
 
 func NewPodsPanel(client *k8s.Client, styles *theme.Styles) *PodsPanel {
@@ -28,12 +44,20 @@ func NewPodsPanel(client *k8s.Client, styles *theme.Styles) *PodsPanel {
 		BasePanel: BasePanel{
 			title:       "Pods",
 			shortcutKey: "2",
+			sortable:    podSortColumns,
 		},
-		client: client,
-		styles: styles,
+		client:  client,
+		styles:  styles,
+		history: newMetricHistory(DefaultMetricsRetention),
 	}
 }
 
+// SetMetricsRetention sets how many samples each pod keeps for its
+// sparklines, dropping the history collected so far.
+func (p *PodsPanel) SetMetricsRetention(samples int) {
+	p.history = newMetricHistory(samples)
+}
+
 func (p *PodsPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -91,6 +115,55 @@ func (p *PodsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 					Containers: containers,
 				}
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("X"))):
+			if p.cursor >= len(p.filtered) {
+				return p, nil
+			}
+
+			pod := p.filtered[p.cursor]
+
+			var containers []string
+			for _, container := range pod.Spec.Containers {
+				containers = append(containers, container.Name)
+			}
+
+			return p, func() tea.Msg {
+				return DebugRequestMsg{
+					PodName:    pod.Name,
+					Namespace:  pod.Namespace,
+					Containers: containers,
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+			if p.cursor >= len(p.filtered) {
+				return p, nil
+			}
+
+			pod := p.filtered[p.cursor]
+			if pod.Spec.NodeName != "" {
+				return p, func() tea.Msg {
+					return StatusMsg{Message: pod.Name + " is already scheduled on " + pod.Spec.NodeName}
+				}
+			}
+
+			return p, func() tea.Msg {
+				return ExplainSchedulingRequestMsg{PodName: pod.Name, Namespace: pod.Namespace}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("S"))):
+			next := sortOrder{}
+			if i := slices.Index(podQuickSorts, p.sort); i+1 < len(podQuickSorts) {
+				next = podQuickSorts[i+1]
+			}
+
+			p.sort = next
+
+			p.applyFilter()
+
+			order := p.sort.String()
+
+			return p, func() tea.Msg {
+				return SortChangedMsg{Panel: p.title, Order: order}
+			}
 		}
 
 	case podsLoadedMsg:
@@ -101,6 +174,23 @@ func (p *PodsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 
 	case PodMetricsMsg:
 		p.metrics = msg.Metrics
+		p.metricsUnavailable = msg.Unavailable
+
+		if p.sort.column == sortCPU || p.sort.column == sortMemory {
+			p.applyFilter()
+		}
+
+		if !msg.Unavailable {
+			for key, m := range msg.Metrics {
+				p.history.record(key, m.CPU, m.Memory)
+			}
+
+			p.history.prune(func(key string) bool {
+				_, ok := msg.Metrics[key]
+
+				return ok
+			})
+		}
 
 		return p, nil
 
@@ -123,11 +213,15 @@ func (p *PodsPanel) View() string {
 		b.WriteString(p.styles.PanelTitle.Render(title))
 	}
 
+	if p.metricsUnavailable {
+		b.WriteString(" " + p.styles.Muted.Render("metrics unavailable"))
+	}
+
 	b.WriteString("\n")
 
-	extraHeader := 0
+	extraHeader := p.table.writeHeader(&b, &p.BasePanel, p.styles)
 
-	if p.width > 80 {
+	if p.table == nil && p.width > 80 {
 		b.WriteString(p.renderPodHeader())
 		b.WriteString("\n")
 
@@ -154,20 +248,25 @@ func (p *PodsPanel) View() string {
 func (p *PodsPanel) renderPodHeader() string {
 	hasMetrics := len(p.metrics) > 0
 
-	header := "  " + utils.PadRight("NAME", p.podNameWidth(hasMetrics))
+	nameW := p.podNameWidth(hasMetrics)
+	header := "  " + utils.PadRight(p.sortHeader("NAME", sortName, nameW), nameW)
 
 	if hasMetrics {
-		header += " " + utils.PadLeft("CPU", 5)
-		header += " " + utils.PadLeft("MEM", 6)
+		header += " " + utils.PadLeft(p.sortHeader("CPU", sortCPU, 5), 5)
+		header += " " + utils.PadLeft(p.sortHeader("MEM", sortMemory, 6), 6)
+
+		if p.width > trendMinPanelWidth {
+			header += " " + utils.PadRight("CPU/MEM TREND", trendColumnWidth)
+		}
 	}
 
-	header += " " + utils.PadRight("STATUS", 10)
-	header += " " + utils.PadRight("READY", 5)
-	header += " " + utils.PadRight("RESTARTS", 8)
-	header += " " + utils.PadRight("AGE", 8)
+	header += " " + utils.PadRight(p.sortHeader("STATUS", sortStatus, 10), 10)
+	header += " " + utils.PadRight(p.sortHeader("READY", sortReady, 5), 5)
+	header += " " + utils.PadRight(p.sortHeader("RESTARTS", sortRestarts, 8), 8)
+	header += " " + utils.PadRight(p.sortHeader("AGE", sortAge, 8), 8)
 
 	if p.width > 120 && p.allNs {
-		header += " " + utils.PadRight("NAMESPACE", 15)
+		header += " " + utils.PadRight(p.sortHeader("NAMESPACE", sortNamespace, 15), 15)
 	}
 
 	return p.styles.TableHeader.Render(
@@ -181,6 +280,10 @@ func (p *PodsPanel) podNameWidth(hasMetrics bool) int {
 
 	if hasMetrics {
 		reserved += 13
+
+		if p.width > trendMinPanelWidth {
+			reserved += trendColumnWidth + 1
+		}
 	}
 
 	if p.width > 120 && p.allNs {
@@ -193,6 +296,10 @@ func (p *PodsPanel) podNameWidth(hasMetrics bool) int {
 }
 
 func (p *PodsPanel) renderPodLine(pod corev1.Pod, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, pod, objectRef("Pod", &pod), selected)
+	}
+
 	status := k8s.GetPodStatus(&pod)
 
 	metricsKey := pod.Namespace + "/" + pod.Name
@@ -206,12 +313,7 @@ func (p *PodsPanel) renderPodLine(pod corev1.Pod, selected bool) string {
 		memStr = utils.FormatMemory(m.Memory)
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Pod", &pod))
 
 	if p.width > 80 {
 		return p.renderPodLineWide(
@@ -229,7 +331,7 @@ func (p *PodsPanel) renderPodLine(pod corev1.Pod, selected bool) string {
 		nameWidth = 10
 	}
 
-	line += utils.Truncate(pod.Name, nameWidth)
+	line += highlightName(p.styles, p.query, pod.Name, nameWidth)
 
 	if hasMetrics {
 		line = utils.PadRight(line, p.width-25)
@@ -251,24 +353,91 @@ func (p *PodsPanel) renderPodLine(pod corev1.Pod, selected bool) string {
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *PodsPanel) builtinColumns() map[string]tableColumn[corev1.Pod] {
+	usage := func(pod corev1.Pod) (PodMetrics, bool) {
+		m, ok := p.metrics[pod.Namespace+"/"+pod.Name]
+
+		return m, ok
+	}
+
+	return withColumns(objectColumns[corev1.Pod](), map[string]tableColumn[corev1.Pod]{
+		"status": {
+			header: "STATUS", width: 10, sort: sortStatus,
+			value: func(pod corev1.Pod) string { return k8s.GetPodStatus(&pod) },
+			style: func(pod corev1.Pod) lipgloss.Style { return p.styles.GetStatusStyle(k8s.GetPodStatus(&pod)) },
+		},
+		"ready": {
+			header: "READY", width: 5, sort: sortReady,
+			value: func(pod corev1.Pod) string { return k8s.GetPodReadyCount(&pod) },
+		},
+		"restarts": {
+			header: "RESTARTS", width: 8, sort: sortRestarts,
+			value: func(pod corev1.Pod) string { return fmt.Sprintf("%d", k8s.GetPodRestarts(&pod)) },
+		},
+		"cpu": {
+			header: "CPU", width: 5, alignRight: true, sort: sortCPU,
+			value: func(pod corev1.Pod) string {
+				if m, ok := usage(pod); ok {
+					return utils.FormatCPU(m.CPU)
+				}
+
+				return ""
+			},
+			style: func(corev1.Pod) lipgloss.Style { return p.styles.Muted },
+		},
+		"memory": {
+			header: "MEM", width: 6, alignRight: true, sort: sortMemory,
+			value: func(pod corev1.Pod) string {
+				if m, ok := usage(pod); ok {
+					return utils.FormatMemory(m.Memory)
+				}
+
+				return ""
+			},
+			style: func(corev1.Pod) lipgloss.Style { return p.styles.Muted },
+		},
+		"trend": {
+			header: "CPU/MEM TREND", width: trendColumnWidth,
+			value: func(pod corev1.Pod) string {
+				return renderTrend(p.styles, p.history.get(pod.Namespace+"/"+pod.Name))
+			},
+		},
+		"node": {
+			header: "NODE", width: 20,
+			value: func(pod corev1.Pod) string { return pod.Spec.NodeName },
+		},
+		"ip": {
+			header: "IP", width: 15,
+			value: func(pod corev1.Pod) string { return pod.Status.PodIP },
+		},
+		"qos": {
+			header: "QOS", width: 10,
+			value: func(pod corev1.Pod) string { return string(pod.Status.QOSClass) },
+		},
+		"images": {
+			header: "IMAGES", width: 30,
+			value: func(pod corev1.Pod) string { return strings.Join(podSpecImages(&pod.Spec), ",") },
+		},
+	})
+}
+
 func (p *PodsPanel) renderPodLineWide(
 	pod corev1.Pod,
 	selected, hasMetrics bool,
 	cpuStr, memStr, status string,
 ) string {
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("Pod", &pod))
 
 	nameW := p.podNameWidth(hasMetrics)
-	line += utils.PadRight(utils.Truncate(pod.Name, nameW), nameW)
+	line += utils.PadRight(highlightName(p.styles, p.query, pod.Name, nameW), nameW)
 
 	if hasMetrics {
 		line += " " + p.styles.Muted.Render(utils.PadLeft(cpuStr, 5))
 		line += " " + p.styles.Muted.Render(utils.PadLeft(memStr, 6))
+
+		if p.width > trendMinPanelWidth {
+			line += " " + renderTrend(p.styles, p.history.get(pod.Namespace+"/"+pod.Name))
+		}
 	}
 
 	statusStyle := p.styles.GetStatusStyle(status)
@@ -355,6 +524,8 @@ func (p *PodsPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
+	renderUsageHistory(&b, p.styles, p.history.get(metricsKey), p.metricsUnavailable, width)
+
 	b.WriteString("\n")
 	b.WriteString(p.styles.DetailTitle.Render("Containers:"))
 	b.WriteString("\n")
@@ -405,15 +576,81 @@ func (p *PodsPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
+	if m, ok := p.metrics[metricsKey]; ok && len(m.Containers) > 0 {
+		p.renderContainerUsage(&b, &pod, m)
+	}
+
+	hint := "[l]ogs [x]exec [X]debug [p]ort-forward [S]ort [d]escribe [y]aml [D]elete"
+	if pod.Spec.NodeName == "" {
+		hint = "[w]hy pending " + hint
+	}
+
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[l]ogs [x]exec [p]ort-forward [d]escribe [y]aml [D]elete"))
+	b.WriteString(p.styles.Muted.Render(hint))
 
 	return b.String()
 }
 
+// containerUsageWarningRatio colors usage this close to a container's limit.
+const containerUsageWarningRatio = 0.9
+
+// renderContainerUsage compares each container's usage with its requests
+// and limits. Usage above the request is highlighted, and usage near the
+// limit, where memory gets OOM-killed and CPU throttled, more so.
+func (p *PodsPanel) renderContainerUsage(b *strings.Builder, pod *corev1.Pod, m PodMetrics) {
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Container Resources:"))
+	b.WriteString("\n")
+
+	header := fmt.Sprintf("  %-20s %-8s %-8s %-8s %-8s %-8s %-8s",
+		"NAME", "CPU", "CPU REQ", "CPU LIM", "MEM", "MEM REQ", "MEM LIM")
+	b.WriteString(p.styles.TableHeader.Render(header))
+	b.WriteString("\n")
+
+	for _, container := range pod.Spec.Containers {
+		usage, ok := m.Containers[container.Name]
+		if !ok {
+			continue
+		}
+
+		requests, limits := container.Resources.Requests, container.Resources.Limits
+
+		b.WriteString(p.styles.TableRow.Render("  " + utils.PadRight(utils.Truncate(container.Name, 20), 20)))
+		b.WriteString(" " + p.renderUsageCell(usage.CPU, k8s.MilliCPU(requests), k8s.MilliCPU(limits), utils.FormatCPU))
+		b.WriteString(" " + p.renderUsageCell(
+			usage.Memory, k8s.MemoryBytes(requests), k8s.MemoryBytes(limits), utils.FormatMemory))
+		b.WriteString("\n")
+	}
+}
+
+// renderUsageCell renders "used request limit" as three 8-wide columns.
+func (p *PodsPanel) renderUsageCell(used, request, limit int64, format func(int64) string) string {
+	orDash := func(v int64) string {
+		if v == 0 {
+			return "-"
+		}
+
+		return format(v)
+	}
+
+	style := p.styles.TableRow
+
+	switch {
+	case limit > 0 && float64(used) >= float64(limit)*containerUsageWarningRatio:
+		style = p.styles.StatusError
+	case request > 0 && used > request:
+		style = p.styles.StatusWarning
+	}
+
+	return style.Render(utils.PadRight(format(used), 8)) + " " +
+		p.styles.TableRow.Render(utils.PadRight(orDash(request), 8)+" "+utils.PadRight(orDash(limit), 8))
+}
+
 func (p *PodsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			pods []corev1.Pod
@@ -545,12 +782,60 @@ func (p *PodsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *PodsPanel) applyFilter() {
-	p.filtered = filterByName(
+	var selected types.NamespacedName
+	if pod := p.SelectedPod(); pod != nil {
+		selected = types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	}
+
+	p.filtered = filterByQuery(
 		p.pods,
-		p.filter,
-		func(pod corev1.Pod) string { return pod.Name },
+		p.query,
+		p.sort,
+		func(pod corev1.Pod) queryFields {
+			f := podFields(pod)
+
+			if m, ok := p.metrics[pod.Namespace+"/"+pod.Name]; ok {
+				f.cpu, f.memory = m.CPU, m.Memory
+			}
+
+			return f
+		},
 		&p.cursor,
 	)
+
+	if p.sort.column == sortNone {
+		return
+	}
+
+	// Usage changes reorder the list; keep the cursor on the same pod
+	if i := slices.IndexFunc(p.filtered, func(pod corev1.Pod) bool {
+		return pod.Name == selected.Name && pod.Namespace == selected.Namespace
+	}); i >= 0 {
+		p.cursor = i
+	}
+}
+
+// podFields exposes a pod's status, node, images and restarts to filters.
+func podFields(pod corev1.Pod) queryFields {
+	f := objectFields(&pod)
+	f.status = k8s.GetPodStatus(&pod)
+	f.node = pod.Spec.NodeName
+	f.images = podSpecImages(&pod.Spec)
+	f.restarts = int64(k8s.GetPodRestarts(&pod))
+
+	if total := len(pod.Spec.Containers); total > 0 {
+		ready := 0
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+		}
+
+		f.ready = float64(ready) / float64(total)
+	}
+
+	return f
 }
 
 func (p *PodsPanel) SetFilter(query string) {
@@ -558,6 +843,27 @@ func (p *PodsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *PodsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *PodsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 // SetTestPods replaces the pod list for cross-package testing.
 func (p *PodsPanel) SetTestPods(pods []corev1.Pod) {
 	p.pods = pods
@@ -585,6 +891,12 @@ func (p *PodsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *PodsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(pod corev1.Pod) ResourceRef {
+		return objectRef("Pod", &pod)
+	})
+}
+
 type podsLoadedMsg struct {
 	pods []corev1.Pod
 }
@@ -0,0 +1,139 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodsPanel_ExplainScheduling(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+
+	pending := testPod()
+	pending.Name = "pending"
+	pending.Status.Phase = corev1.PodPending
+
+	scheduled := testPod()
+	scheduled.Spec.NodeName = "worker-1"
+
+	panel.pods = []corev1.Pod{pending, scheduled}
+	panel.filtered = panel.pods
+
+	if !strings.Contains(panel.DetailView(100, 40), "[w]hy pending") {
+		t.Error("detail view should offer the explainer for an unscheduled pod")
+	}
+
+	msg, ok := pressKey(panel, 'w')().(ExplainSchedulingRequestMsg)
+	if !ok || msg.PodName != "pending" || msg.Namespace != "default" {
+		t.Errorf("expected ExplainSchedulingRequestMsg for pending, got %+v", msg)
+	}
+
+	panel.MoveDown(len(panel.filtered))
+
+	if _, ok := pressKey(panel, 'w')().(StatusMsg); !ok {
+		t.Error("scheduled pods should only report where they run")
+	}
+}
+
+func TestPodsPanel_DebugRequest(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+
+	pod := testPod()
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "proxy"})
+
+	panel.pods = []corev1.Pod{pod}
+	panel.filtered = panel.pods
+
+	msg, ok := pressKey(panel, 'X')().(DebugRequestMsg)
+	if !ok || msg.PodName != "test-pod" || strings.Join(msg.Containers, ",") != "main,proxy" {
+		t.Errorf("expected DebugRequestMsg listing both containers, got %+v", msg)
+	}
+}
+
+func TestPodsPanel_SortByUsage(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+
+	var pods []corev1.Pod
+
+	for _, name := range []string{"idle", "busy", "other-ns"} {
+		pod := testPod()
+		pod.Name = name
+		pods = append(pods, pod)
+	}
+
+	pods[2].Namespace = "kube-system"
+
+	panel.Update(podsLoadedMsg{pods: pods})
+	panel.Update(PodMetricsMsg{Metrics: map[string]PodMetrics{
+		"default/idle":         {CPU: 1, Memory: 900},
+		"default/busy":         {CPU: 500, Memory: 100},
+		"kube-system/other-ns": {CPU: 50, Memory: 500},
+	}})
+
+	names := func() string {
+		var out []string
+		for _, pod := range panel.filtered {
+			out = append(out, pod.Name)
+		}
+
+		return strings.Join(out, ",")
+	}
+
+	pressKey(panel, 'S')
+
+	if got := names(); got != "busy,other-ns,idle" {
+		t.Errorf("cpu order = %s", got)
+	}
+
+	// The cursor follows the selected pod as usage reorders the list
+	panel.cursor = 0
+	panel.Update(PodMetricsMsg{Metrics: map[string]PodMetrics{
+		"default/idle":         {CPU: 900, Memory: 900},
+		"default/busy":         {CPU: 500, Memory: 100},
+		"kube-system/other-ns": {CPU: 50, Memory: 500},
+	}})
+
+	if pod := panel.SelectedPod(); pod == nil || pod.Name != "busy" {
+		t.Errorf("selection should stay on busy, got %+v", pod)
+	}
+
+	pressKey(panel, 'S')
+
+	if got := names(); got != "idle,other-ns,busy" || !strings.Contains(panel.View(), "sorted by memory") {
+		t.Errorf("memory order = %s", got)
+	}
+
+	pressKey(panel, 'S')
+	pressKey(panel, 'S')
+
+	if got := names(); got != "idle,busy,other-ns" || panel.pods[0].Name != "idle" {
+		t.Errorf("name sort should restore the listed order, got %s", got)
+	}
+}
+
+func TestPodsPanel_ContainerUsage(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+
+	pod := testPod()
+	pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+	}
+
+	panel.Update(podsLoadedMsg{pods: []corev1.Pod{pod}})
+	panel.Update(PodMetricsMsg{Metrics: map[string]PodMetrics{
+		"default/test-pod": {CPU: 20, Memory: 120 * 1024 * 1024, Containers: map[string]ContainerUsage{
+			"main": {CPU: 20, Memory: 120 * 1024 * 1024},
+		}},
+	}})
+
+	view := panel.DetailView(120, 60)
+
+	for _, want := range []string{"Container Resources:", "MEM LIM", "120Mi", "64Mi", "128Mi"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view should contain %q", want)
+		}
+	}
+}
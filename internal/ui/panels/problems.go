@@ -0,0 +1,392 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+// ProblemsPanel lists what needs fixing in the current namespace (or all
+// namespaces), most severe first.
+type ProblemsPanel struct {
+	BasePanel
+	client   *k8s.Client
+	styles   *theme.Styles
+	problems []k8s.Problem
+	filtered []k8s.Problem
+	table    *columnTable[k8s.Problem]
+}
+
+func NewProblemsPanel(client *k8s.Client, styles *theme.Styles) *ProblemsPanel {
+	return &ProblemsPanel{
+		BasePanel: BasePanel{
+			title:       "Problems",
+			shortcutKey: "",
+			sortable:    problemSortColumns,
+		},
+		client: client,
+		styles: styles,
+	}
+}
+
+func (p *ProblemsPanel) Init() tea.Cmd {
+	return p.Refresh()
+}
+
+func (p *ProblemsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
+			p.MoveUp()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
+			p.MoveDown(len(p.filtered))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
+			p.MoveToTop()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
+			p.MoveToBottom(len(p.filtered))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			problem := selectedItem(p.filtered, p.cursor)
+			if problem == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return JumpToResourceMsg{
+					Kind:      problem.Kind,
+					Name:      problem.Name,
+					Namespace: problem.Namespace,
+				}
+			}
+		}
+
+	case problemsLoadedMsg:
+		p.problems = msg.problems
+		p.applyFilter()
+
+		return p, nil
+
+	case RefreshMsg:
+		if msg.PanelName == p.Title() {
+			return p, p.Refresh()
+		}
+	}
+
+	return p, nil
+}
+
+func (p *ProblemsPanel) View() string {
+	var b strings.Builder
+
+	title := p.renderTitle()
+	if p.focused {
+		b.WriteString(p.styles.PanelTitleActive.Render(title))
+	} else {
+		b.WriteString(p.styles.PanelTitle.Render(title))
+	}
+
+	b.WriteString("\n")
+
+	if len(p.filtered) == 0 && p.problems != nil && p.filter == "" {
+		b.WriteString(p.styles.StatusSuccess.Render("  No problems found"))
+		b.WriteString("\n")
+	}
+
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
+
+	for i := startIdx; i < endIdx; i++ {
+		b.WriteString(p.renderProblemLine(p.filtered[i], i == p.cursor))
+		b.WriteString("\n")
+	}
+
+	style := p.styles.Panel
+	if p.focused {
+		style = p.styles.PanelFocused
+	}
+
+	return style.Width(p.width).Height(p.height).Render(b.String())
+}
+
+func (p *ProblemsPanel) severityStyle(severity k8s.ProblemSeverity) lipgloss.Style {
+	switch severity {
+	case k8s.SeverityCritical:
+		return p.styles.StatusError
+	case k8s.SeverityWarning:
+		return p.styles.StatusWarning
+	default:
+		return p.styles.Muted
+	}
+}
+
+func (p *ProblemsPanel) renderProblemLine(problem k8s.Problem, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, problem, problemRef(problem), selected)
+	}
+
+	line := p.rowPrefix(selected, problemRef(problem))
+
+	sevStyle := p.severityStyle(problem.Severity)
+	line += sevStyle.Render(utils.PadRight(problem.Severity.String(), 9))
+
+	object := problem.Kind + "/" + problem.Name
+	if p.width > 80 {
+		objectW := max(p.width-42, 20)
+		line += " " + utils.PadRight(highlightName(p.styles, p.query, object, objectW), objectW)
+		line += " " + utils.Truncate(problem.Reason, 25)
+	} else {
+		line += " " + highlightName(p.styles, p.query, object, max(p.width-16, 10))
+	}
+
+	if selected && p.focused {
+		return p.styles.ListItemFocused.Render(line)
+	} else if selected {
+		return p.styles.ListItemSelected.Render(line)
+	}
+
+	return p.styles.ListItem.Render(line)
+}
+
+// builtinColumns for problems, which aren't Kubernetes objects themselves.
+func (p *ProblemsPanel) builtinColumns() map[string]tableColumn[k8s.Problem] {
+	return map[string]tableColumn[k8s.Problem]{
+		"severity": {
+			header: "SEVERITY", width: 9,
+			value: func(problem k8s.Problem) string { return problem.Severity.String() },
+			style: func(problem k8s.Problem) lipgloss.Style { return p.severityStyle(problem.Severity) },
+		},
+		"object": {
+			header: "OBJECT", width: 20, fill: true, highlight: true, sort: sortName,
+			value: func(problem k8s.Problem) string { return problem.Kind + "/" + problem.Name },
+		},
+		"name": {
+			header: "NAME", width: 20, highlight: true, sort: sortName,
+			value: func(problem k8s.Problem) string { return problem.Name },
+		},
+		"kind": {
+			header: "KIND", width: 12,
+			value: func(problem k8s.Problem) string { return problem.Kind },
+		},
+		"namespace": {
+			header: "NAMESPACE", width: 15, sort: sortNamespace,
+			value: func(problem k8s.Problem) string { return problem.Namespace },
+		},
+		"reason": {
+			header: "REASON", width: 25, sort: sortStatus,
+			value: func(problem k8s.Problem) string { return problem.Reason },
+		},
+		"message": {
+			header: "MESSAGE", width: 40,
+			value: func(problem k8s.Problem) string { return problem.Message },
+		},
+	}
+}
+
+func (p *ProblemsPanel) DetailView(width, height int) string {
+	if p.cursor >= len(p.filtered) {
+		return "No problem selected"
+	}
+
+	problem := p.filtered[p.cursor]
+
+	var b strings.Builder
+	b.WriteString(p.styles.DetailTitle.Render("Problem: " + problem.Reason))
+	b.WriteString("\n\n")
+
+	b.WriteString(p.styles.DetailLabel.Render("Severity:"))
+	b.WriteString(p.severityStyle(problem.Severity).Render(problem.Severity.String()))
+	b.WriteString("\n")
+
+	b.WriteString(p.styles.DetailLabel.Render("Resource:"))
+	b.WriteString(p.styles.DetailValue.Render(problem.Kind + "/" + problem.Name))
+	b.WriteString("\n")
+
+	if problem.Namespace != "" {
+		b.WriteString(p.styles.DetailLabel.Render("Namespace:"))
+		b.WriteString(p.styles.DetailValue.Render(problem.Namespace))
+		b.WriteString("\n")
+	}
+
+	if problem.Message != "" {
+		b.WriteString("\n")
+		b.WriteString(p.styles.DetailTitle.Render("Details:"))
+		b.WriteString("\n")
+
+		for _, line := range utils.WrapText(problem.Message, width-4) {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Summary:"))
+	b.WriteString("\n")
+
+	for _, severity := range []k8s.ProblemSeverity{k8s.SeverityCritical, k8s.SeverityWarning, k8s.SeverityInfo} {
+		count := 0
+
+		for _, other := range p.problems {
+			if other.Severity == severity {
+				count++
+			}
+		}
+
+		fmt.Fprintf(&b, "  %-9s %d\n", severity.String(), count)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.Muted.Render("[enter] jump to resource"))
+
+	return b.String()
+}
+
+func (p *ProblemsPanel) Refresh() tea.Cmd {
+	return func() tea.Msg {
+		problems, err := p.client.ScanProblems(context.Background(), p.allNs)
+		if err != nil {
+			return ErrorMsg{Error: err}
+		}
+
+		if problems == nil {
+			problems = []k8s.Problem{}
+		}
+
+		return problemsLoadedMsg{problems: problems}
+	}
+}
+
+func (p *ProblemsPanel) Delete() tea.Cmd {
+	return func() tea.Msg {
+		return StatusMsg{Message: "Problems cannot be deleted; jump to the resource instead"}
+	}
+}
+
+func (p *ProblemsPanel) SelectedItem() any {
+	item := selectedItem(p.filtered, p.cursor)
+	if item == nil {
+		return nil
+	}
+
+	return item
+}
+
+func (p *ProblemsPanel) SelectedName() string {
+	return selectedName(p.filtered, p.cursor, func(pr k8s.Problem) string { return pr.Name })
+}
+
+func (p *ProblemsPanel) GetSelectedYAML() (string, error) {
+	return marshalSelectedYAML(p.filtered, p.cursor)
+}
+
+func (p *ProblemsPanel) GetSelectedDescribe() (string, error) {
+	if p.cursor >= len(p.filtered) {
+		return "", ErrNoSelection
+	}
+
+	problem := p.filtered[p.cursor]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Severity:      %s\n", problem.Severity)
+	fmt.Fprintf(&b, "Resource:      %s/%s\n", problem.Kind, problem.Name)
+
+	if problem.Namespace != "" {
+		fmt.Fprintf(&b, "Namespace:     %s\n", problem.Namespace)
+	}
+
+	fmt.Fprintf(&b, "Reason:        %s\n", problem.Reason)
+	fmt.Fprintf(&b, "\nMessage:\n%s\n", problem.Message)
+
+	return b.String(), nil
+}
+
+func (p *ProblemsPanel) applyFilter() {
+	p.filtered = filterByQuery(p.problems, p.query, p.sort, problemFields, &p.cursor)
+}
+
+// problemFields lets status: queries match the problem's reason.
+func problemFields(problem k8s.Problem) queryFields {
+	return queryFields{
+		text:      problemText(problem),
+		name:      problem.Name,
+		namespace: problem.Namespace,
+		status:    problem.Reason,
+		restarts:  -1,
+		ready:     -1,
+		cpu:       -1,
+		memory:    -1,
+	}
+}
+
+// problemText is what bare filter words and search match against.
+func problemText(problem k8s.Problem) string {
+	return problem.Kind + "/" + problem.Name + " " + problem.Reason
+}
+
+// problemRef is the resource a problem is about, so marked problems act on
+// the resources themselves.
+func problemRef(problem k8s.Problem) ResourceRef {
+	return ResourceRef{Kind: problem.Kind, Name: problem.Name, Namespace: problem.Namespace}
+}
+
+func (p *ProblemsPanel) SetFilter(query string) {
+	p.BasePanel.SetFilter(query)
+	p.applyFilter()
+}
+
+func (p *ProblemsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *ProblemsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
+func (p *ProblemsPanel) SearchItems(query string) []SearchResult {
+	return searchByName(
+		p.problems,
+		query,
+		p.title,
+		problemText,
+		func(pr k8s.Problem) string { return pr.Namespace },
+		func(pr k8s.Problem) string { return pr.Reason },
+	)
+}
+
+func (p *ProblemsPanel) NavigateTo(name, namespace string) bool {
+	return navigateTo(
+		p.filtered,
+		&p.cursor,
+		problemText,
+		func(pr k8s.Problem) string { return pr.Namespace },
+		name,
+		namespace,
+	)
+}
+
+func (p *ProblemsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, problemRef)
+}
+
+type problemsLoadedMsg struct {
+	problems []k8s.Problem
+}
@@ -0,0 +1,63 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+func TestProblemsPanel_ListsAndJumps(t *testing.T) {
+	crashing := testPod()
+	crashing.Name = "crashing"
+	crashing.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s"},
+	}
+
+	panel := NewProblemsPanel(k8s.NewTestClient(fake.NewSimpleClientset(&crashing)), createTestStyles())
+	panel.SetSize(100, 20)
+	panel.Update(panel.Refresh()())
+
+	view := panel.View()
+	for _, want := range []string{"Critical", "Pod/crashing", "CrashLoopBackOff"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	if !strings.Contains(panel.DetailView(80, 30), "back-off 5m0s") {
+		t.Error("detail view should show the container's waiting message")
+	}
+
+	msg, ok := pressEnter(panel)().(JumpToResourceMsg)
+	if !ok || msg.Kind != "Pod" || msg.Name != "crashing" || msg.Namespace != "default" {
+		t.Errorf("expected jump to Pod default/crashing, got %+v", msg)
+	}
+}
+
+func TestProblemsPanel_NoProblems(t *testing.T) {
+	pod := testPod()
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+
+	panel := NewProblemsPanel(k8s.NewTestClient(fake.NewSimpleClientset(&pod)), createTestStyles())
+	panel.SetSize(100, 20)
+	panel.Update(panel.Refresh()())
+
+	if !strings.Contains(panel.View(), "No problems found") {
+		t.Errorf("expected an all-clear message:\n%s", panel.View())
+	}
+
+	if cmd := pressEnter(panel); cmd != nil {
+		t.Error("enter without a selection should do nothing")
+	}
+}
+
+func pressEnter(p Panel) tea.Cmd {
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	return cmd
+}
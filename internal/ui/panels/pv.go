@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -21,6 +22,7 @@ type PVPanel struct {
 	styles   *theme.Styles
 	pvs      []corev1.PersistentVolume
 	filtered []corev1.PersistentVolume
+	table    *columnTable[corev1.PersistentVolume]
 }
 
 func NewPVPanel(client *k8s.Client, styles *theme.Styles) *PVPanel {
@@ -28,6 +30,7 @@ func NewPVPanel(client *k8s.Client, styles *theme.Styles) *PVPanel {
 		BasePanel: BasePanel{
 			title:       "PersistentVolumes",
 			shortcutKey: "v",
+			sortable:    clusterSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -79,7 +82,8 @@ func (p *PVPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		pv := p.filtered[i]
@@ -97,20 +101,19 @@ func (p *PVPanel) View() string {
 }
 
 func (p *PVPanel) renderPVLine(pv corev1.PersistentVolume, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, pv, objectRef("PersistentVolume", &pv), selected)
+	}
+
 	status := string(pv.Status.Phase)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("PersistentVolume", &pv))
 
 	if p.width > 80 {
 		nameW := max(p.width-40, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(pv.Name, nameW), nameW,
+			highlightName(p.styles, p.query, pv.Name, nameW), nameW,
 		)
 
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -124,7 +127,7 @@ func (p *PVPanel) renderPVLine(pv corev1.PersistentVolume, selected bool) string
 		age := utils.FormatAgeFromMeta(pv.CreationTimestamp)
 		line += " " + utils.PadRight(age, 8)
 	} else {
-		name := utils.Truncate(pv.Name, p.width-15)
+		name := highlightName(p.styles, p.query, pv.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-12)
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -140,6 +143,44 @@ func (p *PVPanel) renderPVLine(pv corev1.PersistentVolume, selected bool) string
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *PVPanel) builtinColumns() map[string]tableColumn[corev1.PersistentVolume] {
+	columns := withColumns(objectColumns[corev1.PersistentVolume](), map[string]tableColumn[corev1.PersistentVolume]{
+		"status": {
+			header: "STATUS", width: 10, sort: sortStatus,
+			value: func(pv corev1.PersistentVolume) string { return string(pv.Status.Phase) },
+			style: func(pv corev1.PersistentVolume) lipgloss.Style {
+				return p.styles.GetStatusStyle(string(pv.Status.Phase))
+			},
+		},
+		"capacity": {
+			header: "CAPACITY", width: 8,
+			value: func(pv corev1.PersistentVolume) string {
+				capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+
+				return capacity.String()
+			},
+		},
+		"claim": {
+			header: "CLAIM", width: 25,
+			value: func(pv corev1.PersistentVolume) string {
+				if pv.Spec.ClaimRef == nil {
+					return ""
+				}
+
+				return pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+			},
+		},
+		"storageclass": {
+			header: "STORAGECLASS", width: 15,
+			value: func(pv corev1.PersistentVolume) string { return pv.Spec.StorageClassName },
+		},
+	})
+
+	delete(columns, "namespace")
+
+	return columns
+}
+
 func (p *PVPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No PersistentVolume selected"
@@ -226,13 +267,15 @@ func (p *PVPanel) DetailView(width, height int) string {
 }
 
 func (p *PVPanel) Refresh() tea.Cmd {
+	opts := metav1.ListOptions{LabelSelector: p.LabelSelector()}
+
 	return func() tea.Msg {
 		ctx := context.Background()
 
 		pvs, err := p.client.Clientset().
 			CoreV1().
 			PersistentVolumes().
-			List(ctx, metav1.ListOptions{})
+			List(ctx, opts)
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
@@ -327,10 +370,16 @@ func (p *PVPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *PVPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.pvs,
-		p.filter,
-		func(pv corev1.PersistentVolume) string { return pv.Name },
+		p.query,
+		p.sort,
+		func(pv corev1.PersistentVolume) queryFields {
+			f := objectFields(&pv)
+			f.status = string(pv.Status.Phase)
+
+			return f
+		},
 		&p.cursor,
 	)
 }
@@ -340,6 +389,27 @@ func (p *PVPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *PVPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *PVPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *PVPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.pvs,
@@ -362,6 +432,12 @@ func (p *PVPanel) NavigateTo(name, _ string) bool {
 	)
 }
 
+func (p *PVPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(pv corev1.PersistentVolume) ResourceRef {
+		return objectRef("PersistentVolume", &pv)
+	})
+}
+
 type pvLoadedMsg struct {
 	pvs []corev1.PersistentVolume
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -21,6 +22,7 @@ type PVCPanel struct {
 	styles   *theme.Styles
 	pvcs     []corev1.PersistentVolumeClaim
 	filtered []corev1.PersistentVolumeClaim
+	table    *columnTable[corev1.PersistentVolumeClaim]
 }
 
 func NewPVCPanel(client *k8s.Client, styles *theme.Styles) *PVCPanel {
@@ -28,6 +30,7 @@ func NewPVCPanel(client *k8s.Client, styles *theme.Styles) *PVCPanel {
 		BasePanel: BasePanel{
 			title:       "PersistentVolumeClaims",
 			shortcutKey: "V",
+			sortable:    statusSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -79,7 +82,8 @@ func (p *PVCPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		pvc := p.filtered[i]
@@ -97,14 +101,13 @@ func (p *PVCPanel) View() string {
 }
 
 func (p *PVCPanel) renderPVCLine(pvc corev1.PersistentVolumeClaim, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, pvc, objectRef("PersistentVolumeClaim", &pvc), selected)
+	}
+
 	status := string(pvc.Status.Phase)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("PersistentVolumeClaim", &pvc))
 
 	if p.width > 80 {
 		reserved := 35
@@ -115,7 +118,7 @@ func (p *PVCPanel) renderPVCLine(pvc corev1.PersistentVolumeClaim, selected bool
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(pvc.Name, nameW), nameW,
+			highlightName(p.styles, p.query, pvc.Name, nameW), nameW,
 		)
 
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -137,7 +140,7 @@ func (p *PVCPanel) renderPVCLine(pvc corev1.PersistentVolumeClaim, selected bool
 			line += " " + utils.Truncate(pvc.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(pvc.Name, p.width-15)
+		name := highlightName(p.styles, p.query, pvc.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-12)
 		statusStyle := p.styles.GetStatusStyle(status)
@@ -153,6 +156,44 @@ func (p *PVCPanel) renderPVCLine(pvc corev1.PersistentVolumeClaim, selected bool
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *PVCPanel) builtinColumns() map[string]tableColumn[corev1.PersistentVolumeClaim] {
+	type pvc = corev1.PersistentVolumeClaim
+
+	return withColumns(objectColumns[pvc](), map[string]tableColumn[pvc]{
+		"status": {
+			header: "STATUS", width: 10, sort: sortStatus,
+			value: func(c pvc) string { return string(c.Status.Phase) },
+			style: func(c pvc) lipgloss.Style { return p.styles.GetStatusStyle(string(c.Status.Phase)) },
+		},
+		"capacity": {
+			header: "CAPACITY", width: 8,
+			value: func(c pvc) string {
+				if len(c.Status.Capacity) == 0 {
+					return "-"
+				}
+
+				capacity := c.Status.Capacity[corev1.ResourceStorage]
+
+				return capacity.String()
+			},
+		},
+		"volume": {
+			header: "VOLUME", width: 25,
+			value: func(c pvc) string { return c.Spec.VolumeName },
+		},
+		"storageclass": {
+			header: "STORAGECLASS", width: 15,
+			value: func(c pvc) string {
+				if c.Spec.StorageClassName == nil {
+					return ""
+				}
+
+				return *c.Spec.StorageClassName
+			},
+		},
+	})
+}
+
 func (p *PVCPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No PVC selected"
@@ -240,6 +281,8 @@ func (p *PVCPanel) DetailView(width, height int) string {
 }
 
 func (p *PVCPanel) Refresh() tea.Cmd {
+	opts := metav1.ListOptions{LabelSelector: p.LabelSelector()}
+
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -252,12 +295,12 @@ func (p *PVCPanel) Refresh() tea.Cmd {
 			pvcs, err = p.client.Clientset().
 				CoreV1().
 				PersistentVolumeClaims("").
-				List(ctx, metav1.ListOptions{})
+				List(ctx, opts)
 		} else {
 			pvcs, err = p.client.Clientset().
 				CoreV1().
 				PersistentVolumeClaims(p.client.CurrentNamespace()).
-				List(ctx, metav1.ListOptions{})
+				List(ctx, opts)
 		}
 
 		if err != nil {
@@ -353,10 +396,16 @@ func (p *PVCPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *PVCPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.pvcs,
-		p.filter,
-		func(pvc corev1.PersistentVolumeClaim) string { return pvc.Name },
+		p.query,
+		p.sort,
+		func(pvc corev1.PersistentVolumeClaim) queryFields {
+			f := objectFields(&pvc)
+			f.status = string(pvc.Status.Phase)
+
+			return f
+		},
 		&p.cursor,
 	)
 }
@@ -366,6 +415,27 @@ func (p *PVCPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *PVCPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *PVCPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *PVCPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.pvcs,
@@ -388,6 +458,12 @@ func (p *PVCPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *PVCPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(pvc corev1.PersistentVolumeClaim) ResourceRef {
+		return objectRef("PersistentVolumeClaim", &pvc)
+	})
+}
+
 type pvcLoadedMsg struct {
 	pvcs []corev1.PersistentVolumeClaim
 }
@@ -0,0 +1,680 @@
+package panels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+var (
+	ErrQuerySyntax  = errors.New("invalid filter")
+	ErrUnknownField = errors.New("unknown filter field")
+)
+
+// queryFields is what a filter query can see of a listed item. Fields a
+// panel doesn't fill never match.
+type queryFields struct {
+	// text is what bare words match; the name unless a panel says otherwise.
+	text      string
+	name      string
+	namespace string
+	status    string
+	node      string
+	labels    map[string]string
+	images    []string
+	restarts  int64
+	created   time.Time
+	// ready is the ready fraction of pods or containers; usage is in
+	// millicores and bytes. Like restarts, they are -1 when unknown.
+	ready  float64
+	cpu    int64
+	memory int64
+}
+
+// objectFields fills the fields every Kubernetes object has.
+func objectFields(obj metav1.Object) queryFields {
+	return queryFields{
+		text:      obj.GetName(),
+		name:      obj.GetName(),
+		namespace: obj.GetNamespace(),
+		labels:    obj.GetLabels(),
+		created:   obj.GetCreationTimestamp().Time,
+		restarts:  -1,
+		ready:     -1,
+		cpu:       -1,
+		memory:    -1,
+	}
+}
+
+// readyRatio is the ready fraction of a workload; one scaled to zero has
+// nothing missing and counts as fully ready.
+func readyRatio(ready, desired int32) float64 {
+	if desired <= 0 {
+		return 1
+	}
+
+	return float64(ready) / float64(desired)
+}
+
+func podSpecImages(spec *corev1.PodSpec) []string {
+	images := make([]string, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, c := range spec.InitContainers {
+		images = append(images, c.Image)
+	}
+
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+
+	return images
+}
+
+// filterQuery is a parsed panel filter, e.g.
+// `status:CrashLoopBackOff OR (label:app=web AND NOT ns:kube-*) restarts>3`.
+// Terms next to each other are ANDed.
+type filterQuery struct {
+	root queryNode
+	// labelSelector holds the label terms every match must satisfy, so the
+	// API server can do that part of the filtering.
+	labelSelector string
+	// ranked is set when fuzzy words decide the order of matches.
+	ranked bool
+}
+
+type queryNode interface {
+	match(f *queryFields, now time.Time) bool
+}
+
+type andNode []queryNode
+
+func (n andNode) match(f *queryFields, now time.Time) bool {
+	for _, child := range n {
+		if !child.match(f, now) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type orNode []queryNode
+
+func (n orNode) match(f *queryFields, now time.Time) bool {
+	for _, child := range n {
+		if child.match(f, now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type notNode struct {
+	child queryNode
+}
+
+func (n notNode) match(f *queryFields, now time.Time) bool {
+	return !n.child.match(f, now)
+}
+
+type queryTerm struct {
+	field string
+	op    string
+	value string
+	// pattern matches value, with * and ? as wildcards.
+	pattern *regexp.Regexp
+	// number is the restart count or age in seconds of comparisons.
+	number int64
+	// labelKey and labelOp split label:key=value terms.
+	labelKey string
+	labelOp  string
+	// fuzzy bare words match their runes in order, fzf style.
+	fuzzy bool
+}
+
+func (t *queryTerm) match(f *queryFields, now time.Time) bool {
+	switch t.field {
+	case "":
+		if t.fuzzy {
+			_, _, ok := utils.FuzzyMatch(t.value, f.text)
+
+			return ok
+		}
+
+		return t.pattern.MatchString(f.text)
+	case "name":
+		return t.pattern.MatchString(f.name)
+	case "ns", "namespace":
+		return t.pattern.MatchString(f.namespace)
+	case "status":
+		return f.status != "" && t.pattern.MatchString(f.status)
+	case "node":
+		return f.node != "" && t.pattern.MatchString(f.node)
+	case "image":
+		for _, image := range f.images {
+			if t.pattern.MatchString(image) {
+				return true
+			}
+		}
+
+		return false
+	case "label":
+		value, ok := f.labels[t.labelKey]
+
+		switch t.labelOp {
+		case "=":
+			return ok && t.pattern.MatchString(value)
+		case "!=":
+			return !ok || !t.pattern.MatchString(value)
+		default:
+			return ok
+		}
+	case "restarts":
+		return f.restarts >= 0 && compare(f.restarts, t.op, t.number)
+	case "age":
+		return !f.created.IsZero() && compare(int64(now.Sub(f.created).Seconds()), t.op, t.number)
+	}
+
+	return false
+}
+
+func compare(v int64, op string, target int64) bool {
+	switch op {
+	case ">":
+		return v > target
+	case ">=":
+		return v >= target
+	case "<":
+		return v < target
+	case "<=":
+		return v <= target
+	default:
+		return v == target
+	}
+}
+
+// matches reports whether f passes the query; a nil query passes all.
+func (q *filterQuery) matches(f *queryFields) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+
+	return q.root.match(f, time.Now())
+}
+
+// parseQuery parses a panel filter. Bare words fuzzy-match the name, or
+// match it literally when quoted; field values match exactly unless they
+// use * or ? wildcards. An empty filter yields a nil query.
+func parseQuery(input string) (*filterQuery, error) {
+	tokens, err := tokenizeQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil //nolint:nilnil // an empty filter matches everything
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrQuerySyntax, p.tokens[p.pos].text)
+	}
+
+	return &filterQuery{
+		root:          root,
+		labelSelector: labelSelector(root),
+		ranked:        len(fuzzyTerms(root)) > 0,
+	}, nil
+}
+
+// score ranks a matching item: the better its fuzzy words match, the higher.
+func (q *filterQuery) score(f *queryFields) int {
+	score := 0
+
+	for _, t := range fuzzyTerms(q.root) {
+		if s, _, ok := utils.FuzzyMatch(t.value, f.text); ok {
+			score += s
+		}
+	}
+
+	return score
+}
+
+// highlights returns the rune indexes of name that the query's bare words
+// and name: terms matched, for showing them in the row.
+func (q *filterQuery) highlights(name string) []int {
+	if q == nil {
+		return nil
+	}
+
+	var positions []int
+
+	for _, t := range positiveTerms(q.root) {
+		switch {
+		case t.fuzzy:
+			if _, matched, ok := utils.FuzzyMatch(t.value, name); ok {
+				positions = append(positions, matched...)
+			}
+		case t.field == "" || t.field == "name":
+			if loc := t.pattern.FindStringIndex(name); loc != nil {
+				start := utf8.RuneCountInString(name[:loc[0]])
+				for i := range utf8.RuneCountInString(name[loc[0]:loc[1]]) {
+					positions = append(positions, start+i)
+				}
+			}
+		}
+	}
+
+	return positions
+}
+
+// positiveTerms lists the terms a match satisfies, i.e. those not under NOT.
+func positiveTerms(node queryNode) []*queryTerm {
+	var children []queryNode
+
+	switch n := node.(type) {
+	case andNode:
+		children = n
+	case orNode:
+		children = n
+	case *queryTerm:
+		return []*queryTerm{n}
+	}
+
+	var terms []*queryTerm
+	for _, child := range children {
+		terms = append(terms, positiveTerms(child)...)
+	}
+
+	return terms
+}
+
+func fuzzyTerms(node queryNode) []*queryTerm {
+	var terms []*queryTerm
+
+	for _, t := range positiveTerms(node) {
+		if t.fuzzy {
+			terms = append(terms, t)
+		}
+	}
+
+	return terms
+}
+
+type queryToken struct {
+	text string
+	// quoted tokens are never keywords, parentheses or field terms.
+	quoted bool
+}
+
+func tokenizeQuery(input string) ([]queryToken, error) {
+	var (
+		tokens []queryToken
+		cur    strings.Builder
+		quoted bool
+		inWord bool
+	)
+
+	flush := func() {
+		if inWord {
+			tokens = append(tokens, queryToken{text: cur.String(), quoted: quoted})
+		}
+
+		cur.Reset()
+
+		quoted, inWord = false, false
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated quote", ErrQuerySyntax)
+			}
+
+			// Only a wholly quoted token is literal; status:"a b" is a term
+			quoted = quoted || !inWord
+			inWord = true
+
+			cur.WriteString(string(runes[i+1 : end]))
+
+			i = end
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, queryToken{text: string(r)})
+		default:
+			cur.WriteRune(r)
+
+			inWord = true
+		}
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// keyword reports whether the next token is the unquoted keyword kw.
+func (p *queryParser) keyword(kw string) bool {
+	tok, ok := p.peek()
+
+	return ok && !tok.quoted && tok.text == kw
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := orNode{left}
+
+	for p.keyword("OR") {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, right)
+	}
+
+	if len(nodes) == 1 {
+		return left, nil
+	}
+
+	return nodes, nil
+}
+
+// atAndEnd reports whether the terms of an AND have run out.
+func (p *queryParser) atAndEnd() bool {
+	tok, ok := p.peek()
+
+	return !ok || (!tok.quoted && (tok.text == ")" || tok.text == "OR"))
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes andNode
+
+	for {
+		if p.keyword("AND") {
+			if len(nodes) == 0 {
+				return nil, fmt.Errorf("%w: AND needs a term on each side", ErrQuerySyntax)
+			}
+
+			p.pos++
+
+			if p.atAndEnd() {
+				return nil, fmt.Errorf("%w: AND needs a term on each side", ErrQuerySyntax)
+			}
+		}
+
+		if p.atAndEnd() {
+			break
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil, fmt.Errorf("%w: expected a term", ErrQuerySyntax)
+	case 1:
+		return nodes[0], nil
+	}
+
+	return nodes, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.keyword("NOT") {
+		p.pos++
+
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{child: child}, nil
+	}
+
+	if p.keyword("(") {
+		p.pos++
+
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.keyword(")") {
+			return nil, fmt.Errorf("%w: missing )", ErrQuerySyntax)
+		}
+
+		p.pos++
+
+		return node, nil
+	}
+
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("%w: expected a term", ErrQuerySyntax)
+	}
+
+	p.pos++
+
+	return parseTerm(tok)
+}
+
+var termPattern = regexp.MustCompile(`^([a-zA-Z]+)(>=|<=|:|>|<)(.*)$`)
+
+func parseTerm(tok queryToken) (*queryTerm, error) {
+	m := termPattern.FindStringSubmatch(tok.text)
+	if m == nil || tok.quoted {
+		// Quoted words and wildcards match literally; plain words are fuzzy
+		return &queryTerm{
+			value:   tok.text,
+			pattern: globPattern(tok.text, true),
+			fuzzy:   !tok.quoted && !strings.ContainsAny(tok.text, "*?"),
+		}, nil
+	}
+
+	t := &queryTerm{field: strings.ToLower(m[1]), op: m[2], value: m[3]}
+	if t.value == "" {
+		return nil, fmt.Errorf("%w: %s needs a value", ErrQuerySyntax, t.field)
+	}
+
+	switch t.field {
+	case "name":
+		if t.op != ":" {
+			return nil, fmt.Errorf("%w: use name:value", ErrQuerySyntax)
+		}
+
+		t.pattern = globPattern(t.value, true)
+	case "ns", "namespace", "status", "node", "image":
+		if t.op != ":" {
+			return nil, fmt.Errorf("%w: use %s:value", ErrQuerySyntax, t.field)
+		}
+
+		t.pattern = globPattern(t.value, false)
+	case "label":
+		return parseLabelTerm(t)
+	case "restarts":
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: restarts needs a count, got %q", ErrQuerySyntax, t.value)
+		}
+
+		t.number = n
+	case "age":
+		if t.op == ":" {
+			return nil, fmt.Errorf("%w: use age<duration or age>duration", ErrQuerySyntax)
+		}
+
+		d, err := parseAge(t.value)
+		if err != nil {
+			return nil, err
+		}
+
+		t.number = int64(d.Seconds())
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownField, m[1])
+	}
+
+	return t, nil
+}
+
+func parseLabelTerm(t *queryTerm) (*queryTerm, error) {
+	if t.op != ":" {
+		return nil, fmt.Errorf("%w: use label:key=value", ErrQuerySyntax)
+	}
+
+	key, value, found := strings.Cut(t.value, "=")
+
+	t.labelKey = key
+
+	switch {
+	case !found:
+		t.labelOp = "exists"
+	case strings.HasSuffix(key, "!"):
+		t.labelKey = strings.TrimSuffix(key, "!")
+		t.labelOp = "!="
+	default:
+		t.labelOp = "="
+	}
+
+	if errs := validation.IsQualifiedName(t.labelKey); len(errs) > 0 {
+		return nil, fmt.Errorf("%w: label key %q: %s", ErrQuerySyntax, t.labelKey, strings.Join(errs, "; "))
+	}
+
+	t.pattern = globPattern(value, false)
+
+	return t, nil
+}
+
+// parseAge accepts Go durations plus d for days, e.g. 90s, 1h30m or 7d.
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: age needs a duration like 30m, 2h or 7d, got %q", ErrQuerySyntax, s)
+	}
+
+	return d, nil
+}
+
+// globPattern compiles a case-insensitive match for value where * and ?
+// are wildcards. Without wildcards it matches the whole string, or any part
+// of it when partial is set.
+func globPattern(value string, partial bool) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("(?i)")
+
+	hasWildcard := strings.ContainsAny(value, "*?")
+	if !partial || hasWildcard {
+		b.WriteString("^")
+	}
+
+	for _, r := range value {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if !partial || hasWildcard {
+		b.WriteString("$")
+	}
+
+	return regexp.MustCompile(b.String())
+}
+
+// labelSelector collects the label terms the whole query requires, i.e.
+// those ANDed at the top level, as long as they have no wildcards.
+func labelSelector(root queryNode) string {
+	terms := []queryNode{root}
+	if and, ok := root.(andNode); ok {
+		terms = and
+	}
+
+	var requirements []string
+
+	for _, node := range terms {
+		t, ok := node.(*queryTerm)
+		if !ok || t.field != "label" {
+			continue
+		}
+
+		value := strings.SplitN(t.value, "=", 2)
+
+		switch t.labelOp {
+		case "exists":
+			requirements = append(requirements, t.labelKey)
+		case "=", "!=":
+			if len(value) < 2 || strings.ContainsAny(value[1], "*?") ||
+				len(validation.IsValidLabelValue(value[1])) > 0 {
+				continue
+			}
+
+			requirements = append(requirements, t.labelKey+t.labelOp+value[1])
+		}
+	}
+
+	return strings.Join(requirements, ",")
+}
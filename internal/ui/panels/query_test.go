@@ -0,0 +1,191 @@
+package panels
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func queryTestPod() corev1.Pod {
+	pod := testPod()
+	pod.Name = "web-7d9f"
+	pod.Namespace = "payments"
+	pod.Labels = map[string]string{"app": "web", "tier": "frontend"}
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	pod.Spec.NodeName = "ip-10-0-1-5"
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "cache", Image: "redis:7"})
+	pod.Status.ContainerStatuses[0].RestartCount = 5
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+	}
+
+	return pod
+}
+
+func TestQueryMatchesPod(t *testing.T) {
+	fields := podFields(queryTestPod())
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"web", true},
+		{"WEB-7", true},
+		{"api", false},
+		{"status:CrashLoopBackOff", true},
+		{"status:crashloop*", true},
+		{"status:Running", false},
+		{"ns:payments", true},
+		{"ns:pay", false},
+		{"label:app=web", true},
+		{"label:app=api", false},
+		{"label:app!=api", true},
+		{"label:tier", true},
+		{"label:team", false},
+		{"node:ip-10-*", true},
+		{"node:ip-11-*", false},
+		{"image:*redis*", true},
+		{"image:redis", false},
+		{"restarts>3", true},
+		{"restarts<=3", false},
+		{"age<1h", false},
+		{"age>1h", true},
+		{"age<1d", true},
+		{"ns:payments status:Running", false},
+		{"status:Running OR restarts>3", true},
+		{"NOT label:app=web", false},
+		{"ns:payments AND NOT (status:Running OR image:nginx*)", false},
+		{"ns:payments AND NOT (status:Running OR image:busybox*)", true},
+		{`"web-7d9f"`, true},
+		{`"ns:payments"`, false},
+		{`status:"CrashLoopBackOff"`, true},
+	}
+
+	for _, tt := range tests {
+		q, err := parseQuery(tt.query)
+		if err != nil {
+			t.Errorf("parseQuery(%q) returned unexpected error: %v", tt.query, err)
+
+			continue
+		}
+
+		if got := q.matches(&fields); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{"colour:red", ErrUnknownField},
+		{"restarts>many", ErrQuerySyntax},
+		{"age>soon", ErrQuerySyntax},
+		{"(status:Running", ErrQuerySyntax},
+		{"status:Running)", ErrQuerySyntax},
+		{"status:Running OR", ErrQuerySyntax},
+		{"AND web", ErrQuerySyntax},
+		{"web AND", ErrQuerySyntax},
+		{"NOT", ErrQuerySyntax},
+		{`"web`, ErrQuerySyntax},
+		{"status:", ErrQuerySyntax},
+		{"label:a/b/c=x", ErrQuerySyntax},
+	}
+
+	for _, tt := range tests {
+		if _, err := parseQuery(tt.query); !errors.Is(err, tt.want) {
+			t.Errorf("parseQuery(%q) error = %v, want %v", tt.query, err, tt.want)
+		}
+	}
+
+	if q, err := parseQuery("   "); q != nil || err != nil {
+		t.Errorf("a blank filter should be no query, got %v, %v", q, err)
+	}
+}
+
+func TestQueryLabelSelector(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"label:app=web", "app=web"},
+		{"label:app=web label:tier!=db label:team", "app=web,tier!=db,team"},
+		{"status:Running label:app=web", "app=web"},
+		// Only labels every match needs can go to the server
+		{"label:app=web OR label:app=api", ""},
+		{"NOT label:app=web", ""},
+		{"label:app=web*", ""},
+		{"status:Running", ""},
+	}
+
+	for _, tt := range tests {
+		q, err := parseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("parseQuery(%q) returned unexpected error: %v", tt.query, err)
+		}
+
+		if q.labelSelector != tt.want {
+			t.Errorf("%q selector = %q, want %q", tt.query, q.labelSelector, tt.want)
+		}
+	}
+}
+
+func TestSetFilterKeepsLastValidQuery(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+	panel.Update(podsLoadedMsg{pods: []corev1.Pod{queryTestPod(), testPod()}})
+
+	panel.SetFilter("restarts>3 label:app=web")
+
+	if len(panel.filtered) != 1 || panel.LabelSelector() != "app=web" {
+		t.Fatalf("expected one pod and selector app=web, got %d and %q", len(panel.filtered), panel.LabelSelector())
+	}
+
+	// Half-typed queries report the error and leave the list alone
+	panel.SetFilter("restarts>3 label:app=web OR (")
+
+	if panel.FilterError() == nil {
+		t.Error("expected a filter error")
+	}
+
+	if len(panel.filtered) != 1 || panel.LabelSelector() != "app=web" {
+		t.Errorf("an invalid filter should keep the previous one, got %d pods", len(panel.filtered))
+	}
+
+	panel.SetFilter("")
+
+	if panel.FilterError() != nil || len(panel.filtered) != 2 || panel.LabelSelector() != "" {
+		t.Errorf("clearing the filter should show every pod, got %d", len(panel.filtered))
+	}
+}
+
+func TestFuzzyFilterRanksAndHighlights(t *testing.T) {
+	panel := newMarkedDeploymentsPanel("nginx-web-gateway", "api", "web", "wide-ebb")
+
+	panel.SetFilter("web")
+
+	names := make([]string, len(panel.filtered))
+	for i, d := range panel.filtered {
+		names[i] = d.Name
+	}
+
+	if want := []string{"web", "nginx-web-gateway", "wide-ebb"}; !slices.Equal(names, want) {
+		t.Errorf("filtered = %v, want %v", names, want)
+	}
+
+	if got := panel.query.highlights("nginx-web-gateway"); !slices.Equal(got, []int{6, 7, 8}) {
+		t.Errorf("highlights = %v, want the web runes", got)
+	}
+
+	// Quoted words match literally and keep the list order
+	panel.SetFilter(`"web"`)
+
+	if len(panel.filtered) != 2 || panel.filtered[0].Name != "nginx-web-gateway" {
+		t.Errorf("expected the two literal matches in list order, got %d", len(panel.filtered))
+	}
+}
@@ -787,3 +787,49 @@ func TestAllPanels_WidthBoundary(t *testing.T) {
 		})
 	}
 }
+
+func TestBasePanel_RowAtFollowsRenderedRows(t *testing.T) {
+	client := createTestK8sClient()
+	styles := createTestStyles()
+	panel := NewPodsPanel(client, styles)
+
+	for i := range 10 {
+		pod := corev1.Pod{}
+		pod.Name = "pod-" + string(rune('a'+i))
+		panel.pods = append(panel.pods, pod)
+	}
+
+	// Wide enough for the column header, short enough to scroll
+	panel.filtered = panel.pods
+	panel.SetSize(150, 8)
+	panel.SetCursor(6)
+
+	lines := strings.Split(panel.View(), "\n")
+
+	found := 0
+
+	for y, line := range lines {
+		for i, pod := range panel.pods {
+			if !strings.Contains(line, pod.Name+" ") {
+				continue
+			}
+
+			found++
+
+			// The top border comes before content line 0
+			if row, ok := panel.RowAt(y - 1); !ok || row != i {
+				t.Errorf("RowAt(%d) = %d, %v; want %d, true for %s", y-1, row, ok, i, pod.Name)
+			}
+		}
+	}
+
+	if found != 4 {
+		t.Fatalf("found %d rows, want the 4 that fit", found)
+	}
+
+	for line := range 3 {
+		if _, ok := panel.RowAt(line); ok {
+			t.Errorf("line %d is the title or header, not a row", line)
+		}
+	}
+}
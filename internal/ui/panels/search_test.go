@@ -1,6 +1,7 @@
 package panels
 
 import (
+	"slices"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -1024,3 +1025,51 @@ func TestEventsNavigateTo(t *testing.T) {
 		t.Errorf("expected cursor 1, got %d", panel.Cursor())
 	}
 }
+
+func TestSearchItemsFuzzyAndMetadata(t *testing.T) {
+	panel := NewDeploymentsPanel(createTestK8sClient(), createTestStyles())
+	panel.deployments = []appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "payments-worker", Namespace: "default",
+			Labels: map[string]string{"app": "web"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "nginx-web-gateway", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "api", Namespace: "default",
+			Annotations: map[string]string{"owner": "team-payments"},
+		}},
+	}
+
+	results := panel.SearchItems("web")
+
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+
+	// The closest name first, then looser names, then label matches
+	want := []string{"web", "nginx-web-gateway", "payments-worker"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("results = %v, want %v", names, want)
+	}
+
+	if !slices.Equal(results[1].Matches, []int{6, 7, 8}) {
+		t.Errorf("expected the matched runes of the name, got %v", results[1].Matches)
+	}
+
+	if results[2].MatchedOn != "label app=web" {
+		t.Errorf("expected the label to explain the match, got %q", results[2].MatchedOn)
+	}
+
+	// Runes in order, not necessarily adjacent
+	results = panel.SearchItems("pmtwrk")
+	if len(results) != 1 || results[0].Name != "payments-worker" {
+		t.Errorf("expected a fuzzy name match, got %+v", results)
+	}
+
+	results = panel.SearchItems("team-pay")
+	if len(results) != 1 || results[0].MatchedOn != "annotation owner=team-payments" {
+		t.Errorf("expected an annotation match, got %+v", results)
+	}
+}
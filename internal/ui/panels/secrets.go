@@ -3,10 +3,14 @@ package panels
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -14,12 +18,42 @@ import (
 	"github.com/Starlexxx/lazy-k8s/internal/utils"
 )
 
+const (
+	// defaultSecretRevealTimeout is how long a revealed value stays visible
+	// before it is masked again.
+	defaultSecretRevealTimeout = 30 * time.Second
+
+	// defaultCertExpiryThreshold flags TLS certificates expiring within it.
+	defaultCertExpiryThreshold = 30 * 24 * time.Hour
+)
+
 type SecretsPanel struct {
 	BasePanel
 	client   *k8s.Client
 	styles   *theme.Styles
 	secrets  []corev1.Secret
 	filtered []corev1.Secret
+	table    *columnTable[corev1.Secret]
+
+	// keyCursor selects a data key of the selected secret for the
+	// per-key reveal/copy/edit actions.
+	keyCursor int
+
+	// revealed maps "namespace/name/key" to the generation that revealed
+	// it, so a stale auto-hide tick can't mask a freshly re-revealed value.
+	revealed      map[string]int
+	revealGen     int
+	revealTimeout time.Duration
+
+	// certs caches the parsed certificate of each TLS secret, keyed by
+	// "namespace/name", so rows can be flagged without reparsing per render.
+	certs         map[string]certResult
+	certThreshold time.Duration
+}
+
+type certResult struct {
+	info *k8s.CertificateInfo
+	err  error
 }
 
 func NewSecretsPanel(client *k8s.Client, styles *theme.Styles) *SecretsPanel {
@@ -27,9 +61,30 @@ func NewSecretsPanel(client *k8s.Client, styles *theme.Styles) *SecretsPanel {
 		BasePanel: BasePanel{
 			title:       "Secrets",
 			shortcutKey: "6",
+			sortable:    objectSortColumns,
 		},
-		client: client,
-		styles: styles,
+		client:        client,
+		styles:        styles,
+		revealed:      make(map[string]int),
+		revealTimeout: defaultSecretRevealTimeout,
+		certs:         make(map[string]certResult),
+		certThreshold: defaultCertExpiryThreshold,
+	}
+}
+
+// SetRevealTimeout overrides how long revealed values stay visible.
+// Non-positive durations keep the default.
+func (p *SecretsPanel) SetRevealTimeout(d time.Duration) {
+	if d > 0 {
+		p.revealTimeout = d
+	}
+}
+
+// SetCertExpiryThreshold overrides how close to expiry a TLS certificate
+// must be to get flagged. Non-positive durations keep the default.
+func (p *SecretsPanel) SetCertExpiryThreshold(d time.Duration) {
+	if d > 0 {
+		p.certThreshold = d
 	}
 }
 
@@ -43,20 +98,84 @@ func (p *SecretsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
 			p.MoveUp()
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
 			p.MoveDown(len(p.filtered))
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
 			p.MoveToTop()
+			p.keyCursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
 			p.MoveToBottom(len(p.filtered))
+			p.keyCursor = 0
+		case key.Matches(msg, key.NewBinding(key.WithKeys("]"))):
+			if secret := p.SelectedSecret(); secret != nil && p.keyCursor < len(secret.Data)-1 {
+				p.keyCursor++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("["))):
+			if p.keyCursor > 0 {
+				p.keyCursor--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+			return p, p.toggleReveal()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("Y"))):
+			secret, dataKey := p.selectedKey()
+			if secret == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return CopySecretValueRequestMsg{
+					SecretName: secret.Name,
+					Key:        dataKey,
+					Value:      secret.Data[dataKey],
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			secret, dataKey := p.selectedKey()
+			if secret == nil {
+				return p, nil
+			}
+
+			return p, func() tea.Msg {
+				return EditSecretKeyRequestMsg{
+					SecretName: secret.Name,
+					Namespace:  secret.Namespace,
+					Key:        dataKey,
+					Value:      secret.Data[dataKey],
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("N"))):
+			namespace := p.client.CurrentNamespace()
+			if secret := p.SelectedSecret(); secret != nil && p.allNs {
+				namespace = secret.Namespace
+			}
+
+			return p, func() tea.Msg {
+				return CreateSecretRequestMsg{Namespace: namespace}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			threshold := p.certThreshold
+
+			return p, func() tea.Msg {
+				return ExpiringCertificatesRequestMsg{Threshold: threshold}
+			}
 		}
 
 	case secretsLoadedMsg:
 		p.secrets = msg.secrets
+		p.parseCertificates()
 		p.applyFilter()
 
 		return p, nil
 
+	case secretHideMsg:
+		if p.revealed[msg.id] == msg.gen {
+			delete(p.revealed, msg.id)
+		}
+
+		return p, nil
+
 	case RefreshMsg:
 		if msg.PanelName == p.Title() {
 			return p, p.Refresh()
@@ -78,7 +197,8 @@ func (p *SecretsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		secret := p.filtered[i]
@@ -96,15 +216,21 @@ func (p *SecretsPanel) View() string {
 }
 
 func (p *SecretsPanel) renderSecretLine(secret corev1.Secret, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, secret, objectRef("Secret", &secret), selected)
+	}
+
 	secretType := utils.Truncate(string(secret.Type), 12)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
+	// An expired or expiring certificate replaces the type column so the
+	// warning is visible at every panel width.
+	badge, badgeStyle := p.certBadge(&secret)
+	if badge != "" {
+		secretType = badge
 	}
 
+	line := p.rowPrefix(selected, objectRef("Secret", &secret))
+
 	if p.width > 80 {
 		reserved := 30
 		if p.width > 120 && p.allNs {
@@ -114,7 +240,7 @@ func (p *SecretsPanel) renderSecretLine(secret corev1.Secret, selected bool) str
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(secret.Name, nameW), nameW,
+			highlightName(p.styles, p.query, secret.Name, nameW), nameW,
 		)
 		line += " " + utils.PadRight(secretType, 12)
 
@@ -125,7 +251,7 @@ func (p *SecretsPanel) renderSecretLine(secret corev1.Secret, selected bool) str
 			line += " " + utils.Truncate(secret.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(secret.Name, p.width-15)
+		name := highlightName(p.styles, p.query, secret.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-14)
 		line += " " + secretType
@@ -137,9 +263,66 @@ func (p *SecretsPanel) renderSecretLine(secret corev1.Secret, selected bool) str
 		return p.styles.ListItemSelected.Render(line)
 	}
 
+	if badge != "" {
+		return badgeStyle.Render(line)
+	}
+
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *SecretsPanel) builtinColumns() map[string]tableColumn[corev1.Secret] {
+	return withColumns(objectColumns[corev1.Secret](), map[string]tableColumn[corev1.Secret]{
+		"type": {
+			header: "TYPE", width: 12,
+			value: func(secret corev1.Secret) string {
+				if badge, _ := p.certBadge(&secret); badge != "" {
+					return badge
+				}
+
+				return string(secret.Type)
+			},
+			style: func(secret corev1.Secret) lipgloss.Style {
+				if badge, style := p.certBadge(&secret); badge != "" {
+					return style
+				}
+
+				return lipgloss.NewStyle()
+			},
+		},
+		"data": {
+			header: "DATA", width: 5,
+			value: func(secret corev1.Secret) string { return fmt.Sprintf("%d", len(secret.Data)) },
+		},
+	})
+}
+
+// certBadge returns a short expiry warning for TLS secrets whose certificate
+// is expired, not yet valid, expiring within the threshold or unparseable.
+func (p *SecretsPanel) certBadge(secret *corev1.Secret) (string, lipgloss.Style) {
+	cert, ok := p.certs[secretID(secret)]
+	if !ok {
+		return "", lipgloss.Style{}
+	}
+
+	if cert.err != nil {
+		return "BAD CERT", p.styles.StatusError
+	}
+
+	now := time.Now()
+
+	switch cert.info.Expiry(now, p.certThreshold) {
+	case k8s.CertExpired:
+		return "EXPIRED", p.styles.StatusError
+	case k8s.CertNotYetValid:
+		return "NOT VALID", p.styles.StatusError
+	case k8s.CertExpiringSoon:
+		return fmt.Sprintf("exp %dd", cert.info.DaysUntilExpiry(now)), p.styles.StatusWarning
+	case k8s.CertValid:
+	}
+
+	return "", lipgloss.Style{}
+}
+
 func (p *SecretsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No secret selected"
@@ -169,28 +352,40 @@ func (p *SecretsPanel) DetailView(width, height int) string {
 		b.WriteString("\n")
 	}
 
-	// Data keys (values hidden for security)
-	if len(secret.Data) > 0 {
+	if cert, ok := p.certs[secretID(&secret)]; ok {
+		b.WriteString(p.renderCertificate(cert, width))
+	}
+
+	keys := sortedKeys(secret.Data)
+	if len(keys) > 0 {
 		b.WriteString("\n")
-		b.WriteString(p.styles.DetailTitle.Render("Data Keys:"))
+		b.WriteString(p.styles.DetailTitle.Render("Data:"))
 		b.WriteString("\n")
 
-		for k, v := range secret.Data {
-			b.WriteString(fmt.Sprintf("  %s: %d bytes\n", k, len(v)))
+		keyCursor := min(p.keyCursor, len(keys)-1)
+		valueWidth := max(width-6, 10)
+
+		for i, k := range keys {
+			b.WriteString(p.renderSecretKey(secret, k, i == keyCursor, valueWidth))
 		}
 	}
 
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml [D]elete"))
+	b.WriteString(p.styles.Muted.Render("[d]escribe [y]aml [D]elete [N]ew [C]ert expiry report"))
 	b.WriteString("\n")
-	b.WriteString(p.styles.StatusWarning.Render("Note: Secret values are base64 encoded in YAML"))
+	b.WriteString(p.styles.Muted.Render(fmt.Sprintf(
+		"[v] reveal (hides after %s) [Y] copy value [E]dit value, [ ] to pick key",
+		p.revealTimeout,
+	)))
 
 	return b.String()
 }
 
 func (p *SecretsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			secrets []corev1.Secret
@@ -284,10 +479,11 @@ func (p *SecretsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *SecretsPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.secrets,
-		p.filter,
-		func(s corev1.Secret) string { return s.Name },
+		p.query,
+		p.sort,
+		func(s corev1.Secret) queryFields { return objectFields(&s) },
 		&p.cursor,
 	)
 }
@@ -297,6 +493,27 @@ func (p *SecretsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *SecretsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *SecretsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *SecretsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.secrets,
@@ -319,6 +536,195 @@ func (p *SecretsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *SecretsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(secret corev1.Secret) ResourceRef {
+		return objectRef("Secret", &secret)
+	})
+}
+
+// SelectedSecret returns the secret under the cursor, or nil.
+func (p *SecretsPanel) SelectedSecret() *corev1.Secret {
+	return selectedItem(p.filtered, p.cursor)
+}
+
+// selectedKey returns the selected secret and the data key under the key
+// cursor. The secret is nil when nothing is selected or it has no data.
+func (p *SecretsPanel) selectedKey() (*corev1.Secret, string) {
+	secret := p.SelectedSecret()
+	if secret == nil {
+		return nil, ""
+	}
+
+	keys := sortedKeys(secret.Data)
+	if len(keys) == 0 {
+		return nil, ""
+	}
+
+	return secret, keys[min(p.keyCursor, len(keys)-1)]
+}
+
+// toggleReveal shows or hides the decoded value of the selected key. A newly
+// revealed value is masked again after revealTimeout.
+func (p *SecretsPanel) toggleReveal() tea.Cmd {
+	secret, dataKey := p.selectedKey()
+	if secret == nil {
+		return nil
+	}
+
+	id := revealID(secret, dataKey)
+	if _, ok := p.revealed[id]; ok {
+		delete(p.revealed, id)
+
+		return nil
+	}
+
+	p.revealGen++
+	gen := p.revealGen
+	p.revealed[id] = gen
+
+	return tea.Tick(p.revealTimeout, func(time.Time) tea.Msg {
+		return secretHideMsg{id: id, gen: gen}
+	})
+}
+
+func (p *SecretsPanel) renderSecretKey(
+	secret corev1.Secret,
+	dataKey string,
+	selected bool,
+	width int,
+) string {
+	value := secret.Data[dataKey]
+
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+
+	if _, ok := p.revealed[revealID(&secret, dataKey)]; !ok {
+		line := fmt.Sprintf("%s%s: %s (%d bytes)", prefix, dataKey, "••••••••", len(value))
+		if selected {
+			return p.styles.ListItemSelected.Render(line) + "\n"
+		}
+
+		return line + "\n"
+	}
+
+	var b strings.Builder
+
+	header := prefix + dataKey + ":"
+	if selected {
+		b.WriteString(p.styles.ListItemSelected.Render(header))
+	} else {
+		b.WriteString(header)
+	}
+
+	b.WriteString("\n")
+
+	if !utf8.Valid(value) {
+		b.WriteString(p.styles.Muted.Render(fmt.Sprintf("    <binary, %d bytes>", len(value))))
+		b.WriteString("\n")
+
+		return b.String()
+	}
+
+	for line := range strings.SplitSeq(strings.TrimRight(string(value), "\n"), "\n") {
+		b.WriteString("    ")
+		b.WriteString(p.styles.StatusWarning.Render(utils.Truncate(line, width)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func revealID(secret *corev1.Secret, dataKey string) string {
+	return secretID(secret) + "/" + dataKey
+}
+
+func secretID(secret *corev1.Secret) string {
+	return secret.Namespace + "/" + secret.Name
+}
+
+// parseCertificates refreshes the certificate cache for all TLS secrets.
+func (p *SecretsPanel) parseCertificates() {
+	p.certs = make(map[string]certResult)
+
+	for i := range p.secrets {
+		secret := &p.secrets[i]
+		if secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+
+		info, err := k8s.ParseTLSSecret(secret)
+		p.certs[secretID(secret)] = certResult{info: info, err: err}
+	}
+}
+
+func (p *SecretsPanel) renderCertificate(cert certResult, width int) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Certificate:"))
+	b.WriteString("\n")
+
+	if cert.err != nil {
+		b.WriteString(p.styles.StatusError.Render("  " + cert.err.Error()))
+		b.WriteString("\n")
+
+		return b.String()
+	}
+
+	info := cert.info
+	valueWidth := max(width-16, 10)
+
+	row := func(label, value string, style lipgloss.Style) {
+		b.WriteString(p.styles.DetailLabel.Render(label))
+		b.WriteString(style.Render(utils.Truncate(value, valueWidth)))
+		b.WriteString("\n")
+	}
+
+	row("Subject:", info.Subject, p.styles.DetailValue)
+	row("Issuer:", info.Issuer, p.styles.DetailValue)
+
+	sans := append(slices.Clone(info.DNSNames), info.IPAddresses...)
+	if len(sans) > 0 {
+		row("SANs:", strings.Join(sans, ", "), p.styles.DetailValue)
+	}
+
+	row("Not Before:", info.NotBefore.UTC().Format(time.RFC3339), p.styles.DetailValue)
+	row("Not After:", info.NotAfter.UTC().Format(time.RFC3339), p.styles.DetailValue)
+
+	now := time.Now()
+	days := info.DaysUntilExpiry(now)
+
+	switch info.Expiry(now, p.certThreshold) {
+	case k8s.CertExpired:
+		row("Expires:", fmt.Sprintf("EXPIRED %d days ago", -days), p.styles.StatusError)
+	case k8s.CertNotYetValid:
+		row("Expires:", "not valid yet", p.styles.StatusError)
+	case k8s.CertExpiringSoon:
+		row("Expires:", fmt.Sprintf("in %d days", days), p.styles.StatusWarning)
+	case k8s.CertValid:
+		row("Expires:", fmt.Sprintf("in %d days", days), p.styles.StatusRunning)
+	}
+
+	if info.ChainLength > 1 {
+		row("Chain:", fmt.Sprintf("%d certificates", info.ChainLength), p.styles.DetailValue)
+	}
+
+	if info.KeyMatches {
+		row("Key:", "matches certificate", p.styles.StatusRunning)
+	} else {
+		row("Key:", "DOES NOT MATCH certificate", p.styles.StatusError)
+	}
+
+	return b.String()
+}
+
 type secretsLoadedMsg struct {
 	secrets []corev1.Secret
 }
+
+type secretHideMsg struct {
+	id  string
+	gen int
+}
@@ -0,0 +1,275 @@
+package panels
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestSecretsPanel() *SecretsPanel {
+	panel := NewSecretsPanel(createTestK8sClient(), createTestStyles())
+	secret := testSecret()
+	secret.Data["username"] = []byte("admin")
+	secret.Data["password"] = []byte("hunter2")
+
+	panel.secrets = []corev1.Secret{secret}
+	panel.filtered = panel.secrets
+	panel.SetFocused(true)
+
+	return panel
+}
+
+func pressKey(p Panel, r rune) tea.Cmd {
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+
+	return cmd
+}
+
+func TestSecretsPanel_ValuesHiddenByDefault(t *testing.T) {
+	panel := newTestSecretsPanel()
+
+	view := panel.DetailView(80, 40)
+
+	if strings.Contains(view, "hunter2") || strings.Contains(view, "admin") {
+		t.Error("detail view should not show secret values before reveal")
+	}
+
+	if !strings.Contains(view, "password") || !strings.Contains(view, "username") {
+		t.Error("detail view should list data keys")
+	}
+}
+
+func TestSecretsPanel_RevealSelectedKey(t *testing.T) {
+	panel := newTestSecretsPanel()
+
+	// Keys are sorted: password, username
+	cmd := pressKey(panel, 'v')
+	if cmd == nil {
+		t.Fatal("v should schedule an auto-hide tick")
+	}
+
+	view := panel.DetailView(80, 40)
+	if !strings.Contains(view, "hunter2") {
+		t.Error("revealed value should be shown decoded")
+	}
+
+	if strings.Contains(view, "admin") {
+		t.Error("only the selected key should be revealed")
+	}
+
+	// Toggling again hides without scheduling anything
+	if cmd := pressKey(panel, 'v'); cmd != nil {
+		t.Error("hiding a value should not return a command")
+	}
+
+	if strings.Contains(panel.DetailView(80, 40), "hunter2") {
+		t.Error("value should be hidden after second toggle")
+	}
+}
+
+func TestSecretsPanel_AutoHideIgnoresStaleTick(t *testing.T) {
+	panel := newTestSecretsPanel()
+
+	pressKey(panel, 'v')
+	id := revealID(&panel.filtered[0], "password")
+	firstGen := panel.revealed[id]
+
+	// Hide and reveal again; the first tick must not mask the new reveal
+	pressKey(panel, 'v')
+	pressKey(panel, 'v')
+
+	panel.Update(secretHideMsg{id: id, gen: firstGen})
+
+	if _, ok := panel.revealed[id]; !ok {
+		t.Error("stale hide tick should not mask a newer reveal")
+	}
+
+	panel.Update(secretHideMsg{id: id, gen: panel.revealed[id]})
+
+	if _, ok := panel.revealed[id]; ok {
+		t.Error("current hide tick should mask the value")
+	}
+}
+
+func TestSecretsPanel_KeyCursorAndCopy(t *testing.T) {
+	panel := newTestSecretsPanel()
+
+	pressKey(panel, ']')
+
+	cmd := pressKey(panel, 'Y')
+	if cmd == nil {
+		t.Fatal("Y should return a command")
+	}
+
+	msg, ok := cmd().(CopySecretValueRequestMsg)
+	if !ok {
+		t.Fatalf("expected CopySecretValueRequestMsg, got %T", cmd())
+	}
+
+	if msg.Key != "username" || string(msg.Value) != "admin" {
+		t.Errorf("copied %s=%q, want username=admin", msg.Key, msg.Value)
+	}
+
+	// Cursor is clamped at the last key
+	pressKey(panel, ']')
+
+	if panel.keyCursor != 1 {
+		t.Errorf("keyCursor = %d, want 1", panel.keyCursor)
+	}
+}
+
+func TestSecretsPanel_EditEmitsRequest(t *testing.T) {
+	panel := newTestSecretsPanel()
+
+	cmd := pressKey(panel, 'E')
+	if cmd == nil {
+		t.Fatal("E should return a command")
+	}
+
+	msg, ok := cmd().(EditSecretKeyRequestMsg)
+	if !ok {
+		t.Fatalf("expected EditSecretKeyRequestMsg, got %T", cmd())
+	}
+
+	if msg.SecretName != "test-secret" || msg.Namespace != "default" || msg.Key != "password" {
+		t.Errorf("unexpected edit request %+v", msg)
+	}
+}
+
+func TestSecretsPanel_NoDataKeys(t *testing.T) {
+	panel := newTestSecretsPanel()
+	panel.filtered[0].Data = nil
+
+	for _, r := range []rune{'v', 'Y', 'E'} {
+		if cmd := pressKey(panel, r); cmd != nil {
+			t.Errorf("%q on a secret without data should return nil", r)
+		}
+	}
+}
+
+func TestSecretsPanel_NewSecretUsesCurrentNamespace(t *testing.T) {
+	panel := NewSecretsPanel(createTestK8sClient(), createTestStyles())
+
+	cmd := pressKey(panel, 'N')
+	if cmd == nil {
+		t.Fatal("N should return a command even with no secrets")
+	}
+
+	msg, ok := cmd().(CreateSecretRequestMsg)
+	if !ok {
+		t.Fatalf("expected CreateSecretRequestMsg, got %T", cmd())
+	}
+
+	if msg.Namespace != "default" {
+		t.Errorf("Namespace = %q, want %q", msg.Namespace, "default")
+	}
+}
+
+// testTLSSecret returns a TLS secret holding a self-signed certificate for
+// example.com that expires after validFor.
+func testTLSSecret(t *testing.T, name string, validFor time.Duration) corev1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validFor),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func newTestTLSSecretsPanel(t *testing.T, secrets ...corev1.Secret) *SecretsPanel {
+	t.Helper()
+
+	panel := NewSecretsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetSize(100, 20)
+	panel.SetFocused(true)
+	panel.Update(secretsLoadedMsg{secrets: secrets})
+
+	return panel
+}
+
+func TestSecretsPanel_CertificateDetail(t *testing.T) {
+	panel := newTestTLSSecretsPanel(t, testTLSSecret(t, "web-tls", 90*24*time.Hour))
+
+	view := panel.DetailView(100, 60)
+
+	for _, want := range []string{"Certificate:", "CN=example.com", "example.com", "matches certificate"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view should contain %q", want)
+		}
+	}
+}
+
+func TestSecretsPanel_FlagsExpiringCertificates(t *testing.T) {
+	panel := newTestTLSSecretsPanel(t,
+		testTLSSecret(t, "fine-tls", 90*24*time.Hour),
+		testTLSSecret(t, "soon-tls", 5*24*time.Hour),
+		testTLSSecret(t, "old-tls", -time.Minute),
+	)
+
+	view := panel.View()
+
+	if !strings.Contains(view, "EXPIRED") {
+		t.Error("expired certificate should be flagged in the list")
+	}
+
+	if !strings.Contains(view, "exp 4d") {
+		t.Error("soon-to-expire certificate should show days left")
+	}
+
+	if strings.Count(view, "kubernete") != 1 {
+		t.Error("only the valid certificate should show its plain type")
+	}
+}
+
+func TestSecretsPanel_CertExpiryThreshold(t *testing.T) {
+	panel := newTestTLSSecretsPanel(t, testTLSSecret(t, "soon-tls", 5*24*time.Hour))
+	panel.SetCertExpiryThreshold(24 * time.Hour)
+
+	if strings.Contains(panel.View(), "exp ") {
+		t.Error("certificate outside the threshold should not be flagged")
+	}
+
+	msg, ok := pressKey(panel, 'C')().(ExpiringCertificatesRequestMsg)
+	if !ok || msg.Threshold != 24*time.Hour {
+		t.Errorf("expected report request with the configured threshold, got %+v", msg)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,6 +21,7 @@ type ServiceAccountsPanel struct {
 	styles          *theme.Styles
 	serviceAccounts []corev1.ServiceAccount
 	filtered        []corev1.ServiceAccount
+	table           *columnTable[corev1.ServiceAccount]
 }
 
 func NewServiceAccountsPanel(client *k8s.Client, styles *theme.Styles) *ServiceAccountsPanel {
@@ -27,6 +29,7 @@ func NewServiceAccountsPanel(client *k8s.Client, styles *theme.Styles) *ServiceA
 		BasePanel: BasePanel{
 			title:       "ServiceAccounts",
 			shortcutKey: "",
+			sortable:    objectSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -78,7 +81,8 @@ func (p *ServiceAccountsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		sa := p.filtered[i]
@@ -99,14 +103,13 @@ func (p *ServiceAccountsPanel) renderServiceAccountLine(
 	sa corev1.ServiceAccount,
 	selected bool,
 ) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, sa, objectRef("ServiceAccount", &sa), selected)
+	}
+
 	secrets := k8s.GetServiceAccountSecretsSummary(&sa)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("ServiceAccount", &sa))
 
 	if p.width > 80 {
 		reserved := 35
@@ -117,7 +120,7 @@ func (p *ServiceAccountsPanel) renderServiceAccountLine(
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(sa.Name, nameW), nameW,
+			highlightName(p.styles, p.query, sa.Name, nameW), nameW,
 		)
 		line += " " + p.styles.StatusRunning.Render(
 			utils.PadRight(secrets, 18),
@@ -130,7 +133,7 @@ func (p *ServiceAccountsPanel) renderServiceAccountLine(
 			line += " " + utils.Truncate(sa.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(sa.Name, p.width-15)
+		name := highlightName(p.styles, p.query, sa.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-25)
 		line += " " + p.styles.StatusRunning.Render(secrets)
@@ -145,6 +148,16 @@ func (p *ServiceAccountsPanel) renderServiceAccountLine(
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *ServiceAccountsPanel) builtinColumns() map[string]tableColumn[corev1.ServiceAccount] {
+	return withColumns(objectColumns[corev1.ServiceAccount](), map[string]tableColumn[corev1.ServiceAccount]{
+		"secrets": {
+			header: "SECRETS", width: 18,
+			value: func(sa corev1.ServiceAccount) string { return k8s.GetServiceAccountSecretsSummary(&sa) },
+			style: func(corev1.ServiceAccount) lipgloss.Style { return p.styles.StatusRunning },
+		},
+	})
+}
+
 func (p *ServiceAccountsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No service account selected"
@@ -211,8 +224,10 @@ func (p *ServiceAccountsPanel) DetailView(width, height int) string {
 }
 
 func (p *ServiceAccountsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			serviceAccounts []corev1.ServiceAccount
@@ -325,10 +340,11 @@ func (p *ServiceAccountsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *ServiceAccountsPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.serviceAccounts,
-		p.filter,
-		func(sa corev1.ServiceAccount) string { return sa.Name },
+		p.query,
+		p.sort,
+		func(sa corev1.ServiceAccount) queryFields { return objectFields(&sa) },
 		&p.cursor,
 	)
 }
@@ -338,6 +354,27 @@ func (p *ServiceAccountsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *ServiceAccountsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *ServiceAccountsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *ServiceAccountsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.serviceAccounts,
@@ -362,6 +399,12 @@ func (p *ServiceAccountsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *ServiceAccountsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(sa corev1.ServiceAccount) ResourceRef {
+		return objectRef("ServiceAccount", &sa)
+	})
+}
+
 type serviceAccountsLoadedMsg struct {
 	serviceAccounts []corev1.ServiceAccount
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
@@ -20,6 +22,10 @@ type ServicesPanel struct {
 	styles   *theme.Styles
 	services []corev1.Service
 	filtered []corev1.Service
+	table    *columnTable[corev1.Service]
+
+	// Keyed by namespace/name; nil when endpoint slices couldn't be listed.
+	health map[string]*k8s.ServiceHealth
 }
 
 func NewServicesPanel(client *k8s.Client, styles *theme.Styles) *ServicesPanel {
@@ -27,6 +33,7 @@ func NewServicesPanel(client *k8s.Client, styles *theme.Styles) *ServicesPanel {
 		BasePanel: BasePanel{
 			title:       "Services",
 			shortcutKey: "4",
+			sortable:    objectSortColumns,
 		},
 		client: client,
 		styles: styles,
@@ -53,6 +60,7 @@ func (p *ServicesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 
 	case servicesLoadedMsg:
 		p.services = msg.services
+		p.health = msg.health
 		p.applyFilter()
 
 		return p, nil
@@ -78,7 +86,8 @@ func (p *ServicesPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		svc := p.filtered[i]
@@ -96,15 +105,19 @@ func (p *ServicesPanel) View() string {
 }
 
 func (p *ServicesPanel) renderServiceLine(svc corev1.Service, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, svc, objectRef("Service", &svc), selected)
+	}
+
 	svcType := string(svc.Spec.Type)
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
+	badge, badgeStyle := p.healthBadge(&svc)
+	if badge != "" {
+		svcType = badge
 	}
 
+	line := p.rowPrefix(selected, objectRef("Service", &svc))
+
 	if p.width > 80 {
 		reserved := 50
 		if p.width > 120 {
@@ -114,7 +127,7 @@ func (p *ServicesPanel) renderServiceLine(svc corev1.Service, selected bool) str
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(svc.Name, nameW), nameW,
+			highlightName(p.styles, p.query, svc.Name, nameW), nameW,
 		)
 		line += " " + utils.PadRight(utils.Truncate(svcType, 12), 12)
 		line += " " + utils.PadRight(
@@ -129,7 +142,7 @@ func (p *ServicesPanel) renderServiceLine(svc corev1.Service, selected bool) str
 			line += " " + utils.Truncate(extIP, 18)
 		}
 	} else {
-		name := utils.Truncate(svc.Name, p.width-15)
+		name := highlightName(p.styles, p.query, svc.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-12)
 		line += " " + utils.Truncate(svcType, 10)
@@ -141,9 +154,69 @@ func (p *ServicesPanel) renderServiceLine(svc corev1.Service, selected bool) str
 		return p.styles.ListItemSelected.Render(line)
 	}
 
+	if badge != "" {
+		return badgeStyle.Render(line)
+	}
+
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *ServicesPanel) builtinColumns() map[string]tableColumn[corev1.Service] {
+	return withColumns(objectColumns[corev1.Service](), map[string]tableColumn[corev1.Service]{
+		"type": {
+			header: "TYPE", width: 12,
+			value: func(svc corev1.Service) string {
+				if badge, _ := p.healthBadge(&svc); badge != "" {
+					return badge
+				}
+
+				return string(svc.Spec.Type)
+			},
+			style: func(svc corev1.Service) lipgloss.Style {
+				if badge, style := p.healthBadge(&svc); badge != "" {
+					return style
+				}
+
+				return lipgloss.NewStyle()
+			},
+		},
+		"cluster-ip": {
+			header: "CLUSTER-IP", width: 15,
+			value: func(svc corev1.Service) string { return svc.Spec.ClusterIP },
+		},
+		"ports": {
+			header: "PORTS", width: 20,
+			value: func(svc corev1.Service) string { return k8s.GetServicePorts(&svc) },
+		},
+		"external-ip": {
+			header: "EXTERNAL-IP", width: 18,
+			value: func(svc corev1.Service) string { return k8s.GetServiceExternalIP(&svc) },
+		},
+	})
+}
+
+// healthBadge returns a short warning for services that can't serve
+// traffic: no pods behind the selector, no ready endpoint or a named
+// targetPort missing on the pods.
+func (p *ServicesPanel) healthBadge(svc *corev1.Service) (string, lipgloss.Style) {
+	health, ok := p.health[serviceID(svc)]
+	if !ok {
+		return "", lipgloss.Style{}
+	}
+
+	switch health.Problem(svc) {
+	case k8s.ServiceNoMatchingPods:
+		return "NO PODS", p.styles.StatusError
+	case k8s.ServiceNoReadyEndpoints:
+		return "0 READY", p.styles.StatusError
+	case k8s.ServiceMissingTargetPort:
+		return "BAD PORT", p.styles.StatusWarning
+	case k8s.ServiceOK:
+	}
+
+	return "", lipgloss.Style{}
+}
+
 func (p *ServicesPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No service selected"
@@ -221,32 +294,127 @@ func (p *ServicesPanel) DetailView(width, height int) string {
 		}
 	}
 
+	if health, ok := p.health[serviceID(&svc)]; ok && svc.Spec.Type != corev1.ServiceTypeExternalName {
+		p.renderEndpoints(&b, health)
+	}
+
 	b.WriteString("\n")
 	b.WriteString(p.styles.Muted.Render("[p]ort-forward [d]escribe [y]aml [D]elete"))
 
 	return b.String()
 }
 
+func (p *ServicesPanel) renderEndpoints(b *strings.Builder, health *k8s.ServiceHealth) {
+	b.WriteString("\n")
+	b.WriteString(p.styles.DetailTitle.Render("Endpoints:"))
+	b.WriteString("\n")
+
+	summary := fmt.Sprintf("  %d ready, %d not ready", health.Ready, health.NotReady)
+	if health.MatchingPods >= 0 {
+		summary += fmt.Sprintf(", %d pods match the selector", health.MatchingPods)
+	}
+
+	if health.Ready == 0 {
+		b.WriteString(p.styles.StatusError.Render(summary))
+	} else {
+		b.WriteString(summary)
+	}
+
+	b.WriteString("\n")
+
+	if health.MatchingPods == 0 {
+		b.WriteString(p.styles.StatusError.Render("  Selector matches no pods"))
+		b.WriteString("\n")
+	}
+
+	for _, missing := range health.MissingTargetPorts {
+		b.WriteString(p.styles.StatusWarning.Render(fmt.Sprintf(
+			"  targetPort %q not declared by: %s", missing.Port, strings.Join(missing.Pods, ", "),
+		)))
+		b.WriteString("\n")
+	}
+
+	if len(health.Endpoints) == 0 {
+		return
+	}
+
+	header := fmt.Sprintf("  %-16s %-30s %-20s %s", "ADDRESS", "POD", "NODE", "READY")
+	b.WriteString(p.styles.TableHeader.Render(header))
+	b.WriteString("\n")
+
+	for _, ep := range health.Endpoints {
+		state := "yes"
+		if ep.Terminating {
+			state = "terminating"
+		} else if !ep.Ready {
+			state = "no"
+		}
+
+		row := fmt.Sprintf("  %-16s %-30s %-20s %s",
+			ep.Address,
+			utils.Truncate(orDash(ep.PodName), 30),
+			utils.Truncate(orDash(ep.NodeName), 20),
+			state,
+		)
+
+		if ep.Ready {
+			b.WriteString(p.styles.TableRow.Render(row))
+		} else {
+			b.WriteString(p.styles.StatusWarning.Render(row))
+		}
+
+		b.WriteString("\n")
+	}
+}
+
 func (p *ServicesPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
 		ctx := context.Background()
+		listCtx := k8s.WithLabelSelector(ctx, selector)
 
 		var (
 			services []corev1.Service
+			slices   []discoveryv1.EndpointSlice
+			pods     []corev1.Pod
 			err      error
 		)
 
 		if p.allNs {
-			services, err = p.client.ListServicesAllNamespaces(ctx)
+			services, err = p.client.ListServicesAllNamespaces(listCtx)
 		} else {
-			services, err = p.client.ListServices(ctx, "")
+			services, err = p.client.ListServices(listCtx, "")
 		}
 
 		if err != nil {
 			return ErrorMsg{Error: err}
 		}
 
-		return servicesLoadedMsg{services: services}
+		// Endpoint health only enriches the view; without slices every
+		// service would look broken, so skip the analysis entirely.
+		if p.allNs {
+			slices, err = p.client.ListEndpointSlicesAllNamespaces(ctx)
+			if err == nil {
+				pods, err = p.client.ListPodsAllNamespaces(ctx)
+			}
+		} else {
+			slices, err = p.client.ListEndpointSlices(ctx, "")
+			if err == nil {
+				pods, err = p.client.ListPods(ctx, "")
+			}
+		}
+
+		if err != nil {
+			return servicesLoadedMsg{services: services}
+		}
+
+		health := make(map[string]*k8s.ServiceHealth, len(services))
+		for i := range services {
+			health[serviceID(&services[i])] = k8s.AnalyzeService(&services[i], slices, pods)
+		}
+
+		return servicesLoadedMsg{services: services, health: health}
 	}
 }
 
@@ -343,10 +511,11 @@ func (p *ServicesPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *ServicesPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.services,
-		p.filter,
-		func(svc corev1.Service) string { return svc.Name },
+		p.query,
+		p.sort,
+		func(svc corev1.Service) queryFields { return objectFields(&svc) },
 		&p.cursor,
 	)
 }
@@ -356,6 +525,27 @@ func (p *ServicesPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *ServicesPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *ServicesPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *ServicesPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.services,
@@ -378,6 +568,25 @@ func (p *ServicesPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *ServicesPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(svc corev1.Service) ResourceRef {
+		return objectRef("Service", &svc)
+	})
+}
+
 type servicesLoadedMsg struct {
 	services []corev1.Service
+	health   map[string]*k8s.ServiceHealth
+}
+
+func serviceID(svc *corev1.Service) string {
+	return svc.Namespace + "/" + svc.Name
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
 }
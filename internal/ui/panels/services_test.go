@@ -0,0 +1,128 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+)
+
+// loadServicesPanel runs a real Refresh against a fake cluster holding a
+// service "web" (selector app=web, named targetPort "http") and the
+// given objects.
+func loadServicesPanel(t *testing.T, objects ...*discoveryv1.EndpointSlice) *ServicesPanel {
+	t.Helper()
+
+	svc := testService()
+	svc.Name = "web"
+	svc.Spec.Selector = map[string]string{"app": "web"}
+	svc.Spec.Ports[0].TargetPort = intstr.FromString("http")
+
+	pod := testPod()
+	pod.Name = "web-a"
+	pod.Labels = map[string]string{"app": "web"}
+
+	clientset := fake.NewSimpleClientset(&svc, &pod)
+	for _, slice := range objects {
+		if err := clientset.Tracker().Add(slice); err != nil {
+			t.Fatalf("failed to add slice: %v", err)
+		}
+	}
+
+	panel := NewServicesPanel(k8s.NewTestClient(clientset), createTestStyles())
+	panel.SetSize(100, 30)
+	panel.Update(panel.Refresh()())
+
+	return panel
+}
+
+func webEndpointSlice(ready bool) *discoveryv1.EndpointSlice {
+	node := "node-1"
+
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.1.0.5"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			NodeName:   &node,
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-a"},
+		}},
+	}
+}
+
+func TestServicesPanel_EndpointDetail(t *testing.T) {
+	panel := loadServicesPanel(t, webEndpointSlice(true))
+
+	detail := panel.DetailView(100, 40)
+
+	for _, want := range []string{"Endpoints:", "1 ready, 0 not ready", "10.1.0.5", "web-a", "node-1"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail view missing %q:\n%s", want, detail)
+		}
+	}
+
+	// The pod doesn't declare a port named "http"
+	if !strings.Contains(detail, `targetPort "http" not declared by: web-a`) {
+		t.Errorf("expected missing target port warning:\n%s", detail)
+	}
+
+	if !strings.Contains(panel.View(), "BAD PORT") {
+		t.Error("list should flag the missing named target port")
+	}
+}
+
+func TestServicesPanel_FlagsNoReadyEndpoints(t *testing.T) {
+	panel := loadServicesPanel(t, webEndpointSlice(false))
+
+	if !strings.Contains(panel.View(), "0 READY") {
+		t.Errorf("list should flag a service without ready endpoints:\n%s", panel.View())
+	}
+
+	if !strings.Contains(panel.DetailView(100, 40), "not ready") {
+		t.Error("detail view should list the not-ready endpoint")
+	}
+}
+
+func TestServicesPanel_FlagsSelectorWithoutPods(t *testing.T) {
+	panel := loadServicesPanel(t)
+	panel.services[0].Spec.Selector = map[string]string{"app": "nothing"}
+	panel.health[serviceID(&panel.services[0])] = k8s.AnalyzeService(&panel.services[0], nil, nil)
+	panel.applyFilter()
+
+	if !strings.Contains(panel.View(), "NO PODS") {
+		t.Errorf("list should flag a selector matching no pods:\n%s", panel.View())
+	}
+
+	if !strings.Contains(panel.DetailView(100, 40), "Selector matches no pods") {
+		t.Error("detail view should explain the empty selector")
+	}
+}
+
+func TestServicesPanel_NoBadgeWithoutHealth(t *testing.T) {
+	panel := NewServicesPanel(createTestK8sClient(), createTestStyles())
+	panel.services = []corev1.Service{testService()}
+	panel.filtered = panel.services
+	panel.SetSize(100, 30)
+
+	view := panel.View()
+	for _, badge := range []string{"NO PODS", "0 READY", "BAD PORT"} {
+		if strings.Contains(view, badge) {
+			t.Errorf("unexpected %s badge without endpoint data", badge)
+		}
+	}
+
+	if strings.Contains(panel.DetailView(100, 40), "Endpoints:") {
+		t.Error("detail view should omit endpoints without endpoint data")
+	}
+}
@@ -0,0 +1,241 @@
+package panels
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrUnknownSort = errors.New("unknown sort column")
+
+// sortColumn is a column a list panel can be sorted by.
+type sortColumn int
+
+const (
+	// sortNone keeps the order the API server lists items in.
+	sortNone sortColumn = iota
+	sortName
+	sortNamespace
+	sortAge
+	sortStatus
+	sortRestarts
+	sortReady
+	sortCPU
+	sortMemory
+)
+
+var sortColumnNames = [...]string{"", "name", "namespace", "age", "status", "restarts", "ready", "cpu", "memory"}
+
+func (c sortColumn) String() string {
+	return sortColumnNames[c]
+}
+
+// descByDefault reports whether a column lists the largest first when
+// picked, like top does for usage.
+func (c sortColumn) descByDefault() bool {
+	return c == sortRestarts || c == sortCPU || c == sortMemory
+}
+
+// sortOrder is a panel's sort column and direction.
+type sortOrder struct {
+	column sortColumn
+	desc   bool
+}
+
+// String is the form saved in the config, e.g. "restarts desc"; the API
+// order is "".
+func (o sortOrder) String() string {
+	if o.column == sortNone {
+		return ""
+	}
+
+	if o.desc {
+		return o.column.String() + " desc"
+	}
+
+	return o.column.String() + " asc"
+}
+
+// arrow marks the sorted column's direction.
+func (o sortOrder) arrow() string {
+	if o.desc {
+		return "↓"
+	}
+
+	return "↑"
+}
+
+// parseSortOrder reads a saved sort order; the direction is optional.
+func parseSortOrder(spec string) (sortOrder, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return sortOrder{}, nil
+	}
+
+	i := slices.Index(sortColumnNames[1:], strings.ToLower(fields[0]))
+	if i < 0 || len(fields) > 2 {
+		return sortOrder{}, fmt.Errorf("%w %q", ErrUnknownSort, spec)
+	}
+
+	order := sortOrder{column: sortColumn(i + 1)}
+	order.desc = order.column.descByDefault()
+
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+			order.desc = false
+		case "desc":
+			order.desc = true
+		default:
+			return sortOrder{}, fmt.Errorf("%w %q: use asc or desc", ErrUnknownSort, spec)
+		}
+	}
+
+	return order, nil
+}
+
+// compare orders two items by the column. Values a panel doesn't know,
+// like usage without metrics-server, go last whichever the direction.
+func (o sortOrder) compare(a, b *queryFields) int {
+	var (
+		c                  int
+		aUnknown, bUnknown bool
+	)
+
+	switch o.column {
+	case sortName:
+		c = cmp.Compare(a.name, b.name)
+	case sortNamespace:
+		c = cmp.Or(cmp.Compare(a.namespace, b.namespace), cmp.Compare(a.name, b.name))
+	case sortAge:
+		// Older items were created earlier and are larger in age
+		c = b.created.Compare(a.created)
+		aUnknown, bUnknown = a.created.IsZero(), b.created.IsZero()
+	case sortStatus:
+		c = cmp.Compare(a.status, b.status)
+		aUnknown, bUnknown = a.status == "", b.status == ""
+	case sortRestarts:
+		c = cmp.Compare(a.restarts, b.restarts)
+		aUnknown, bUnknown = a.restarts < 0, b.restarts < 0
+	case sortReady:
+		c = cmp.Compare(a.ready, b.ready)
+		aUnknown, bUnknown = a.ready < 0, b.ready < 0
+	case sortCPU:
+		c = cmp.Compare(a.cpu, b.cpu)
+		aUnknown, bUnknown = a.cpu < 0, b.cpu < 0
+	case sortMemory:
+		c = cmp.Compare(a.memory, b.memory)
+		aUnknown, bUnknown = a.memory < 0, b.memory < 0
+	}
+
+	switch {
+	case aUnknown && bUnknown:
+		return 0
+	case aUnknown:
+		return 1
+	case bUnknown:
+		return -1
+	case o.desc:
+		return -c
+	}
+
+	return c
+}
+
+// SortOrder is the active panel sort in its config form, e.g. "cpu desc".
+func (b *BasePanel) SortOrder() string {
+	return b.sort.String()
+}
+
+// SetSortOrder sorts by a saved order; panels re-apply their filter after.
+func (b *BasePanel) SetSortOrder(spec string) error {
+	order, err := parseSortOrder(spec)
+	if err != nil {
+		return err
+	}
+
+	if order.column != sortNone && !slices.Contains(b.sortable, order.column) {
+		return fmt.Errorf("%w %q for %s", ErrUnknownSort, order.column, b.title)
+	}
+
+	b.sort = order
+
+	return nil
+}
+
+// NextSortOrder is the order step columns along from the panel's current
+// one, in the column's usual direction; after the last column comes the
+// API order. It returns "" for panels that can't be sorted.
+func NextSortOrder(panel Panel, step int) string {
+	columns := panel.SortColumns()
+	if len(columns) == 0 {
+		return ""
+	}
+
+	current, _ := parseSortOrder(panel.SortOrder())
+
+	// Position 0 is the API order, then each sortable column
+	pos := slices.Index(columns, current.column.String()) + 1
+	pos = ((pos+step)%(len(columns)+1) + len(columns) + 1) % (len(columns) + 1)
+
+	if pos == 0 {
+		return ""
+	}
+
+	next, _ := parseSortOrder(columns[pos-1])
+
+	return next.String()
+}
+
+// ReversedSortOrder flips the direction of the panel's sort; the API order
+// has none to flip.
+func ReversedSortOrder(panel Panel) string {
+	order, _ := parseSortOrder(panel.SortOrder())
+	if order.column == sortNone {
+		return ""
+	}
+
+	order.desc = !order.desc
+
+	return order.String()
+}
+
+// SortColumns names the columns the panel can be sorted by.
+func (b *BasePanel) SortColumns() []string {
+	names := make([]string, len(b.sortable))
+	for i, c := range b.sortable {
+		names[i] = c.String()
+	}
+
+	return names
+}
+
+// sortHeader labels a header column of the given width, marking it when
+// the panel is sorted by it. The label is shortened so the arrow fits.
+func (b *BasePanel) sortHeader(label string, column sortColumn, width int) string {
+	if b.sort.column != column {
+		return label
+	}
+
+	if len(label) >= width {
+		label = label[:max(width-1, 1)]
+	}
+
+	return label + b.sort.arrow()
+}
+
+// Columns each kind of panel can be sorted by, in the order the sort key
+// cycles through them.
+var (
+	objectSortColumns   = []sortColumn{sortName, sortNamespace, sortAge}
+	statusSortColumns   = []sortColumn{sortName, sortNamespace, sortAge, sortStatus}
+	workloadSortColumns = []sortColumn{sortName, sortNamespace, sortAge, sortReady}
+	clusterSortColumns  = []sortColumn{sortName, sortAge, sortStatus}
+	problemSortColumns  = []sortColumn{sortName, sortNamespace, sortStatus}
+	nodeSortColumns     = []sortColumn{sortName, sortAge, sortStatus, sortCPU, sortMemory}
+	podSortColumns      = []sortColumn{
+		sortName, sortNamespace, sortAge, sortStatus, sortRestarts, sortReady, sortCPU, sortMemory,
+	}
+)
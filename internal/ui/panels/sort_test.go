@@ -0,0 +1,140 @@
+package panels
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", ""},
+		{"name", "name asc"},
+		{"CPU", "cpu desc"},
+		{"restarts asc", "restarts asc"},
+		{"age desc", "age desc"},
+	}
+
+	for _, tt := range tests {
+		order, err := parseSortOrder(tt.spec)
+		if err != nil {
+			t.Errorf("parseSortOrder(%q) returned unexpected error: %v", tt.spec, err)
+
+			continue
+		}
+
+		if order.String() != tt.want {
+			t.Errorf("parseSortOrder(%q) = %q, want %q", tt.spec, order, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"colour", "name sideways", "name asc now"} {
+		if _, err := parseSortOrder(spec); !errors.Is(err, ErrUnknownSort) {
+			t.Errorf("parseSortOrder(%q) error = %v, want ErrUnknownSort", spec, err)
+		}
+	}
+}
+
+func TestSortOrderUnknownValuesLast(t *testing.T) {
+	known, unknown := queryFields{cpu: 5}, queryFields{cpu: -1}
+
+	for _, desc := range []bool{false, true} {
+		order := sortOrder{column: sortCPU, desc: desc}
+		if order.compare(&known, &unknown) >= 0 || order.compare(&unknown, &known) <= 0 {
+			t.Errorf("desc=%v: items without usage should sort last", desc)
+		}
+	}
+}
+
+func TestSortCyclesColumns(t *testing.T) {
+	panel := newMarkedDeploymentsPanel("b", "c", "a")
+	panel.deployments[0].CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	panel.deployments[1].CreationTimestamp = metav1.NewTime(time.Now().Add(-3 * time.Hour))
+	panel.deployments[2].CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	panel.deployments[1].Status.ReadyReplicas = 1
+	panel.applyFilter()
+
+	names := func() []string {
+		var out []string
+		for _, d := range panel.filtered {
+			out = append(out, d.Name)
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{"name asc", []string{"a", "b", "c"}},
+		{"namespace asc", []string{"a", "b", "c"}},
+		{"age asc", []string{"b", "a", "c"}},
+		{"ready asc", []string{"c", "b", "a"}},
+		{"", []string{"b", "c", "a"}},
+	}
+
+	for _, tt := range tests {
+		next := NextSortOrder(panel, 1)
+		if next != tt.order {
+			t.Fatalf("next sort = %q, want %q", next, tt.order)
+		}
+
+		if err := panel.SetSortOrder(next); err != nil {
+			t.Fatalf("SetSortOrder(%q) returned unexpected error: %v", next, err)
+		}
+
+		if got := names(); !slices.Equal(got, tt.want) {
+			t.Errorf("%q order = %v, want %v", next, got, tt.want)
+		}
+	}
+
+	if got := NextSortOrder(panel, -1); got != "ready asc" {
+		t.Errorf("stepping back from the API order should pick the last column, got %q", got)
+	}
+
+	if err := panel.SetSortOrder("cpu desc"); !errors.Is(err, ErrUnknownSort) {
+		t.Errorf("deployments have no cpu column, got %v", err)
+	}
+}
+
+func TestSortShownInTitleAndKeptWithFilter(t *testing.T) {
+	panel := newMarkedDeploymentsPanel("web-b", "api", "web-a")
+	panel.SetSize(100, 10)
+
+	if err := panel.SetSortOrder("name desc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if ReversedSortOrder(panel) != "name asc" {
+		t.Errorf("reversing should flip the direction, got %q", ReversedSortOrder(panel))
+	}
+
+	// An explicit sort beats fuzzy ranking
+	panel.SetFilter("web")
+
+	if len(panel.filtered) != 2 || panel.filtered[0].Name != "web-b" {
+		t.Errorf("expected web-b then web-a, got %+v", panel.filtered)
+	}
+
+	if view := panel.View(); !strings.Contains(view, "sorted by name ↓") {
+		t.Errorf("title should show the sort:\n%s", view)
+	}
+
+	panel.SetFilter("")
+
+	if err := panel.SetSortOrder(""); err != nil {
+		t.Fatal(err)
+	}
+
+	if panel.filtered[0].Name != "web-b" || panel.filtered[2].Name != "web-a" {
+		t.Errorf("clearing the sort should restore the listed order, got %+v", panel.filtered)
+	}
+}
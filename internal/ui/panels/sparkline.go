@@ -0,0 +1,200 @@
+package panels
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+// DefaultMetricsRetention is how many samples a pod or node keeps for its
+// sparklines when the config doesn't say; at one sample every 10 seconds
+// it covers 15 minutes.
+const DefaultMetricsRetention = 90
+
+const (
+	// trendWidth is the width of each sparkline in list rows, shown once a
+	// panel is wider than trendMinPanelWidth.
+	trendWidth = 8
+	// trendColumnWidth fits the CPU and memory sparklines side by side.
+	trendColumnWidth   = 2*trendWidth + 1
+	trendMinPanelWidth = 110
+	// chartHeight is the number of rows of each detail view chart.
+	chartHeight = 4
+)
+
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+type metricSeries struct {
+	cpu    []int64
+	memory []int64
+}
+
+// metricHistory is a bounded buffer of usage samples per pod or node, so
+// list rows and detail views can show trends rather than the last value.
+type metricHistory struct {
+	size   int
+	series map[string]*metricSeries
+}
+
+func newMetricHistory(size int) *metricHistory {
+	if size <= 0 {
+		size = DefaultMetricsRetention
+	}
+
+	return &metricHistory{size: size, series: make(map[string]*metricSeries)}
+}
+
+func (h *metricHistory) record(key string, cpu, memory int64) {
+	s, ok := h.series[key]
+	if !ok {
+		s = &metricSeries{}
+		h.series[key] = s
+	}
+
+	s.cpu = appendBounded(s.cpu, cpu, h.size)
+	s.memory = appendBounded(s.memory, memory, h.size)
+}
+
+// prune drops the series of everything that no longer reports metrics.
+func (h *metricHistory) prune(keep func(key string) bool) {
+	for key := range h.series {
+		if !keep(key) {
+			delete(h.series, key)
+		}
+	}
+}
+
+func (h *metricHistory) get(key string) *metricSeries {
+	return h.series[key]
+}
+
+func appendBounded(values []int64, v int64, size int) []int64 {
+	values = append(values, v)
+	if len(values) > size {
+		values = slices.Delete(values, 0, len(values)-size)
+	}
+
+	return values
+}
+
+// sparkline renders the last width values scaled to their own peak, padded
+// on the left so rows line up while history builds.
+func sparkline(values []int64, width int) string {
+	values = values[max(len(values)-width, 0):]
+	peak := slices.Max(append([]int64{0}, values...))
+
+	var b strings.Builder
+
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+
+	for _, v := range values {
+		idx := 0
+		if peak > 0 {
+			idx = int(v * int64(len(sparkRunes)-1) / peak)
+		}
+
+		b.WriteRune(sparkRunes[idx])
+	}
+
+	return b.String()
+}
+
+// renderChart draws the last width values as a bar chart height rows tall,
+// top row first, using eighth blocks for sub-row resolution.
+func renderChart(values []int64, width, height int) []string {
+	values = values[max(len(values)-width, 0):]
+	peak := slices.Max(append([]int64{0}, values...))
+	levels := int64(len(sparkRunes))
+
+	rows := make([]string, height)
+
+	for row := range height {
+		var b strings.Builder
+
+		floor := int64(height-1-row) * levels
+
+		for _, v := range values {
+			var level int64
+			if peak > 0 {
+				level = v * int64(height) * levels / peak
+			}
+
+			switch cell := level - floor; {
+			case cell >= levels:
+				b.WriteRune(sparkRunes[levels-1])
+			case cell > 0:
+				b.WriteRune(sparkRunes[cell-1])
+			default:
+				b.WriteRune(' ')
+			}
+		}
+
+		rows[row] = b.String()
+	}
+
+	return rows
+}
+
+// renderTrend is the CPU and memory sparklines of a list row, blank until
+// the first sample.
+func renderTrend(styles *theme.Styles, s *metricSeries) string {
+	if s == nil {
+		return strings.Repeat(" ", trendColumnWidth)
+	}
+
+	return styles.Muted.Render(sparkline(s.cpu, trendWidth) + " " + sparkline(s.memory, trendWidth))
+}
+
+// renderUsageHistory writes the detail view charts of a pod or node. When
+// metrics-server stops answering the last known samples stay on screen
+// under an explicit notice.
+func renderUsageHistory(b *strings.Builder, styles *theme.Styles, s *metricSeries, unavailable bool, width int) {
+	if s == nil && !unavailable {
+		return
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DetailTitle.Render("Usage History:"))
+	b.WriteString("\n")
+
+	if unavailable {
+		b.WriteString("  " + styles.StatusWarning.Render("metrics unavailable"))
+
+		if s != nil {
+			b.WriteString(styles.Muted.Render(" - showing the last known samples"))
+		}
+
+		b.WriteString("\n")
+	}
+
+	if s == nil {
+		return
+	}
+
+	chartW := max(width-6, 10)
+	chartStyle := lipgloss.NewStyle().Foreground(styles.Primary)
+
+	charts := []struct {
+		label  string
+		values []int64
+		format func(int64) string
+	}{
+		{"CPU", s.cpu, utils.FormatCPU},
+		{"Memory", s.memory, utils.FormatMemory},
+	}
+
+	for _, c := range charts {
+		fmt.Fprintf(b, "  %s %s\n", c.label, styles.Muted.Render(fmt.Sprintf(
+			"last %s, peak %s over %d samples",
+			c.format(c.values[len(c.values)-1]), c.format(slices.Max(c.values)), len(c.values))))
+
+		for _, row := range renderChart(c.values, chartW, chartHeight) {
+			b.WriteString("  " + chartStyle.Render(row) + "\n")
+		}
+	}
+}
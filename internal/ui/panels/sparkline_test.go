@@ -0,0 +1,75 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMetricHistoryBounded(t *testing.T) {
+	h := newMetricHistory(3)
+
+	for i := range 5 {
+		h.record("default/web", int64(i), int64(i*10))
+	}
+
+	s := h.get("default/web")
+	if len(s.cpu) != 3 || s.cpu[0] != 2 || s.memory[2] != 40 {
+		t.Fatalf("expected the last 3 samples, got %+v", s)
+	}
+
+	h.prune(func(string) bool { return false })
+
+	if h.get("default/web") != nil {
+		t.Error("prune should drop series that are no longer kept")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int64{0, 50, 100}, 3); got != "▁▄█" {
+		t.Errorf("sparkline = %q, want %q", got, "▁▄█")
+	}
+
+	// Short history is right-aligned
+	if got := sparkline([]int64{5}, 3); got != "  █" {
+		t.Errorf("sparkline = %q, want %q", got, "  █")
+	}
+
+	if got := sparkline([]int64{0, 0}, 2); got != "▁▁" {
+		t.Errorf("all-zero sparkline = %q, want %q", got, "▁▁")
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	rows := renderChart([]int64{100, 60, 0}, 3, 2)
+
+	want := []string{"█▁ ", "██ "}
+	if strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("chart = %q, want %q", rows, want)
+	}
+}
+
+func TestPodsPanelMetricsUnavailable(t *testing.T) {
+	panel := NewPodsPanel(createTestK8sClient(), createTestStyles())
+	panel.SetSize(140, 20)
+
+	panel.Update(PodMetricsMsg{Metrics: map[string]PodMetrics{
+		"default/web": {Name: "web", Namespace: "default", CPU: 100, Memory: 1024},
+	}})
+	panel.Update(PodMetricsMsg{Unavailable: true})
+
+	if !strings.Contains(panel.View(), "metrics unavailable") {
+		t.Error("list should say metrics are unavailable")
+	}
+
+	if panel.history.get("default/web") == nil {
+		t.Error("history should survive metrics becoming unavailable")
+	}
+
+	var b strings.Builder
+
+	renderUsageHistory(&b, panel.styles, panel.history.get("default/web"), true, 60)
+
+	if out := b.String(); !strings.Contains(out, "last known samples") || !strings.Contains(out, "peak 100m") {
+		t.Errorf("detail chart should keep the last known samples, got:\n%s", out)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	appsv1 "k8s.io/api/apps/v1"
 
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -20,6 +21,8 @@ type StatefulSetsPanel struct {
 	styles       *theme.Styles
 	statefulsets []appsv1.StatefulSet
 	filtered     []appsv1.StatefulSet
+	table        *columnTable[appsv1.StatefulSet]
+	lintPolicy   *k8s.LintPolicy
 }
 
 func NewStatefulSetsPanel(client *k8s.Client, styles *theme.Styles) *StatefulSetsPanel {
@@ -27,12 +30,18 @@ func NewStatefulSetsPanel(client *k8s.Client, styles *theme.Styles) *StatefulSet
 		BasePanel: BasePanel{
 			title:       "StatefulSets",
 			shortcutKey: "",
+			sortable:    workloadSortColumns,
 		},
 		client: client,
 		styles: styles,
 	}
 }
 
+// SetLintPolicy sets which best-practice rules the detail view reports.
+func (p *StatefulSetsPanel) SetLintPolicy(policy *k8s.LintPolicy) {
+	p.lintPolicy = policy
+}
+
 func (p *StatefulSetsPanel) Init() tea.Cmd {
 	return p.Refresh()
 }
@@ -81,6 +90,16 @@ func (p *StatefulSetsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 					Namespace:       sts.Namespace,
 				}
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+			if p.cursor >= len(p.filtered) {
+				return p, nil
+			}
+
+			sts := p.filtered[p.cursor]
+
+			return p, func() tea.Msg {
+				return RightsizeRequestMsg{Kind: "StatefulSet", Name: sts.Name, Namespace: sts.Namespace}
+			}
 		}
 
 	case statefulSetsLoadedMsg:
@@ -110,7 +129,8 @@ func (p *StatefulSetsPanel) View() string {
 
 	b.WriteString("\n")
 
-	startIdx, endIdx := p.visibleWindow(len(p.filtered), 0)
+	headerRows := p.table.writeHeader(&b, &p.BasePanel, p.styles)
+	startIdx, endIdx := p.visibleWindow(len(p.filtered), headerRows)
 
 	for i := startIdx; i < endIdx; i++ {
 		sts := p.filtered[i]
@@ -128,6 +148,10 @@ func (p *StatefulSetsPanel) View() string {
 }
 
 func (p *StatefulSetsPanel) renderStatefulSetLine(sts appsv1.StatefulSet, selected bool) string {
+	if p.table != nil {
+		return p.table.row(&p.BasePanel, p.styles, sts, objectRef("StatefulSet", &sts), selected)
+	}
+
 	ready := k8s.GetStatefulSetReadyCount(&sts)
 
 	readyStyle := p.styles.StatusRunning
@@ -141,12 +165,7 @@ func (p *StatefulSetsPanel) renderStatefulSetLine(sts appsv1.StatefulSet, select
 		readyStyle = p.styles.StatusPending
 	}
 
-	var line string
-	if selected {
-		line = "> "
-	} else {
-		line = "  "
-	}
+	line := p.rowPrefix(selected, objectRef("StatefulSet", &sts))
 
 	if p.width > 80 {
 		reserved := 22
@@ -157,7 +176,7 @@ func (p *StatefulSetsPanel) renderStatefulSetLine(sts appsv1.StatefulSet, select
 		nameW := max(p.width-reserved, 10)
 
 		line += utils.PadRight(
-			utils.Truncate(sts.Name, nameW), nameW,
+			highlightName(p.styles, p.query, sts.Name, nameW), nameW,
 		)
 		line += " " + readyStyle.Render(utils.PadRight(ready, 7))
 
@@ -168,7 +187,7 @@ func (p *StatefulSetsPanel) renderStatefulSetLine(sts appsv1.StatefulSet, select
 			line += " " + utils.Truncate(sts.Namespace, 15)
 		}
 	} else {
-		name := utils.Truncate(sts.Name, p.width-15)
+		name := highlightName(p.styles, p.query, sts.Name, p.width-15)
 		line += name
 		line = utils.PadRight(line, p.width-10)
 		line += " " + readyStyle.Render(ready)
@@ -183,6 +202,27 @@ func (p *StatefulSetsPanel) renderStatefulSetLine(sts appsv1.StatefulSet, select
 	return p.styles.ListItem.Render(line)
 }
 
+func (p *StatefulSetsPanel) builtinColumns() map[string]tableColumn[appsv1.StatefulSet] {
+	return withColumns(objectColumns[appsv1.StatefulSet](), map[string]tableColumn[appsv1.StatefulSet]{
+		"ready": {
+			header: "READY", width: 7, sort: sortReady,
+			value: func(s appsv1.StatefulSet) string { return k8s.GetStatefulSetReadyCount(&s) },
+			style: func(s appsv1.StatefulSet) lipgloss.Style {
+				desired := int32(0)
+				if s.Spec.Replicas != nil {
+					desired = *s.Spec.Replicas
+				}
+
+				return readyStyle(p.styles, s.Status.ReadyReplicas, desired)
+			},
+		},
+		"images": {
+			header: "IMAGES", width: 30,
+			value: func(s appsv1.StatefulSet) string { return strings.Join(podSpecImages(&s.Spec.Template.Spec), ",") },
+		},
+	})
+}
+
 func (p *StatefulSetsPanel) DetailView(width, height int) string {
 	if p.cursor >= len(p.filtered) {
 		return "No statefulset selected"
@@ -247,15 +287,19 @@ func (p *StatefulSetsPanel) DetailView(width, height int) string {
 		}
 	}
 
+	renderLintFindings(&b, p.styles, k8s.LintStatefulSet(&sts, p.lintPolicy), width)
+
 	b.WriteString("\n")
-	b.WriteString(p.styles.Muted.Render("[s]cale [r]estart [l]ogs [d]escribe [y]aml [D]elete"))
+	b.WriteString(p.styles.Muted.Render("[s]cale [r]estart [u]sage sizing [l]ogs [d]escribe [y]aml [D]elete"))
 
 	return b.String()
 }
 
 func (p *StatefulSetsPanel) Refresh() tea.Cmd {
+	selector := p.LabelSelector()
+
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := k8s.WithLabelSelector(context.Background(), selector)
 
 		var (
 			statefulsets []appsv1.StatefulSet
@@ -391,10 +435,23 @@ func (p *StatefulSetsPanel) GetSelectedDescribe() (string, error) {
 }
 
 func (p *StatefulSetsPanel) applyFilter() {
-	p.filtered = filterByName(
+	p.filtered = filterByQuery(
 		p.statefulsets,
-		p.filter,
-		func(s appsv1.StatefulSet) string { return s.Name },
+		p.query,
+		p.sort,
+		func(s appsv1.StatefulSet) queryFields {
+			f := objectFields(&s)
+			f.images = podSpecImages(&s.Spec.Template.Spec)
+
+			desired := int32(0)
+			if s.Spec.Replicas != nil {
+				desired = *s.Spec.Replicas
+			}
+
+			f.ready = readyRatio(s.Status.ReadyReplicas, desired)
+
+			return f
+		},
 		&p.cursor,
 	)
 }
@@ -404,6 +461,27 @@ func (p *StatefulSetsPanel) SetFilter(query string) {
 	p.applyFilter()
 }
 
+func (p *StatefulSetsPanel) SetSortOrder(spec string) error {
+	if err := p.BasePanel.SetSortOrder(spec); err != nil {
+		return err
+	}
+
+	p.applyFilter()
+
+	return nil
+}
+
+func (p *StatefulSetsPanel) SetColumns(defs []ColumnDef) error {
+	table, err := newColumnTable(defs, p.builtinColumns())
+	if err != nil {
+		return err
+	}
+
+	p.table = table
+
+	return nil
+}
+
 func (p *StatefulSetsPanel) SearchItems(query string) []SearchResult {
 	return searchByName(
 		p.statefulsets,
@@ -426,6 +504,12 @@ func (p *StatefulSetsPanel) NavigateTo(name, namespace string) bool {
 	)
 }
 
+func (p *StatefulSetsPanel) VisibleRefs() []ResourceRef {
+	return refsOf(p.filtered, func(sts appsv1.StatefulSet) ResourceRef {
+		return objectRef("StatefulSet", &sts)
+	})
+}
+
 type statefulSetsLoadedMsg struct {
 	statefulsets []appsv1.StatefulSet
 }
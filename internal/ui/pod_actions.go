@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func (m *Model) loadSchedulingDiagnosis(namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		diagnosis, err := m.k8sClient.DiagnoseScheduling(ctx, namespace, name)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to diagnose scheduling: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatSchedulingDiagnosis(diagnosis)}
+	}
+}
+
+func formatSchedulingDiagnosis(diagnosis *k8s.SchedulingDiagnosis) string {
+	var b strings.Builder
+
+	fitting := 0
+
+	nameWidth := len("NODE")
+	for _, fit := range diagnosis.Nodes {
+		nameWidth = max(nameWidth, len(fit.Node))
+
+		if fit.Fits() {
+			fitting++
+		}
+	}
+
+	fmt.Fprintf(&b, "Scheduling %s: %d/%d nodes fit\n\n", diagnosis.Pod, fitting, len(diagnosis.Nodes))
+
+	if len(diagnosis.Nodes) == 0 {
+		b.WriteString("No nodes in the cluster\n")
+	} else {
+		fmt.Fprintf(&b, "%-*s  %-4s  %s\n", nameWidth, "NODE", "FITS", "REASONS")
+	}
+
+	for _, fit := range diagnosis.Nodes {
+		if fit.Fits() {
+			fmt.Fprintf(&b, "%-*s  %-4s  -\n", nameWidth, fit.Node, "yes")
+
+			continue
+		}
+
+		// One reason per line keeps long resource explanations readable
+		for i, reason := range fit.Reasons {
+			if i == 0 {
+				fmt.Fprintf(&b, "%-*s  %-4s  %s\n", nameWidth, fit.Node, "no", reason)
+			} else {
+				fmt.Fprintf(&b, "%-*s  %-4s  %s\n", nameWidth, "", "", reason)
+			}
+		}
+	}
+
+	if fitting > 0 {
+		b.WriteString("\nThe pod fits on some nodes; the scheduler may not have retried yet,\n")
+		b.WriteString("or a constraint not simulated here (volumes, ports, preemption) applies.\n")
+	}
+
+	b.WriteString("\nFailedScheduling events:\n")
+
+	if len(diagnosis.Events) == 0 {
+		b.WriteString("  none\n")
+	}
+
+	for _, event := range diagnosis.Events {
+		fmt.Fprintf(&b, "  %s\n", event)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestLoadSchedulingDiagnosis(t *testing.T) {
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeSelector: map[string]string{"disk": "ssd"}},
+		},
+	))
+
+	_, cmd := m.Update(panels.ExplainSchedulingRequestMsg{PodName: "pending", Namespace: "default"})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+
+	report, ok := cmd().(reportLoadedMsg)
+	if !ok {
+		t.Fatalf("expected reportLoadedMsg, got %T", cmd())
+	}
+
+	for _, want := range []string{"0/1 nodes fit", "worker-1", "nodeSelector disk=ssd not matched"} {
+		if !strings.Contains(report.content, want) {
+			t.Errorf("report should contain %q:\n%s", want, report.content)
+		}
+	}
+}
+
+func TestFormatSchedulingDiagnosis(t *testing.T) {
+	out := formatSchedulingDiagnosis(&k8s.SchedulingDiagnosis{
+		Pod: "default/web",
+		Nodes: []k8s.NodeFit{
+			{Node: "a"},
+			{Node: "b", Reasons: []string{"node is cordoned", "untolerated taint gpu:NoSchedule"}},
+		},
+		Events: []string{"0/2 nodes are available"},
+	})
+
+	for _, want := range []string{
+		"1/2 nodes fit", "a     yes", "b     no    node is cordoned", "0/2 nodes are available",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report should contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// relationTreeLoadedMsg carries a built relationship tree to the x-ray view.
+type relationTreeLoadedMsg struct {
+	tree *k8s.RelationNode
+}
+
+// jumpReloadedMsg carries the reload of a panel a jump cleared the label
+// selector of, so the resource is selected once it is listed.
+type jumpReloadedMsg struct {
+	loaded          tea.Msg
+	panelIdx        int
+	name, namespace string
+}
+
+// relationPanelTitles maps kinds whose panel title isn't simply the plural
+// of the kind.
+var relationPanelTitles = map[string]string{
+	"Ingress":                 "Ingresses",
+	"HorizontalPodAutoscaler": "HPAs",
+	"NetworkPolicy":           "NetworkPolicies",
+}
+
+// relationTarget returns the kind, name and namespace of a panel item that
+// the x-ray view supports.
+func relationTarget(item any) (kind, name, namespace string, ok bool) {
+	switch v := item.(type) {
+	case *appsv1.Deployment:
+		return "Deployment", v.Name, v.Namespace, true
+	case *appsv1.StatefulSet:
+		return "StatefulSet", v.Name, v.Namespace, true
+	case *appsv1.DaemonSet:
+		return "DaemonSet", v.Name, v.Namespace, true
+	case *batchv1.Job:
+		return "Job", v.Name, v.Namespace, true
+	case *batchv1.CronJob:
+		return "CronJob", v.Name, v.Namespace, true
+	case *corev1.Pod:
+		return "Pod", v.Name, v.Namespace, true
+	case *corev1.Service:
+		return "Service", v.Name, v.Namespace, true
+	case *networkingv1.Ingress:
+		return "Ingress", v.Name, v.Namespace, true
+	case *networkingv1.NetworkPolicy:
+		return "NetworkPolicy", v.Name, v.Namespace, true
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return "HorizontalPodAutoscaler", v.Name, v.Namespace, true
+	case *corev1.ConfigMap:
+		return "ConfigMap", v.Name, v.Namespace, true
+	case *corev1.Secret:
+		return "Secret", v.Name, v.Namespace, true
+	case *corev1.ServiceAccount:
+		return "ServiceAccount", v.Name, v.Namespace, true
+	case *corev1.PersistentVolumeClaim:
+		return "PersistentVolumeClaim", v.Name, v.Namespace, true
+	}
+
+	return "", "", "", false
+}
+
+func (m *Model) loadRelationTree() tea.Cmd {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return nil
+	}
+
+	kind, name, namespace, ok := relationTarget(m.panels[m.activePanelIdx].SelectedItem())
+	if !ok {
+		m.statusBar.SetMessage("X-ray not available for this resource")
+
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		tree, err := m.k8sClient.BuildRelationTree(ctx, kind, namespace, name)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to build relationship tree: %w", err)}
+		}
+
+		return relationTreeLoadedMsg{tree: tree}
+	}
+}
+
+func relationPanelTitle(kind string) string {
+	if title, ok := relationPanelTitles[kind]; ok {
+		return title
+	}
+
+	return kind + "s"
+}
+
+// jumpToResource focuses the panel listing kind and selects the resource.
+// It serves both the x-ray view and the problems panel. A label selector
+// left the resource out of the panel's list, so the panel is reloaded
+// without it first.
+func (m *Model) jumpToResource(kind, name, namespace string) tea.Cmd {
+	title := relationPanelTitle(kind)
+
+	for idx, panel := range m.panels {
+		if panel.Title() != title {
+			continue
+		}
+
+		m.selectPanel(idx)
+		m.viewMode = ViewNormal
+
+		narrowed := panel.LabelSelector() != ""
+
+		// Clear any active per-panel filter so NavigateTo sees all items
+		panel.SetFilter("")
+		m.searchQuery = ""
+		m.searchActive = false
+
+		if !narrowed {
+			panel.NavigateTo(name, namespace)
+
+			return nil
+		}
+
+		refresh := panel.Refresh()
+
+		return func() tea.Msg {
+			return jumpReloadedMsg{loaded: refresh(), panelIdx: idx, name: name, namespace: namespace}
+		}
+	}
+
+	m.statusBar.SetMessage(fmt.Sprintf("No panel shows %s resources", kind))
+
+	return nil
+}
+
+// finishJump hands a panel its reload and then selects the resource a jump
+// was headed for.
+func (m *Model) finishJump(msg jumpReloadedMsg) tea.Cmd {
+	_, cmd := m.Update(msg.loaded)
+
+	if msg.panelIdx < len(m.panels) {
+		m.panels[msg.panelIdx].NavigateTo(msg.name, msg.namespace)
+	}
+
+	return cmd
+}
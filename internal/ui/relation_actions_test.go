@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestRelationTarget(t *testing.T) {
+	tests := []struct {
+		item any
+		kind string
+		ok   bool
+	}{
+		{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web"}}, "Deployment", true},
+		{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}, "Pod", true},
+		{&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web"}}, "Service", true},
+		{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cfg"}}, "ConfigMap", true},
+		{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}, "", false},
+		{nil, "", false},
+	}
+
+	for _, tt := range tests {
+		kind, _, _, ok := relationTarget(tt.item)
+		if kind != tt.kind || ok != tt.ok {
+			t.Errorf("relationTarget(%T) = %q, %v; want %q, %v", tt.item, kind, ok, tt.kind, tt.ok)
+		}
+	}
+}
+
+func TestRelationPanelTitle(t *testing.T) {
+	tests := map[string]string{
+		"Deployment":              "Deployments",
+		"Ingress":                 "Ingresses",
+		"HorizontalPodAutoscaler": "HPAs",
+		"PersistentVolumeClaim":   "PersistentVolumeClaims",
+	}
+
+	for kind, want := range tests {
+		if got := relationPanelTitle(kind); got != want {
+			t.Errorf("relationPanelTitle(%q) = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+func TestXRayOpensAndJumps(t *testing.T) {
+	replicas := int32(1)
+	dep := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "dep-uid"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: "web"},
+			},
+		},
+	}
+
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(&dep))
+	m.statusBar = components.NewStatusBar(m.styles)
+
+	deploysPanel, ok := m.panels[1].(*panels.DeploymentsPanel)
+	if !ok {
+		t.Fatal("expected second panel to be DeploymentsPanel")
+	}
+
+	deploysPanel.SetTestDeployments([]appsv1.Deployment{dep})
+	deploysPanel.SetFilter("")
+	m.selectPanel(1)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if cmd == nil {
+		t.Fatal("expected a command loading the relationship tree")
+	}
+
+	updated.(*Model).Update(cmd())
+
+	if m.viewMode != ViewXRay {
+		t.Fatalf("viewMode = %d, want ViewXRay", m.viewMode)
+	}
+
+	if node := m.relationView.SelectedNode(); node == nil || node.Name != "web" {
+		t.Fatalf("expected root node web, got %+v", node)
+	}
+
+	podsPanel, ok := m.panels[0].(*panels.PodsPanel)
+	if !ok {
+		t.Fatal("expected first panel to be PodsPanel")
+	}
+
+	podsPanel.SetTestPods([]corev1.Pod{pod})
+	podsPanel.SetFilter("")
+
+	m.Update(components.RelationJumpMsg{Kind: "Pod", Name: "web-1", Namespace: "default"})
+
+	if m.viewMode != ViewNormal || m.activePanelIdx != 0 {
+		t.Errorf("after jump viewMode = %d, panel = %d; want normal view on pods", m.viewMode, m.activePanelIdx)
+	}
+
+	if podsPanel.SelectedName() != "web-1" {
+		t.Errorf("selected pod = %q, want web-1", podsPanel.SelectedName())
+	}
+}
+
+func TestXRayUnsupportedResource(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+
+	if cmd := m.loadRelationTree(); cmd != nil {
+		t.Error("expected no command without a selected resource")
+	}
+}
+
+func TestJumpToResourceFromProblems(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+
+	m.Update(panels.JumpToResourceMsg{Kind: "Deployment", Name: "web", Namespace: "default"})
+
+	if m.activePanelIdx != 1 {
+		t.Errorf("activePanelIdx = %d, want the deployments panel", m.activePanelIdx)
+	}
+
+	m.Update(panels.JumpToResourceMsg{Kind: "PersistentVolumeClaim", Name: "data", Namespace: "default"})
+
+	if m.activePanelIdx != 1 {
+		t.Error("jumping to a kind without a panel should keep the current panel")
+	}
+}
+
+func TestJumpToResourceClearsLabelSelector(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "api", Namespace: "default", Labels: map[string]string{"app": "api"},
+		}},
+	))
+
+	panel := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	panel.SetFilter("label:app=web")
+	panel.Update(panel.Refresh()())
+
+	m.panels = []panels.Panel{panel}
+
+	_, cmd := m.Update(panels.JumpToResourceMsg{Kind: "Deployment", Name: "api", Namespace: "default"})
+	if cmd == nil {
+		t.Fatal("a label selector hiding the resource should reload the panel")
+	}
+
+	m.Update(cmd())
+
+	if panel.LabelSelector() != "" {
+		t.Errorf("the jump should clear the label selector, got %q", panel.LabelSelector())
+	}
+
+	if panel.SelectedName() != "api" {
+		t.Errorf("selected deployment = %q, want api", panel.SelectedName())
+	}
+}
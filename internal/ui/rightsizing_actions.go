@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
+)
+
+var ErrNoUsageHistory = errors.New("right-sizing needs metrics-server to sample usage")
+
+// rightsizingLoadedMsg carries a recommendation shown as a diff of the
+// current requests against the suggested ones.
+type rightsizingLoadedMsg struct {
+	rec *k8s.WorkloadRecommendation
+}
+
+// usageHistoryLoadedMsg carries the usage history restored from path.
+type usageHistoryLoadedMsg struct {
+	path    string
+	history *k8s.UsageHistory
+}
+
+// initUsageHistory restores the usage history of the current context. A
+// corrupt or unreadable file starts an empty history rather than blocking
+// startup.
+func (m *Model) initUsageHistory() {
+	samples := m.config.Metrics.HistorySamples
+	m.usageHistoryPath = m.usageHistoryFile()
+
+	if m.usageHistoryPath != "" {
+		if history, err := k8s.LoadUsageHistory(m.usageHistoryPath, samples); err == nil {
+			m.usageHistory = history
+
+			return
+		}
+	}
+
+	m.usageHistory = k8s.NewUsageHistory(samples)
+}
+
+// usageHistoryFile is where the current context's usage history is kept,
+// or "" when it isn't persisted.
+func (m *Model) usageHistoryFile() string {
+	if !m.config.Metrics.PersistHistory {
+		return ""
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "lazy-k8s", "usage-"+url.PathEscape(m.k8sClient.CurrentContext())+".json")
+}
+
+// switchUsageHistory follows a context switch: metrics come from the new
+// cluster, the history of the context left is saved and the new context's
+// is restored, both in the background. Samples taken before it arrives go
+// to a fresh history that the restored one replaces.
+func (m *Model) switchUsageHistory() tea.Cmd {
+	if m.metricsClient == nil {
+		return nil
+	}
+
+	old, oldPath := m.usageHistory, m.usageHistoryPath
+
+	metricsClient, err := m.k8sClient.NewMetricsClient()
+	if err != nil {
+		m.metricsClient, m.usageHistory, m.usageHistoryPath = nil, nil, ""
+	} else {
+		m.metricsClient = metricsClient
+		m.usageHistory = k8s.NewUsageHistory(m.config.Metrics.HistorySamples)
+		m.usageHistoryPath = m.usageHistoryFile()
+	}
+
+	path, samples := m.usageHistoryPath, m.config.Metrics.HistorySamples
+
+	return func() tea.Msg {
+		if old != nil && oldPath != "" {
+			if err := old.Save(oldPath); err != nil {
+				return panels.ErrorMsg{Error: fmt.Errorf("failed to save usage history: %w", err)}
+			}
+		}
+
+		if path == "" {
+			return nil
+		}
+
+		history, err := k8s.LoadUsageHistory(path, samples)
+		if err != nil {
+			return nil
+		}
+
+		return usageHistoryLoadedMsg{path: path, history: history}
+	}
+}
+
+// SaveUsageHistory persists the usage history when enabled in the config.
+func (m *Model) SaveUsageHistory() error {
+	if m.usageHistory == nil || m.usageHistoryPath == "" {
+		return nil
+	}
+
+	return m.usageHistory.Save(m.usageHistoryPath)
+}
+
+func (m *Model) loadRightsizing(msg panels.RightsizeRequestMsg) tea.Cmd {
+	usageHistory := m.usageHistory
+
+	return func() tea.Msg {
+		if usageHistory == nil {
+			return panels.ErrorMsg{Error: ErrNoUsageHistory}
+		}
+
+		ctx := context.Background()
+
+		rec, err := m.k8sClient.RecommendWorkload(ctx, msg.Kind, msg.Namespace, msg.Name, usageHistory)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to load right-sizing: %w", err)}
+		}
+
+		return rightsizingLoadedMsg{rec: rec}
+	}
+}
+
+// showRightsizing opens the recommendation in the diff viewer, where "a"
+// applies it when any request changes.
+func (m *Model) showRightsizing(rec *k8s.WorkloadRecommendation) {
+	m.diffView.SetContent(
+		fmt.Sprintf("Right-size %s %s/%s (current → suggested)", rec.Kind, rec.Namespace, rec.Name),
+		formatRightsizing(rec, false),
+		formatRightsizing(rec, true),
+	)
+	m.viewMode = ViewDiff
+
+	if rec.Changed() {
+		m.diffView.SetActionHint("a apply")
+		m.diffApply = func() tea.Cmd { return m.confirmRightsizing(rec) }
+	}
+}
+
+func (m *Model) confirmRightsizing(rec *k8s.WorkloadRecommendation) tea.Cmd {
+	m.confirm.Show(
+		"Apply Right-sizing",
+		fmt.Sprintf("Set the suggested requests on %s %s/%s? This rolls out new pods.",
+			rec.Kind, rec.Namespace, rec.Name),
+		func() tea.Cmd {
+			return m.applyRightsizing(rec)
+		},
+	)
+	m.viewMode = ViewConfirm
+
+	return nil
+}
+
+func (m *Model) applyRightsizing(rec *k8s.WorkloadRecommendation) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if err := m.k8sClient.ApplyRightsizing(ctx, rec); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to apply right-sizing: %w", err)}
+		}
+
+		var changed []string
+
+		for _, c := range rec.Containers {
+			if c.Changed() {
+				changed = append(changed, fmt.Sprintf("%s %s/%s", c.Container,
+					formatMilliCPU(c.SuggestedCPURequest), formatMemoryBytes(c.SuggestedMemoryRequest)))
+			}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpRightsize,
+			Resource:  rec.Name,
+			Namespace: rec.Namespace,
+			Message:   fmt.Sprintf("Set requests of %s %s: %s", rec.Kind, rec.Name, strings.Join(changed, ", ")),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Right-sized %s %s", rec.Kind, rec.Name),
+		}
+	}
+}
+
+// formatRightsizing renders the container requests as YAML, either current
+// or suggested. Everything but the requests is shared by both sides so the
+// diff only highlights what would change.
+func formatRightsizing(rec *k8s.WorkloadRecommendation, suggested bool) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Suggested requests are p95 usage plus 15%% headroom, given %d+ samples\n",
+		k8s.MinRightsizingSamples)
+
+	if !rec.Changed() {
+		b.WriteString("# Requests already match observed usage\n")
+	}
+
+	b.WriteString("containers:\n")
+
+	for _, c := range rec.Containers {
+		fmt.Fprintf(&b, "- name: %s\n", c.Container)
+
+		if c.Samples < k8s.MinRightsizingSamples {
+			fmt.Fprintf(&b, "  # not enough samples (%d of %d)\n", c.Samples, k8s.MinRightsizingSamples)
+		} else {
+			fmt.Fprintf(&b, "  # %d samples: cpu p95 %s, memory p95 %s, memory peak %s\n", c.Samples,
+				utils.FormatCPU(c.CPUP95), utils.FormatMemory(c.MemoryP95), utils.FormatMemory(c.MemoryPeak))
+		}
+
+		for _, flag := range c.Flags {
+			fmt.Fprintf(&b, "  # %s\n", flag)
+		}
+
+		cpu, memory := c.CPURequest, c.MemoryRequest
+		if suggested && c.Changed() {
+			cpu, memory = c.SuggestedCPURequest, c.SuggestedMemoryRequest
+		}
+
+		b.WriteString("  requests:\n")
+		fmt.Fprintf(&b, "    cpu: %s\n", formatMilliCPU(cpu))
+		fmt.Fprintf(&b, "    memory: %s\n", formatMemoryBytes(memory))
+		b.WriteString("  limits:\n")
+		fmt.Fprintf(&b, "    cpu: %s\n", formatMilliCPU(c.CPULimit))
+		fmt.Fprintf(&b, "    memory: %s\n", formatMemoryBytes(c.MemoryLimit))
+	}
+
+	return b.String()
+}
+
+// formatMilliCPU renders millicores as the quantity the patch would set;
+// unset values show as "-".
+func formatMilliCPU(v int64) string {
+	if v == 0 {
+		return "-"
+	}
+
+	return resource.NewMilliQuantity(v, resource.DecimalSI).String()
+}
+
+func formatMemoryBytes(v int64) string {
+	if v == 0 {
+		return "-"
+	}
+
+	return resource.NewQuantity(v, resource.BinarySI).String()
+}
@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func createRightsizingTestModel() *Model {
+	labels := map[string]string{"app": "web"}
+
+	m := createTestModel()
+	m.confirm = components.NewConfirm(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}},
+				}}},
+			},
+		},
+	}))
+
+	m.usageHistory = k8s.NewUsageHistory(100)
+	for range 20 {
+		m.usageHistory.Record(time.Now(), []k8s.ContainerMetrics{
+			{Namespace: "default", Pod: "web-1", Container: "app", Labels: labels, CPU: 100, Memory: 200 * 1024 * 1024},
+		})
+	}
+
+	return m
+}
+
+func TestRightsizingFlow(t *testing.T) {
+	m := createRightsizingTestModel()
+
+	_, cmd := m.Update(panels.RightsizeRequestMsg{Kind: "Deployment", Name: "web", Namespace: "default"})
+	if cmd == nil {
+		t.Fatal("expected a command loading the recommendation")
+	}
+
+	loaded, ok := cmd().(rightsizingLoadedMsg)
+	if !ok {
+		t.Fatal("expected rightsizingLoadedMsg")
+	}
+
+	m.Update(loaded)
+
+	if m.viewMode != ViewDiff || m.diffApply == nil {
+		t.Fatalf("expected an applicable diff, got view %d", m.viewMode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+
+	if m.viewMode != ViewConfirm {
+		t.Fatalf("expected ViewConfirm, got %d", m.viewMode)
+	}
+
+	if result := m.confirm.Action()(); result != (panels.StatusWithRefreshMsg{Message: "Right-sized Deployment web"}) {
+		t.Fatalf("expected StatusWithRefreshMsg, got %+v", result)
+	}
+
+	deploy, _ := m.k8sClient.GetDeployment(t.Context(), "default", "web")
+	if cpu := deploy.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]; cpu.String() != "115m" {
+		t.Errorf("cpu request = %s, want 115m", cpu.String())
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpRightsize {
+		t.Errorf("expected an OpRightsize history record, got %+v", rec)
+	}
+
+	// A plain diff afterwards can't be applied
+	m.Update(diffLoadedMsg{title: "other", oldYAML: "a\n", newYAML: "b\n"})
+
+	if m.diffApply != nil {
+		t.Error("diffLoadedMsg should clear diffApply")
+	}
+}
+
+func TestRightsizingWithoutMetrics(t *testing.T) {
+	m := createTestModel()
+
+	result := m.loadRightsizing(panels.RightsizeRequestMsg{Kind: "Deployment", Name: "web", Namespace: "default"})()
+
+	errMsg, ok := result.(panels.ErrorMsg)
+	if !ok || !errors.Is(errMsg.Error, ErrNoUsageHistory) {
+		t.Fatalf("expected ErrNoUsageHistory, got %+v", result)
+	}
+}
+
+func TestFormatRightsizing(t *testing.T) {
+	rec := &k8s.WorkloadRecommendation{Kind: "Deployment", Name: "web", Namespace: "default"}
+	rec.Containers = []k8s.ContainerRecommendation{
+		{
+			Container: "app", Samples: 20, CPUP95: 100, MemoryP95: 200 * 1024 * 1024, MemoryPeak: 210 * 1024 * 1024,
+			CPURequest: 1000, MemoryRequest: 1024 * 1024 * 1024,
+			SuggestedCPURequest: 115, SuggestedMemoryRequest: 230 * 1024 * 1024,
+			Flags: []k8s.RightsizingFlag{k8s.FlagOverProvisioned},
+		},
+		{Container: "sidecar", Samples: 3},
+	}
+
+	current := formatRightsizing(rec, false)
+	suggested := formatRightsizing(rec, true)
+
+	for _, want := range []string{"cpu: 1\n", "memory: 1Gi\n", "# over-provisioned", "not enough samples (3 of 10)"} {
+		if !strings.Contains(current, want) {
+			t.Errorf("current should contain %q:\n%s", want, current)
+		}
+	}
+
+	for _, want := range []string{"cpu: 115m\n", "memory: 230Mi\n"} {
+		if !strings.Contains(suggested, want) {
+			t.Errorf("suggested should contain %q:\n%s", want, suggested)
+		}
+	}
+
+	currentLines := strings.Split(current, "\n")
+	suggestedLines := strings.Split(suggested, "\n")
+
+	if len(currentLines) != len(suggestedLines) {
+		t.Fatal("both sides should have the same lines")
+	}
+
+	var changed int
+
+	for i := range currentLines {
+		if currentLines[i] != suggestedLines[i] {
+			changed++
+		}
+	}
+
+	if changed != 2 {
+		t.Errorf("only the two request lines should differ, got %d", changed)
+	}
+}
+
+func TestUsageHistoryFollowsContext(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: one
+  cluster: {server: "https://127.0.0.1:6443"}
+- name: two
+  cluster: {server: "https://127.0.0.2:6443"}
+contexts:
+- name: one
+  context: {cluster: one, user: test}
+- name: two
+  context: {cluster: two, user: test}
+users:
+- name: test
+  user: {token: test}
+current-context: one
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUBECONFIG", kubeconfig)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	m := createTestModel()
+	m.config = &config.Config{Metrics: config.MetricsConfig{PersistHistory: true, HistorySamples: 10}}
+	m.metricsClient = &k8s.MetricsClient{}
+
+	switchTo := func(kubeContext string) {
+		t.Helper()
+
+		if err := m.k8sClient.SwitchContext(kubeContext); err != nil {
+			t.Fatal(err)
+		}
+
+		if msg := m.switchUsageHistory()(); msg != nil {
+			m.Update(msg)
+		}
+	}
+
+	labels := map[string]string{"app": "web"}
+	selector := &metav1.LabelSelector{MatchLabels: labels}
+
+	switchTo("one")
+	m.usageHistory.Record(time.Now(), []k8s.ContainerMetrics{
+		{Namespace: "default", Pod: "web-1", Container: "app", Labels: labels, CPU: 100},
+	})
+
+	switchTo("two")
+
+	if !strings.HasSuffix(m.usageHistoryPath, "usage-two.json") {
+		t.Errorf("the history should be kept per context, path %q", m.usageHistoryPath)
+	}
+
+	if samples := m.usageHistory.WorkloadSamples("default", selector, "app"); len(samples) != 0 {
+		t.Errorf("another context should start without the samples, got %d", len(samples))
+	}
+
+	switchTo("one")
+
+	if samples := m.usageHistory.WorkloadSamples("default", selector, "app"); len(samples) != 1 {
+		t.Errorf("switching back should restore the context's samples, got %d", len(samples))
+	}
+}
+
+func TestFetchMetricsRecordsIntoItsOwnHistory(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+
+	fakeMetrics := metricsfake.NewSimpleClientset()
+	fakeMetrics.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: labels},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Name: "app", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}},
+		}}}, nil
+	})
+
+	m := createTestModel()
+	m.metricsClient = k8s.NewTestMetricsClient(fakeMetrics)
+	m.usageHistory = k8s.NewUsageHistory(10)
+	started := m.usageHistory
+
+	fetch := m.fetchMetrics()
+
+	// A context switch while the fetch is running
+	m.metricsClient, m.usageHistory = nil, k8s.NewUsageHistory(10)
+
+	fetch()
+
+	selector := &metav1.LabelSelector{MatchLabels: labels}
+	if samples := started.WorkloadSamples("default", selector, "app"); len(samples) != 1 {
+		t.Errorf("the fetch should record into the history it started with, got %d samples", len(samples))
+	}
+
+	if samples := m.usageHistory.WorkloadSamples("default", selector, "app"); len(samples) != 0 {
+		t.Errorf("the new context's history should stay empty, got %d samples", len(samples))
+	}
+}
@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+var (
+	ErrInvalidSecretType = errors.New("secret type must be generic, docker-registry or tls")
+	ErrEmptyName         = errors.New("name must not be empty")
+)
+
+const (
+	secretTypeGeneric        = "generic"
+	secretTypeDockerRegistry = "docker-registry"
+	secretTypeTLS            = "tls"
+
+	defaultDockerServer = "https://index.docker.io/v1/"
+)
+
+func (m *Model) copySecretValue(msg panels.CopySecretValueRequestMsg) {
+	if err := clipboard.WriteAll(string(msg.Value)); err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Failed to copy: %v", err))
+
+		return
+	}
+
+	m.statusBar.SetMessage(
+		fmt.Sprintf("Copied value of '%s' from %s to clipboard", msg.Key, msg.SecretName),
+	)
+}
+
+// editSecretKey opens the decoded value of a single secret key in the
+// user's editor and writes it back when it changed.
+func (m *Model) editSecretKey(msg panels.EditSecretKeyRequestMsg) tea.Cmd {
+	return m.editValue(
+		fmt.Sprintf("lazy-k8s-%s-%s-*", msg.SecretName, msg.Key),
+		msg.Value,
+		fmt.Sprintf("No changes to %s in %s", msg.Key, msg.SecretName),
+		func(value []byte) tea.Msg {
+			return m.saveSecretKey(msg.Namespace, msg.SecretName, msg.Key, value)
+		},
+	)
+}
+
+func (m *Model) saveSecretKey(namespace, name, key string, value []byte) tea.Msg {
+	ctx := context.Background()
+
+	if err := m.k8sClient.SetSecretKey(ctx, namespace, name, key, value); err != nil {
+		return panels.ErrorMsg{Error: fmt.Errorf("failed to update secret: %w", err)}
+	}
+
+	m.historyStore.Add(components.OperationRecord{
+		Type:      components.OpEditSecret,
+		Resource:  name,
+		Namespace: namespace,
+		Message:   fmt.Sprintf("Updated key %s in secret %s", key, name),
+	})
+
+	return panels.StatusWithRefreshMsg{
+		Message: fmt.Sprintf("Updated %s in secret %s", key, name),
+	}
+}
+
+// startCreateSecret walks the user through a chain of input prompts:
+// secret type, name, then the type-specific data.
+func (m *Model) startCreateSecret(namespace string) {
+	m.showInput(
+		"Create Secret",
+		"Secret type: generic, docker-registry or tls",
+		secretTypeGeneric,
+		func(value string) tea.Cmd {
+			secretType := strings.TrimSpace(value)
+			if secretType == "" {
+				secretType = secretTypeGeneric
+			}
+
+			switch secretType {
+			case secretTypeGeneric, secretTypeDockerRegistry, secretTypeTLS:
+			default:
+				return errorCmd(ErrInvalidSecretType)
+			}
+
+			m.promptSecretName(namespace, secretType)
+
+			return nil
+		},
+	)
+	m.input.SetValue(secretTypeGeneric)
+}
+
+func (m *Model) promptSecretName(namespace, secretType string) {
+	m.showInput(
+		"Create Secret",
+		fmt.Sprintf("Name of the new %s secret in %s", secretType, namespace),
+		"my-secret",
+		func(value string) tea.Cmd {
+			name := strings.TrimSpace(value)
+			if name == "" {
+				return errorCmd(ErrEmptyName)
+			}
+
+			switch secretType {
+			case secretTypeDockerRegistry:
+				m.promptDockerRegistrySecret(namespace, name)
+			case secretTypeTLS:
+				m.promptTLSSecret(namespace, name)
+			default:
+				m.promptGenericSecret(namespace, name)
+			}
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) promptGenericSecret(namespace, name string) {
+	m.showInput(
+		"Create Secret: "+name,
+		"Data as key=value, key=@file or @file (space separated)",
+		"username=admin password=@./password.txt",
+		func(value string) tea.Cmd {
+			data, err := k8s.ReadDataSources(value)
+			if err != nil {
+				return errorCmd(err)
+			}
+
+			return m.createSecret(k8s.NewGenericSecret(namespace, name, data))
+		},
+	)
+}
+
+func (m *Model) promptDockerRegistrySecret(namespace, name string) {
+	m.showInput(
+		"Create Secret: "+name,
+		"Registry server",
+		defaultDockerServer,
+		func(server string) tea.Cmd {
+			server = strings.TrimSpace(server)
+			if server == "" {
+				server = defaultDockerServer
+			}
+
+			m.showInput("Create Secret: "+name, "Registry username", "", func(username string) tea.Cmd {
+				username = strings.TrimSpace(username)
+
+				m.showInput("Create Secret: "+name, "Registry password", "", func(password string) tea.Cmd {
+					m.showInput(
+						"Create Secret: "+name,
+						"Email (optional)",
+						"",
+						func(email string) tea.Cmd {
+							secret, err := k8s.NewDockerRegistrySecret(
+								namespace, name, server, username, password,
+								strings.TrimSpace(email),
+							)
+							if err != nil {
+								return errorCmd(err)
+							}
+
+							return m.createSecret(secret)
+						},
+					)
+
+					return nil
+				})
+				m.input.SetMasked(true)
+
+				return nil
+			})
+
+			return nil
+		},
+	)
+	m.input.SetValue(defaultDockerServer)
+}
+
+func (m *Model) promptTLSSecret(namespace, name string) {
+	m.showInput(
+		"Create Secret: "+name,
+		"Path to the PEM-encoded certificate",
+		"./tls.crt",
+		func(certPath string) tea.Cmd {
+			cert, err := os.ReadFile(strings.TrimSpace(certPath))
+			if err != nil {
+				return errorCmd(fmt.Errorf("failed to read certificate: %w", err))
+			}
+
+			m.showInput(
+				"Create Secret: "+name,
+				"Path to the PEM-encoded private key",
+				"./tls.key",
+				func(keyPath string) tea.Cmd {
+					key, err := os.ReadFile(strings.TrimSpace(keyPath))
+					if err != nil {
+						return errorCmd(fmt.Errorf("failed to read private key: %w", err))
+					}
+
+					secret, err := k8s.NewTLSSecret(namespace, name, cert, key)
+					if err != nil {
+						return errorCmd(err)
+					}
+
+					return m.createSecret(secret)
+				},
+			)
+
+			return nil
+		},
+	)
+}
+
+func (m *Model) createSecret(secret *corev1.Secret) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if _, err := m.k8sClient.CreateSecret(ctx, secret); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to create secret: %w", err)}
+		}
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpCreateSecret,
+			Resource:  secret.Name,
+			Namespace: secret.Namespace,
+			Message: fmt.Sprintf(
+				"Created %s secret %s with %d keys",
+				secret.Type, secret.Name, len(secret.Data),
+			),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Created secret: %s", secret.Name),
+		}
+	}
+}
+
+func errorCmd(err error) tea.Cmd {
+	return func() tea.Msg {
+		return panels.ErrorMsg{Error: err}
+	}
+}
+
+// loadExpiringCertificates builds the cluster-wide report of TLS secrets
+// whose certificates are expired, expiring within threshold or unreadable.
+func (m *Model) loadExpiringCertificates(threshold time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		report, err := m.k8sClient.ListExpiringCertificates(ctx, threshold)
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("failed to list certificates: %w", err)}
+		}
+
+		return reportLoadedMsg{content: formatCertificateReport(report, threshold, time.Now())}
+	}
+}
+
+func formatCertificateReport(
+	report []k8s.ExpiringCertificate,
+	threshold time.Duration,
+	now time.Time,
+) string {
+	days := int(threshold.Hours() / 24)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "TLS certificates expired or expiring within %d days\n\n", days)
+
+	if len(report) == 0 {
+		b.WriteString("No TLS certificates need attention.\n")
+
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%-20s %-40s %-12s %-20s %s\n", "NAMESPACE", "NAME", "STATUS", "NOT AFTER", "SUBJECT")
+
+	for _, cert := range report {
+		if cert.Err != nil {
+			fmt.Fprintf(&b, "%-20s %-40s %-12s %-20s %v\n",
+				cert.Namespace, cert.Name, "UNREADABLE", "-", cert.Err)
+
+			continue
+		}
+
+		status := fmt.Sprintf("%dd left", cert.Info.DaysUntilExpiry(now))
+
+		switch cert.Info.Expiry(now, threshold) {
+		case k8s.CertExpired:
+			status = "EXPIRED"
+		case k8s.CertNotYetValid:
+			status = "NOT VALID"
+		case k8s.CertValid, k8s.CertExpiringSoon:
+		}
+
+		fmt.Fprintf(&b, "%-20s %-40s %-12s %-20s %s\n",
+			cert.Namespace, cert.Name, status,
+			cert.Info.NotAfter.UTC().Format(time.RFC3339), cert.Info.Subject)
+	}
+
+	return b.String()
+}
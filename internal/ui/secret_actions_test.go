@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// submitInput simulates the user pressing enter on the current prompt.
+func submitInput(t *testing.T, m *Model, value string) any {
+	t.Helper()
+
+	if m.viewMode != ViewInput {
+		t.Fatalf("expected ViewInput before submitting %q, got %d", value, m.viewMode)
+	}
+
+	_, cmd := m.Update(components.InputSubmitMsg{Value: value})
+	if cmd == nil {
+		return nil
+	}
+
+	return cmd()
+}
+
+func TestCreateGenericSecretFlow(t *testing.T) {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+
+	m.Update(panels.CreateSecretRequestMsg{Namespace: "default"})
+
+	submitInput(t, m, "generic")
+	submitInput(t, m, "app-creds")
+	result := submitInput(t, m, "user=admin pass=hunter2")
+
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T", result)
+	}
+
+	secret, err := m.k8sClient.GetSecret(t.Context(), "default", "app-creds")
+	if err != nil {
+		t.Fatalf("secret was not created: %v", err)
+	}
+
+	if string(secret.Data["pass"]) != "hunter2" {
+		t.Errorf("pass = %q, want %q", secret.Data["pass"], "hunter2")
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpCreateSecret {
+		t.Errorf("expected an OpCreateSecret history record, got %+v", rec)
+	}
+}
+
+func TestCreateSecretInvalidType(t *testing.T) {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+
+	m.Update(panels.CreateSecretRequestMsg{Namespace: "default"})
+
+	result := submitInput(t, m, "bogus")
+
+	errMsg, ok := result.(panels.ErrorMsg)
+	if !ok {
+		t.Fatalf("expected ErrorMsg, got %T", result)
+	}
+
+	if !errors.Is(errMsg.Error, ErrInvalidSecretType) {
+		t.Errorf("expected ErrInvalidSecretType, got %v", errMsg.Error)
+	}
+
+	if m.viewMode != ViewNormal {
+		t.Errorf("flow should end after an invalid type, viewMode = %d", m.viewMode)
+	}
+}
+
+func TestCreateDockerRegistrySecretFlow(t *testing.T) {
+	m := createTestModel()
+	m.input = components.NewInput(m.styles)
+
+	m.Update(panels.CreateSecretRequestMsg{Namespace: "default"})
+
+	submitInput(t, m, "docker-registry")
+	submitInput(t, m, "regcred")
+	submitInput(t, m, "")
+	submitInput(t, m, "bot")
+	submitInput(t, m, "s3cret")
+	result := submitInput(t, m, "")
+
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T", result)
+	}
+
+	secret, err := m.k8sClient.GetSecret(t.Context(), "default", "regcred")
+	if err != nil {
+		t.Fatalf("secret was not created: %v", err)
+	}
+
+	if secret.Type != corev1.SecretTypeDockerConfigJson {
+		t.Errorf("Type = %q, want %q", secret.Type, corev1.SecretTypeDockerConfigJson)
+	}
+}
+
+func TestSaveSecretKeyRecordsHistory(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("old")},
+		},
+	)
+	m := createTestModel()
+	m.k8sClient = k8s.NewTestClient(fakeClientset)
+
+	result := m.saveSecretKey("default", "app", "token", []byte("new"))
+
+	if _, ok := result.(panels.StatusWithRefreshMsg); !ok {
+		t.Fatalf("expected StatusWithRefreshMsg, got %T", result)
+	}
+
+	rec, ok := m.historyStore.Get(0)
+	if !ok || rec.Type != components.OpEditSecret {
+		t.Fatalf("expected an OpEditSecret history record, got %+v", rec)
+	}
+
+	if rec.Resource != "app" {
+		t.Errorf("Resource = %q, want %q", rec.Resource, "app")
+	}
+}
+
+func TestEditSecretKeyDropsEditorNewline(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		saved    string
+		want     string
+	}{
+		{"added newline", "old", "new\n", "new"},
+		{"added CRLF", "old", "new\r\n", "new"},
+		{"value ends in a newline", "old\n", "new\n", "new\n"},
+		{"unchanged but for the newline", "old", "old\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "value")
+			if err := os.WriteFile(path, []byte(tt.saved), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got := ""
+			msg := finishEdit(path, []byte(tt.original), "unchanged", func(value []byte) tea.Msg {
+				got = string(value)
+
+				return nil
+			})
+
+			if got != tt.want {
+				t.Errorf("saved %q, want %q", got, tt.want)
+			}
+
+			if tt.want == "" && msg != (panels.StatusMsg{Message: "unchanged"}) {
+				t.Errorf("expected the unchanged status, got %+v", msg)
+			}
+		})
+	}
+}
+
+func TestSaveSecretKeyErrorSkipsHistory(t *testing.T) {
+	m := createTestModel()
+
+	result := m.saveSecretKey("default", "missing", "token", []byte("new"))
+
+	if _, ok := result.(panels.ErrorMsg); !ok {
+		t.Fatalf("expected ErrorMsg, got %T", result)
+	}
+
+	if m.historyStore.Len() != 0 {
+		t.Error("history should not be recorded on error")
+	}
+}
+
+func TestFormatCertificateReport(t *testing.T) {
+	now := time.Now()
+	report := []k8s.ExpiringCertificate{
+		{Namespace: "default", Name: "broken", Err: k8s.ErrNoCertificatePEM},
+		{
+			Namespace: "web",
+			Name:      "old-tls",
+			Info: &k8s.CertificateInfo{
+				Subject:   "CN=old.example.com",
+				NotBefore: now.Add(-48 * time.Hour),
+				NotAfter:  now.Add(-time.Hour),
+			},
+		},
+		{
+			Namespace: "web",
+			Name:      "soon-tls",
+			Info: &k8s.CertificateInfo{
+				Subject:   "CN=soon.example.com",
+				NotBefore: now.Add(-time.Hour),
+				NotAfter:  now.Add(3*24*time.Hour + time.Hour),
+			},
+		},
+	}
+
+	out := formatCertificateReport(report, 30*24*time.Hour, now)
+
+	for _, want := range []string{"within 30 days", "UNREADABLE", "EXPIRED", "3d left", "CN=soon.example.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report should contain %q:\n%s", want, out)
+		}
+	}
+
+	empty := formatCertificateReport(nil, 7*24*time.Hour, now)
+	if !strings.Contains(empty, "No TLS certificates need attention") {
+		t.Errorf("empty report should say so, got:\n%s", empty)
+	}
+}
+
+func TestExpiringCertificatesRequestShowsReport(t *testing.T) {
+	m := createTestModel()
+	m.yamlView = components.NewYamlViewer(m.styles)
+
+	_, cmd := m.Update(panels.ExpiringCertificatesRequestMsg{Threshold: 24 * time.Hour})
+	if cmd == nil {
+		t.Fatal("expected a command to load the report")
+	}
+
+	m.Update(cmd())
+
+	if m.viewMode != ViewYaml {
+		t.Errorf("viewMode = %d, want ViewYaml", m.viewMode)
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// applySavedSort restores a panel's sort from the config, which keys them
+// by the panel's name in panels.visible.
+func (m *Model) applySavedSort(panel panels.Panel) {
+	key, order, ok := panelSetting(m.config.Panels.Sort, panel.Title())
+	if !ok {
+		return
+	}
+
+	if err := panel.SetSortOrder(order); err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Config panels.sort.%s: %v", key, err))
+	}
+}
+
+// panelConfigKey is the key of a panel's own settings in the config: its
+// name in panels.visible and the command prompt.
+func panelConfigKey(title string) string {
+	for _, kind := range panelKinds {
+		if kind.title == title {
+			return kind.name
+		}
+	}
+
+	return strings.ToLower(title)
+}
+
+// panelSetting looks up a panel's setting under its config key, or under
+// one of the aliases the command prompt accepts for it.
+func panelSetting[T any](settings map[string]T, title string) (string, T, bool) {
+	key := panelConfigKey(title)
+	if value, ok := settings[key]; ok {
+		return key, value, true
+	}
+
+	if kind, ok := findPanelKind(key); ok {
+		for _, alias := range kind.aliases {
+			if value, ok := settings[alias]; ok {
+				return alias, value, true
+			}
+		}
+	}
+
+	var zero T
+
+	return key, zero, false
+}
+
+// cycleSort moves the active panel's sort step columns along, or flips its
+// direction when reverse is set, and saves the result.
+func (m *Model) cycleSort(step int, reverse bool) tea.Cmd {
+	if len(m.panels) == 0 || m.activePanelIdx >= len(m.panels) {
+		return nil
+	}
+
+	panel := m.panels[m.activePanelIdx]
+	if len(panel.SortColumns()) == 0 {
+		return nil
+	}
+
+	order := panels.NextSortOrder(panel, step)
+	if reverse {
+		order = panels.ReversedSortOrder(panel)
+	}
+
+	if err := panel.SetSortOrder(order); err != nil {
+		m.statusBar.SetError(err.Error())
+
+		return nil
+	}
+
+	return m.saveSort(panel.Title(), order)
+}
+
+// saveSort reports the new order and persists it in the background; a
+// config that can't be written only costs the order on the next start.
+func (m *Model) saveSort(title, order string) tea.Cmd {
+	message := title + ": API order"
+	if order != "" {
+		message = fmt.Sprintf("%s: sorted by %s", title, order)
+	}
+
+	m.statusBar.SetMessage(message)
+
+	if m.config == nil {
+		return nil
+	}
+
+	cfg, key := m.config, panelConfigKey(title)
+	cfg.SetPanelSort(key, order)
+
+	return func() tea.Msg {
+		if err := cfg.SavePanelSort(key); err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("%s not saved: %w", message, err)}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/config"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+func TestSortKeysPersistPerPanel(t *testing.T) {
+	m, panel := createBulkTestModel(t)
+	m.config = &config.Config{File: filepath.Join(t.TempDir(), "config.yaml")}
+
+	_, save := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	save()
+
+	if panel.SortOrder() != "name asc" {
+		t.Fatalf("expected name asc, got %q", panel.SortOrder())
+	}
+
+	_, save = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	save()
+
+	if panel.SortOrder() != "name desc" {
+		t.Fatalf("expected name desc, got %q", panel.SortOrder())
+	}
+
+	data, err := os.ReadFile(m.config.File)
+	if err != nil || !strings.Contains(string(data), "deployments: name desc") {
+		t.Fatalf("the sort should be saved to the config, got %q (%v)", data, err)
+	}
+
+	// A new session picks the saved sort back up
+	restored := createTestModel()
+	restored.statusBar = components.NewStatusBar(restored.styles)
+	restored.config = &config.Config{Panels: config.PanelsConfig{Sort: map[string]string{
+		"deployments": "name desc",
+		"pods":        "colour",
+	}}}
+	for _, panel := range restored.panels {
+		restored.applySavedSort(panel)
+	}
+
+	if got := restored.panels[1].SortOrder(); got != "name desc" {
+		t.Errorf("restored sort = %q, want name desc", got)
+	}
+
+	if !strings.Contains(restored.statusBar.View(200), "unknown sort column") {
+		t.Error("an invalid saved sort should be reported")
+	}
+}
+
+func TestSortChangedMsgSaves(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.config = &config.Config{File: filepath.Join(t.TempDir(), "config.yaml")}
+
+	_, save := m.Update(panels.SortChangedMsg{Panel: "Pods", Order: "cpu desc"})
+	if save == nil {
+		t.Fatal("the sort should be saved in the background")
+	}
+
+	if msg := save(); msg != nil {
+		t.Errorf("saving should succeed, got %+v", msg)
+	}
+
+	if m.config.Panels.Sort["pods"] != "cpu desc" {
+		t.Errorf("expected the pods sort saved, got %v", m.config.Panels.Sort)
+	}
+}
+
+func TestPanelSettingsUseConfigNames(t *testing.T) {
+	m := createTestModel()
+	m.statusBar = components.NewStatusBar(m.styles)
+	m.config = &config.Config{File: filepath.Join(t.TempDir(), "config.yaml")}
+
+	_, save := m.Update(panels.SortChangedMsg{Panel: "HPAs", Order: "name desc"})
+	save()
+
+	if m.config.Panels.Sort["horizontalpodautoscalers"] != "name desc" {
+		t.Errorf("the HPA sort should be saved under its panels.visible name, got %v", m.config.Panels.Sort)
+	}
+
+	// The prompt's short name works when reading
+	columns := map[string][]config.ColumnConfig{"hpa": {{Name: "name"}}}
+	if key, _, ok := panelSetting(columns, "HPAs"); !ok || key != "hpa" {
+		t.Errorf("expected the hpa alias to match, got %q %v", key, ok)
+	}
+
+	if key, _, ok := panelSetting(columns, "Pods"); ok || key != "pods" {
+		t.Errorf("expected no pods setting, got %q %v", key, ok)
+	}
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// terminalCloseTimeout bounds waiting for sessions to end on quit.
+const terminalCloseTimeout = shellPodCleanupTimeout
+
+// openTerminal starts a program on a new terminal tab and gives it the
+// keyboard.
+func (m *Model) openTerminal(title string, run components.TerminalRunner) tea.Cmd {
+	m.terminalSeq++
+
+	session := components.NewTerminalSession(m.terminalSeq, title)
+
+	m.terminals = append(m.terminals, session)
+	m.activeTerminal = len(m.terminals) - 1
+
+	// The terminal takes the detail view's place, which zoom hides
+	m.exitZoom()
+	m.viewMode = ViewTerminal
+
+	// The program learns the real size when the pane is first drawn
+	return session.Start(run)
+}
+
+// terminalScreenSize leaves room in the pane for its padding and the tab
+// bar.
+func terminalScreenSize(paneWidth, paneHeight int) (int, int) {
+	return max(paneWidth-2, 1), max(paneHeight-1, 1)
+}
+
+// showTerminals gives the keyboard back to the last shell used.
+func (m *Model) showTerminals() {
+	if len(m.terminals) == 0 {
+		m.statusBar.SetMessage("No shell sessions; x on a pod opens one")
+
+		return
+	}
+
+	m.exitZoom()
+	m.viewMode = ViewTerminal
+}
+
+// handleTerminalKey passes every key to the shell except the few that
+// leave it or switch tabs.
+func (m *Model) handleTerminalKey(msg tea.KeyMsg) {
+	if len(m.terminals) == 0 {
+		m.viewMode = ViewNormal
+
+		return
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.TerminalLeave):
+		m.viewMode = ViewNormal
+		m.statusBar.SetMessage("Shell sessions keep running; T returns to them")
+	case key.Matches(msg, m.keys.TerminalNext):
+		m.activeTerminal = (m.activeTerminal + 1) % len(m.terminals)
+	case key.Matches(msg, m.keys.TerminalPrev):
+		m.activeTerminal = (m.activeTerminal - 1 + len(m.terminals)) % len(m.terminals)
+	default:
+		m.terminals[m.activeTerminal].SendKey(msg)
+	}
+}
+
+func (m *Model) terminalIndex(id int) int {
+	return slices.IndexFunc(m.terminals, func(t *components.TerminalSession) bool {
+		return t.ID() == id
+	})
+}
+
+// terminalOutput draws a session's output and waits for more, whether or
+// not the session is shown.
+func (m *Model) terminalOutput(msg components.TerminalOutputMsg) tea.Cmd {
+	idx := m.terminalIndex(msg.ID)
+	if idx < 0 {
+		return nil
+	}
+
+	session := m.terminals[idx]
+	session.Feed(msg.Data)
+
+	return session.WaitOutput()
+}
+
+// terminalExited closes the tab of a program that ended, returning to the
+// panels after the last one.
+func (m *Model) terminalExited(msg components.TerminalExitMsg) tea.Cmd {
+	idx := m.terminalIndex(msg.ID)
+	if idx < 0 {
+		return nil
+	}
+
+	session := m.terminals[idx]
+	session.MarkExited()
+
+	m.terminals = slices.Delete(m.terminals, idx, idx+1)
+	if m.activeTerminal > idx || m.activeTerminal == len(m.terminals) {
+		m.activeTerminal = max(m.activeTerminal-1, 0)
+	}
+
+	if len(m.terminals) == 0 && m.viewMode == ViewTerminal {
+		m.viewMode = ViewNormal
+	}
+
+	if msg.Err != nil && !errors.Is(msg.Err, context.Canceled) {
+		return func() tea.Msg {
+			return panels.ErrorMsg{Error: fmt.Errorf("exec failed: %w", msg.Err)}
+		}
+	}
+
+	return func() tea.Msg {
+		return panels.StatusMsg{Message: "Exited shell in " + session.Title()}
+	}
+}
+
+// closeTerminals ends every session, on quit, giving them a moment to
+// clean up after themselves, like deleting debug copies of pods.
+func (m *Model) closeTerminals() {
+	for _, session := range m.terminals {
+		session.Close()
+	}
+
+	deadline := time.After(terminalCloseTimeout)
+
+	for _, session := range m.terminals {
+		select {
+		case <-session.Done():
+		case <-deadline:
+			return
+		}
+	}
+}
+
+// renderTerminalView draws the active session with a tab per session in
+// the detail view's place.
+func (m *Model) renderTerminalView(width, height int) string {
+	if len(m.terminals) == 0 {
+		return m.renderDetailView(width, height)
+	}
+
+	session := m.terminals[m.activeTerminal]
+	session.SetSize(terminalScreenSize(width, height))
+
+	screenWidth, _ := terminalScreenSize(width, height)
+	content := m.renderTerminalTabs(screenWidth) + "\n" + session.View(true)
+
+	return m.styles.PanelFocused.Width(width).Height(height).Render(content)
+}
+
+func (m *Model) renderTerminalTabs(width int) string {
+	tabs := make([]string, 0, len(m.terminals))
+
+	for i, session := range m.terminals {
+		label := strconv.Itoa(i+1) + " " + session.Title()
+		if i == m.activeTerminal {
+			tabs = append(tabs, m.styles.PanelTitleActive.Render(label))
+		} else {
+			tabs = append(tabs, m.styles.Muted.Render(label))
+		}
+	}
+
+	hint := m.styles.Muted.Render("  ctrl+] panels · alt+n/alt+p switch")
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(tabs, " │ ") + hint)
+}
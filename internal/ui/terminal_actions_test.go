@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Starlexxx/lazy-k8s/internal/k8s"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
+	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
+)
+
+// promptRunner prints a prompt and echoes its input until the session
+// ends.
+func promptRunner(prompt string) components.TerminalRunner {
+	return func(ctx context.Context, stdin io.Reader, stdout io.Writer, _ <-chan k8s.TerminalSize) error {
+		if _, err := io.WriteString(stdout, prompt); err != nil {
+			return err
+		}
+
+		_, _ = io.Copy(stdout, stdin)
+
+		return ctx.Err()
+	}
+}
+
+// runTerminalCmd delivers a session's output to the model until it
+// exits or shows the wanted text.
+func runTerminalCmd(t *testing.T, m *Model, cmd tea.Cmd, want string) tea.Cmd {
+	t.Helper()
+
+	for cmd != nil {
+		msg := cmd()
+
+		_, cmd = m.Update(msg)
+
+		if _, ok := msg.(components.TerminalOutputMsg); ok && strings.Contains(m.View(), want) {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+func TestOpenTerminal_ShowsSessionInDetailPane(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	cmd := m.openTerminal("alpha/app", promptRunner("alpha$"))
+	defer m.closeTerminals()
+
+	runTerminalCmd(t, m, cmd, "alpha$")
+
+	if m.viewMode != ViewTerminal {
+		t.Fatalf("view mode = %v, want ViewTerminal", m.viewMode)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "1 alpha/app") {
+		t.Error("the session's tab should show")
+	}
+
+	// The panels stay on screen next to the shell
+	if !strings.Contains(view, "bravo") {
+		t.Error("the panels should stay visible")
+	}
+}
+
+func TestHandleTerminalKey_SendsKeysToShell(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	cmd := m.openTerminal("alpha/app", promptRunner("$"))
+	defer m.closeTerminals()
+
+	cmd = runTerminalCmd(t, m, cmd, "$")
+
+	// q would quit from the panels; here it's typed into the shell
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	runTerminalCmd(t, m, cmd, "$q")
+
+	if m.viewMode != ViewTerminal {
+		t.Error("typing shouldn't leave the shell")
+	}
+}
+
+func TestHandleTerminalKey_LeaveAndReturn(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	runTerminalCmd(t, m, m.openTerminal("alpha/app", promptRunner("$")), "$")
+	defer m.closeTerminals()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlCloseBracket})
+
+	if m.viewMode != ViewNormal {
+		t.Fatalf("ctrl+] should leave the shell, view mode = %v", m.viewMode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+
+	if m.viewMode != ViewTerminal {
+		t.Errorf("T should return to the shell, view mode = %v", m.viewMode)
+	}
+}
+
+func TestHandleTerminalKey_SwitchesTabs(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	runTerminalCmd(t, m, m.openTerminal("alpha/app", promptRunner("first$")), "first$")
+	runTerminalCmd(t, m, m.openTerminal("bravo/app", promptRunner("second$")), "second$")
+	defer m.closeTerminals()
+
+	if m.activeTerminal != 1 {
+		t.Fatalf("the new session should be active, got %d", m.activeTerminal)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n"), Alt: true})
+
+	if m.activeTerminal != 0 || !strings.Contains(m.View(), "first$") {
+		t.Errorf("alt+n should wrap to the first session, active = %d", m.activeTerminal)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p"), Alt: true})
+
+	if m.activeTerminal != 1 {
+		t.Errorf("alt+p should wrap to the last session, active = %d", m.activeTerminal)
+	}
+}
+
+func TestTerminalExited_ClosesTab(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	cmd := runTerminalCmd(t, m, m.openTerminal("alpha/app", promptRunner("$")), "$")
+
+	m.terminals[0].Close()
+
+	_, cmd = m.Update(cmd())
+
+	if len(m.terminals) != 0 || m.viewMode != ViewNormal {
+		t.Fatalf("the last session's exit should return to the panels, %d sessions", len(m.terminals))
+	}
+
+	if status, ok := cmd().(panels.StatusMsg); !ok || !strings.Contains(status.Message, "alpha/app") {
+		t.Errorf("exit should report the closed shell, got %#v", status)
+	}
+}
+
+func TestShowTerminals_WithoutSessions(t *testing.T) {
+	m := createMouseTestModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+
+	if m.viewMode != ViewNormal {
+		t.Errorf("T without sessions should stay on the panels, view mode = %v", m.viewMode)
+	}
+}
@@ -54,6 +54,24 @@ type KeyMap struct {
 	Diff         key.Binding
 	GlobalSearch key.Binding
 	History      key.Binding
+	XRay         key.Binding
+	Command      key.Binding
+	Terminals    key.Binding
+
+	// Embedded terminal, while it has the keyboard
+	TerminalLeave key.Binding
+	TerminalNext  key.Binding
+	TerminalPrev  key.Binding
+
+	// Sorting
+	SortNext    key.Binding
+	SortPrev    key.Binding
+	SortReverse key.Binding
+
+	// Marking
+	Mark    key.Binding
+	MarkAll key.Binding
+	Label   key.Binding
 }
 
 func NewKeyMap() *KeyMap {
@@ -209,6 +227,57 @@ func NewKeyMap() *KeyMap {
 			key.WithKeys("H"),
 			key.WithHelp("H", "history"),
 		),
+		XRay: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "x-ray relations"),
+		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command"),
+		),
+		Terminals: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "shell sessions"),
+		),
+
+		TerminalLeave: key.NewBinding(
+			key.WithKeys("ctrl+]"),
+			key.WithHelp("ctrl+]", "back to panels"),
+		),
+		TerminalNext: key.NewBinding(
+			key.WithKeys("alt+n"),
+			key.WithHelp("alt+n", "next session"),
+		),
+		TerminalPrev: key.NewBinding(
+			key.WithKeys("alt+p"),
+			key.WithHelp("alt+p", "previous session"),
+		),
+
+		SortNext: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "sort by next column"),
+		),
+		SortPrev: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "sort by previous column"),
+		),
+		SortReverse: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "invert sort"),
+		),
+
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		MarkAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "mark all matching"),
+		),
+		Label: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "label"),
+		),
 	}
 }
 
@@ -220,10 +289,12 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.NextPanel, k.PrevPanel, k.Top, k.Bottom},
-		{k.Enter, k.Back, k.Zoom, k.Search, k.GlobalSearch, k.History, k.Refresh},
-		{k.Describe, k.Yaml, k.Logs, k.Exec},
+		{k.Enter, k.Back, k.Zoom, k.Search, k.GlobalSearch, k.Command, k.History, k.Refresh},
+		{k.Describe, k.Yaml, k.Logs, k.Exec, k.Terminals, k.XRay},
 		{k.Delete, k.Scale, k.Restart, k.PortForward, k.Diff},
 		{k.Context, k.Namespace, k.CopyName, k.Copy},
+		{k.SortNext, k.SortPrev, k.SortReverse},
+		{k.Mark, k.MarkAll, k.Label},
 		{k.Help, k.Quit},
 	}
 }
@@ -1,6 +1,8 @@
 package theme
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/Starlexxx/lazy-k8s/internal/config"
@@ -67,6 +69,8 @@ type Styles struct {
 
 	DiffAdded   lipgloss.Style
 	DiffRemoved lipgloss.Style
+
+	SearchMatch lipgloss.Style
 }
 
 func NewStyles(cfg *config.ThemeConfig) *Styles {
@@ -231,9 +235,51 @@ func NewStyles(cfg *config.ThemeConfig) *Styles {
 	s.DiffRemoved = lipgloss.NewStyle().
 		Foreground(s.Error)
 
+	s.SearchMatch = lipgloss.NewStyle().
+		Foreground(s.Warning).
+		Bold(true)
+
 	return s
 }
 
+// HighlightMatches renders the runes of text at the given rune indexes in
+// SearchMatch, to show which characters a search matched.
+func (s *Styles) HighlightMatches(text string, positions []int) string {
+	if len(positions) == 0 {
+		return text
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var (
+		b   strings.Builder
+		run []rune
+	)
+
+	runes := []rune(text)
+	for i, r := range runes {
+		run = append(run, r)
+
+		// Render each run of matched or unmatched runes in one piece
+		if i+1 < len(runes) && matched[i+1] == matched[i] {
+			continue
+		}
+
+		if matched[i] {
+			b.WriteString(s.SearchMatch.Render(string(run)))
+		} else {
+			b.WriteString(string(run))
+		}
+
+		run = run[:0]
+	}
+
+	return b.String()
+}
+
 func (s *Styles) GetStatusStyle(status string) lipgloss.Style {
 	switch status {
 	case "Running", "Active", "Ready", "Bound":
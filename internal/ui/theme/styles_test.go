@@ -1,6 +1,7 @@
 package theme
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
@@ -105,6 +106,7 @@ func TestStylesNotNil(t *testing.T) {
 		{"StatusSucceeded", styles.StatusSucceeded},
 		{"StatusUnknown", styles.StatusUnknown},
 		{"StatusTerminating", styles.StatusTerminating},
+		{"SearchMatch", styles.SearchMatch},
 	}
 
 	for _, sf := range styleFields {
@@ -250,3 +252,25 @@ func TestMutedColor(t *testing.T) {
 		t.Errorf("MutedColor = %v, want #565f89", styles.MutedColor)
 	}
 }
+
+func TestHighlightMatches(t *testing.T) {
+	styles := NewStyles(createTestConfig())
+	// Tests have no color profile, so mark matches in a visible way
+	styles.SearchMatch = lipgloss.NewStyle().Transform(strings.ToUpper)
+
+	tests := []struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		{"nginx-web", []int{6, 7, 8}, "nginx-WEB"},
+		{"deploy-web", []int{0, 2, 7}, "DePloy-Web"},
+		{"web", nil, "web"},
+	}
+
+	for _, tt := range tests {
+		if got := styles.HighlightMatches(tt.text, tt.positions); got != tt.want {
+			t.Errorf("HighlightMatches(%q, %v) = %q, want %q", tt.text, tt.positions, got, tt.want)
+		}
+	}
+}
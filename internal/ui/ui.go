@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strconv"
@@ -22,6 +24,7 @@ import (
 	"github.com/Starlexxx/lazy-k8s/internal/ui/components"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/panels"
 	"github.com/Starlexxx/lazy-k8s/internal/ui/theme"
+	"github.com/Starlexxx/lazy-k8s/internal/utils"
 )
 
 var (
@@ -47,6 +50,9 @@ const (
 	ViewDiff
 	ViewGlobalSearch
 	ViewHistory
+	ViewXRay
+	ViewCommand
+	ViewTerminal
 )
 
 // borderLines is the number of lines used by panel borders (top + bottom).
@@ -104,10 +110,13 @@ type Model struct {
 	// Port forwarding
 	portForwards map[string]*k8s.PortForwarder
 
-	// Exec container selection
+	// Exec container selection; when execDebug is set the list holds the
+	// debugOptions' labels
 	execContainers []string
 	execPodName    string
 	execNamespace  string
+	execDebug      bool
+	debugOptions   []debugOption
 
 	// Global search
 	globalSearch        *components.GlobalSearch
@@ -115,10 +124,39 @@ type Model struct {
 
 	// Metrics
 	metricsClient *k8s.MetricsClient
+	// Usage samples behind right-sizing, saved to usageHistoryPath on exit
+	// when persistence is enabled
+	usageHistory     *k8s.UsageHistory
+	usageHistoryPath string
+
+	// diffApply, when set, runs on "a" in the diff view for diffs that can
+	// be applied
+	diffApply func() tea.Cmd
 
 	// Operations history
 	historyStore *components.HistoryStore
 	historyView  *components.HistoryViewer
+
+	// Relationship x-ray
+	relationView *components.RelationViewer
+
+	// Command prompt, completing from the resources discovery found and
+	// the namespaces listed when it opened
+	commandPrompt     *components.CommandPrompt
+	apiResources      []k8s.APIResource
+	commandNamespaces []string
+
+	// Last left click on a panel row, to tell double-clicks
+	lastClick rowClick
+
+	// Shells and other programs on embedded terminals, shown in place of
+	// the detail view
+	terminals      []*components.TerminalSession
+	activeTerminal int
+	terminalSeq    int
+
+	// Best-practice lint rules enabled by the config
+	lintPolicy *k8s.LintPolicy
 }
 
 func NewModel(client *k8s.Client, cfg *config.Config) *Model {
@@ -132,6 +170,7 @@ func NewModel(client *k8s.Client, cfg *config.Config) *Model {
 		keys:         keys,
 		viewMode:     ViewNormal,
 		portForwards: make(map[string]*k8s.PortForwarder),
+		lintPolicy:   k8s.NewLintPolicy(cfg.Lint.Disabled, cfg.Lint.Suppress),
 	}
 
 	m.header = components.NewHeader(styles, client.CurrentContext(), client.CurrentNamespace())
@@ -146,11 +185,15 @@ func NewModel(client *k8s.Client, cfg *config.Config) *Model {
 	m.globalSearch = components.NewGlobalSearch(styles)
 	m.historyStore = components.NewHistoryStore()
 	m.historyView = components.NewHistoryViewer(styles, m.historyStore)
+	m.relationView = components.NewRelationViewer(styles)
+	m.commandPrompt = components.NewCommandPrompt(styles)
+	m.commandPrompt.SetCompleter(m.completeCommand)
 
 	// Initialize metrics client (optional - may fail if metrics-server not installed)
 	metricsClient, err := client.NewMetricsClient()
 	if err == nil {
 		m.metricsClient = metricsClient
+		m.initUsageHistory()
 	}
 
 	m.initPanels()
@@ -162,43 +205,10 @@ func (m *Model) initPanels() {
 	m.panels = make([]panels.Panel, 0)
 
 	for _, panelName := range m.config.Panels.Visible {
-		switch panelName {
-		case "namespaces":
-			m.panels = append(m.panels, panels.NewNamespacesPanel(m.k8sClient, m.styles))
-		case "pods":
-			m.panels = append(m.panels, panels.NewPodsPanel(m.k8sClient, m.styles))
-		case "deployments":
-			m.panels = append(m.panels, panels.NewDeploymentsPanel(m.k8sClient, m.styles))
-		case "services":
-			m.panels = append(m.panels, panels.NewServicesPanel(m.k8sClient, m.styles))
-		case "configmaps":
-			m.panels = append(m.panels, panels.NewConfigMapsPanel(m.k8sClient, m.styles))
-		case "secrets":
-			m.panels = append(m.panels, panels.NewSecretsPanel(m.k8sClient, m.styles))
-		case "nodes":
-			m.panels = append(m.panels, panels.NewNodesPanel(m.k8sClient, m.styles))
-		case "events":
-			m.panels = append(m.panels, panels.NewEventsPanel(m.k8sClient, m.styles))
-		case "jobs":
-			m.panels = append(m.panels, panels.NewJobsPanel(m.k8sClient, m.styles))
-		case "ingress", "ingresses":
-			m.panels = append(m.panels, panels.NewIngressPanel(m.k8sClient, m.styles))
-		case "pv", "persistentvolumes":
-			m.panels = append(m.panels, panels.NewPVPanel(m.k8sClient, m.styles))
-		case "pvc", "persistentvolumeclaims":
-			m.panels = append(m.panels, panels.NewPVCPanel(m.k8sClient, m.styles))
-		case "statefulsets", "sts":
-			m.panels = append(m.panels, panels.NewStatefulSetsPanel(m.k8sClient, m.styles))
-		case "daemonsets", "ds":
-			m.panels = append(m.panels, panels.NewDaemonSetsPanel(m.k8sClient, m.styles))
-		case "cronjobs", "cj":
-			m.panels = append(m.panels, panels.NewCronJobsPanel(m.k8sClient, m.styles))
-		case "hpa", "horizontalpodautoscalers":
-			m.panels = append(m.panels, panels.NewHPAPanel(m.k8sClient, m.styles))
-		case "networkpolicies", "netpol":
-			m.panels = append(m.panels, panels.NewNetworkPoliciesPanel(m.k8sClient, m.styles))
-		case "serviceaccounts", "sa":
-			m.panels = append(m.panels, panels.NewServiceAccountsPanel(m.k8sClient, m.styles))
+		if panel := m.newPanel(panelName); panel != nil {
+			m.applySavedSort(panel)
+			m.applyColumns(panel)
+			m.panels = append(m.panels, panel)
 		}
 	}
 
@@ -207,6 +217,84 @@ func (m *Model) initPanels() {
 	}
 }
 
+// newPanel creates the panel a name in panels.visible stands for, or nil
+// for an unknown name.
+func (m *Model) newPanel(name string) panels.Panel {
+	switch name {
+	case "namespaces":
+		return panels.NewNamespacesPanel(m.k8sClient, m.styles)
+	case "pods":
+		podsPanel := panels.NewPodsPanel(m.k8sClient, m.styles)
+		podsPanel.SetMetricsRetention(m.config.Metrics.ChartSamples)
+
+		return podsPanel
+	case "deployments":
+		deploymentsPanel := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+		deploymentsPanel.SetLintPolicy(m.lintPolicy)
+
+		return deploymentsPanel
+	case "services":
+		return panels.NewServicesPanel(m.k8sClient, m.styles)
+	case "configmaps":
+		return panels.NewConfigMapsPanel(m.k8sClient, m.styles)
+	case "secrets":
+		secretsPanel := panels.NewSecretsPanel(m.k8sClient, m.styles)
+		secretsPanel.SetRevealTimeout(
+			time.Duration(m.config.Secrets.RevealTimeout) * time.Second,
+		)
+		secretsPanel.SetCertExpiryThreshold(
+			time.Duration(m.config.Secrets.CertExpiryWarningDays) * 24 * time.Hour,
+		)
+
+		return secretsPanel
+	case "nodes":
+		nodesPanel := panels.NewNodesPanel(m.k8sClient, m.styles)
+		nodesPanel.SetMetricsRetention(m.config.Metrics.ChartSamples)
+
+		return nodesPanel
+	case "events":
+		return panels.NewEventsPanel(m.k8sClient, m.styles)
+	case "jobs":
+		return panels.NewJobsPanel(m.k8sClient, m.styles)
+	case "ingress", "ingresses":
+		ingressPanel := panels.NewIngressPanel(m.k8sClient, m.styles)
+		ingressPanel.SetCertExpiryThreshold(
+			time.Duration(m.config.Secrets.CertExpiryWarningDays) * 24 * time.Hour,
+		)
+
+		return ingressPanel
+	case "pv", "persistentvolumes":
+		return panels.NewPVPanel(m.k8sClient, m.styles)
+	case "pvc", "persistentvolumeclaims":
+		return panels.NewPVCPanel(m.k8sClient, m.styles)
+	case "statefulsets", "sts":
+		statefulSetsPanel := panels.NewStatefulSetsPanel(m.k8sClient, m.styles)
+		statefulSetsPanel.SetLintPolicy(m.lintPolicy)
+
+		return statefulSetsPanel
+	case "daemonsets", "ds":
+		daemonSetsPanel := panels.NewDaemonSetsPanel(m.k8sClient, m.styles)
+		daemonSetsPanel.SetLintPolicy(m.lintPolicy)
+
+		return daemonSetsPanel
+	case "cronjobs", "cj":
+		cronJobsPanel := panels.NewCronJobsPanel(m.k8sClient, m.styles)
+		cronJobsPanel.SetLintPolicy(m.lintPolicy)
+
+		return cronJobsPanel
+	case "hpa", "horizontalpodautoscalers":
+		return panels.NewHPAPanel(m.k8sClient, m.styles)
+	case "networkpolicies", "netpol":
+		return panels.NewNetworkPoliciesPanel(m.k8sClient, m.styles)
+	case "serviceaccounts", "sa":
+		return panels.NewServiceAccountsPanel(m.k8sClient, m.styles)
+	case "problems":
+		return panels.NewProblemsPanel(m.k8sClient, m.styles)
+	}
+
+	return nil
+}
+
 func (m *Model) Init() tea.Cmd {
 	var cmds []tea.Cmd
 
@@ -214,8 +302,13 @@ func (m *Model) Init() tea.Cmd {
 		cmds = append(cmds, panel.Init())
 	}
 
+	// Without a metrics client the one fetch only reports metrics as
+	// unavailable
+	cmds = append(cmds, m.fetchMetrics())
+	cmds = append(cmds, m.sweepNodeShells())
+
 	if m.metricsClient != nil {
-		cmds = append(cmds, m.fetchMetrics(), m.metricsTickCmd())
+		cmds = append(cmds, m.metricsTickCmd())
 	}
 
 	return tea.Batch(cmds...)
@@ -279,6 +372,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+			if msg.String() == "a" && m.diffApply != nil && !m.diffView.SearchActive() {
+				return m, m.diffApply()
+			}
+
 			var cmd tea.Cmd
 
 			m.diffView, cmd = m.diffView.Update(msg)
@@ -330,6 +427,31 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, cmd
 
+		case ViewXRay:
+			if key.Matches(msg, m.keys.Back) {
+				m.viewMode = ViewNormal
+
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+
+			m.relationView, cmd = m.relationView.Update(msg)
+
+			return m, cmd
+
+		case ViewCommand:
+			var cmd tea.Cmd
+
+			m.commandPrompt, cmd = m.commandPrompt.Update(msg)
+
+			return m, cmd
+
+		case ViewTerminal:
+			m.handleTerminalKey(msg)
+
+			return m, nil
+
 		case ViewNormal:
 			// Fall through to normal key handling below
 		}
@@ -357,15 +479,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.searchQuery = m.search.Value()
 
 			if len(m.panels) > m.activePanelIdx {
-				m.panels[m.activePanelIdx].SetFilter(m.searchQuery)
+				panel := m.panels[m.activePanelIdx]
+				selector := panel.LabelSelector()
+
+				panel.SetFilter(m.searchQuery)
+				m.search.SetError(panel.FilterError())
+
+				// Label terms are filtered by the API server, so a new
+				// selector needs a fresh list
+				if panel.LabelSelector() != selector {
+					return m, tea.Batch(cmd, m.refreshForSelector(panel))
+				}
 			}
 
 			return m, cmd
 		}
 
+		if cmd, ok := m.handleBulkKey(msg); ok {
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			m.stopAllPortForwards()
+			m.closeTerminals()
 
 			return m, tea.Quit
 
@@ -381,6 +518,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, nil
 
+		case key.Matches(msg, m.keys.Command):
+			return m, m.startCommand()
+
+		case key.Matches(msg, m.keys.Terminals):
+			m.showTerminals()
+
+			return m, nil
+
 		case key.Matches(msg, m.keys.History):
 			m.historyView.Reset()
 			m.viewMode = ViewHistory
@@ -462,6 +607,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Describe):
 			return m.showDescribe()
 
+		case key.Matches(msg, m.keys.XRay):
+			return m, m.loadRelationTree()
+
 		case key.Matches(msg, m.keys.CopyName):
 			return m.copyNameToClipboard()
 
@@ -471,6 +619,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Edit):
 			return m.editResource()
 
+		case key.Matches(msg, m.keys.Mark):
+			m.toggleMark()
+
+			return m, nil
+
+		case key.Matches(msg, m.keys.MarkAll):
+			m.toggleMarkAll()
+
+			return m, nil
+
+		case key.Matches(msg, m.keys.SortNext):
+			return m, m.cycleSort(1, false)
+
+		case key.Matches(msg, m.keys.SortPrev):
+			return m, m.cycleSort(-1, false)
+
+		case key.Matches(msg, m.keys.SortReverse):
+			return m, m.cycleSort(0, true)
+
 		default:
 			if len(m.panels) > m.activePanelIdx {
 				panel, cmd := m.panels[m.activePanelIdx].Update(msg)
@@ -480,6 +647,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case tea.MouseMsg:
+		return m, m.handleMouse(msg, time.Now())
+
+	case components.TerminalOutputMsg:
+		return m, m.terminalOutput(msg)
+
+	case components.TerminalExitMsg:
+		return m, m.terminalExited(msg)
+
 	case components.LogLineMsg:
 		var cmd tea.Cmd
 
@@ -487,6 +663,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, cmd
 
+	case panels.SortChangedMsg:
+		return m, m.saveSort(msg.Panel, msg.Order)
+
 	case panels.RefreshMsg:
 		for i, panel := range m.panels {
 			if panel.Title() == msg.PanelName {
@@ -529,10 +708,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		for _, panel := range m.panels {
 			if panel.Title() == "Pods" {
-				_, cmd := panel.Update(panels.PodMetricsMsg{Metrics: msg.podMetrics})
+				_, cmd := panel.Update(panels.PodMetricsMsg{Metrics: msg.podMetrics, Unavailable: msg.podsUnavailable})
 				cmds = append(cmds, cmd)
 			} else if panel.Title() == "Nodes" {
-				_, cmd := panel.Update(panels.NodeMetricsMsg{Metrics: msg.nodeMetrics})
+				_, cmd := panel.Update(panels.NodeMetricsMsg{Metrics: msg.nodeMetrics, Unavailable: msg.nodesUnavailable})
 				cmds = append(cmds, cmd)
 			}
 		}
@@ -550,6 +729,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case commandNamespacesLoadedMsg:
+		if msg.context == m.k8sClient.CurrentContext() {
+			m.commandNamespaces = msg.namespaces
+		}
+
+		return m, nil
+
+	case apiResourcesLoadedMsg:
+		return m, m.apiResourcesLoaded(msg)
+
+	case components.CommandSubmitMsg:
+		m.viewMode = ViewNormal
+
+		return m, m.runCommand(msg.Command)
+
+	case components.CommandCancelMsg:
+		m.viewMode = ViewNormal
+
+		return m, nil
+
 	case components.InputCancelMsg:
 		m.viewMode = ViewNormal
 		m.pendingInputAction = nil
@@ -600,10 +799,52 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case diffLoadedMsg:
 		m.diffView.SetContent(msg.title, msg.oldYAML, msg.newYAML)
+		m.diffApply = nil
 		m.viewMode = ViewDiff
 
 		return m, nil
 
+	case rightsizingLoadedMsg:
+		m.diffApply = nil
+		m.showRightsizing(msg.rec)
+
+		return m, nil
+
+	case bulkResultsMsg:
+		return m, m.showBulkResults(msg)
+
+	case reportLoadedMsg:
+		m.yamlView.SetContent(msg.content)
+		m.viewMode = ViewYaml
+
+		return m, nil
+
+	case relationTreeLoadedMsg:
+		m.relationView.SetTree(msg.tree)
+		m.viewMode = ViewXRay
+
+		return m, nil
+
+	case selectorLoadedMsg:
+		if msg.panel.LabelSelector() != msg.selector {
+			return m, nil
+		}
+
+		return m.Update(msg.loaded)
+
+	case usageHistoryLoadedMsg:
+		if msg.path == m.usageHistoryPath {
+			m.usageHistory = msg.history
+		}
+
+		return m, nil
+
+	case jumpReloadedMsg:
+		return m, m.finishJump(msg)
+
+	case components.RelationJumpMsg:
+		return m, m.jumpToResource(msg.Kind, msg.Name, msg.Namespace)
+
 	case panels.PortForwardRequestMsg:
 		if len(msg.Ports) == 0 {
 			m.statusBar.SetMessage("No ports exposed on this pod")
@@ -638,6 +879,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.execContainers = msg.Containers
 		m.execPodName = msg.PodName
 		m.execNamespace = msg.Namespace
+		m.execDebug = false
 		m.selectIdx = 0
 		m.switchFilter = ""
 		m.switchFiltered = msg.Containers
@@ -645,6 +887,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case panels.DebugRequestMsg:
+		if len(msg.Containers) == 0 {
+			m.statusBar.SetError("No containers in this pod")
+
+			return m, nil
+		}
+
+		m.showDebugOptions(msg)
+
+		return m, nil
+
 	case panels.ScaleStatefulSetRequestMsg:
 		description := fmt.Sprintf(
 			"Enter new replica count for %s (current: %d)",
@@ -729,6 +982,86 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case panels.TriggerCronJobRequestMsg:
 		return m, m.triggerCronJob(msg.Namespace, msg.CronJobName)
 
+	case panels.CopySecretValueRequestMsg:
+		m.copySecretValue(msg)
+
+		return m, nil
+
+	case panels.EditSecretKeyRequestMsg:
+		return m, m.editSecretKey(msg)
+
+	case panels.CreateSecretRequestMsg:
+		m.startCreateSecret(msg.Namespace)
+
+		return m, nil
+
+	case panels.ExpiringCertificatesRequestMsg:
+		return m, m.loadExpiringCertificates(msg.Threshold)
+
+	case panels.EditConfigMapKeyRequestMsg:
+		return m, m.editConfigMapKey(msg)
+
+	case panels.AddConfigMapKeyRequestMsg:
+		m.promptAddConfigMapKey(msg)
+
+		return m, nil
+
+	case panels.RenameConfigMapKeyRequestMsg:
+		m.promptRenameConfigMapKey(msg)
+
+		return m, nil
+
+	case panels.RemoveConfigMapKeyRequestMsg:
+		m.confirmRemoveConfigMapKey(msg)
+
+		return m, nil
+
+	case panels.CreateConfigMapRequestMsg:
+		m.startCreateConfigMap(msg.Namespace)
+
+		return m, nil
+
+	case panels.CreateNamespaceRequestMsg:
+		m.startCreateNamespace()
+
+		return m, nil
+
+	case panels.EditNamespaceMetadataRequestMsg:
+		return m, m.editNamespaceMetadata(msg)
+
+	case panels.NamespaceBlockersRequestMsg:
+		return m, m.loadNamespaceBlockers(msg.Name)
+
+	case panels.LintNamespaceRequestMsg:
+		return m, m.loadLintReport(msg.Name)
+
+	case panels.NodePodsRequestMsg:
+		return m, m.loadNodeAllocation(msg.Node)
+
+	case panels.NodeShellRequestMsg:
+		return m, m.confirmNodeShell(msg.Node)
+
+	case nodeShellsSweptMsg:
+		if msg.count > 0 {
+			m.statusBar.SetMessage(fmt.Sprintf("Deleted %d node shell pods left behind", msg.count))
+		}
+
+		return m, nil
+
+	case panels.RightsizeRequestMsg:
+		return m, m.loadRightsizing(msg)
+
+	case panels.ExplainSchedulingRequestMsg:
+		return m, m.loadSchedulingDiagnosis(msg.Namespace, msg.PodName)
+
+	case panels.JumpToResourceMsg:
+		return m, m.jumpToResource(msg.Kind, msg.Name, msg.Namespace)
+
+	case panels.CheckReachabilityRequestMsg:
+		m.startReachabilityCheck(msg)
+
+		return m, nil
+
 	case components.UndoRequestMsg:
 		return m, m.handleUndo(msg.RecordID)
 	}
@@ -771,9 +1104,11 @@ func (m *Model) View() string {
 		content = m.renderGlobalSearchView()
 	case ViewHistory:
 		content = m.historyView.View(m.width, m.height)
+	case ViewXRay:
+		content = m.relationView.View(m.width, m.height)
 	case ViewInput:
 		content = m.overlayView(m.input.View())
-	case ViewNormal:
+	case ViewNormal, ViewCommand, ViewTerminal:
 		content = m.renderNormalView()
 	}
 
@@ -781,24 +1116,21 @@ func (m *Model) View() string {
 }
 
 func (m *Model) renderNormalView() string {
-	headerHeight := 1
-	statusBarHeight := 1
-
 	m.header.SetContext(m.k8sClient.CurrentContext())
 	m.header.SetNamespace(m.k8sClient.CurrentNamespace())
 	header := m.header.View(m.width)
 
 	statusBar := m.statusBar.View(m.width)
 
-	// Reserve space for header, status bar, and panel borders
-	panelHeight := max(m.height-headerHeight-statusBarHeight, 3)
+	_, panelHeight := m.panelArea()
 
-	// Search bar if active (takes 1 line + newline = 2 lines total)
 	var searchView string
 
-	if m.searchActive {
+	switch {
+	case m.viewMode == ViewCommand:
+		searchView = m.commandPrompt.View(m.width)
+	case m.searchActive:
 		searchView = m.search.View(m.width)
-		panelHeight -= 2
 	}
 
 	panelsView := m.renderPanels(m.width, panelHeight)
@@ -807,7 +1139,7 @@ func (m *Model) renderNormalView() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	if m.searchActive {
+	if searchView != "" {
 		b.WriteString(searchView)
 		b.WriteString("\n")
 	}
@@ -824,17 +1156,77 @@ func (m *Model) renderPanels(width, height int) string {
 		return "No panels configured"
 	}
 
+	rects := m.panelRects(width, height)
+
 	if m.zoomed {
-		panelHeight := max(height-borderLines, 1)
+		rect := rects[m.activePanelIdx]
 		activePanel := m.panels[m.activePanelIdx]
-		activePanel.SetSize(width, panelHeight)
+		activePanel.SetSize(rect.width, rect.height)
 		activePanel.SetFocused(true)
 
 		return activePanel.View()
 	}
 
+	var leftPanels []string
+
+	for i, panel := range m.panels {
+		panel.SetSize(rects[i].width, rects[i].height)
+		panel.SetFocused(i == m.activePanelIdx)
+		leftPanels = append(leftPanels, panel.View())
+	}
+
+	leftView := lipgloss.JoinVertical(lipgloss.Left, leftPanels...)
+
+	rightPanelWidth, detailHeight := detailSize(rects[0].width, width, height)
+
+	var rightView string
+	if m.viewMode == ViewTerminal {
+		rightView = m.renderTerminalView(rightPanelWidth, detailHeight)
+	} else {
+		rightView = m.renderDetailView(rightPanelWidth, detailHeight)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftView, rightView)
+}
+
+// detailSize is the size inside the border of the pane right of the
+// panels, which fills the rest of the panel area.
+func detailSize(leftPanelWidth, width, height int) (int, int) {
+	// Both panes have a border on either side
+	return max(width-leftPanelWidth-4, 1), max(height-borderLines, 1)
+}
+
+// panelRect is where renderPanels draws a panel, relative to the panel
+// area: the top-left corner of its border and the size inside it.
+type panelRect struct {
+	x, y          int
+	width, height int
+}
+
+// contentLine returns the line of the panel's content at a point of the
+// panel area, or false when the point is outside the panel or on its
+// border.
+func (r panelRect) contentLine(x, y int) (int, bool) {
+	if r.width == 0 || x <= r.x || x > r.x+r.width || y <= r.y || y > r.y+r.height {
+		return 0, false
+	}
+
+	return y - r.y - 1, true
+}
+
+// panelRects lays out the panels in an area of the given size: stacked
+// in the left quarter, or only the active one when zoomed. Hidden panels
+// get an empty rect.
+func (m *Model) panelRects(width, height int) []panelRect {
+	rects := make([]panelRect, len(m.panels))
+
+	if m.zoomed {
+		rects[m.activePanelIdx] = panelRect{width: width, height: max(height-borderLines, 1)}
+
+		return rects
+	}
+
 	leftPanelWidth := width / 4
-	rightPanelWidth := width - leftPanelWidth - 1
 
 	numPanels := len(m.panels)
 	borderOverhead := numPanels * borderLines
@@ -843,21 +1235,36 @@ func (m *Model) renderPanels(width, height int) string {
 
 	panelHeight := availableHeight / numPanels
 
-	var leftPanels []string
-
-	for i, panel := range m.panels {
-		panel.SetSize(leftPanelWidth, panelHeight)
-		panel.SetFocused(i == m.activePanelIdx)
-		leftPanels = append(leftPanels, panel.View())
+	for i := range rects {
+		rects[i] = panelRect{
+			y:      i * (panelHeight + borderLines),
+			width:  leftPanelWidth,
+			height: panelHeight,
+		}
 	}
 
-	leftView := lipgloss.JoinVertical(lipgloss.Left, leftPanels...)
+	return rects
+}
 
-	detailHeight := max(height-borderLines, 1)
+// panelArea returns the first screen row renderNormalView draws the panels
+// on and how many rows they get.
+func (m *Model) panelArea() (top, height int) {
+	// Both draw a border line under or over their text
+	headerHeight := lipgloss.Height(m.header.View(m.width))
+	statusBarHeight := lipgloss.Height(m.statusBar.View(m.width))
 
-	rightView := m.renderDetailView(rightPanelWidth, detailHeight)
+	// Reserve space for header, status bar, and panel borders
+	top = headerHeight
+	height = max(m.height-headerHeight-statusBarHeight, 3)
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, leftView, rightView)
+	// Search bar or command prompt if active (takes 1 line + newline = 2
+	// lines total)
+	if m.viewMode == ViewCommand || m.searchActive {
+		top++
+		height -= 2
+	}
+
+	return top, height
 }
 
 func (m *Model) renderDetailView(width, height int) string {
@@ -887,8 +1294,11 @@ func (m *Model) renderSwitchView() string {
 		title = "Switch Namespace"
 	case ViewContainerSelect:
 		title = "Select Container"
+		if m.execDebug {
+			title = "Debug Container"
+		}
 	case ViewNormal, ViewHelp, ViewYaml, ViewLogs, ViewDiff, ViewConfirm, ViewInput,
-		ViewGlobalSearch, ViewHistory:
+		ViewGlobalSearch, ViewHistory, ViewXRay, ViewCommand, ViewTerminal:
 		// These view modes don't use renderSwitchView
 	}
 
@@ -1160,31 +1570,46 @@ func (m *Model) handleContextSwitch(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	return m.handleListSelect(msg, m.contextList, func(ctx string) (*Model, tea.Cmd) {
 		m.viewMode = ViewNormal
 
-		if err := m.k8sClient.SwitchContext(ctx); err != nil {
-			m.statusBar.SetError(fmt.Sprintf("Failed to switch context: %v", err))
+		return m, m.switchContext(ctx)
+	})
+}
+
+func (m *Model) switchContext(ctx string) tea.Cmd {
+	if err := m.k8sClient.SwitchContext(ctx); err != nil {
+		m.statusBar.SetError(fmt.Sprintf("Failed to switch context: %v", err))
 
-			return m, nil
-		}
+		return nil
+	}
 
-		m.header.SetContext(ctx)
-		m.header.SetNamespace(m.k8sClient.CurrentNamespace())
-		m.statusBar.SetMessage(fmt.Sprintf("Switched to context: %s", ctx))
+	// Another cluster serves other custom resources
+	m.apiResources = nil
+	m.clearAllMarks()
 
-		return m, m.refreshAllPanels()
-	})
+	m.header.SetContext(ctx)
+	m.header.SetNamespace(m.k8sClient.CurrentNamespace())
+	m.statusBar.SetMessage(fmt.Sprintf("Switched to context: %s", ctx))
+
+	// Node shells left behind in this cluster are only found from it
+	return tea.Batch(m.refreshAllPanels(), m.sweepNodeShells(), m.switchUsageHistory())
 }
 
 func (m *Model) handleNamespaceSwitch(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	return m.handleListSelect(msg, m.namespaceList, func(ns string) (*Model, tea.Cmd) {
-		m.k8sClient.SetNamespace(ns)
-		m.header.SetNamespace(ns)
-		m.statusBar.SetMessage(fmt.Sprintf("Switched to namespace: %s", ns))
 		m.viewMode = ViewNormal
 
-		return m, m.refreshAllPanels()
+		return m, m.switchNamespace(ns)
 	})
 }
 
+func (m *Model) switchNamespace(ns string) tea.Cmd {
+	m.k8sClient.SetNamespace(ns)
+	m.clearAllMarks()
+	m.header.SetNamespace(ns)
+	m.statusBar.SetMessage(fmt.Sprintf("Switched to namespace: %s", ns))
+
+	return m.refreshAllPanels()
+}
+
 // applySwitchFilter filters the source list by the current switchFilter
 // and resets the selection cursor.
 func (m *Model) applySwitchFilter(source []string) {
@@ -1730,6 +2155,10 @@ func (m *Model) handleContainerSelect(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	return m.handleListSelect(msg, m.execContainers, func(container string) (*Model, tea.Cmd) {
 		m.viewMode = ViewNormal
 
+		if m.execDebug {
+			return m, m.debugPod(m.execNamespace, m.execPodName, container)
+		}
+
 		return m, m.execIntoPod(m.execNamespace, m.execPodName, container)
 	})
 }
@@ -1744,31 +2173,24 @@ func (m *Model) execIntoPod(namespace, podName, container string) tea.Cmd {
 		),
 	})
 
-	args := []string{
-		"exec", "-it",
-		"-n", namespace,
-		podName,
-		"-c", container,
-		"--", "/bin/sh", "-c",
-		"if command -v bash > /dev/null; then exec bash; else exec sh; fi",
+	client := m.k8sClient
+	opts := k8s.TerminalOptions{
+		Namespace: namespace,
+		Pod:       podName,
+		Container: container,
+		Command: []string{
+			"/bin/sh", "-c",
+			"if command -v bash > /dev/null; then exec bash; else exec sh; fi",
+		},
 	}
 
-	return tea.ExecProcess(
-		newKubectlCmd(args...),
-		func(err error) tea.Msg {
-			if err != nil {
-				return panels.ErrorMsg{
-					Error: fmt.Errorf("exec failed: %w", err),
-				}
-			}
+	return m.openTerminal(podName+"/"+container, func(
+		ctx context.Context, stdin io.Reader, stdout io.Writer, resize <-chan k8s.TerminalSize,
+	) error {
+		opts.Stdin, opts.Stdout, opts.Resize = stdin, stdout, resize
 
-			return panels.StatusMsg{
-				Message: fmt.Sprintf(
-					"Exited shell in %s/%s", podName, container,
-				),
-			}
-		},
-	)
+		return client.ExecTTY(ctx, opts)
+	})
 }
 
 func (m *Model) copyNameToClipboard() (*Model, tea.Cmd) {
@@ -1842,35 +2264,76 @@ func (m *Model) editResource() (*Model, tea.Cmd) {
 		return m, nil
 	}
 
-	tmpDir := os.TempDir()
-
-	f, err := os.CreateTemp(tmpDir, fmt.Sprintf("lazy-k8s-%s-*.yaml", name))
+	tmpFile, err := writeTempFile(fmt.Sprintf("lazy-k8s-%s-*.yaml", name), []byte(yamlContent))
 	if err != nil {
-		m.statusBar.SetError(fmt.Sprintf("Failed to create temp file: %v", err))
+		m.statusBar.SetError(err.Error())
 
 		return m, nil
 	}
 
+	cmd := editorCommand(tmpFile)
+
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer func() { _ = os.Remove(tmpFile) }()
+
+		if err != nil {
+			return panels.ErrorMsg{Error: fmt.Errorf("editor failed: %w", err)}
+		}
+
+		applyCmd := exec.Command("kubectl", "apply", "-f", tmpFile) //nolint:gosec,noctx
+
+		output, err := applyCmd.CombinedOutput()
+		if err != nil {
+			return panels.ErrorMsg{
+				Error: fmt.Errorf("%w: %s", ErrApplyFailed, strings.TrimSpace(string(output))),
+			}
+		}
+
+		ns := m.k8sClient.CurrentNamespace()
+
+		m.historyStore.Add(components.OperationRecord{
+			Type:      components.OpEditResource,
+			Resource:  name,
+			Namespace: ns,
+			Message: fmt.Sprintf(
+				"Edited and applied changes to %s", name,
+			),
+		})
+
+		return panels.StatusWithRefreshMsg{
+			Message: fmt.Sprintf("Applied changes to %s", name),
+		}
+	})
+}
+
+// writeTempFile writes content to a new temp file named after pattern and
+// returns its path. The caller owns the file and must remove it.
+func writeTempFile(pattern string, content []byte) (string, error) {
+	f, err := os.CreateTemp(os.TempDir(), pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
 	tmpFile := f.Name()
 
-	if _, err := f.WriteString(yamlContent); err != nil {
+	if _, err := f.Write(content); err != nil {
 		_ = f.Close()
 		_ = os.Remove(tmpFile)
 
-		m.statusBar.SetError(fmt.Sprintf("Failed to write temp file: %v", err))
-
-		return m, nil
+		return "", fmt.Errorf("failed to write temp file: %w", err)
 	}
 
 	if err := f.Close(); err != nil {
 		_ = os.Remove(tmpFile)
 
-		m.statusBar.SetError(fmt.Sprintf("Failed to close temp file: %v", err))
-
-		return m, nil
+		return "", fmt.Errorf("failed to close temp file: %w", err)
 	}
 
-	// Get editor from environment, fall back to vim
+	return tmpFile, nil
+}
+
+// editorCommand opens path in $EDITOR, falling back to $VISUAL and then vim.
+func editorCommand(path string) *exec.Cmd {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = os.Getenv("VISUAL")
@@ -1880,42 +2343,70 @@ func (m *Model) editResource() (*Model, tea.Cmd) {
 		editor = "vim"
 	}
 
-	cmd := exec.Command(editor, tmpFile) //nolint:gosec,noctx
+	cmd := exec.Command(editor, path) //nolint:gosec,noctx
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+	return cmd
+}
+
+// editValue opens value in the user's editor and hands the edited bytes to
+// save. When nothing changed, unchanged is shown instead; an empty unchanged
+// saves the value regardless, for new values that may be left empty. The
+// temp file is created 0600 and removed as soon as the editor exits since
+// it may hold plaintext secrets.
+func (m *Model) editValue(
+	pattern string,
+	value []byte,
+	unchanged string,
+	save func([]byte) tea.Msg,
+) tea.Cmd {
+	tmpFile, err := writeTempFile(pattern, value)
+	if err != nil {
+		m.statusBar.SetError(err.Error())
+
+		return nil
+	}
+
+	return tea.ExecProcess(editorCommand(tmpFile), func(err error) tea.Msg {
 		defer func() { _ = os.Remove(tmpFile) }()
 
 		if err != nil {
 			return panels.ErrorMsg{Error: fmt.Errorf("editor failed: %w", err)}
 		}
 
-		applyCmd := exec.Command("kubectl", "apply", "-f", tmpFile) //nolint:gosec,noctx
+		return finishEdit(tmpFile, value, unchanged, save)
+	})
+}
 
-		output, err := applyCmd.CombinedOutput()
-		if err != nil {
-			return panels.ErrorMsg{
-				Error: fmt.Errorf("%w: %s", ErrApplyFailed, strings.TrimSpace(string(output))),
-			}
-		}
+// finishEdit reads back a value edited in path and saves it if it changed,
+// or always with an empty unchanged.
+func finishEdit(path string, value []byte, unchanged string, save func([]byte) tea.Msg) tea.Msg {
+	edited, err := os.ReadFile(path) //nolint:gosec // file created by editValue
+	if err != nil {
+		return panels.ErrorMsg{Error: fmt.Errorf("failed to read edited value: %w", err)}
+	}
 
-		ns := m.k8sClient.CurrentNamespace()
+	edited = trimEditorNewline(edited, value)
 
-		m.historyStore.Add(components.OperationRecord{
-			Type:      components.OpEditResource,
-			Resource:  name,
-			Namespace: ns,
-			Message: fmt.Sprintf(
-				"Edited and applied changes to %s", name,
-			),
-		})
+	if unchanged != "" && bytes.Equal(edited, value) {
+		return panels.StatusMsg{Message: unchanged}
+	}
 
-		return panels.StatusWithRefreshMsg{
-			Message: fmt.Sprintf("Applied changes to %s", name),
-		}
-	})
+	return save(edited)
+}
+
+// trimEditorNewline drops the newline most editors end a saved file with,
+// unless the value had one to begin with.
+func trimEditorNewline(edited, original []byte) []byte {
+	if bytes.HasSuffix(original, []byte("\n")) {
+		return edited
+	}
+
+	edited = bytes.TrimSuffix(edited, []byte("\n"))
+
+	return bytes.TrimSuffix(edited, []byte("\r"))
 }
 
 func newKubectlCmd(args ...string) *exec.Cmd {
@@ -2115,7 +2606,15 @@ func (m *Model) handleUndo(recordID int) tea.Cmd {
 		components.OpPortForward,
 		components.OpExec,
 		components.OpEditResource,
-		components.OpTriggerCronJob:
+		components.OpTriggerCronJob,
+		components.OpEditSecret,
+		components.OpCreateSecret,
+		components.OpEditConfigMap,
+		components.OpCreateConfigMap,
+		components.OpCreateNamespace,
+		components.OpEditNamespace,
+		components.OpRightsize,
+		components.OpLabelResource:
 		// These operations are not reversible
 		return nil
 	}
@@ -2233,31 +2732,48 @@ func (m *Model) metricsTickCmd() tea.Cmd {
 	})
 }
 
+// fetchMetrics loads pod and node usage. A list that fails is reported as
+// unavailable rather than empty, so the panels keep their usage history
+// through a transient error. The client and history are those of the
+// context current now: a fetch still running after a context switch
+// records into the history it started with.
 func (m *Model) fetchMetrics() tea.Cmd {
-	if m.metricsClient == nil {
-		return nil
+	metricsClient := m.metricsClient
+	usageHistory := m.usageHistory
+
+	namespace := m.k8sClient.CurrentNamespace()
+	if m.showAllNs {
+		namespace = ""
 	}
 
 	return func() tea.Msg {
+		if metricsClient == nil {
+			return metricsLoadedMsg{podsUnavailable: true, nodesUnavailable: true}
+		}
+
 		ctx := context.Background()
 
-		var podMetrics map[string]panels.PodMetrics
+		var msg metricsLoadedMsg
 
-		if m.showAllNs {
-			k8sMetrics, _ := m.metricsClient.GetAllPodMetrics(ctx)
-			podMetrics = convertPodMetrics(k8sMetrics)
+		containerMetrics, err := metricsClient.GetContainerMetrics(ctx, namespace)
+		if err != nil {
+			msg.podsUnavailable = true
 		} else {
-			k8sMetrics, _ := m.metricsClient.GetPodMetrics(ctx, m.k8sClient.CurrentNamespace())
-			podMetrics = convertPodMetrics(k8sMetrics)
-		}
+			if usageHistory != nil {
+				usageHistory.Record(time.Now(), containerMetrics)
+			}
 
-		k8sNodeMetrics, _ := m.metricsClient.GetNodeMetrics(ctx)
-		nodeMetrics := convertNodeMetrics(k8sNodeMetrics)
+			msg.podMetrics = convertPodMetrics(k8s.SumPodMetrics(containerMetrics))
+		}
 
-		return metricsLoadedMsg{
-			podMetrics:  podMetrics,
-			nodeMetrics: nodeMetrics,
+		nodeMetrics, err := metricsClient.GetNodeMetrics(ctx)
+		if err != nil {
+			msg.nodesUnavailable = true
+		} else {
+			msg.nodeMetrics = convertNodeMetrics(nodeMetrics)
 		}
+
+		return msg
 	}
 }
 
@@ -2267,19 +2783,51 @@ type diffLoadedMsg struct {
 	newYAML string
 }
 
+// selectorLoadedMsg carries a panel's list for a label selector. Typing
+// changes the selector faster than lists load, so lists for a selector
+// that has since changed are dropped rather than shown.
+type selectorLoadedMsg struct {
+	panel    panels.Panel
+	selector string
+	loaded   tea.Msg
+}
+
+// refreshForSelector reloads a panel for its current label selector.
+func (m *Model) refreshForSelector(panel panels.Panel) tea.Cmd {
+	selector := panel.LabelSelector()
+	refresh := panel.Refresh()
+
+	return func() tea.Msg {
+		return selectorLoadedMsg{panel: panel, selector: selector, loaded: refresh()}
+	}
+}
+
+// reportLoadedMsg carries a plain-text report shown in the YAML viewer.
+type reportLoadedMsg struct {
+	content string
+}
+
 type metricsLoadedMsg struct {
-	podMetrics  map[string]panels.PodMetrics
-	nodeMetrics map[string]panels.NodeMetrics
+	podMetrics       map[string]panels.PodMetrics
+	nodeMetrics      map[string]panels.NodeMetrics
+	podsUnavailable  bool
+	nodesUnavailable bool
 }
 
 func convertPodMetrics(k8sMetrics map[string]k8s.PodMetrics) map[string]panels.PodMetrics {
 	result := make(map[string]panels.PodMetrics, len(k8sMetrics))
 	for key, m := range k8sMetrics {
+		containers := make(map[string]panels.ContainerUsage, len(m.Containers))
+		for _, c := range m.Containers {
+			containers[c.Container] = panels.ContainerUsage{CPU: c.CPU, Memory: c.Memory}
+		}
+
 		result[key] = panels.PodMetrics{
-			Name:      m.Name,
-			Namespace: m.Namespace,
-			CPU:       m.CPU,
-			Memory:    m.Memory,
+			Name:       m.Name,
+			Namespace:  m.Namespace,
+			CPU:        m.CPU,
+			Memory:     m.Memory,
+			Containers: containers,
 		}
 	}
 
@@ -2308,8 +2856,20 @@ func (m *Model) handleGlobalSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	if key.Matches(msg, m.keys.Enter) {
+		// Clearing a filter with label terms needs a fresh, unselected list
+		var narrowed panels.Panel
+		if idx := m.globalSearch.Cursor(); idx >= 0 && idx < len(m.globalSearchResults) {
+			if panel := m.panels[m.globalSearchResults[idx].PanelIdx]; panel.LabelSelector() != "" {
+				narrowed = panel
+			}
+		}
+
 		m.navigateToSearchResult()
 
+		if narrowed != nil {
+			return m, narrowed.Refresh()
+		}
+
 		return m, nil
 	}
 
@@ -2500,8 +3060,19 @@ func (m *Model) renderGlobalSearchView() string {
 		}
 
 		name := r.Name
+		visible := len(name)
+
 		if len(name) > maxNameW {
 			name = name[:maxNameW-3] + "..."
+			visible = maxNameW - 3
+		}
+
+		var matches []int
+
+		for _, pos := range r.Matches {
+			if pos < visible {
+				matches = append(matches, pos)
+			}
 		}
 
 		ns := r.Namespace
@@ -2514,10 +3085,16 @@ func (m *Model) renderGlobalSearchView() string {
 			status = status[:statusW-2] + ".."
 		}
 
-		entry := prefix + fmt.Sprintf(
-			"%-*s  %-*s  %s",
-			maxNameW, name, nsW, ns, status,
-		)
+		entry := prefix + utils.PadRight(m.styles.HighlightMatches(name, matches), maxNameW) +
+			fmt.Sprintf("  %-*s  %-*s", nsW, ns, statusW, status)
+
+		// Say why a result whose name doesn't match is listed
+		if r.MatchedOn != "" {
+			room := innerWidth - lipgloss.Width(entry) - 2
+			if room > 10 {
+				entry += "  " + m.styles.Muted.Render(utils.Truncate(r.MatchedOn, room))
+			}
+		}
 
 		if isSelected {
 			b.WriteString(m.styles.ListItemFocused.Render(entry))
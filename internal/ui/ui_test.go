@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -9,8 +10,12 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/clientcmd/api"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
 
 	"github.com/Starlexxx/lazy-k8s/internal/config"
 	"github.com/Starlexxx/lazy-k8s/internal/k8s"
@@ -1137,6 +1142,7 @@ func createTestModel() *Model {
 		globalSearch: components.NewGlobalSearch(styles),
 		historyStore: historyStore,
 		historyView:  components.NewHistoryViewer(styles, historyStore),
+		relationView: components.NewRelationViewer(styles),
 		panels:       []panels.Panel{podsPanel, deploysPanel},
 		portForwards: make(map[string]*k8s.PortForwarder),
 		width:        100,
@@ -2149,3 +2155,140 @@ func TestExecRecordsHistory(t *testing.T) {
 		t.Errorf("expected resource test-pod, got %s", rec.Resource)
 	}
 }
+
+func TestConvertPodMetricsKeepsContainers(t *testing.T) {
+	metrics := k8s.SumPodMetrics([]k8s.ContainerMetrics{
+		{Namespace: "default", Pod: "web", Container: "app", CPU: 100, Memory: 10},
+		{Namespace: "default", Pod: "web", Container: "proxy", CPU: 20, Memory: 5},
+	})
+
+	pod := convertPodMetrics(metrics)["default/web"]
+	if pod.CPU != 120 || pod.Containers["proxy"] != (panels.ContainerUsage{CPU: 20, Memory: 5}) {
+		t.Errorf("unexpected pod metrics %+v", pod)
+	}
+}
+
+func TestFetchMetricsReportsFailedListsUnavailable(t *testing.T) {
+	fakeMetrics := metricsfake.NewSimpleClientset()
+	fakeMetrics.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("metrics-server timed out")
+	})
+	fakeMetrics.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.NodeMetricsList{Items: []metricsv1beta1.NodeMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		}}}, nil
+	})
+
+	m := createTestModel()
+	m.metricsClient = k8s.NewTestMetricsClient(fakeMetrics)
+
+	msg, ok := m.fetchMetrics()().(metricsLoadedMsg)
+	if !ok {
+		t.Fatal("expected metricsLoadedMsg")
+	}
+
+	// An empty map would prune every pod's sparkline
+	if !msg.podsUnavailable || msg.podMetrics != nil {
+		t.Errorf("a failed pod list should be unavailable, got %+v", msg)
+	}
+
+	if msg.nodesUnavailable || len(msg.nodeMetrics) != 1 {
+		t.Errorf("node metrics should still load, got %+v", msg)
+	}
+}
+
+func TestSearchLabelTermsRefreshFromServer(t *testing.T) {
+	m := createTestModel()
+	m.search = components.NewSearch(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "api", Namespace: "default", Labels: map[string]string{"app": "api"},
+		}},
+	))
+
+	panel := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	panel.Update(panel.Refresh()())
+
+	m.panels = []panels.Panel{panel}
+	m.activePanelIdx = 0
+	m.searchActive = true
+
+	m.search.Focus()
+	m.search.SetValue("label:app=we")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+
+	if cmd == nil {
+		t.Fatal("a new label selector should refresh the panel")
+	}
+
+	m.Update(selectorLoad(t, cmd))
+
+	if refs := panel.VisibleRefs(); len(refs) != 1 || refs[0].Name != "web" {
+		t.Errorf("expected only web listed, got %+v", refs)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'('}})
+
+	if !strings.Contains(m.search.View(120), "invalid filter") {
+		t.Errorf("search should show the parse error:\n%s", m.search.View(120))
+	}
+}
+
+func TestSearchDropsListsForOldSelectors(t *testing.T) {
+	m := createTestModel()
+	m.search = components.NewSearch(m.styles)
+	m.k8sClient = k8s.NewTestClient(fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"},
+		}},
+	))
+
+	panel := panels.NewDeploymentsPanel(m.k8sClient, m.styles)
+	m.panels = []panels.Panel{panel}
+	m.activePanelIdx = 0
+	m.searchActive = true
+
+	m.search.Focus()
+	m.search.SetValue("label:app=w")
+	_, first := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	_, second := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+
+	if first == nil || second == nil {
+		t.Fatal("each new label selector should refresh the panel")
+	}
+
+	// The list for app=we arrives last but is out of date
+	m.Update(selectorLoad(t, second))
+	m.Update(selectorLoad(t, first))
+
+	if refs := panel.VisibleRefs(); len(refs) != 1 || refs[0].Name != "web" {
+		t.Errorf("the list for the old selector should be dropped, got %+v", refs)
+	}
+}
+
+// selectorLoad runs the panel reload among a search key's commands.
+func selectorLoad(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		batch = tea.BatchMsg{cmd}
+	}
+
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+
+		if msg, ok := c().(selectorLoadedMsg); ok {
+			return msg
+		}
+	}
+
+	t.Fatal("expected a panel reload")
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fuzzy scoring weights, loosely after fzf: runs of consecutive matches and
+// matches at the start of a word beat scattered ones, and among equal
+// matches the one nearer the start of a shorter text wins.
+const (
+	fuzzyMatchScore      = 16
+	fuzzyConsecutive     = 8
+	fuzzyBoundaryBonus   = 8
+	fuzzyGapStartPenalty = 3
+	fuzzyGapPenalty      = 1
+	fuzzyMaxLeadPenalty  = 3
+	fuzzyTrailDivisor    = 4
+)
+
+// FuzzyMatch reports whether the runes of pattern appear in text in order,
+// ignoring case, e.g. "dpweb" in "deploy-web". It returns a score where
+// higher is a better match and the rune indexes of text that matched.
+func FuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	// Find where the earliest match ends, then walk back from there to the
+	// latest start, so the match covers as short a stretch as it can.
+	end, pi := -1, 0
+
+	for i := 0; i < len(t) && pi < len(p); i++ {
+		if t[i] == p[pi] {
+			pi++
+			end = i
+		}
+	}
+
+	if pi < len(p) {
+		return 0, nil, false
+	}
+
+	start, pi := end, len(p)-1
+
+	for i := end; i >= 0; i-- {
+		if t[i] == p[pi] {
+			start = i
+
+			if pi--; pi < 0 {
+				break
+			}
+		}
+	}
+
+	positions = make([]int, 0, len(p))
+	pi = 0
+
+	for i := start; i <= end && pi < len(p); i++ {
+		if t[i] == p[pi] {
+			positions = append(positions, i)
+			pi++
+		}
+	}
+
+	return fuzzyScore(t, positions), positions, true
+}
+
+func fuzzyScore(text []rune, positions []int) int {
+	score := -min(positions[0], fuzzyMaxLeadPenalty) - (len(text)-len(positions))/fuzzyTrailDivisor
+
+	for i, pos := range positions {
+		score += fuzzyMatchScore
+
+		if pos == 0 || isWordSeparator(text[pos-1]) {
+			score += fuzzyBoundaryBonus
+			if i == 0 {
+				score += fuzzyBoundaryBonus
+			}
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if gap := pos - positions[i-1] - 1; gap == 0 {
+			score += fuzzyConsecutive
+		} else {
+			score -= fuzzyGapStartPenalty + (gap-1)*fuzzyGapPenalty
+		}
+	}
+
+	return score
+}
+
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("-_./:=", r)
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		text      string
+		match     bool
+		positions []int
+	}{
+		{"substring", "web", "nginx-web", true, []int{6, 7, 8}},
+		{"scattered", "dpw", "deploy-web", true, []int{0, 2, 7}},
+		{"case-insensitive", "WEB", "Web-1", true, []int{0, 1, 2}},
+		{"shortest stretch", "ab", "a-x-ab", true, []int{4, 5}},
+		{"out of order", "bew", "web", false, nil},
+		{"missing rune", "webz", "web", false, nil},
+		{"empty pattern", "", "web", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.match {
+				t.Fatalf("FuzzyMatch(%q, %q) ok = %v, want %v", tt.pattern, tt.text, ok, tt.match)
+			}
+
+			if ok && len(tt.positions) > 0 && !slices.Equal(positions, tt.positions) {
+				t.Errorf("positions = %v, want %v", positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	// Each text should score higher than the one after it for "web"
+	texts := []string{"web", "web-canary", "nginx-web", "w-e-b", "wide-ebb"}
+
+	prev := 0
+
+	for i, text := range texts {
+		score, _, ok := FuzzyMatch("web", text)
+		if !ok {
+			t.Fatalf("%q should match", text)
+		}
+
+		if i > 0 && score >= prev {
+			t.Errorf("%q scored %d, want below %q's %d", text, score, texts[i-1], prev)
+		}
+
+		prev = score
+	}
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Truncate appends "..." if s exceeds maxLen. If maxLen <= 3, hard-cuts without ellipsis.
@@ -17,20 +19,24 @@ func Truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// PadRight pads s with spaces to length columns. Styled text is measured
+// without its escape codes, so highlighted names line up with plain ones.
 func PadRight(s string, length int) string {
-	if len(s) >= length {
+	width := lipgloss.Width(s)
+	if width >= length {
 		return s
 	}
 
-	return s + strings.Repeat(" ", length-len(s))
+	return s + strings.Repeat(" ", length-width)
 }
 
 func PadLeft(s string, length int) string {
-	if len(s) >= length {
+	width := lipgloss.Width(s)
+	if width >= length {
 		return s
 	}
 
-	return strings.Repeat(" ", length-len(s)) + s
+	return strings.Repeat(" ", length-width) + s
 }
 
 func WrapText(text string, width int) []string {